@@ -0,0 +1,414 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/safety"
+	"github.com/spf13/cobra"
+)
+
+// Values accepted by --output.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// pruneKinds lists what prune can remove, in the order it removes them.
+// Containers go first so the resources they held become unused.
+var pruneKinds = []string{"containers", "images", "networks", "volumes"}
+
+// listing describes how a non-interactive command lists one resource type.
+type listing[T any] struct {
+	fetch   func(ctx context.Context, b backend.Backend) ([]T, error)
+	headers []string
+	row     func(item T) []string
+	fields  func(item T) filter.Fields
+}
+
+var containerListing = listing[backend.Container]{
+	fetch: func(ctx context.Context, b backend.Backend) ([]backend.Container, error) {
+		containers, err := b.ListContainers(ctx)
+		return containers, multi.IgnorePartial(err)
+	},
+	headers: []string{"CONTAINER ID", "NAME", "IMAGE", "STATUS", "CREATED"},
+	row: func(c backend.Container) []string {
+		return []string{shortID(c.ID), c.Name, c.Image, c.Status, infopanel.FormatTimeAgo(c.Created)}
+	},
+	fields: filter.ContainerFields,
+}
+
+var imageListing = listing[backend.Image]{
+	fetch: func(ctx context.Context, b backend.Backend) ([]backend.Image, error) {
+		images, err := b.ListImages(ctx)
+		return images, multi.IgnorePartial(err)
+	},
+	headers: []string{"IMAGE ID", "TAGS", "SIZE", "CREATED"},
+	row: func(image backend.Image) []string {
+		tags := "<none>"
+		if len(image.RepoTags) > 0 {
+			tags = strings.Join(image.RepoTags, ", ")
+		}
+		return []string{shortID(image.ID), tags, infopanel.FormatBytes(image.Size), infopanel.FormatTimeAgo(image.Created)}
+	},
+	fields: filter.ImageFields,
+}
+
+var volumeListing = listing[backend.Volume]{
+	fetch: func(ctx context.Context, b backend.Backend) ([]backend.Volume, error) {
+		volumes, err := b.ListVolumes(ctx)
+		return volumes, multi.IgnorePartial(err)
+	},
+	headers: []string{"NAME", "DRIVER", "CREATED"},
+	row: func(volume backend.Volume) []string {
+		return []string{volume.Name, volume.Driver, infopanel.FormatTimeAgo(volume.CreatedAt)}
+	},
+	fields: filter.VolumeFields,
+}
+
+var networkListing = listing[backend.Network]{
+	fetch: func(ctx context.Context, b backend.Backend) ([]backend.Network, error) {
+		networks, err := b.ListNetworks(ctx)
+		return networks, multi.IgnorePartial(err)
+	},
+	headers: []string{"NETWORK ID", "NAME", "DRIVER", "SCOPE"},
+	row: func(network backend.Network) []string {
+		return []string{shortID(network.ID), network.Name, network.Driver, network.Scope}
+	},
+	fields: filter.NetworkFields,
+}
+
+// shortID abbreviates an ID like docker does, keeping the host prefix of
+// IDs from a multi-host backend.
+func shortID(id string) string {
+	prefix := ""
+	if host, rest, ok := multi.SplitID(id); ok {
+		prefix, id = host+multi.Separator, rest
+	}
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return prefix + id
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// withBackend loads the configuration, connects to the backend selected by
+// the flags and runs fn against it.
+func withBackend(cmd *cobra.Command, flags rootFlags, kind config.OperationKind, fn func(ctx context.Context, b backend.Backend) error) error {
+	cfg, err := loadConfig(flags, "")
+	if err != nil {
+		return err
+	}
+	state.SetConfig(cfg)
+
+	cleanup, err := connect(flags)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx, cancel := state.OperationContext(cmd.Context(), kind)
+	defer cancel()
+	return fn(ctx, state.GetBackend())
+}
+
+// writeMarshalled writes data as JSON or YAML using the same marshalling as
+// the details panel.
+func writeMarshalled(w io.Writer, data any, format string) error {
+	var marshalFormat infopanel.OutputFormat
+	switch format {
+	case outputJSON:
+		marshalFormat = infopanel.FormatJSON
+	case outputYAML:
+		marshalFormat = infopanel.FormatYAML
+	default:
+		return fmt.Errorf("unsupported output format %q (expected %s, %s or %s)", format, outputTable, outputJSON, outputYAML)
+	}
+
+	text, err := infopanel.MarshalToFormat(data, marshalFormat)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, strings.TrimRight(text, "\n"))
+	return err
+}
+
+// writeListing writes items as an aligned table or marshals them.
+func writeListing[T any](w io.Writer, l listing[T], items []T, format string) error {
+	if format != "" && format != outputTable {
+		if items == nil {
+			items = []T{}
+		}
+		return writeMarshalled(w, items, format)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(l.headers, "\t"))
+	for _, item := range items {
+		fmt.Fprintln(tw, strings.Join(l.row(item), "\t"))
+	}
+	return tw.Flush()
+}
+
+func runListing[T any](cmd *cobra.Command, flags rootFlags, l listing[T], format string) error {
+	f, err := filter.Parse(flags.filters)
+	if err != nil {
+		return err
+	}
+	return withBackend(cmd, flags, config.OperationList, func(ctx context.Context, b backend.Backend) error {
+		items, err := l.fetch(ctx, b)
+		if err != nil {
+			return err
+		}
+		items = slices.DeleteFunc(items, func(item T) bool {
+			return !f.Match(l.fields(item))
+		})
+		return writeListing(cmd.OutOrStdout(), l, items, format)
+	})
+}
+
+// newListCommand creates a command that only prints a listing.
+func newListCommand[T any](flags *rootFlags, use, short string, l listing[T]) *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:          use,
+		Short:        short,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runListing(cmd, *flags, l, output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "output format: table, json or yaml")
+	return cmd
+}
+
+// addListing makes a tab subcommand print its resources instead of opening
+// the TUI when --output is given or stdout is not a terminal.
+func addListing[T any](cmd *cobra.Command, flags *rootFlags, l listing[T]) *cobra.Command {
+	var output string
+	launch := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if output == "" && isTerminal(os.Stdout) {
+			return launch(cmd, args)
+		}
+		return runListing(cmd, *flags, l, output)
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "print the list instead of opening the TUI: table, json or yaml")
+	return cmd
+}
+
+// inspectResource returns the detail of the container, image, network or
+// volume called name, trying them in that order like docker inspect.
+func inspectResource(ctx context.Context, b backend.Backend, name string) (any, error) {
+	containerDetail, err := b.InspectContainer(ctx, name)
+	if err == nil {
+		return containerDetail, nil
+	}
+	if imageDetail, imageErr := b.InspectImage(ctx, name); imageErr == nil {
+		return imageDetail, nil
+	}
+	if networkDetail, networkErr := b.InspectNetwork(ctx, name); networkErr == nil {
+		return networkDetail, nil
+	}
+	if volumeDetail, volumeErr := b.InspectVolume(ctx, name); volumeErr == nil {
+		return volumeDetail, nil
+	}
+	return nil, fmt.Errorf("no container, image, network or volume matches %q: %w", name, err)
+}
+
+func newInspectCommand(flags *rootFlags) *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:               "inspect <name>",
+		Short:             "print the details of a container, image, network or volume",
+		Args:              cobra.ExactArgs(1),
+		SilenceUsage:      true,
+		ValidArgsFunction: completeResourceNames(flags, containerNames, imageNames, networkNames, volumeNames),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withBackend(cmd, *flags, config.OperationInspect, func(ctx context.Context, b backend.Backend) error {
+				detail, err := inspectResource(ctx, b, args[0])
+				if err != nil {
+					return err
+				}
+
+				format := output
+				if format == "" {
+					format = string(infopanel.GetOutputFormat())
+				}
+				// The summary is drawn for the details panel, so scripts get YAML
+				if format == string(infopanel.FormatSummary) {
+					format = outputYAML
+				}
+				return writeMarshalled(cmd.OutOrStdout(), detail, format)
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format: json or yaml (defaults to the inspection format)")
+	return cmd
+}
+
+// pruneCandidates returns what pruning kind would remove, using the same
+// rules as the confirmation dialogs.
+func pruneCandidates(ctx context.Context, b backend.Backend, kind string) ([]string, error) {
+	switch kind {
+	case "containers":
+		containers, err := containerListing.fetch(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		return safety.PruneContainerCandidates(containers), nil
+	case "images":
+		images, err := imageListing.fetch(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		containers, err := containerListing.fetch(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		return safety.PruneImageCandidates(images, safety.ImagesInUse(images, containers)), nil
+	case "networks":
+		networks, err := networkListing.fetch(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		usage, err := b.GetAllNetworkUsage(ctx)
+		if err := multi.IgnorePartial(err); err != nil {
+			return nil, err
+		}
+		return safety.PruneNetworkCandidates(networks, usage), nil
+	case "volumes":
+		volumes, err := volumeListing.fetch(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		usage, err := b.GetAllVolumeUsage(ctx)
+		if err := multi.IgnorePartial(err); err != nil {
+			return nil, err
+		}
+		return safety.PruneVolumeCandidates(volumes, usage), nil
+	}
+	return nil, fmt.Errorf("unknown resource type %q (expected %s)", kind, strings.Join(pruneKinds, ", "))
+}
+
+// prune removes the unused resources of kind and describes the result.
+func prune(ctx context.Context, b backend.Backend, kind string) (string, error) {
+	switch kind {
+	case "containers":
+		reclaimed, err := b.PruneContainers(ctx)
+		return fmt.Sprintf("Pruned containers, reclaimed %s", infopanel.FormatBytes(int64(reclaimed))), err
+	case "images":
+		reclaimed, err := b.PruneImages(ctx)
+		return fmt.Sprintf("Pruned images, reclaimed %s", infopanel.FormatBytes(int64(reclaimed))), err
+	case "networks":
+		removed, err := b.PruneNetworks(ctx)
+		return fmt.Sprintf("Pruned %d networks", removed), err
+	case "volumes":
+		reclaimed, err := b.PruneVolumes(ctx)
+		return fmt.Sprintf("Pruned volumes, reclaimed %s", infopanel.FormatBytes(int64(reclaimed))), err
+	}
+	return "", fmt.Errorf("unknown resource type %q (expected %s)", kind, strings.Join(pruneKinds, ", "))
+}
+
+// selectPruneKinds validates the resource types given to prune, defaulting
+// to all of them, and returns them in prune order.
+func selectPruneKinds(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return pruneKinds, nil
+	}
+	for _, arg := range args {
+		if !slices.Contains(pruneKinds, arg) {
+			return nil, fmt.Errorf("unknown resource type %q (expected %s)", arg, strings.Join(pruneKinds, ", "))
+		}
+	}
+	return slices.DeleteFunc(slices.Clone(pruneKinds), func(kind string) bool {
+		return !slices.Contains(args, kind)
+	}), nil
+}
+
+// writePruneCandidates prints a dry run as a list per resource type, or as
+// a document keyed by resource type.
+func writePruneCandidates(w io.Writer, kinds []string, candidates map[string][]string, format string) error {
+	if format != "" && format != outputTable {
+		return writeMarshalled(w, candidates, format)
+	}
+
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "Would prune %d %s\n", len(candidates[kind]), kind)
+		for _, candidate := range candidates[kind] {
+			fmt.Fprintf(w, "  - %s\n", candidate)
+		}
+	}
+	return nil
+}
+
+func newPruneCommand(flags *rootFlags) *cobra.Command {
+	var dryRun bool
+	var force bool
+	var output string
+	cmd := &cobra.Command{
+		Use:          "prune [containers|images|networks|volumes]...",
+		Short:        "remove unused containers, images, networks and volumes",
+		ValidArgs:    pruneKinds,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kinds, err := selectPruneKinds(args)
+			if err != nil {
+				return err
+			}
+			if !dryRun && !force {
+				return fmt.Errorf("refusing to prune without --force; use --dry-run to see what would be removed")
+			}
+			// Prune removes everything unused, so a filter would misrepresent its scope
+			if len(flags.filters) > 0 {
+				return fmt.Errorf("prune does not support --filter")
+			}
+
+			return withBackend(cmd, *flags, config.OperationPrune, func(ctx context.Context, b backend.Backend) error {
+				if dryRun {
+					candidates := make(map[string][]string, len(kinds))
+					for _, kind := range kinds {
+						names, err := pruneCandidates(ctx, b, kind)
+						if err != nil {
+							return err
+						}
+						candidates[kind] = names
+					}
+					return writePruneCandidates(cmd.OutOrStdout(), kinds, candidates, output)
+				}
+
+				for _, kind := range kinds {
+					summary, err := prune(ctx, b, kind)
+					if err != nil {
+						return fmt.Errorf("failed to prune %s: %w", kind, err)
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), summary)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list what would be removed without removing anything")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "prune without a dry run")
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "dry run output format: table, json or yaml")
+	return cmd
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+// runDemoCommand runs cmd against the demo backend and returns its output.
+func runDemoCommand(t *testing.T, cmd *cobra.Command, args ...string) string {
+	t.Helper()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("%s %v returned error: %v", cmd.Name(), args, err)
+	}
+	return out.String()
+}
+
+func TestShortID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "sha256:0123456789abcdef0123", want: "0123456789ab"},
+		{id: "0123456789abcdef", want: "0123456789ab"},
+		{id: "staging/0123456789abcdef", want: "staging/0123456789ab"},
+		{id: "abc", want: "abc"},
+	}
+
+	for _, tt := range tests {
+		if got := shortID(tt.id); got != tt.want {
+			t.Fatalf("shortID(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestSelectPruneKinds(t *testing.T) {
+	kinds, err := selectPruneKinds([]string{"volumes", "containers"})
+	if err != nil || strings.Join(kinds, ",") != "containers,volumes" {
+		t.Fatalf("expected containers then volumes, got %v (%v)", kinds, err)
+	}
+	if kinds, _ := selectPruneKinds(nil); len(kinds) != len(pruneKinds) {
+		t.Fatalf("expected every kind by default, got %v", kinds)
+	}
+	if _, err := selectPruneKinds([]string{"secrets"}); err == nil {
+		t.Fatal("expected an unknown resource type to be rejected")
+	}
+}
+
+func TestPsListsDemoContainers(t *testing.T) {
+	flags := rootFlags{demo: true}
+
+	table := runDemoCommand(t, newListCommand(&flags, "ps", "", containerListing))
+	if !strings.HasPrefix(table, "CONTAINER ID") || !strings.Contains(table, "containertui-demo-nginx") {
+		t.Fatalf("expected a container table, got:\n%s", table)
+	}
+
+	var containers []map[string]any
+	output := runDemoCommand(t, newListCommand(&flags, "ps", "", containerListing), "-o", "json")
+	if err := json.Unmarshal([]byte(output), &containers); err != nil || len(containers) == 0 {
+		t.Fatalf("expected a JSON list of containers, got %q (%v)", output, err)
+	}
+}
+
+func TestPsHonoursFilters(t *testing.T) {
+	flags := rootFlags{demo: true, filters: []string{
+		"state=running",
+		"label=ctui.namespace=containertui-demo",
+		"name=*-busybox*",
+	}}
+
+	table := runDemoCommand(t, newListCommand(&flags, "ps", "", containerListing))
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "containertui-demo-busybox") {
+		t.Fatalf("expected only the running busybox container, got:\n%s", table)
+	}
+
+	flags.filters = []string{"colour=red"}
+	cmd := newListCommand(&flags, "ps", "", containerListing)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected an unknown filter key to be rejected")
+	}
+}
+
+func TestInspectPrintsDetailsOfAnyResource(t *testing.T) {
+	flags := rootFlags{demo: true}
+
+	output := runDemoCommand(t, newInspectCommand(&flags), "containertui-demo-data", "-o", "json")
+	var volume map[string]any
+	if err := json.Unmarshal([]byte(output), &volume); err != nil || volume["Name"] != "containertui-demo-data" {
+		t.Fatalf("expected the volume's details, got %q (%v)", output, err)
+	}
+
+	cmd := newInspectCommand(&flags)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"missing"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected inspecting an unknown name to fail")
+	}
+}
+
+func TestPruneRequiresForceOrDryRun(t *testing.T) {
+	flags := rootFlags{demo: true}
+
+	cmd := newPruneCommand(&flags)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected prune without --force to be refused, got %v", err)
+	}
+
+	output := runDemoCommand(t, newPruneCommand(&flags), "containers", "--dry-run", "-o", "json")
+	var candidates map[string][]string
+	if err := json.Unmarshal([]byte(output), &candidates); err != nil {
+		t.Fatalf("expected JSON candidates, got %q (%v)", output, err)
+	}
+	if got := strings.Join(candidates["containers"], ","); got != "containertui-demo-alpine-stopped,containertui-demo-busybox-created" {
+		t.Fatalf("expected the stopped and created containers, got %q", got)
+	}
+	if _, ok := candidates["volumes"]; ok {
+		t.Fatalf("expected only containers to be listed, got %v", candidates)
+	}
+}
+
+func TestResolveContainerByNameOrIDPrefix(t *testing.T) {
+	b := fakeContainers{containers: []backend.Container{
+		{ID: "abc123", Name: "web"},
+		{ID: "abd456", Name: "worker"},
+		{ID: "staging/fff789", Name: "db"},
+	}}
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{ref: "web", want: "web"},
+		{ref: "abd", want: "worker"},
+		{ref: "fff", want: "db"},
+		{ref: "ab", wantErr: true},
+		{ref: "zzz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveContainer(context.Background(), b, tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("resolveContainer(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+		}
+		if got.Name != tt.want {
+			t.Fatalf("resolveContainer(%q) = %q, want %q", tt.ref, got.Name, tt.want)
+		}
+	}
+}
+
+// fakeContainers is a backend that only lists a fixed set of containers.
+type fakeContainers struct {
+	backend.Backend
+	containers []backend.Container
+}
+
+func (f fakeContainers) ListContainers(context.Context) ([]backend.Container, error) {
+	return f.containers, nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds how long completion waits for the daemon, so an
+// unreachable one does not hang the shell.
+var completionTimeout = 2 * time.Second
+
+// nameLister lists the names of one resource type for completion.
+type nameLister func(ctx context.Context, b backend.Backend) ([]string, error)
+
+func containerNamesWhere(keep func(backend.Container) bool) nameLister {
+	return func(ctx context.Context, b backend.Backend) ([]string, error) {
+		containers, err := containerListing.fetch(ctx, b)
+		var names []string
+		for _, c := range containers {
+			if keep(c) {
+				names = append(names, c.Name)
+			}
+		}
+		return names, err
+	}
+}
+
+var (
+	containerNames = containerNamesWhere(func(backend.Container) bool { return true })
+
+	runningContainerNames = containerNamesWhere(func(c backend.Container) bool {
+		return c.State == "running"
+	})
+)
+
+func imageNames(ctx context.Context, b backend.Backend) ([]string, error) {
+	images, err := imageListing.fetch(ctx, b)
+	var names []string
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			if tag != "<none>:<none>" {
+				names = append(names, tag)
+			}
+		}
+	}
+	return names, err
+}
+
+func volumeNames(ctx context.Context, b backend.Backend) ([]string, error) {
+	volumes, err := volumeListing.fetch(ctx, b)
+	var names []string
+	for _, volume := range volumes {
+		names = append(names, volume.Name)
+	}
+	return names, err
+}
+
+func networkNames(ctx context.Context, b backend.Backend) ([]string, error) {
+	networks, err := networkListing.fetch(ctx, b)
+	var names []string
+	for _, network := range networks {
+		names = append(names, network.Name)
+	}
+	return names, err
+}
+
+// matchingNames returns the sorted names starting with prefix from every
+// lister, skipping listers that fail and giving up once completionTimeout
+// has passed.
+func matchingNames(ctx context.Context, b backend.Backend, prefix string, listers []nameLister) []string {
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	var matches []string
+	for _, list := range listers {
+		names, _ := list(ctx, b)
+		for _, name := range names {
+			if strings.HasPrefix(name, prefix) {
+				matches = append(matches, name)
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	slices.Sort(matches)
+	return slices.Compact(matches)
+}
+
+// completeResourceNames completes the first argument with the names listed
+// by listers.
+func completeResourceNames(flags *rootFlags, listers ...nameLister) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeNames(cmd, flags, toComplete, listers...)
+	}
+}
+
+// completeNames queries the backend selected by flags for names starting
+// with toComplete. Errors are swallowed: completion never fails loudly.
+func completeNames(cmd *cobra.Command, flags *rootFlags, toComplete string, listers ...nameLister) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	_ = withBackend(cmd, *flags, config.OperationList, func(ctx context.Context, b backend.Backend) error {
+		names = matchingNames(ctx, b, toComplete, listers)
+		return nil
+	})
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// selectListers maps each tab subcommand to the names --select can take.
+// The root command opens the containers tab unless configured otherwise.
+var selectListers = map[string]nameLister{
+	"images":   imageNames,
+	"volumes":  volumeNames,
+	"networks": networkNames,
+}
+
+// completeSelection completes --select with names from the tab the command
+// opens.
+func completeSelection(flags *rootFlags) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		lister, ok := selectListers[cmd.Name()]
+		if !ok {
+			lister = containerNames
+		}
+		return completeNames(cmd, flags, toComplete, lister)
+	}
+}
+
+// completeFilter completes the keys of a --filter expression.
+func completeFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var keys []string
+	for _, key := range filter.Keys {
+		if strings.HasPrefix(key+"=", toComplete) {
+			keys = append(keys, key+"=")
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+func newCompletionCommand(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "generate a shell completion script",
+		Long: `Generate a completion script for your shell. Resource names are completed
+from the daemon selected by --host, --context or --demo.
+
+  bash: source <(containertui completion bash)
+  zsh:  containertui completion zsh > "${fpath[1]}/_containertui"
+  fish: containertui completion fish > ~/.config/fish/completions/containertui.fish`,
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			}
+			return fmt.Errorf("unsupported shell %q", args[0])
+		},
+	}
+}
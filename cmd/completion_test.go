@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+// hangingBackend never answers, like a daemon behind a dropped connection.
+type hangingBackend struct {
+	backend.Backend
+}
+
+func (hangingBackend) ListContainers(ctx context.Context) ([]backend.Container, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestMatchingNamesGivesUpOnUnreachableDaemon(t *testing.T) {
+	previous := completionTimeout
+	completionTimeout = 50 * time.Millisecond
+	defer func() { completionTimeout = previous }()
+
+	fixed := func(context.Context, backend.Backend) ([]string, error) {
+		return []string{"web", "worker", "db"}, nil
+	}
+
+	start := time.Now()
+	names := matchingNames(context.Background(), hangingBackend{}, "w", []nameLister{fixed, containerNames})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected completion to give up quickly, took %v", elapsed)
+	}
+	if strings.Join(names, ",") != "web,worker" {
+		t.Fatalf("expected the names that were listed in time, got %v", names)
+	}
+}
+
+func TestCompletionCommandGeneratesScripts(t *testing.T) {
+	root := &cobra.Command{Use: "containertui"}
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var out bytes.Buffer
+		cmd := newCompletionCommand(root)
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{shell})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("completion %s returned error: %v", shell, err)
+		}
+		if !strings.Contains(out.String(), "containertui") {
+			t.Fatalf("expected a %s script for containertui, got %q", shell, out.String())
+		}
+	}
+
+	cmd := newCompletionCommand(root)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"powershell"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected an unsupported shell to be rejected")
+	}
+}
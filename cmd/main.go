@@ -3,10 +3,15 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
+	dockerbackend "github.com/givensuman/containertui/internal/backend/docker"
+	"github.com/givensuman/containertui/internal/backend/fake"
+	"github.com/givensuman/containertui/internal/backend/recording"
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui"
 	"github.com/spf13/cobra"
@@ -22,30 +27,42 @@ func resolveStartupTab(configTab, subcommandTab string) string {
 	return startupTab
 }
 
-func runContainertui(cmd *cobra.Command, tabName string, noNerdFonts bool, configPath string, colorsFlag []string, jsonFormat bool) (*cobra.Command, error) {
-	var cfg *config.Config
-	var err error
-	if configPath != "" {
-		cfg, err = config.LoadFromFile(configPath)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = config.DefaultConfig()
+// rootFlags holds the persistent flags shared by every command.
+type rootFlags struct {
+	noNerdFonts  bool
+	configPath   string
+	colors       []string
+	jsonFormat   bool
+	host         string
+	contextNames []string
+	demo         bool
+	recordPath   string
+	replayPath   string
+	redactions   []string
+	filters      []string
+	selectName   string
+}
+
+// loadConfig builds the configuration from the config file and flags.
+func loadConfig(flags rootFlags, tabName string) (*config.Config, error) {
+	// Without --config the default config file is read, if there is one
+	cfg, err := config.LoadFromFile(flags.configPath)
+	if err != nil {
+		return nil, err
 	}
 
-	if noNerdFonts {
+	if flags.noNerdFonts {
 		cfg.NoNerdFonts = true
 	}
 
-	if jsonFormat {
+	if flags.jsonFormat {
 		cfg.InspectionFormat = "json"
 	}
 
 	cfg.StartupTab = resolveStartupTab(cfg.StartupTab, tabName)
 
-	if len(colorsFlag) > 0 {
-		colorOverrides, err := colors.ParseColors(colorsFlag)
+	if len(flags.colors) > 0 {
+		colorOverrides, err := colors.ParseColors(flags.colors)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse colors: %w", err)
 		}
@@ -76,22 +93,104 @@ func runContainertui(cmd *cobra.Command, tabName string, noNerdFonts bool, confi
 		}
 	}
 
-	state.SetConfig(cfg)
+	return cfg, nil
+}
+
+// connect sets up the shared backend selected by the flags. The returned
+// function closes it and finishes any recording.
+func connect(flags rootFlags) (func(), error) {
+	if flags.demo && flags.replayPath != "" {
+		return nil, fmt.Errorf("--demo and --replay cannot be combined")
+	}
+
+	var cleanups []func()
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+
+	if flags.recordPath != "" {
+		options, err := recording.ParseRedactions(flags.redactions)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.Create(flags.recordPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create recording: %w", err)
+		}
+		session := recording.NewSession(file, options)
+		state.RecordTo(session)
+		cleanups = append(cleanups, func() {
+			if err := session.Err(); err != nil {
+				log.Printf("error writing recording: %v", err)
+			}
+			if err := file.Close(); err != nil {
+				log.Printf("error closing recording: %v", err)
+			}
+		})
+	}
+
+	switch {
+	case flags.replayPath != "":
+		// Serve the responses of a recorded session instead of a daemon
+		replayer, err := recording.Load(flags.replayPath)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		state.SetEndpoint(dockerbackend.Endpoint{Context: "replay"})
+		state.UseBackend(replayer)
+	case flags.demo:
+		// Run against an in-memory environment instead of a daemon
+		state.SetEndpoint(dockerbackend.Endpoint{Context: "demo"})
+		state.UseBackend(fake.NewDemo())
+	default:
+		// Resolve which daemons to talk to from the flags, environment and Docker contexts
+		endpoints, err := dockerbackend.ResolveEndpoints(flags.host, flags.contextNames)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		state.SetEndpoints(endpoints)
+	}
 
 	// Initialize the shared Docker client
 	if err := state.InitializeClient(); err != nil {
+		cleanup()
 		return nil, fmt.Errorf("failed to initialize Docker client: %w", err)
 	}
-	defer func() {
+	cleanups = append(cleanups, func() {
 		if err := state.CloseClient(); err != nil {
 			log.Printf("error closing Docker client: %v", err)
 		}
-	}()
+	})
+
+	return cleanup, nil
+}
+
+func runContainertui(cmd *cobra.Command, tabName string, flags rootFlags) (*cobra.Command, error) {
+	cfg, err := loadConfig(flags, tabName)
+	if err != nil {
+		return nil, err
+	}
+	state.SetConfig(cfg)
+
+	launchFilter, err := filter.Parse(flags.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanup, err := connect(flags)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
 
 	state.InitializeLog()
 
 	// Start the UI
-	if err := ui.Start(); err != nil {
+	if err := ui.Start(ui.Options{Filter: launchFilter, Select: flags.selectName}); err != nil {
 		return nil, fmt.Errorf("failed to run application: %w", err)
 	}
 
@@ -99,10 +198,7 @@ func runContainertui(cmd *cobra.Command, tabName string, noNerdFonts bool, confi
 }
 
 func main() {
-	var noNerdFonts bool
-	var configPath string
-	var colorsFlag []string
-	var jsonFormat bool
+	var flags rootFlags
 
 	// Create subcommand runner factory
 	makeSubcommand := func(tabName string, use string, short string) *cobra.Command {
@@ -110,7 +206,7 @@ func main() {
 			Use:   use,
 			Short: short,
 			RunE: func(cmd *cobra.Command, args []string) error {
-				_, err := runContainertui(cmd, tabName, noNerdFonts, configPath, colorsFlag, jsonFormat)
+				_, err := runContainertui(cmd, tabName, flags)
 				return err
 			},
 		}
@@ -120,23 +216,46 @@ func main() {
 		Use:   "containertui",
 		Short: "a tui for managing container lifecycles",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, err := runContainertui(cmd, "", noNerdFonts, configPath, colorsFlag, jsonFormat)
+			_, err := runContainertui(cmd, "", flags)
 			return err
 		},
 	}
 
 	// Add subcommands
 	rootCmd.AddCommand(makeSubcommand("containers", "containers", "launch containertui to the containers tab"))
-	rootCmd.AddCommand(makeSubcommand("images", "images", "launch containertui to the images tab"))
-	rootCmd.AddCommand(makeSubcommand("volumes", "volumes", "launch containertui to the volumes tab"))
-	rootCmd.AddCommand(makeSubcommand("networks", "networks", "launch containertui to the networks tab"))
+	rootCmd.AddCommand(addListing(makeSubcommand("images", "images", "launch containertui to the images tab, or list images"), &flags, imageListing))
+	rootCmd.AddCommand(addListing(makeSubcommand("volumes", "volumes", "launch containertui to the volumes tab, or list volumes"), &flags, volumeListing))
+	rootCmd.AddCommand(addListing(makeSubcommand("networks", "networks", "launch containertui to the networks tab, or list networks"), &flags, networkListing))
 	rootCmd.AddCommand(makeSubcommand("browse", "browse", "launch containertui to the browse tab"))
 
+	// Add non-interactive subcommands for scripting
+	rootCmd.AddCommand(newListCommand(&flags, "ps", "list containers", containerListing))
+	rootCmd.AddCommand(newInspectCommand(&flags))
+	rootCmd.AddCommand(newPruneCommand(&flags))
+
+	// Add subcommands that open a single view and exit when it closes
+	rootCmd.AddCommand(newLogsCommand(&flags))
+	rootCmd.AddCommand(newExecCommand(&flags))
+
+	// Replace cobra's default completion command with one for the supported shells
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(newCompletionCommand(rootCmd))
+
 	// Add flags to root command
-	rootCmd.PersistentFlags().BoolVar(&noNerdFonts, "no-nerd-fonts", false, "disable nerd fonts")
-	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "path to config file")
-	rootCmd.PersistentFlags().StringSliceVar(&colorsFlag, "colors", nil, "color overrides (format: --colors 'primary=#b4befe' --colors 'warning=#f9e2af,success=#a6e3a1')")
-	rootCmd.PersistentFlags().BoolVar(&jsonFormat, "json", false, "use JSON format for inspection output")
+	rootCmd.PersistentFlags().BoolVar(&flags.noNerdFonts, "no-nerd-fonts", false, "disable nerd fonts")
+	rootCmd.PersistentFlags().StringVar(&flags.configPath, "config", "", "path to config file")
+	rootCmd.PersistentFlags().StringSliceVar(&flags.colors, "colors", nil, "color overrides (format: --colors 'primary=#b4befe' --colors 'warning=#f9e2af,success=#a6e3a1')")
+	rootCmd.PersistentFlags().BoolVar(&flags.jsonFormat, "json", false, "use JSON format for inspection output")
+	rootCmd.PersistentFlags().StringVarP(&flags.host, "host", "H", "", "daemon socket to connect to (overrides DOCKER_HOST and contexts)")
+	rootCmd.PersistentFlags().StringSliceVarP(&flags.contextNames, "context", "c", nil, "Docker context to use (overrides DOCKER_HOST and DOCKER_CONTEXT); repeat to connect to several hosts at once")
+	rootCmd.PersistentFlags().BoolVar(&flags.demo, "demo", false, "run against a built-in demo environment instead of a daemon")
+	rootCmd.PersistentFlags().StringVar(&flags.recordPath, "record", "", "record every backend call and its result to a JSONL file")
+	rootCmd.PersistentFlags().StringVar(&flags.replayPath, "replay", "", "replay a session recorded with --record instead of connecting to a daemon")
+	rootCmd.PersistentFlags().StringSliceVar(&flags.redactions, "redact", nil, "redact recorded values: ids, env or all")
+	rootCmd.PersistentFlags().StringArrayVar(&flags.filters, "filter", nil, "only show resources matching key=value (keys: id, name, state, label); repeat to narrow further")
+	rootCmd.PersistentFlags().StringVar(&flags.selectName, "select", "", "put the cursor on the named resource at launch")
+	_ = rootCmd.RegisterFlagCompletionFunc("filter", completeFilter)
+	_ = rootCmd.RegisterFlagCompletionFunc("select", completeSelection(&flags))
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
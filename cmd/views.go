@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/ui/containers"
+	"github.com/spf13/cobra"
+)
+
+// resolveContainer finds the container called ref, or the only container
+// whose ID starts with ref.
+func resolveContainer(ctx context.Context, b backend.Backend, ref string) (backend.Container, error) {
+	all, err := containerListing.fetch(ctx, b)
+	if err != nil {
+		return backend.Container{}, err
+	}
+
+	var matches []backend.Container
+	for _, c := range all {
+		if c.Name == ref || c.ID == ref {
+			return c, nil
+		}
+		_, id, ok := multi.SplitID(c.ID)
+		if !ok {
+			id = c.ID
+		}
+		if strings.HasPrefix(c.ID, ref) || strings.HasPrefix(id, ref) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return backend.Container{}, fmt.Errorf("no container named %q or with that ID prefix", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return backend.Container{}, fmt.Errorf("ID prefix %q matches %d containers", ref, len(matches))
+	}
+}
+
+// runAttached runs command on the current terminal until it exits.
+func runAttached(command *exec.Cmd) error {
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	return command.Run()
+}
+
+func newLogsCommand(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:               "logs <container>",
+		Short:             "open the log viewer for one container",
+		Args:              cobra.ExactArgs(1),
+		SilenceUsage:      true,
+		ValidArgsFunction: completeResourceNames(flags, containerNames),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var target backend.Container
+			err := withBackend(cmd, *flags, config.OperationList, func(ctx context.Context, b backend.Backend) error {
+				var err error
+				target, err = resolveContainer(ctx, b, args[0])
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return runAttached(containers.LogsCommand(target.ID))
+		},
+	}
+}
+
+func newExecCommand(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:               "exec <container>",
+		Short:             "open a shell in one running container",
+		Args:              cobra.ExactArgs(1),
+		SilenceUsage:      true,
+		ValidArgsFunction: completeResourceNames(flags, runningContainerNames),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var target backend.Container
+			err := withBackend(cmd, *flags, config.OperationInspect, func(ctx context.Context, b backend.Backend) error {
+				var err error
+				if target, err = resolveContainer(ctx, b, args[0]); err != nil {
+					return err
+				}
+
+				// Check the live state so a stopped container fails with a clear message
+				detail, err := b.InspectContainer(ctx, target.ID)
+				if err != nil {
+					return fmt.Errorf("could not verify container state: %w", err)
+				}
+				if detail.State != "running" {
+					return fmt.Errorf("%s is not running (state: %s)", target.Name, detail.State)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return runAttached(containers.ShellCommand(target.ID))
+		},
+	}
+}
@@ -10,9 +10,10 @@ import (
 // This allows containertui to support multiple backends (Docker, Podman, etc.) with a unified API.
 type Backend interface {
 	// Metadata
-	Name() string    // Returns the backend name (e.g., "docker", "podman")
-	Version() string // Returns the backend version
-	Close() error    // Closes the backend connection
+	Name() string                       // Returns the backend name (e.g., "docker", "podman")
+	Version(ctx context.Context) string // Returns the backend version
+	Ping(ctx context.Context) error     // Checks that the daemon is reachable
+	Close() error                       // Closes the backend connection
 
 	// Container operations
 	ListContainers(ctx context.Context) ([]Container, error)
@@ -50,6 +51,7 @@ type Backend interface {
 
 	// Image history and usage
 	ImageHistory(ctx context.Context, imageID string) ([]ImageHistoryItem, error)
+	SaveImage(ctx context.Context, id string) (io.ReadCloser, error)
 	GetAllNetworkUsage(ctx context.Context) (map[string]bool, error)
 	GetAllVolumeUsage(ctx context.Context) (map[string]bool, error)
 
@@ -0,0 +1,270 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DefaultContext is the name of the implicit context that uses DOCKER_HOST
+// or the platform's default socket.
+const DefaultContext = "default"
+
+// Context is a Docker CLI context read from the context store.
+type Context struct {
+	Name          string
+	Description   string
+	Host          string
+	SkipTLSVerify bool
+	// TLSDir holds ca.pem, cert.pem and key.pem, or is empty when the
+	// context has no TLS material.
+	TLSDir string
+}
+
+// Endpoint describes how to reach a Docker daemon. An empty Host means the
+// client is configured from the environment.
+type Endpoint struct {
+	Context       string
+	Host          string
+	CACert        string
+	Cert          string
+	Key           string
+	SkipTLSVerify bool
+}
+
+// Name returns a short label for the endpoint, preferring the context name.
+func (e Endpoint) Name() string {
+	if e.Context != "" {
+		return e.Context
+	}
+	if e.Host != "" {
+		return e.Host
+	}
+	return DefaultContext
+}
+
+func (e Endpoint) hasTLS() bool {
+	return e.CACert != "" || e.Cert != "" || e.Key != "" || e.SkipTLSVerify
+}
+
+type contextMeta struct {
+	Name     string `json:"Name"`
+	Metadata struct {
+		Description string `json:"Description"`
+	} `json:"Metadata"`
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// ConfigDir returns the Docker CLI configuration directory, honouring DOCKER_CONFIG.
+func ConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".docker"
+	}
+	return filepath.Join(home, ".docker")
+}
+
+// ListContexts returns the default context followed by every context in the
+// store, sorted by name.
+func ListContexts() ([]Context, error) {
+	return listContexts(ConfigDir())
+}
+
+func listContexts(configDir string) ([]Context, error) {
+	contexts := []Context{{
+		Name:        DefaultContext,
+		Description: "Current DOCKER_HOST based configuration",
+		Host:        os.Getenv("DOCKER_HOST"),
+	}}
+
+	metaDir := filepath.Join(configDir, "contexts", "meta")
+	entries, err := os.ReadDir(metaDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return contexts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Docker contexts: %w", err)
+	}
+
+	var stored []Context
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		context, err := readContext(configDir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, context)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].Name < stored[j].Name })
+
+	return append(contexts, stored...), nil
+}
+
+func readContext(configDir, id string) (Context, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, "contexts", "meta", id, "meta.json"))
+	if err != nil {
+		return Context{}, fmt.Errorf("failed to read Docker context %s: %w", id, err)
+	}
+
+	var meta contextMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Context{}, fmt.Errorf("failed to parse Docker context %s: %w", id, err)
+	}
+
+	context := Context{
+		Name:        meta.Name,
+		Description: meta.Metadata.Description,
+	}
+	if endpoint, ok := meta.Endpoints["docker"]; ok {
+		context.Host = endpoint.Host
+		context.SkipTLSVerify = endpoint.SkipTLSVerify
+	}
+
+	tlsDir := filepath.Join(configDir, "contexts", "tls", id, "docker")
+	if info, err := os.Stat(tlsDir); err == nil && info.IsDir() {
+		context.TLSDir = tlsDir
+	}
+	return context, nil
+}
+
+// contextID returns the directory name the Docker CLI uses for a context.
+func contextID(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+// CurrentContext returns the context selected by DOCKER_CONTEXT or the
+// Docker CLI config, falling back to the default context.
+func CurrentContext() string {
+	return currentContext(ConfigDir())
+}
+
+func currentContext(configDir string) string {
+	if name := os.Getenv("DOCKER_CONTEXT"); name != "" {
+		return name
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return DefaultContext
+	}
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil || cfg.CurrentContext == "" {
+		return DefaultContext
+	}
+	return cfg.CurrentContext
+}
+
+// ResolveEndpoint picks the daemon endpoint the way the Docker CLI does: an
+// explicit host wins, then an explicit context, then DOCKER_HOST, then
+// DOCKER_CONTEXT and finally the current context from the CLI config.
+func ResolveEndpoint(host, contextName string) (Endpoint, error) {
+	return resolveEndpoint(ConfigDir(), host, contextName)
+}
+
+func resolveEndpoint(configDir, host, contextName string) (Endpoint, error) {
+	if host != "" {
+		return Endpoint{Host: host}, nil
+	}
+	if contextName == "" && os.Getenv("DOCKER_HOST") != "" {
+		return Endpoint{Context: DefaultContext}, nil
+	}
+	if contextName == "" {
+		contextName = currentContext(configDir)
+	}
+	return endpointForContext(configDir, contextName)
+}
+
+// ResolveEndpoints is ResolveEndpoint for one or more contexts. Naming several
+// contexts connects to all of them at once, which cannot be combined with an
+// explicit host.
+func ResolveEndpoints(host string, contextNames []string) ([]Endpoint, error) {
+	return resolveEndpoints(ConfigDir(), host, contextNames)
+}
+
+func resolveEndpoints(configDir, host string, contextNames []string) ([]Endpoint, error) {
+	if len(contextNames) <= 1 {
+		contextName := ""
+		if len(contextNames) == 1 {
+			contextName = contextNames[0]
+		}
+		endpoint, err := resolveEndpoint(configDir, host, contextName)
+		if err != nil {
+			return nil, err
+		}
+		return []Endpoint{endpoint}, nil
+	}
+	if host != "" {
+		return nil, errors.New("--host cannot be combined with multiple contexts")
+	}
+
+	seen := make(map[string]bool, len(contextNames))
+	endpoints := make([]Endpoint, 0, len(contextNames))
+	for _, name := range contextNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		endpoint, err := endpointForContext(configDir, name)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// EndpointForContext returns the endpoint of the named context.
+func EndpointForContext(name string) (Endpoint, error) {
+	return endpointForContext(ConfigDir(), name)
+}
+
+func endpointForContext(configDir, name string) (Endpoint, error) {
+	if name == DefaultContext {
+		return Endpoint{Context: DefaultContext}, nil
+	}
+
+	id := contextID(name)
+	if _, err := os.Stat(filepath.Join(configDir, "contexts", "meta", id)); err != nil {
+		return Endpoint{}, fmt.Errorf("docker context %q not found", name)
+	}
+
+	context, err := readContext(configDir, id)
+	if err != nil {
+		return Endpoint{}, err
+	}
+
+	endpoint := Endpoint{
+		Context:       context.Name,
+		Host:          context.Host,
+		SkipTLSVerify: context.SkipTLSVerify,
+	}
+	if context.TLSDir != "" {
+		endpoint.CACert = existingFile(filepath.Join(context.TLSDir, "ca.pem"))
+		endpoint.Cert = existingFile(filepath.Join(context.TLSDir, "cert.pem"))
+		endpoint.Key = existingFile(filepath.Join(context.TLSDir, "key.pem"))
+	}
+	return endpoint, nil
+}
+
+func existingFile(path string) string {
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,137 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestContext(t *testing.T, configDir, name, meta string, withTLS bool) {
+	t.Helper()
+	id := contextID(name)
+	metaDir := filepath.Join(configDir, "contexts", "meta", id)
+	if err := os.MkdirAll(metaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if withTLS {
+		tlsDir := filepath.Join(configDir, "contexts", "tls", id, "docker")
+		if err := os.MkdirAll(tlsDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range []string{"ca.pem", "cert.pem", "key.pem"} {
+			if err := os.WriteFile(filepath.Join(tlsDir, file), []byte("pem"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestListContextsIncludesDefaultAndStoredContexts(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	configDir := t.TempDir()
+	writeTestContext(t, configDir, "remote", `{"Name":"remote","Metadata":{"Description":"prod"},"Endpoints":{"docker":{"Host":"tcp://10.0.0.5:2376","SkipTLSVerify":false}}}`, true)
+	writeTestContext(t, configDir, "build-vm", `{"Name":"build-vm","Endpoints":{"docker":{"Host":"tcp://build:2375"}}}`, false)
+
+	contexts, err := listContexts(configDir)
+	if err != nil {
+		t.Fatalf("listContexts returned error: %v", err)
+	}
+
+	names := make([]string, len(contexts))
+	for i, context := range contexts {
+		names[i] = context.Name
+	}
+	want := []string{DefaultContext, "build-vm", "remote"}
+	if len(names) != len(want) {
+		t.Fatalf("expected contexts %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected contexts %v, got %v", want, names)
+		}
+	}
+	if contexts[2].Description != "prod" || contexts[2].TLSDir == "" {
+		t.Fatalf("expected remote context description and TLS dir, got %+v", contexts[2])
+	}
+}
+
+func TestResolveEndpointPrecedence(t *testing.T) {
+	configDir := t.TempDir()
+	writeTestContext(t, configDir, "remote", `{"Name":"remote","Endpoints":{"docker":{"Host":"tcp://10.0.0.5:2376","SkipTLSVerify":true}}}`, true)
+	writeTestContext(t, configDir, "laptop", `{"Name":"laptop","Endpoints":{"docker":{"Host":"unix:///var/run/docker.sock"}}}`, false)
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{"currentContext":"laptop"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		dockerHost    string
+		dockerContext string
+		host          string
+		contextName   string
+		wantContext   string
+		wantHost      string
+	}{
+		{name: "host flag wins", dockerHost: "tcp://env:2375", host: "tcp://flag:2375", contextName: "remote", wantHost: "tcp://flag:2375"},
+		{name: "context flag beats DOCKER_HOST", dockerHost: "tcp://env:2375", contextName: "remote", wantContext: "remote", wantHost: "tcp://10.0.0.5:2376"},
+		{name: "DOCKER_HOST beats DOCKER_CONTEXT", dockerHost: "tcp://env:2375", dockerContext: "remote", wantContext: DefaultContext},
+		{name: "DOCKER_CONTEXT beats config", dockerContext: "remote", wantContext: "remote", wantHost: "tcp://10.0.0.5:2376"},
+		{name: "config current context", wantContext: "laptop", wantHost: "unix:///var/run/docker.sock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DOCKER_HOST", tt.dockerHost)
+			t.Setenv("DOCKER_CONTEXT", tt.dockerContext)
+
+			endpoint, err := resolveEndpoint(configDir, tt.host, tt.contextName)
+			if err != nil {
+				t.Fatalf("resolveEndpoint returned error: %v", err)
+			}
+			if endpoint.Context != tt.wantContext || endpoint.Host != tt.wantHost {
+				t.Fatalf("expected context %q host %q, got %+v", tt.wantContext, tt.wantHost, endpoint)
+			}
+		})
+	}
+}
+
+func TestResolveEndpointsForSeveralContexts(t *testing.T) {
+	configDir := t.TempDir()
+	writeTestContext(t, configDir, "staging-1", `{"Name":"staging-1","Endpoints":{"docker":{"Host":"tcp://s1:2375"}}}`, false)
+	writeTestContext(t, configDir, "staging-2", `{"Name":"staging-2","Endpoints":{"docker":{"Host":"tcp://s2:2375"}}}`, false)
+
+	endpoints, err := resolveEndpoints(configDir, "", []string{"staging-1", "staging-2", "staging-1"})
+	if err != nil {
+		t.Fatalf("resolveEndpoints returned error: %v", err)
+	}
+	if len(endpoints) != 2 || endpoints[0].Host != "tcp://s1:2375" || endpoints[1].Host != "tcp://s2:2375" {
+		t.Fatalf("expected both staging endpoints once, got %+v", endpoints)
+	}
+
+	if _, err := resolveEndpoints(configDir, "tcp://flag:2375", []string{"staging-1", "staging-2"}); err == nil {
+		t.Fatal("expected --host with several contexts to fail")
+	}
+	if _, err := resolveEndpoints(configDir, "", []string{"staging-1", "missing"}); err == nil {
+		t.Fatal("expected an unknown context to fail")
+	}
+}
+
+func TestEndpointForContextLoadsTLSMaterial(t *testing.T) {
+	configDir := t.TempDir()
+	writeTestContext(t, configDir, "remote", `{"Name":"remote","Endpoints":{"docker":{"Host":"tcp://10.0.0.5:2376","SkipTLSVerify":true}}}`, true)
+
+	endpoint, err := endpointForContext(configDir, "remote")
+	if err != nil {
+		t.Fatalf("endpointForContext returned error: %v", err)
+	}
+	if endpoint.CACert == "" || endpoint.Cert == "" || endpoint.Key == "" || !endpoint.SkipTLSVerify {
+		t.Fatalf("expected TLS settings from context, got %+v", endpoint)
+	}
+
+	if _, err := endpointForContext(configDir, "missing"); err == nil {
+		t.Fatal("expected error for unknown context")
+	}
+}
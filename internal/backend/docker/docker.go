@@ -4,11 +4,15 @@ package docker
 import (
 	"archive/tar"
 	"bufio"
+	"cmp"
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,33 +23,95 @@ import (
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-connections/tlsconfig"
 	"github.com/givensuman/containertui/internal/backend"
 )
 
 // DockerBackend implements the Backend interface for Docker.
 type DockerBackend struct {
 	client *client.Client
+	// tunnel carries API requests for ssh:// endpoints.
+	tunnel *sshDialer
 }
 
 var _ backend.Backend = (*DockerBackend)(nil)
 
-// New creates a new Docker backend.
+// New creates a new Docker backend configured from the environment.
 func New() (*DockerBackend, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	return NewForEndpoint(Endpoint{})
+}
+
+// NewForEndpoint creates a new Docker backend connected to endpoint. An
+// endpoint without a host is configured from the environment, and ssh://
+// hosts, including one set in DOCKER_HOST, are reached through an SSH tunnel
+// to the remote Docker socket.
+func NewForEndpoint(endpoint Endpoint) (*DockerBackend, error) {
+	if host := cmp.Or(endpoint.Host, os.Getenv(client.EnvOverrideHost)); isSSHHost(host) {
+		endpoint.Host = host
+		login, addr, socket, err := parseSSHHost(endpoint.Host)
+		if err != nil {
+			return nil, err
+		}
+		config, agentConn, err := sshClientConfig(login)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up ssh for %s: %w", endpoint.Name(), err)
+		}
+		dialer := newSSHDialer(addr, socket, config, agentConn)
+		backend, err := newSSHBackend(dialer)
+		if err != nil {
+			_ = dialer.Close()
+			return nil, err
+		}
+		return backend, nil
+	}
+
+	opts := []client.Opt{client.WithAPIVersionNegotiation()}
+	if endpoint.Host == "" {
+		opts = append(opts, client.FromEnv)
+	} else {
+		opts = append(opts, client.WithHost(endpoint.Host), client.WithVersionFromEnv())
+		if endpoint.hasTLS() {
+			opts = append(opts, withEndpointTLS(endpoint))
+		}
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+		return nil, fmt.Errorf("failed to create Docker client for %s: %w", endpoint.Name(), err)
 	}
 	return &DockerBackend{client: cli}, nil
 }
 
+// withEndpointTLS applies the endpoint's certificates and verification
+// setting to the client transport.
+func withEndpointTLS(endpoint Endpoint) client.Opt {
+	return func(c *client.Client) error {
+		transport, ok := c.HTTPClient().Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("cannot apply TLS config to transport: %T", c.HTTPClient().Transport)
+		}
+		tlsConfig, err := tlsconfig.Client(tlsconfig.Options{
+			CAFile:             endpoint.CACert,
+			CertFile:           endpoint.Cert,
+			KeyFile:            endpoint.Key,
+			InsecureSkipVerify: endpoint.SkipTLSVerify,
+			ExclusiveRootPools: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create TLS config: %w", err)
+		}
+		transport.TLSClientConfig = tlsConfig
+		return nil
+	}
+}
+
 // Name returns the backend name.
 func (d *DockerBackend) Name() string {
 	return "docker"
 }
 
 // Version returns the Docker version.
-func (d *DockerBackend) Version() string {
-	ctx := context.Background()
+func (d *DockerBackend) Version(ctx context.Context) string {
 	version, err := d.client.ServerVersion(ctx)
 	if err != nil {
 		return "unknown"
@@ -53,11 +119,24 @@ func (d *DockerBackend) Version() string {
 	return version.Version
 }
 
+// Ping checks that the Docker daemon is reachable.
+func (d *DockerBackend) Ping(ctx context.Context) error {
+	if _, err := d.client.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to reach Docker daemon: %w", err)
+	}
+	return nil
+}
+
 // Close closes the Docker client connection.
 func (d *DockerBackend) Close() error {
 	if err := d.client.Close(); err != nil {
 		return fmt.Errorf("failed to close Docker client: %w", err)
 	}
+	if d.tunnel != nil {
+		if err := d.tunnel.Close(); err != nil {
+			return fmt.Errorf("failed to close ssh tunnel: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -81,13 +160,31 @@ func (d *DockerBackend) ListContainers(ctx context.Context) ([]backend.Container
 		// Parse created time - Docker API uses int64 Unix timestamp for list
 		createdTime := time.Unix(c.Created, 0).UTC()
 
+		ports := make([]backend.Port, len(c.Ports))
+		for j, port := range c.Ports {
+			ports[j] = backend.Port{PrivatePort: port.PrivatePort, PublicPort: port.PublicPort, Type: port.Type}
+		}
+
+		var addresses map[string]string
+		if c.NetworkSettings != nil {
+			addresses = make(map[string]string, len(c.NetworkSettings.Networks))
+			for networkName, endpoint := range c.NetworkSettings.Networks {
+				if endpoint != nil {
+					addresses[networkName] = endpoint.IPAddress
+				}
+			}
+		}
+
 		result[i] = backend.Container{
-			ID:      c.ID,
-			Name:    name,
-			Image:   c.Image,
-			State:   c.State,
-			Status:  c.Status,
-			Created: createdTime,
+			ID:          c.ID,
+			Name:        name,
+			Image:       c.Image,
+			State:       c.State,
+			Status:      c.Status,
+			Created:     createdTime,
+			Labels:      c.Labels,
+			Ports:       ports,
+			IPAddresses: addresses,
 		}
 	}
 	return result, nil
@@ -112,7 +209,9 @@ func (d *DockerBackend) InspectContainer(ctx context.Context, id string) (backen
 			State:   c.State.Status,
 			Status:  c.State.Status,
 			Created: createdTime,
+			Labels:  c.Config.Labels,
 		},
+		Lifecycle: convertLifecycle(c.State),
 		Config: backend.ContainerConfigDetail{
 			Hostname:     c.Config.Hostname,
 			Domainname:   c.Config.Domainname,
@@ -131,6 +230,7 @@ func (d *DockerBackend) InspectContainer(ctx context.Context, id string) (backen
 			Entrypoint:   c.Config.Entrypoint,
 			Labels:       c.Config.Labels,
 			ExposedPorts: convertExposedPorts(c.Config.ExposedPorts),
+			Healthcheck:  convertHealthcheck(c.Config.Healthcheck),
 		},
 		HostConfig: convertHostConfig(c.HostConfig),
 		NetworkSettings: backend.NetworkSettings{
@@ -144,6 +244,22 @@ func (d *DockerBackend) InspectContainer(ctx context.Context, id string) (backen
 	return detail, nil
 }
 
+// convertLifecycle reads the start and stop times, exit code and health from
+// a container's inspected state.
+func convertLifecycle(s *types.ContainerState) backend.ContainerLifecycle {
+	startedAt, _ := time.Parse(time.RFC3339Nano, s.StartedAt)
+	finishedAt, _ := time.Parse(time.RFC3339Nano, s.FinishedAt)
+	lifecycle := backend.ContainerLifecycle{
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		ExitCode:   s.ExitCode,
+	}
+	if s.Health != nil {
+		lifecycle.Health = s.Health.Status
+	}
+	return lifecycle
+}
+
 // GetContainerState returns the state of a container.
 func (d *DockerBackend) GetContainerState(ctx context.Context, id string) (string, error) {
 	c, err := d.client.ContainerInspect(ctx, id)
@@ -166,6 +282,14 @@ func (d *DockerBackend) CreateContainer(ctx context.Context, config backend.Cont
 		portBindings[natPort] = []nat.PortBinding{{HostPort: hostPort}}
 		exposedPorts[natPort] = struct{}{}
 	}
+	for _, port := range config.ExposedPorts {
+		proto, number := nat.SplitProtoPort(port)
+		natPort, err := nat.NewPort(proto, number)
+		if err != nil {
+			return "", fmt.Errorf("invalid port: %w", err)
+		}
+		exposedPorts[natPort] = struct{}{}
+	}
 
 	containerConfig := &container.Config{
 		Image:        config.Image,
@@ -173,10 +297,18 @@ func (d *DockerBackend) CreateContainer(ctx context.Context, config backend.Cont
 		ExposedPorts: exposedPorts,
 		Tty:          config.Tty,
 		OpenStdin:    config.OpenStdin,
+		Labels:       config.Labels,
+		Hostname:     config.Hostname,
+		Domainname:   config.Domainname,
+		User:         config.User,
+		WorkingDir:   config.WorkingDir,
 	}
 	if len(config.Cmd) > 0 {
 		containerConfig.Cmd = config.Cmd
 	}
+	if config.Entrypoint != nil {
+		containerConfig.Entrypoint = config.Entrypoint
+	}
 
 	hostConfig := &container.HostConfig{
 		Binds:        config.Volumes,
@@ -189,9 +321,39 @@ func (d *DockerBackend) CreateContainer(ctx context.Context, config backend.Cont
 	if config.AutoStart {
 		hostConfig.RestartPolicy.Name = "always"
 	}
+	if config.Host != nil {
+		var err error
+		if hostConfig, err = dockerHostConfig(*config.Host); err != nil {
+			return "", err
+		}
+		for port := range hostConfig.PortBindings {
+			exposedPorts[port] = struct{}{}
+		}
+	}
+	if len(config.Tmpfs) > 0 {
+		hostConfig.Tmpfs = make(map[string]string, len(config.Tmpfs))
+		for _, destination := range config.Tmpfs {
+			hostConfig.Tmpfs[destination] = ""
+		}
+	}
 
+	// Only one network can be given on creation; the others are connected
+	// once the container exists
 	networkConfig := &network.NetworkingConfig{}
-	if config.Network != "" {
+	var networks []string
+	if config.Networks != nil {
+		networks = slices.Sorted(maps.Keys(config.Networks))
+		if config.Host != nil && slices.Contains(networks, config.Host.NetworkMode) {
+			networks = slices.DeleteFunc(networks, func(name string) bool { return name == config.Host.NetworkMode })
+			networks = slices.Insert(networks, 0, config.Host.NetworkMode)
+		}
+	}
+	if len(networks) > 0 {
+		hostConfig.NetworkMode = container.NetworkMode(networks[0])
+		networkConfig.EndpointsConfig = map[string]*network.EndpointSettings{
+			networks[0]: dockerEndpointSettings(config.Networks[networks[0]]),
+		}
+	} else if config.Network != "" {
 		networkConfig.EndpointsConfig = map[string]*network.EndpointSettings{
 			config.Network: {},
 		}
@@ -201,9 +363,76 @@ func (d *DockerBackend) CreateContainer(ctx context.Context, config backend.Cont
 	if err != nil {
 		return "", fmt.Errorf("failed to create container: %w", err)
 	}
+	for _, name := range networks[min(1, len(networks)):] {
+		if err := d.client.NetworkConnect(ctx, name, resp.ID, dockerEndpointSettings(config.Networks[name])); err != nil {
+			// A container missing a network would otherwise pass for complete
+			_ = d.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
+			return "", fmt.Errorf("failed to connect container to network %s: %w", name, err)
+		}
+	}
 	return resp.ID, nil
 }
 
+// dockerHostConfig converts host settings back to their Docker form.
+func dockerHostConfig(hc backend.HostConfig) (*container.HostConfig, error) {
+	portBindings := nat.PortMap{}
+	for port, bindings := range hc.PortBindings {
+		proto, number := nat.SplitProtoPort(port)
+		natPort, err := nat.NewPort(proto, number)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port: %w", err)
+		}
+		for _, binding := range bindings {
+			portBindings[natPort] = append(portBindings[natPort], nat.PortBinding{HostIP: binding.HostIP, HostPort: binding.HostPort})
+		}
+	}
+
+	hostConfig := &container.HostConfig{
+		Binds:        hc.Binds,
+		NetworkMode:  container.NetworkMode(hc.NetworkMode),
+		PortBindings: portBindings,
+		RestartPolicy: container.RestartPolicy{
+			Name:              container.RestartPolicyMode(cmp.Or(hc.RestartPolicy.Name, "no")),
+			MaximumRetryCount: hc.RestartPolicy.MaximumRetryCount,
+		},
+		AutoRemove:      hc.AutoRemove,
+		Privileged:      hc.Privileged,
+		PublishAllPorts: hc.PublishAllPorts,
+		ReadonlyRootfs:  hc.ReadonlyRootfs,
+		DNS:             hc.DNS,
+		DNSOptions:      hc.DNSOptions,
+		DNSSearch:       hc.DNSSearch,
+		ExtraHosts:      hc.ExtraHosts,
+		CapAdd:          hc.CapAdd,
+		CapDrop:         hc.CapDrop,
+	}
+	hostConfig.CPUShares = hc.CpuShares
+	hostConfig.NanoCPUs = hc.NanoCPUs
+	hostConfig.Memory = hc.Memory
+	hostConfig.MemorySwap = hc.MemorySwap
+	hostConfig.MemoryReservation = hc.MemoryReservation
+	if hc.OomKillDisable {
+		hostConfig.OomKillDisable = &hc.OomKillDisable
+	}
+	if hc.PidsLimit != 0 {
+		hostConfig.PidsLimit = &hc.PidsLimit
+	}
+	return hostConfig, nil
+}
+
+// dockerEndpointSettings converts the aliases and addresses of a network
+// attachment to their Docker form.
+func dockerEndpointSettings(endpoint backend.EndpointSettings) *network.EndpointSettings {
+	settings := &network.EndpointSettings{Aliases: endpoint.Aliases, Links: endpoint.Links}
+	if endpoint.IPAMConfig != nil {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{
+			IPv4Address: endpoint.IPAMConfig.IPv4Address,
+			IPv6Address: endpoint.IPAMConfig.IPv6Address,
+		}
+	}
+	return settings
+}
+
 // StartContainer starts a container.
 func (d *DockerBackend) StartContainer(ctx context.Context, id string) error {
 	if err := d.client.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
@@ -395,6 +624,7 @@ func (d *DockerBackend) ListImages(ctx context.Context) ([]backend.Image, error)
 			RepoDigests: img.RepoDigests,
 			Size:        img.Size,
 			Created:     time.Unix(img.Created, 0),
+			Labels:      img.Labels,
 		}
 	}
 	return result, nil
@@ -409,6 +639,11 @@ func (d *DockerBackend) InspectImage(ctx context.Context, id string) (backend.Im
 
 	createdTime, _ := time.Parse(time.RFC3339Nano, img.Created)
 
+	var labels map[string]string
+	if img.Config != nil {
+		labels = img.Config.Labels
+	}
+
 	detail := backend.ImageDetail{
 		Image: backend.Image{
 			ID:          img.ID,
@@ -416,6 +651,7 @@ func (d *DockerBackend) InspectImage(ctx context.Context, id string) (backend.Im
 			RepoDigests: img.RepoDigests,
 			Size:        img.Size,
 			Created:     createdTime,
+			Labels:      labels,
 		},
 		Author:       img.Author,
 		Comment:      img.Comment,
@@ -449,6 +685,7 @@ func (d *DockerBackend) InspectImage(ctx context.Context, id string) (backend.Im
 			Entrypoint:   img.Config.Entrypoint,
 			Labels:       img.Config.Labels,
 			ExposedPorts: convertExposedPorts(img.Config.ExposedPorts),
+			Healthcheck:  convertHealthcheck(img.Config.Healthcheck),
 		}
 	}
 
@@ -545,6 +782,7 @@ func (d *DockerBackend) ListNetworks(ctx context.Context) ([]backend.Network, er
 			Name:   net.Name,
 			Driver: net.Driver,
 			Scope:  net.Scope,
+			Labels: net.Labels,
 		}
 	}
 	return result, nil
@@ -563,6 +801,7 @@ func (d *DockerBackend) InspectNetwork(ctx context.Context, id string) (backend.
 			Name:   net.Name,
 			Driver: net.Driver,
 			Scope:  net.Scope,
+			Labels: net.Labels,
 		},
 		EnableIPv6: net.EnableIPv6,
 		IPAM: backend.IPAM{
@@ -576,7 +815,6 @@ func (d *DockerBackend) InspectNetwork(ctx context.Context, id string) (backend.
 		ConfigOnly: net.ConfigOnly,
 		Containers: convertEndpointResources(net.Containers),
 		Options:    net.Options,
-		Labels:     net.Labels,
 		Raw:        net,
 	}
 
@@ -670,6 +908,7 @@ func (d *DockerBackend) ListVolumes(ctx context.Context) ([]backend.Volume, erro
 			Driver:     vol.Driver,
 			Mountpoint: vol.Mountpoint,
 			CreatedAt:  createdAt,
+			Labels:     vol.Labels,
 		}
 	}
 	return result, nil
@@ -693,8 +932,8 @@ func (d *DockerBackend) InspectVolume(ctx context.Context, name string) (backend
 			Driver:     vol.Driver,
 			Mountpoint: vol.Mountpoint,
 			CreatedAt:  createdAt,
+			Labels:     vol.Labels,
 		},
-		Labels:  vol.Labels,
 		Scope:   vol.Scope,
 		Options: vol.Options,
 		Raw:     vol,
@@ -824,6 +1063,16 @@ func (d *DockerBackend) ImageHistory(ctx context.Context, imageID string) ([]bac
 	return result, nil
 }
 
+// SaveImage streams an image as a docker save archive: its config,
+// manifest and one tar per layer.
+func (d *DockerBackend) SaveImage(ctx context.Context, id string) (io.ReadCloser, error) {
+	archive, err := d.client.ImageSave(ctx, []string{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+	return archive, nil
+}
+
 // GetAllNetworkUsage returns a map of network IDs that are in use by any container.
 func (d *DockerBackend) GetAllNetworkUsage(ctx context.Context) (map[string]bool, error) {
 	containers, err := d.client.ContainerList(ctx, container.ListOptions{All: true})
@@ -870,6 +1119,19 @@ func convertExposedPorts(ports nat.PortSet) map[string]struct{} {
 	return result
 }
 
+func convertHealthcheck(hc *container.HealthConfig) *backend.Healthcheck {
+	if hc == nil {
+		return nil
+	}
+	return &backend.Healthcheck{
+		Test:        hc.Test,
+		Interval:    hc.Interval,
+		Timeout:     hc.Timeout,
+		StartPeriod: hc.StartPeriod,
+		Retries:     hc.Retries,
+	}
+}
+
 func convertHostConfig(hc *container.HostConfig) backend.HostConfig {
 	if hc == nil {
 		return backend.HostConfig{}
@@ -912,6 +1174,7 @@ func convertHostConfig(hc *container.HostConfig) backend.HostConfig {
 		CapAdd:            hc.CapAdd,
 		CapDrop:           hc.CapDrop,
 		CpuShares:         hc.CPUShares,
+		NanoCPUs:          hc.NanoCPUs,
 		Memory:            hc.Memory,
 		MemorySwap:        hc.MemorySwap,
 		MemoryReservation: hc.MemoryReservation,
@@ -973,6 +1236,7 @@ func convertMounts(mounts []types.MountPoint) []backend.Mount {
 	for i, m := range mounts {
 		result[i] = backend.Mount{
 			Type:        string(m.Type),
+			Name:        m.Name,
 			Source:      m.Source,
 			Destination: m.Destination,
 			Mode:        m.Mode,
@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+func TestCreateContainerRemovesItWhenANetworkFailsToConnect(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var removed []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Api-Version", "1.44")
+		switch {
+		case r.URL.Path == "/_ping":
+			_, _ = io.WriteString(w, "OK")
+		case strings.HasSuffix(r.URL.Path, "/containers/create"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"Id": "abc123"})
+		case strings.HasSuffix(r.URL.Path, "/networks/shop_back/connect"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "network shop_back is full"})
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/containers/abc123"):
+			mu.Lock()
+			removed = append(removed, "abc123")
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	b, err := NewForEndpoint(Endpoint{Host: "unix://" + socket})
+	if err != nil {
+		t.Fatalf("NewForEndpoint returned error: %v", err)
+	}
+	defer b.Close()
+
+	id, err := b.CreateContainer(context.Background(), backend.ContainerConfig{
+		Name:  "shop-api-1",
+		Image: "shop/api:latest",
+		Networks: map[string]backend.EndpointSettings{
+			"shop_back":  {},
+			"shop_front": {},
+		},
+		Host: &backend.HostConfig{NetworkMode: "shop_front"},
+	})
+	if err == nil || id != "" {
+		t.Fatalf("expected CreateContainer to fail without an ID, got %q, %v", id, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(removed) != 1 {
+		t.Fatalf("expected the half-connected container removed, got %v", removed)
+	}
+}
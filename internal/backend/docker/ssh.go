@@ -0,0 +1,207 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// defaultRemoteSocket is the Docker socket used on the remote host when the
+// ssh:// URL has no path.
+const defaultRemoteSocket = "/var/run/docker.sock"
+
+// isSSHHost reports whether host is an ssh:// endpoint.
+func isSSHHost(host string) bool {
+	return strings.HasPrefix(host, "ssh://")
+}
+
+// parseSSHHost splits an ssh://[user@]host[:port][/socket] URL into the
+// login user, the host:port to dial and the remote Docker socket path.
+func parseSSHHost(host string) (login, addr, socket string, err error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid ssh host %q: %w", host, err)
+	}
+	if u.Scheme != "ssh" || u.Hostname() == "" {
+		return "", "", "", fmt.Errorf("invalid ssh host %q: expected ssh://[user@]host[:port]", host)
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		return "", "", "", fmt.Errorf("invalid ssh host %q: passwords are not supported, use ssh-agent", host)
+	}
+
+	login = u.User.Username()
+	if login == "" {
+		login = currentUsername()
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "22"
+	}
+
+	socket = u.Path
+	if socket == "" || socket == "/" {
+		socket = defaultRemoteSocket
+	}
+	return login, net.JoinHostPort(u.Hostname(), port), socket, nil
+}
+
+func currentUsername() string {
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
+
+// sshClientConfig authenticates with the keys held by the local ssh-agent and
+// verifies the host against ~/.ssh/known_hosts. The config signs through the
+// returned agent connection, which the caller closes once it is done with it.
+func sshClientConfig(login string) (*ssh.ClientConfig, net.Conn, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to locate known_hosts: %w", err)
+	}
+	hostKeyCallback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read known_hosts: %w", err)
+	}
+
+	agentSocket := os.Getenv("SSH_AUTH_SOCK")
+	if agentSocket == "" {
+		return nil, nil, errors.New("no ssh-agent available: SSH_AUTH_SOCK is not set")
+	}
+	agentConn, err := net.Dial("unix", agentSocket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to ssh-agent: %w", err)
+	}
+
+	return &ssh.ClientConfig{
+		User:            login,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers)},
+		HostKeyCallback: hostKeyCallback,
+	}, agentConn, nil
+}
+
+// sshDialer tunnels connections to the remote Docker socket over a single
+// SSH connection, re-establishing it when it drops.
+type sshDialer struct {
+	addr   string
+	socket string
+	config *ssh.ClientConfig
+	// agent is the ssh-agent connection config signs through, if any.
+	agent io.Closer
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+func newSSHDialer(addr, socket string, config *ssh.ClientConfig, agent io.Closer) *sshDialer {
+	return &sshDialer{addr: addr, socket: socket, config: config, agent: agent}
+}
+
+// DialContext opens a stream to the remote Docker socket. The network and
+// address requested by the HTTP transport are ignored.
+func (d *sshDialer) DialContext(ctx context.Context, _, _ string) (net.Conn, error) {
+	sshClient, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sshClient.Dial("unix", d.socket)
+	if err == nil {
+		return conn, nil
+	}
+
+	// The SSH connection may have gone stale; reconnect once before giving up.
+	d.reset(sshClient)
+	if sshClient, err = d.connect(ctx); err != nil {
+		return nil, err
+	}
+	conn, err = sshClient.Dial("unix", d.socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s over ssh: %w", d.socket, err)
+	}
+	return conn, nil
+}
+
+func (d *sshDialer) connect(ctx context.Context) (*ssh.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client != nil {
+		return d.client, nil
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", d.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", d.addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, d.addr, d.config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("ssh handshake with %s failed: %w", d.addr, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	d.client = ssh.NewClient(sshConn, chans, reqs)
+	return d.client, nil
+}
+
+func (d *sshDialer) reset(stale *ssh.Client) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client == stale {
+		_ = d.client.Close()
+		d.client = nil
+	}
+}
+
+// Close closes the SSH connection, if any, and the ssh-agent connection.
+func (d *sshDialer) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var errs []error
+	if d.client != nil {
+		errs = append(errs, d.client.Close())
+		d.client = nil
+	}
+	if d.agent != nil {
+		errs = append(errs, d.agent.Close())
+		d.agent = nil
+	}
+	return errors.Join(errs...)
+}
+
+// newSSHBackend creates a Docker backend whose API requests are tunnelled
+// through dialer.
+func newSSHBackend(dialer *sshDialer) (*DockerBackend, error) {
+	cli, err := client.NewClientWithOpts(
+		client.WithAPIVersionNegotiation(),
+		client.WithVersionFromEnv(),
+		// The host is only used to build request URLs; every connection goes through the tunnel.
+		client.WithHost("http://docker.sock"),
+		client.WithDialContext(dialer.DialContext),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client over ssh: %w", err)
+	}
+	return &DockerBackend{client: cli, tunnel: dialer}, nil
+}
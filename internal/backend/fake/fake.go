@@ -0,0 +1,1482 @@
+// Package fake provides an in-memory backend.Backend for demos and tests.
+// It keeps a mutable model of containers, images, networks and volumes, so
+// the UI can be exercised end to end without a container daemon.
+package fake
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// Version is the daemon version reported by the fake backend.
+const Version = "fake-1.0"
+
+// ErrNotFound is wrapped by errors for resources that do not exist.
+var ErrNotFound = errors.New("not found")
+
+// defaultNetworks exist on every fake daemon and are never pruned.
+var defaultNetworks = []string{"bridge", "host", "none"}
+
+type containerRecord struct {
+	detail   backend.ContainerDetail
+	exitCode int
+	logs     []string
+}
+
+type imageRecord struct {
+	detail  backend.ImageDetail
+	history []backend.ImageHistoryItem
+	layers  []FixtureLayer
+}
+
+// Backend is an in-memory container runtime. It is safe for concurrent use.
+type Backend struct {
+	mu         sync.Mutex
+	containers []*containerRecord
+	images     []*imageRecord
+	networks   []*backend.NetworkDetail
+	volumes    []*backend.VolumeDetail
+	closed     bool
+
+	// Now returns the current time; tests may replace it.
+	Now func() time.Time
+}
+
+var _ backend.Backend = (*Backend)(nil)
+
+// New creates a fake backend seeded with fixture.
+func New(fixture Fixture) (*Backend, error) {
+	b := &Backend{Now: time.Now}
+	for _, name := range defaultNetworks {
+		driver := name
+		if name == "none" {
+			driver = "null"
+		}
+		b.networks = append(b.networks, &backend.NetworkDetail{
+			Network: backend.Network{ID: newID(), Name: name, Driver: driver, Scope: "local"},
+		})
+	}
+
+	for _, image := range fixture.Images {
+		b.addImage(image)
+	}
+	for _, network := range fixture.Networks {
+		if network.Name == "" {
+			return nil, errors.New("fixture network without a name")
+		}
+		b.networks = append(b.networks, &backend.NetworkDetail{
+			Network: backend.Network{
+				ID:     cmp.Or(network.ID, newID()),
+				Name:   network.Name,
+				Driver: cmp.Or(network.Driver, "bridge"),
+				Scope:  "local",
+				Labels: network.Labels,
+			},
+			IPAM:     backend.IPAM{Driver: "default", Config: ipamConfig(network.Subnet, network.Gateway)},
+			Internal: network.Internal,
+		})
+	}
+	for _, volume := range fixture.Volumes {
+		if volume.Name == "" {
+			return nil, errors.New("fixture volume without a name")
+		}
+		b.addVolume(volume.Name, volume.Driver, volume.Labels)
+	}
+	for _, container := range fixture.Containers {
+		if err := b.addFixtureContainer(container); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func newID() string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func ipamConfig(subnet, gateway string) []backend.IPAMConfig {
+	if subnet == "" && gateway == "" {
+		return nil
+	}
+	return []backend.IPAMConfig{{Subnet: subnet, Gateway: gateway}}
+}
+
+func (b *Backend) addImage(image FixtureImage) *imageRecord {
+	id := image.ID
+	if id == "" {
+		id = "sha256:" + newID()
+	}
+	created := image.Created
+	if created.IsZero() {
+		created = b.Now()
+	}
+
+	exposed := make(map[string]struct{}, len(image.ExposedPorts))
+	for _, port := range image.ExposedPorts {
+		exposed[port] = struct{}{}
+	}
+
+	record := &imageRecord{detail: backend.ImageDetail{
+		Image: backend.Image{
+			ID:       id,
+			RepoTags: slices.Clone(image.Tags),
+			Size:     image.Size,
+			Created:  created,
+			Labels:   image.Labels,
+		},
+		Config: backend.ContainerConfigDetail{
+			Cmd:          image.Cmd,
+			Entrypoint:   image.Entrypoint,
+			Env:          image.Env,
+			WorkingDir:   image.WorkingDir,
+			User:         image.User,
+			Labels:       image.Labels,
+			ExposedPorts: exposed,
+		},
+		Architecture: "amd64",
+		Os:           "linux",
+		Size:         image.Size,
+		VirtualSize:  image.Size,
+		RootFS:       backend.RootFS{Type: "layers"},
+	}, layers: slices.Clone(image.Layers)}
+
+	for _, layer := range image.Layers {
+		if layer.Size > 0 {
+			record.detail.RootFS.Layers = append(record.detail.RootFS.Layers, "sha256:"+newID())
+		}
+	}
+
+	// History is reported newest first, with the image ID on the top layer
+	for i := len(image.Layers) - 1; i >= 0; i-- {
+		layer := image.Layers[i]
+		item := backend.ImageHistoryItem{
+			ID:        "<missing>",
+			Created:   created,
+			CreatedBy: layer.CreatedBy,
+			Size:      layer.Size,
+			Comment:   layer.Comment,
+		}
+		if i == len(image.Layers)-1 {
+			item.ID = id
+			item.Tags = slices.Clone(image.Tags)
+		}
+		record.history = append(record.history, item)
+	}
+
+	b.images = append(b.images, record)
+	return record
+}
+
+func (b *Backend) addVolume(name, driver string, labels map[string]string) *backend.VolumeDetail {
+	volume := &backend.VolumeDetail{
+		Volume: backend.Volume{
+			Name:       name,
+			Driver:     cmp.Or(driver, "local"),
+			Mountpoint: "/var/lib/docker/volumes/" + name + "/_data",
+			CreatedAt:  b.Now(),
+			Labels:     labels,
+		},
+		Scope: "local",
+	}
+	b.volumes = append(b.volumes, volume)
+	return volume
+}
+
+func (b *Backend) addFixtureContainer(fixture FixtureContainer) error {
+	if fixture.Name == "" {
+		return errors.New("fixture container without a name")
+	}
+	config := backend.ContainerConfig{
+		Name:    fixture.Name,
+		Image:   fixture.Image,
+		Ports:   fixture.Ports,
+		Volumes: fixture.Volumes,
+		Env:     fixture.Env,
+		Cmd:     fixture.Cmd,
+	}
+	if len(fixture.Networks) > 0 {
+		config.Network = fixture.Networks[0]
+	}
+
+	record, err := b.createContainer(config, fixture.ID)
+	if err != nil {
+		return fmt.Errorf("fixture container %s: %w", fixture.Name, err)
+	}
+	for _, network := range fixture.Networks[min(1, len(fixture.Networks)):] {
+		if err := b.connect(record, network); err != nil {
+			return fmt.Errorf("fixture container %s: %w", fixture.Name, err)
+		}
+	}
+
+	record.detail.Config.Labels = fixture.Labels
+	record.detail.HostConfig.RestartPolicy.Name = fixture.Restart
+	record.logs = fixture.Logs
+	record.exitCode = fixture.ExitCode
+	if !fixture.Created.IsZero() {
+		record.detail.Created = fixture.Created
+	}
+	switch fixture.State {
+	case "", "created":
+	case "running", "paused", "exited":
+		b.setState(record, fixture.State)
+	default:
+		return fmt.Errorf("fixture container %s: unknown state %q", fixture.Name, fixture.State)
+	}
+	return nil
+}
+
+// setState moves a container to state and updates its status text and
+// lifecycle times.
+func (b *Backend) setState(record *containerRecord, state string) {
+	previous := record.detail.State
+	record.detail.State = state
+	switch state {
+	case "running":
+		record.detail.Status = "Up"
+		// Unpausing keeps the container's start time
+		if previous != "paused" {
+			record.detail.Lifecycle.StartedAt = b.Now()
+		}
+	case "paused":
+		record.detail.Status = "Up (Paused)"
+	case "exited":
+		record.detail.Status = fmt.Sprintf("Exited (%d)", record.exitCode)
+		record.detail.Lifecycle.FinishedAt = b.Now()
+		record.detail.Lifecycle.ExitCode = record.exitCode
+	case "created":
+		record.detail.Status = "Created"
+	}
+}
+
+func notFound(kind, id string) error {
+	return fmt.Errorf("no such %s: %s: %w", kind, id, ErrNotFound)
+}
+
+// findContainer resolves a container by ID, unique ID prefix or name.
+func (b *Backend) findContainer(id string) (*containerRecord, error) {
+	var match *containerRecord
+	for _, record := range b.containers {
+		if record.detail.ID == id || record.detail.Name == id || record.detail.Name == strings.TrimPrefix(id, "/") {
+			return record, nil
+		}
+		if id != "" && strings.HasPrefix(record.detail.ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("multiple containers match %s", id)
+			}
+			match = record
+		}
+	}
+	if match == nil {
+		return nil, notFound("container", id)
+	}
+	return match, nil
+}
+
+// findImage resolves an image by ID, ID prefix or tag. Untagged references
+// default to the latest tag.
+func (b *Backend) findImage(ref string) (*imageRecord, error) {
+	withLatest := ref
+	if !strings.Contains(ref[strings.LastIndex(ref, "/")+1:], ":") {
+		withLatest = ref + ":latest"
+	}
+	for _, record := range b.images {
+		id := record.detail.ID
+		if id == ref || strings.TrimPrefix(id, "sha256:") == ref ||
+			(len(ref) >= 12 && strings.HasPrefix(strings.TrimPrefix(id, "sha256:"), strings.TrimPrefix(ref, "sha256:"))) {
+			return record, nil
+		}
+		if slices.Contains(record.detail.RepoTags, ref) || slices.Contains(record.detail.RepoTags, withLatest) {
+			return record, nil
+		}
+	}
+	return nil, notFound("image", ref)
+}
+
+func (b *Backend) findNetwork(id string) (*backend.NetworkDetail, error) {
+	for _, network := range b.networks {
+		if network.ID == id || network.Name == id || (len(id) >= 12 && strings.HasPrefix(network.ID, id)) {
+			return network, nil
+		}
+	}
+	return nil, notFound("network", id)
+}
+
+func (b *Backend) findVolume(name string) (*backend.VolumeDetail, error) {
+	for _, volume := range b.volumes {
+		if volume.Name == name {
+			return volume, nil
+		}
+	}
+	return nil, notFound("volume", name)
+}
+
+// Name returns the backend name.
+func (b *Backend) Name() string {
+	return "fake"
+}
+
+// Version returns the fake daemon version.
+func (b *Backend) Version(context.Context) string {
+	return Version
+}
+
+// Ping fails once the backend has been closed.
+func (b *Backend) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return errors.New("fake backend is closed")
+	}
+	return nil
+}
+
+// Close marks the backend as closed. Its state is kept.
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+// Container operations
+
+// ListContainers returns every container, oldest first.
+func (b *Backend) ListContainers(ctx context.Context) ([]backend.Container, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	containers := make([]backend.Container, len(b.containers))
+	for i, record := range b.containers {
+		containers[i] = record.detail.Container
+		containers[i].Labels = record.detail.Config.Labels
+		containers[i].IPAddresses = make(map[string]string, len(record.detail.NetworkSettings.Networks))
+		for name, endpoint := range record.detail.NetworkSettings.Networks {
+			containers[i].IPAddresses[name] = endpoint.IPAddress
+		}
+	}
+	return containers, nil
+}
+
+// InspectContainer returns a copy of a container's details.
+func (b *Backend) InspectContainer(ctx context.Context, id string) (backend.ContainerDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return backend.ContainerDetail{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(id)
+	if err != nil {
+		return backend.ContainerDetail{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	detail := record.detail
+	detail.Labels = detail.Config.Labels
+	detail.Raw = nil
+	return detail, nil
+}
+
+// GetContainerState returns a container's state.
+func (b *Backend) GetContainerState(ctx context.Context, id string) (string, error) {
+	detail, err := b.InspectContainer(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container state: %w", errors.Unwrap(err))
+	}
+	return detail.State, nil
+}
+
+// CreateContainer creates a container from config, starting it if AutoStart is set.
+func (b *Backend) CreateContainer(ctx context.Context, config backend.ContainerConfig) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.createContainer(config, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create container: %w", err)
+	}
+	if config.AutoStart {
+		b.setState(record, "running")
+	}
+	return record.detail.ID, nil
+}
+
+func (b *Backend) createContainer(config backend.ContainerConfig, id string) (*containerRecord, error) {
+	image, err := b.findImage(config.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "fake_" + newID()[:8]
+	}
+	if _, err := b.findContainer(name); err == nil {
+		return nil, fmt.Errorf("container name %q is already in use", name)
+	}
+
+	cmd := config.Cmd
+	if len(cmd) == 0 {
+		cmd = image.detail.Config.Cmd
+	}
+	entrypoint := image.detail.Config.Entrypoint
+	if config.Entrypoint != nil {
+		entrypoint = config.Entrypoint
+	}
+
+	// Host settings are made up from the simple ones unless given whole
+	binds := config.Volumes
+	bindings := map[string][]backend.PortBinding{}
+	for hostPort, containerPort := range config.Ports {
+		port := containerPort
+		if !strings.Contains(port, "/") {
+			port += "/tcp"
+		}
+		bindings[port] = []backend.PortBinding{{HostIP: "0.0.0.0", HostPort: hostPort}}
+	}
+	host := backend.HostConfig{
+		NetworkMode: cmp.Or(config.Network, "bridge"),
+		AutoRemove:  config.AutoRemove,
+	}
+	if config.AutoStart {
+		host.RestartPolicy.Name = "always"
+	}
+	if config.Host != nil {
+		host = *config.Host
+		binds = host.Binds
+		bindings = maps.Clone(host.PortBindings)
+		host.NetworkMode = cmp.Or(host.NetworkMode, "bridge")
+	}
+	host.Binds = binds
+	host.PortBindings = bindings
+
+	record := &containerRecord{detail: backend.ContainerDetail{
+		Container: backend.Container{
+			ID:      cmp.Or(id, newID()),
+			Name:    name,
+			Image:   config.Image,
+			Created: b.Now(),
+		},
+		Config: backend.ContainerConfigDetail{
+			Env:          append(slices.Clone(image.detail.Config.Env), config.Env...),
+			Cmd:          cmd,
+			Image:        config.Image,
+			Entrypoint:   entrypoint,
+			WorkingDir:   cmp.Or(config.WorkingDir, image.detail.Config.WorkingDir),
+			User:         cmp.Or(config.User, image.detail.Config.User),
+			Domainname:   config.Domainname,
+			Labels:       maps.Clone(config.Labels),
+			Tty:          config.Tty,
+			OpenStdin:    config.OpenStdin,
+			ExposedPorts: maps.Clone(image.detail.Config.ExposedPorts),
+		},
+		HostConfig: host,
+		NetworkSettings: backend.NetworkSettings{
+			Networks: map[string]backend.EndpointSettings{},
+			Ports:    map[string][]backend.PortBinding{},
+		},
+	}}
+	record.detail.Config.Hostname = cmp.Or(config.Hostname, record.detail.ID[:12])
+	for _, port := range config.ExposedPorts {
+		if record.detail.Config.ExposedPorts == nil {
+			record.detail.Config.ExposedPorts = map[string]struct{}{}
+		}
+		record.detail.Config.ExposedPorts[port] = struct{}{}
+	}
+
+	for port, binding := range bindings {
+		record.detail.NetworkSettings.Ports[port] = binding
+		if record.detail.Config.ExposedPorts == nil {
+			record.detail.Config.ExposedPorts = map[string]struct{}{}
+		}
+		record.detail.Config.ExposedPorts[port] = struct{}{}
+
+		private, protocol, _ := strings.Cut(port, "/")
+		privatePort, _ := strconv.ParseUint(private, 10, 16)
+		for _, bound := range binding {
+			publicPort, _ := strconv.ParseUint(bound.HostPort, 10, 16)
+			record.detail.Ports = append(record.detail.Ports, backend.Port{
+				PrivatePort: uint16(privatePort),
+				PublicPort:  uint16(publicPort),
+				Type:        protocol,
+			})
+		}
+	}
+	slices.SortFunc(record.detail.Ports, func(a, b backend.Port) int {
+		return cmp.Compare(a.PrivatePort, b.PrivatePort)
+	})
+
+	for _, bind := range binds {
+		source, destination, ok := strings.Cut(bind, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid volume %q", bind)
+		}
+		destination, mode, _ := strings.Cut(destination, ":")
+		mount := backend.Mount{Source: source, Destination: destination, Mode: mode, RW: mode != "ro"}
+		if strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") {
+			mount.Type = "bind"
+		} else {
+			mount.Type = "volume"
+			mount.Name = source
+			if _, err := b.findVolume(source); err != nil {
+				b.addVolume(source, "", nil)
+			}
+		}
+		record.detail.Mounts = append(record.detail.Mounts, mount)
+	}
+	for _, destination := range config.Tmpfs {
+		record.detail.Mounts = append(record.detail.Mounts, backend.Mount{Type: "tmpfs", Destination: destination, RW: true})
+	}
+
+	switch mode := host.NetworkMode; {
+	case len(config.Networks) > 0:
+		for _, network := range slices.Sorted(maps.Keys(config.Networks)) {
+			if err := b.connect(record, network); err != nil {
+				return nil, err
+			}
+			endpoint := record.detail.NetworkSettings.Networks[network]
+			endpoint.Aliases = config.Networks[network].Aliases
+			endpoint.IPAMConfig = config.Networks[network].IPAMConfig
+			record.detail.NetworkSettings.Networks[network] = endpoint
+		}
+	case strings.HasPrefix(mode, "container:"):
+		// The container shares the other container's network stack
+	default:
+		if err := b.connect(record, mode); err != nil {
+			return nil, err
+		}
+	}
+
+	b.setState(record, "created")
+	b.containers = append(b.containers, record)
+	return record, nil
+}
+
+// connect attaches a container to a network.
+func (b *Backend) connect(record *containerRecord, networkID string) error {
+	network, err := b.findNetwork(networkID)
+	if err != nil {
+		return err
+	}
+	if _, ok := record.detail.NetworkSettings.Networks[network.Name]; ok {
+		return fmt.Errorf("container %s is already connected to network %s", record.detail.Name, network.Name)
+	}
+
+	endpointID := newID()
+	address := fmt.Sprintf("172.18.0.%d", len(network.Containers)+2)
+	record.detail.NetworkSettings.Networks[network.Name] = backend.EndpointSettings{
+		NetworkID:   network.ID,
+		EndpointID:  endpointID,
+		IPAddress:   address,
+		IPPrefixLen: 16,
+	}
+	if network.Containers == nil {
+		network.Containers = map[string]backend.EndpointResource{}
+	}
+	network.Containers[record.detail.ID] = backend.EndpointResource{
+		Name:        record.detail.Name,
+		EndpointID:  endpointID,
+		IPv4Address: address + "/16",
+	}
+	return nil
+}
+
+// transition applies a state change to a container, failing when the
+// container is not in one of the allowed states.
+func (b *Backend) transition(ctx context.Context, verb, id string, allowed []string, next string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(id)
+	if err != nil {
+		return fmt.Errorf("failed to %s container: %w", verb, err)
+	}
+	if !slices.Contains(allowed, record.detail.State) {
+		return fmt.Errorf("failed to %s container: container %s is %s", verb, record.detail.Name, record.detail.State)
+	}
+	if next == "exited" {
+		record.exitCode = 0
+	}
+	b.setState(record, next)
+	if next == "exited" && record.detail.HostConfig.AutoRemove {
+		b.removeContainer(record)
+	}
+	return nil
+}
+
+func eachID(ids []string, fn func(id string) error) error {
+	var errs []error
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StartContainer starts a created or exited container.
+func (b *Backend) StartContainer(ctx context.Context, id string) error {
+	return b.transition(ctx, "start", id, []string{"created", "exited", "running"}, "running")
+}
+
+// StartContainers starts multiple containers.
+func (b *Backend) StartContainers(ctx context.Context, ids []string) error {
+	return eachID(ids, func(id string) error { return b.StartContainer(ctx, id) })
+}
+
+// StopContainer stops a running or paused container.
+func (b *Backend) StopContainer(ctx context.Context, id string) error {
+	return b.transition(ctx, "stop", id, []string{"running", "paused", "exited", "created"}, "exited")
+}
+
+// StopContainers stops multiple containers.
+func (b *Backend) StopContainers(ctx context.Context, ids []string) error {
+	return eachID(ids, func(id string) error { return b.StopContainer(ctx, id) })
+}
+
+// RestartContainer restarts a container.
+func (b *Backend) RestartContainer(ctx context.Context, id string) error {
+	return b.transition(ctx, "restart", id, []string{"running", "paused", "exited", "created"}, "running")
+}
+
+// RestartContainers restarts multiple containers.
+func (b *Backend) RestartContainers(ctx context.Context, ids []string) error {
+	return eachID(ids, func(id string) error { return b.RestartContainer(ctx, id) })
+}
+
+// PauseContainer pauses a running container.
+func (b *Backend) PauseContainer(ctx context.Context, id string) error {
+	return b.transition(ctx, "pause", id, []string{"running"}, "paused")
+}
+
+// PauseContainers pauses multiple containers.
+func (b *Backend) PauseContainers(ctx context.Context, ids []string) error {
+	return eachID(ids, func(id string) error { return b.PauseContainer(ctx, id) })
+}
+
+// UnpauseContainer resumes a paused container.
+func (b *Backend) UnpauseContainer(ctx context.Context, id string) error {
+	return b.transition(ctx, "unpause", id, []string{"paused"}, "running")
+}
+
+// UnpauseContainers resumes multiple containers.
+func (b *Backend) UnpauseContainers(ctx context.Context, ids []string) error {
+	return eachID(ids, func(id string) error { return b.UnpauseContainer(ctx, id) })
+}
+
+// RemoveContainer removes a container. Running containers need force.
+func (b *Backend) RemoveContainer(ctx context.Context, id string, force bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(id)
+	if err != nil {
+		return fmt.Errorf("failed to remove container: %w", err)
+	}
+	if !force && (record.detail.State == "running" || record.detail.State == "paused") {
+		return fmt.Errorf("failed to remove container: container %s is %s, stop it or use force", record.detail.Name, record.detail.State)
+	}
+	b.removeContainer(record)
+	return nil
+}
+
+func (b *Backend) removeContainer(record *containerRecord) {
+	for _, network := range b.networks {
+		delete(network.Containers, record.detail.ID)
+	}
+	b.containers = slices.DeleteFunc(b.containers, func(r *containerRecord) bool { return r == record })
+}
+
+// RemoveContainers removes multiple containers.
+func (b *Backend) RemoveContainers(ctx context.Context, ids []string, force bool) error {
+	return eachID(ids, func(id string) error { return b.RemoveContainer(ctx, id, force) })
+}
+
+// RenameContainer renames a container.
+func (b *Backend) RenameContainer(ctx context.Context, id, newName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(id)
+	if err != nil {
+		return fmt.Errorf("failed to rename container: %w", err)
+	}
+	if existing, err := b.findContainer(newName); err == nil && existing != record {
+		return fmt.Errorf("failed to rename container: name %q is already in use", newName)
+	}
+	record.detail.Name = newName
+	for _, network := range b.networks {
+		if resource, ok := network.Containers[record.detail.ID]; ok {
+			resource.Name = newName
+			network.Containers[record.detail.ID] = resource
+		}
+	}
+	return nil
+}
+
+// PruneContainers removes every container that is not running or paused.
+func (b *Backend) PruneContainers(ctx context.Context) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var reclaimed uint64
+	for _, record := range slices.Clone(b.containers) {
+		if record.detail.State == "exited" || record.detail.State == "created" || record.detail.State == "dead" {
+			reclaimed += 4096
+			b.removeContainer(record)
+		}
+	}
+	return reclaimed, nil
+}
+
+// Container logs and exec
+
+// OpenLogs returns the container's canned log lines.
+func (b *Backend) OpenLogs(ctx context.Context, id string) (backend.Logs, error) {
+	if err := ctx.Err(); err != nil {
+		return backend.Logs{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(id)
+	if err != nil {
+		return backend.Logs{}, fmt.Errorf("failed to open logs: %w", err)
+	}
+
+	var lines strings.Builder
+	for _, line := range record.logs {
+		fmt.Fprintf(&lines, "%s %s\n", record.detail.Created.UTC().Format(time.RFC3339Nano), line)
+	}
+	stream := io.NopCloser(strings.NewReader(lines.String()))
+	return backend.Logs{Stream: stream, Close: stream.Close}, nil
+}
+
+// ExecShell is not supported by the fake backend.
+func (b *Backend) ExecShell(ctx context.Context, id string, shell []string) (io.ReadWriteCloser, error) {
+	return nil, errors.New("exec is not supported by the fake backend")
+}
+
+// Image operations
+
+// ListImages returns every image.
+func (b *Backend) ListImages(ctx context.Context) ([]backend.Image, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	images := make([]backend.Image, len(b.images))
+	for i, record := range b.images {
+		images[i] = record.detail.Image
+		images[i].RepoTags = slices.Clone(record.detail.RepoTags)
+	}
+	return images, nil
+}
+
+// InspectImage returns a copy of an image's details.
+func (b *Backend) InspectImage(ctx context.Context, id string) (backend.ImageDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return backend.ImageDetail{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findImage(id)
+	if err != nil {
+		return backend.ImageDetail{}, fmt.Errorf("failed to inspect image: %w", err)
+	}
+	return record.detail, nil
+}
+
+// pullMessage is a line of the Docker pull progress stream.
+type pullMessage struct {
+	Status         string `json:"status"`
+	ID             string `json:"id,omitempty"`
+	ProgressDetail *struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail,omitempty"`
+}
+
+// PullImage simulates a pull, streaming Docker-style progress messages and
+// adding the image if it does not exist yet.
+func (b *Backend) PullImage(ctx context.Context, ref string, progressChan chan<- string) error {
+	if !strings.Contains(ref[strings.LastIndex(ref, "/")+1:], ":") {
+		ref += ":latest"
+	}
+	repository, tag, _ := strings.Cut(ref, ":")
+
+	send := func(message pullMessage) error {
+		line, _ := json.Marshal(message)
+		select {
+		case progressChan <- string(line):
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("failed to pull image: %w", ctx.Err())
+		}
+	}
+
+	if err := send(pullMessage{Status: "Pulling from " + repository, ID: tag}); err != nil {
+		return err
+	}
+	const total = 3 << 20
+	for _, layer := range []string{newID()[:12], newID()[:12]} {
+		for current := int64(0); current <= total; current += total / 2 {
+			message := pullMessage{Status: "Downloading", ID: layer}
+			message.ProgressDetail = &struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			}{Current: current, Total: total}
+			if err := send(message); err != nil {
+				return err
+			}
+		}
+		if err := send(pullMessage{Status: "Pull complete", ID: layer}); err != nil {
+			return err
+		}
+	}
+
+	b.mu.Lock()
+	status := "Status: Image is up to date for " + ref
+	if _, err := b.findImage(ref); err != nil {
+		b.addImage(FixtureImage{Tags: []string{ref}, Size: 2 * total, Layers: []FixtureLayer{
+			{CreatedBy: "/bin/sh -c #(nop) ADD file:rootfs in / ", Size: 2 * total},
+			{CreatedBy: `/bin/sh -c #(nop)  CMD ["sh"]`},
+		}})
+		status = "Status: Downloaded newer image for " + ref
+	}
+	b.mu.Unlock()
+
+	if err := send(pullMessage{Status: status}); err != nil {
+		return err
+	}
+	close(progressChan)
+	return nil
+}
+
+// BuildImage simulates a build of the Dockerfile at dockerfilePath, streaming
+// one Docker-style step per instruction and tagging the result.
+func (b *Backend) BuildImage(ctx context.Context, dockerfilePath, tag, contextPath string, buildArgs map[string]*string) (io.ReadCloser, error) {
+	if !filepath.IsAbs(dockerfilePath) {
+		dockerfilePath = filepath.Join(contextPath, dockerfilePath)
+	}
+	data, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+
+	var instructions []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			instructions = append(instructions, line)
+		}
+	}
+	if len(instructions) == 0 {
+		return nil, errors.New("failed to build image: the Dockerfile is empty")
+	}
+
+	var out strings.Builder
+	encoder := json.NewEncoder(&out)
+	layers := make([]FixtureLayer, 0, len(instructions))
+	for i, instruction := range instructions {
+		_ = encoder.Encode(map[string]string{"stream": fmt.Sprintf("Step %d/%d : %s\n", i+1, len(instructions), instruction)})
+		_ = encoder.Encode(map[string]string{"stream": fmt.Sprintf(" ---> %s\n", newID()[:12])})
+		layers = append(layers, FixtureLayer{CreatedBy: "/bin/sh -c " + instruction})
+	}
+
+	b.mu.Lock()
+	image := FixtureImage{Size: 1 << 20, Layers: layers}
+	if tag != "" {
+		image.Tags = []string{tag}
+		b.untag(tag)
+	}
+	record := b.addImage(image)
+	b.mu.Unlock()
+
+	_ = encoder.Encode(map[string]string{"stream": fmt.Sprintf("Successfully built %s\n", strings.TrimPrefix(record.detail.ID, "sha256:")[:12])})
+	if tag != "" {
+		_ = encoder.Encode(map[string]string{"stream": fmt.Sprintf("Successfully tagged %s\n", tag)})
+	}
+	return io.NopCloser(strings.NewReader(out.String())), nil
+}
+
+// untag removes tag from whichever image currently holds it.
+func (b *Backend) untag(tag string) {
+	for _, record := range b.images {
+		record.detail.RepoTags = slices.DeleteFunc(record.detail.RepoTags, func(t string) bool { return t == tag })
+	}
+}
+
+// TagImage adds target as a tag of source.
+func (b *Backend) TagImage(ctx context.Context, source, target string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findImage(source)
+	if err != nil {
+		return fmt.Errorf("failed to tag image: %w", err)
+	}
+	if !strings.Contains(target[strings.LastIndex(target, "/")+1:], ":") {
+		target += ":latest"
+	}
+	b.untag(target)
+	record.detail.RepoTags = append(record.detail.RepoTags, target)
+	return nil
+}
+
+// RemoveImage removes an image that no container uses.
+func (b *Backend) RemoveImage(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findImage(id)
+	if err != nil {
+		return fmt.Errorf("failed to remove image: %w", err)
+	}
+	if users := b.containersUsingImage(record); len(users) > 0 {
+		return fmt.Errorf("failed to remove image: image is being used by container %s", users[0])
+	}
+	b.images = slices.DeleteFunc(b.images, func(r *imageRecord) bool { return r == record })
+	return nil
+}
+
+// RemoveImages removes multiple images.
+func (b *Backend) RemoveImages(ctx context.Context, ids []string) error {
+	return eachID(ids, func(id string) error { return b.RemoveImage(ctx, id) })
+}
+
+// PruneImages removes untagged images that no container uses.
+func (b *Backend) PruneImages(ctx context.Context) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var reclaimed uint64
+	for _, record := range slices.Clone(b.images) {
+		if len(record.detail.RepoTags) == 0 && len(b.containersUsingImage(record)) == 0 {
+			reclaimed += uint64(record.detail.Size)
+			b.images = slices.DeleteFunc(b.images, func(r *imageRecord) bool { return r == record })
+		}
+	}
+	return reclaimed, nil
+}
+
+func (b *Backend) containersUsingImage(image *imageRecord) []string {
+	var names []string
+	for _, record := range b.containers {
+		if resolved, err := b.findImage(record.detail.Image); err == nil && resolved == image {
+			names = append(names, record.detail.Name)
+		}
+	}
+	return names
+}
+
+// Image history and usage
+
+// ImageHistory returns an image's layers, newest first.
+func (b *Backend) ImageHistory(ctx context.Context, imageID string) ([]backend.ImageHistoryItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findImage(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image history: %w", err)
+	}
+	return slices.Clone(record.history), nil
+}
+
+// SaveImage writes an image as a docker save archive, with each layer
+// holding the files its fixture lists. File contents are zeros.
+func (b *Backend) SaveImage(ctx context.Context, id string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findImage(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	type step struct {
+		Created    time.Time `json:"created"`
+		CreatedBy  string    `json:"created_by"`
+		Comment    string    `json:"comment,omitempty"`
+		EmptyLayer bool      `json:"empty_layer,omitempty"`
+	}
+	var history []step
+	var layerPaths []string
+	var out bytes.Buffer
+	archive := tar.NewWriter(&out)
+	for _, layer := range record.layers {
+		history = append(history, step{Created: record.detail.Created, CreatedBy: layer.CreatedBy, Comment: layer.Comment, EmptyLayer: layer.Size == 0})
+		if layer.Size == 0 {
+			continue
+		}
+		diffID := record.detail.RootFS.Layers[len(layerPaths)]
+		layerPath := strings.TrimPrefix(diffID, "sha256:") + "/layer.tar"
+		layerPaths = append(layerPaths, layerPath)
+		if err := writeTarFile(archive, layerPath, layerTar(layer.Files)); err != nil {
+			return nil, fmt.Errorf("failed to save image: %w", err)
+		}
+	}
+
+	config, err := json.Marshal(map[string]any{
+		"architecture": record.detail.Architecture,
+		"os":           record.detail.Os,
+		"created":      record.detail.Created,
+		"rootfs":       map[string]any{"type": "layers", "diff_ids": record.detail.RootFS.Layers},
+		"history":      history,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+	configPath := strings.TrimPrefix(record.detail.ID, "sha256:") + ".json"
+	manifest, err := json.Marshal([]map[string]any{{
+		"Config":   configPath,
+		"RepoTags": record.detail.RepoTags,
+		"Layers":   layerPaths,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+	for _, file := range []struct {
+		path string
+		data []byte
+	}{{configPath, config}, {"manifest.json", manifest}} {
+		if err := writeTarFile(archive, file.path, file.data); err != nil {
+			return nil, fmt.Errorf("failed to save image: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+	return io.NopCloser(&out), nil
+}
+
+// layerTar writes a layer's files as a tar, with deleted files as
+// whiteouts.
+func layerTar(files []FixtureFile) []byte {
+	var out bytes.Buffer
+	layer := tar.NewWriter(&out)
+	for _, file := range files {
+		if file.Deleted {
+			dir, name := path.Split(file.Path)
+			_ = writeTarFile(layer, dir+".wh."+name, nil)
+			continue
+		}
+		_ = writeTarFile(layer, file.Path, make([]byte, file.Size))
+	}
+	_ = layer.Close()
+	return out.Bytes()
+}
+
+func writeTarFile(writer *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+	if err := writer.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := writer.Write(data)
+	return err
+}
+
+// GetAllNetworkUsage returns the IDs of networks with containers attached.
+func (b *Backend) GetAllNetworkUsage(ctx context.Context) (map[string]bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	usage := make(map[string]bool)
+	for _, record := range b.containers {
+		for _, settings := range record.detail.NetworkSettings.Networks {
+			usage[settings.NetworkID] = true
+		}
+	}
+	return usage, nil
+}
+
+// GetAllVolumeUsage returns the names of volumes mounted by any container.
+func (b *Backend) GetAllVolumeUsage(ctx context.Context) (map[string]bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	usage := make(map[string]bool)
+	for _, record := range b.containers {
+		for _, mount := range record.detail.Mounts {
+			if mount.Type == "volume" {
+				usage[mount.Source] = true
+			}
+		}
+	}
+	return usage, nil
+}
+
+// Network operations
+
+// ListNetworks returns every network.
+func (b *Backend) ListNetworks(ctx context.Context) ([]backend.Network, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	networks := make([]backend.Network, len(b.networks))
+	for i, network := range b.networks {
+		networks[i] = network.Network
+	}
+	return networks, nil
+}
+
+// InspectNetwork returns a copy of a network's details.
+func (b *Backend) InspectNetwork(ctx context.Context, id string) (backend.NetworkDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return backend.NetworkDetail{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	network, err := b.findNetwork(id)
+	if err != nil {
+		return backend.NetworkDetail{}, fmt.Errorf("failed to inspect network: %w", err)
+	}
+	detail := *network
+	detail.Containers = maps.Clone(network.Containers)
+	return detail, nil
+}
+
+// CreateNetwork creates a network with a unique name.
+func (b *Backend) CreateNetwork(ctx context.Context, name, driver, subnet, gateway string, enableIPv6 bool, labels map[string]string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, err := b.findNetwork(name); err == nil {
+		return "", fmt.Errorf("failed to create network: network with name %s already exists", name)
+	}
+	network := &backend.NetworkDetail{
+		Network:    backend.Network{ID: newID(), Name: name, Driver: cmp.Or(driver, "bridge"), Scope: "local", Labels: labels},
+		EnableIPv6: enableIPv6,
+		IPAM:       backend.IPAM{Driver: "default", Config: ipamConfig(subnet, gateway)},
+	}
+	b.networks = append(b.networks, network)
+	return network.ID, nil
+}
+
+// RemoveNetwork removes a user-defined network without containers.
+func (b *Backend) RemoveNetwork(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	network, err := b.findNetwork(id)
+	if err != nil {
+		return fmt.Errorf("failed to remove network: %w", err)
+	}
+	if slices.Contains(defaultNetworks, network.Name) {
+		return fmt.Errorf("failed to remove network: %s is a pre-defined network and cannot be removed", network.Name)
+	}
+	if len(network.Containers) > 0 {
+		return fmt.Errorf("failed to remove network: network %s has active endpoints", network.Name)
+	}
+	b.networks = slices.DeleteFunc(b.networks, func(n *backend.NetworkDetail) bool { return n == network })
+	return nil
+}
+
+// PruneNetworks removes user-defined networks without containers.
+func (b *Backend) PruneNetworks(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	before := len(b.networks)
+	b.networks = slices.DeleteFunc(b.networks, func(n *backend.NetworkDetail) bool {
+		return !slices.Contains(defaultNetworks, n.Name) && len(n.Containers) == 0
+	})
+	return before - len(b.networks), nil
+}
+
+// ConnectContainerToNetwork attaches a container to a network.
+func (b *Backend) ConnectContainerToNetwork(ctx context.Context, containerID, networkID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(containerID)
+	if err != nil {
+		return fmt.Errorf("failed to connect container to network: %w", err)
+	}
+	if err := b.connect(record, networkID); err != nil {
+		return fmt.Errorf("failed to connect container to network: %w", err)
+	}
+	return nil
+}
+
+// DisconnectContainerFromNetwork detaches a container from a network.
+func (b *Backend) DisconnectContainerFromNetwork(ctx context.Context, containerID, networkID string, force bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findContainer(containerID)
+	if err != nil {
+		return fmt.Errorf("failed to disconnect container from network: %w", err)
+	}
+	network, err := b.findNetwork(networkID)
+	if err != nil {
+		return fmt.Errorf("failed to disconnect container from network: %w", err)
+	}
+	if _, ok := record.detail.NetworkSettings.Networks[network.Name]; !ok {
+		return fmt.Errorf("failed to disconnect container from network: container %s is not connected to %s", record.detail.Name, network.Name)
+	}
+	delete(record.detail.NetworkSettings.Networks, network.Name)
+	delete(network.Containers, record.detail.ID)
+	return nil
+}
+
+// Volume operations
+
+// ListVolumes returns every volume.
+func (b *Backend) ListVolumes(ctx context.Context) ([]backend.Volume, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	volumes := make([]backend.Volume, len(b.volumes))
+	for i, volume := range b.volumes {
+		volumes[i] = volume.Volume
+	}
+	return volumes, nil
+}
+
+// InspectVolume returns a copy of a volume's details.
+func (b *Backend) InspectVolume(ctx context.Context, name string) (backend.VolumeDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return backend.VolumeDetail{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	volume, err := b.findVolume(name)
+	if err != nil {
+		return backend.VolumeDetail{}, fmt.Errorf("failed to inspect volume: %w", err)
+	}
+	return *volume, nil
+}
+
+// CreateVolume creates a volume, generating a name if none is given.
+func (b *Backend) CreateVolume(ctx context.Context, name, driver string, labels map[string]string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if name == "" {
+		name = newID()
+	}
+	if _, err := b.findVolume(name); err == nil {
+		return "", fmt.Errorf("failed to create volume: volume %s already exists", name)
+	}
+	return b.addVolume(name, driver, labels).Name, nil
+}
+
+// RemoveVolume removes a volume that no container mounts.
+func (b *Backend) RemoveVolume(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	volume, err := b.findVolume(name)
+	if err != nil {
+		return fmt.Errorf("failed to remove volume: %w", err)
+	}
+	if users := b.containersUsingVolume(name); len(users) > 0 {
+		return fmt.Errorf("failed to remove volume: volume is in use by container %s", users[0])
+	}
+	b.volumes = slices.DeleteFunc(b.volumes, func(v *backend.VolumeDetail) bool { return v == volume })
+	return nil
+}
+
+// PruneVolumes removes volumes that no container mounts.
+func (b *Backend) PruneVolumes(ctx context.Context) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var reclaimed uint64
+	b.volumes = slices.DeleteFunc(b.volumes, func(v *backend.VolumeDetail) bool {
+		if len(b.containersUsingVolume(v.Name)) > 0 {
+			return false
+		}
+		reclaimed += 1 << 20
+		return true
+	})
+	return reclaimed, nil
+}
+
+func (b *Backend) containersUsingVolume(name string) []string {
+	var names []string
+	for _, record := range b.containers {
+		for _, mount := range record.detail.Mounts {
+			if mount.Type == "volume" && mount.Source == name {
+				names = append(names, record.detail.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Service operations
+
+// ListServices groups containers into services by their Compose labels.
+func (b *Backend) ListServices(ctx context.Context) ([]backend.Service, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var services []backend.Service
+	seen := make(map[string]bool)
+	for _, record := range b.containers {
+		project := record.detail.Config.Labels["com.docker.compose.project"]
+		service := record.detail.Config.Labels["com.docker.compose.service"]
+		if project == "" || service == "" {
+			continue
+		}
+		id := project + "_" + service
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		services = append(services, backend.Service{ID: id, Name: service, State: record.detail.State})
+	}
+	return services, nil
+}
+
+// Dependency checking
+
+// GetContainersUsingImage returns the names of containers using an image.
+func (b *Backend) GetContainersUsingImage(ctx context.Context, imageID string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	record, err := b.findImage(imageID)
+	if err != nil {
+		return nil, nil
+	}
+	return b.containersUsingImage(record), nil
+}
+
+// GetContainersUsingVolume returns the names of containers mounting a volume.
+func (b *Backend) GetContainersUsingVolume(ctx context.Context, volumeName string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.containersUsingVolume(volumeName), nil
+}
+
+// GetContainersUsingNetwork returns the names of containers attached to a network.
+func (b *Backend) GetContainersUsingNetwork(ctx context.Context, networkID string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	network, err := b.findNetwork(networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect network: %w", err)
+	}
+	var names []string
+	for _, resource := range network.Containers {
+		names = append(names, resource.Name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
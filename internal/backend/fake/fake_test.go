@@ -0,0 +1,299 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/layers"
+)
+
+const testFixture = `
+images:
+  - tags: [alpine:latest]
+    size: 1024
+    cmd: [/bin/sh]
+    layers:
+      - created-by: ADD rootfs /
+        size: 1024
+      - created-by: CMD ["/bin/sh"]
+networks:
+  - name: app
+volumes:
+  - name: data
+containers:
+  - name: web
+    image: alpine
+    state: running
+    networks: [app]
+    volumes: ["data:/data"]
+    ports: {"8080": "80"}
+  - name: job
+    image: alpine:latest
+    state: exited
+    exit-code: 1
+`
+
+func newTestBackend(t *testing.T) *Backend {
+	t.Helper()
+	fixture, err := ParseFixture([]byte(testFixture))
+	if err != nil {
+		t.Fatalf("ParseFixture returned error: %v", err)
+	}
+	b, err := New(fixture)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return b
+}
+
+func containerByName(t *testing.T, b *Backend, name string) backend.Container {
+	t.Helper()
+	containers, err := b.ListContainers(context.Background())
+	if err != nil {
+		t.Fatalf("ListContainers returned error: %v", err)
+	}
+	for _, container := range containers {
+		if container.Name == name {
+			return container
+		}
+	}
+	t.Fatalf("container %s not found in %+v", name, containers)
+	return backend.Container{}
+}
+
+func TestDemoFixtureSeedsEveryResource(t *testing.T) {
+	b := NewDemo()
+	ctx := context.Background()
+
+	containers, _ := b.ListContainers(ctx)
+	images, _ := b.ListImages(ctx)
+	networks, _ := b.ListNetworks(ctx)
+	volumes, _ := b.ListVolumes(ctx)
+	if len(containers) == 0 || len(images) == 0 || len(networks) <= len(defaultNetworks) || len(volumes) == 0 {
+		t.Fatalf("expected a populated demo, got %d containers, %d images, %d networks, %d volumes",
+			len(containers), len(images), len(networks), len(volumes))
+	}
+}
+
+func TestFixtureSeedsContainerDetails(t *testing.T) {
+	b := newTestBackend(t)
+	ctx := context.Background()
+
+	web := containerByName(t, b, "web")
+	if web.State != "running" || web.Status != "Up" {
+		t.Fatalf("expected web to be running, got %+v", web)
+	}
+	detail, err := b.InspectContainer(ctx, web.ID[:12])
+	if err != nil {
+		t.Fatalf("InspectContainer by ID prefix returned error: %v", err)
+	}
+	if _, ok := detail.NetworkSettings.Networks["app"]; !ok {
+		t.Fatalf("expected web to be attached to app, got %+v", detail.NetworkSettings.Networks)
+	}
+	if bindings := detail.HostConfig.PortBindings["80/tcp"]; len(bindings) != 1 || bindings[0].HostPort != "8080" {
+		t.Fatalf("expected port 8080->80, got %+v", detail.HostConfig.PortBindings)
+	}
+	if job := containerByName(t, b, "job"); job.Status != "Exited (1)" {
+		t.Fatalf("expected job to have exited with 1, got %q", job.Status)
+	}
+}
+
+func TestInvalidFixturesAreRejected(t *testing.T) {
+	for _, fixture := range []Fixture{
+		{Containers: []FixtureContainer{{Name: "web", Image: "missing:latest"}}},
+		{Images: []FixtureImage{{Tags: []string{"alpine:latest"}}}, Containers: []FixtureContainer{{Name: "web", Image: "alpine", State: "sleeping"}}},
+		{Volumes: []FixtureVolume{{}}},
+	} {
+		if _, err := New(fixture); err == nil {
+			t.Fatalf("expected fixture %+v to be rejected", fixture)
+		}
+	}
+}
+
+func TestContainerLifecycle(t *testing.T) {
+	b := newTestBackend(t)
+	ctx := context.Background()
+
+	if err := b.RemoveContainer(ctx, "web", false); err == nil {
+		t.Fatal("expected removing a running container without force to fail")
+	}
+	if err := b.PauseContainer(ctx, "web"); err != nil {
+		t.Fatalf("PauseContainer returned error: %v", err)
+	}
+	if err := b.PauseContainer(ctx, "web"); err == nil {
+		t.Fatal("expected pausing a paused container to fail")
+	}
+	if err := b.StopContainers(ctx, []string{"web", "job"}); err != nil {
+		t.Fatalf("StopContainers returned error: %v", err)
+	}
+	if state, _ := b.GetContainerState(ctx, "web"); state != "exited" {
+		t.Fatalf("expected web to be exited, got %q", state)
+	}
+
+	if _, err := b.PruneContainers(ctx); err != nil {
+		t.Fatalf("PruneContainers returned error: %v", err)
+	}
+	if containers, _ := b.ListContainers(ctx); len(containers) != 0 {
+		t.Fatalf("expected prune to remove stopped containers, got %+v", containers)
+	}
+	if _, err := b.InspectContainer(ctx, "web"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after removal, got %v", err)
+	}
+}
+
+func TestContainerLifecycleTimes(t *testing.T) {
+	b := newTestBackend(t)
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b.Now = func() time.Time { return now }
+
+	if detail, _ := b.InspectContainer(ctx, "job"); detail.Lifecycle.ExitCode != 1 {
+		t.Fatalf("expected job's exit code in its lifecycle, got %+v", detail.Lifecycle)
+	}
+	if err := b.StartContainer(ctx, "job"); err != nil {
+		t.Fatalf("StartContainer returned error: %v", err)
+	}
+	now = now.Add(time.Hour)
+	if err := b.PauseContainer(ctx, "job"); err != nil {
+		t.Fatalf("PauseContainer returned error: %v", err)
+	}
+	if err := b.UnpauseContainer(ctx, "job"); err != nil {
+		t.Fatalf("UnpauseContainer returned error: %v", err)
+	}
+	if detail, _ := b.InspectContainer(ctx, "job"); !detail.Lifecycle.StartedAt.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("expected unpausing to keep the start time, got %s", detail.Lifecycle.StartedAt)
+	}
+	if err := b.StopContainer(ctx, "job"); err != nil {
+		t.Fatalf("StopContainer returned error: %v", err)
+	}
+	if detail, _ := b.InspectContainer(ctx, "job"); !detail.Lifecycle.FinishedAt.Equal(now) || detail.Lifecycle.ExitCode != 0 {
+		t.Fatalf("expected a clean stop at %s, got %+v", now, detail.Lifecycle)
+	}
+}
+
+func TestCreateContainerAndNetworkUsage(t *testing.T) {
+	b := newTestBackend(t)
+	ctx := context.Background()
+
+	id, err := b.CreateContainer(ctx, backend.ContainerConfig{Name: "worker", Image: "alpine:latest", Network: "app", AutoStart: true})
+	if err != nil {
+		t.Fatalf("CreateContainer returned error: %v", err)
+	}
+	if _, err := b.CreateContainer(ctx, backend.ContainerConfig{Name: "worker", Image: "alpine"}); err == nil {
+		t.Fatal("expected a duplicate container name to fail")
+	}
+
+	users, err := b.GetContainersUsingNetwork(ctx, "app")
+	if err != nil || strings.Join(users, ",") != "web,worker" {
+		t.Fatalf("expected web and worker on app, got %v (%v)", users, err)
+	}
+	if err := b.RemoveNetwork(ctx, "app"); err == nil {
+		t.Fatal("expected removing a network with containers to fail")
+	}
+	if err := b.DisconnectContainerFromNetwork(ctx, id, "app", false); err != nil {
+		t.Fatalf("DisconnectContainerFromNetwork returned error: %v", err)
+	}
+	if users, _ := b.GetContainersUsingNetwork(ctx, "app"); len(users) != 1 {
+		t.Fatalf("expected only web on app, got %v", users)
+	}
+}
+
+func TestImagesVolumesAndPrune(t *testing.T) {
+	b := newTestBackend(t)
+	ctx := context.Background()
+
+	if err := b.RemoveImage(ctx, "alpine:latest"); err == nil {
+		t.Fatal("expected removing an image in use to fail")
+	}
+	if err := b.RemoveVolume(ctx, "data"); err == nil {
+		t.Fatal("expected removing a mounted volume to fail")
+	}
+
+	history, err := b.ImageHistory(ctx, "alpine")
+	if err != nil || len(history) != 2 || history[0].CreatedBy != `CMD ["/bin/sh"]` {
+		t.Fatalf("expected history newest first, got %+v (%v)", history, err)
+	}
+
+	if err := b.TagImage(ctx, "alpine", "registry.local/alpine:1"); err != nil {
+		t.Fatalf("TagImage returned error: %v", err)
+	}
+	if _, err := b.InspectImage(ctx, "registry.local/alpine:1"); err != nil {
+		t.Fatalf("expected the new tag to resolve, got %v", err)
+	}
+}
+
+func TestSaveImageWritesTheFixtureLayers(t *testing.T) {
+	b := NewDemo()
+	ctx := context.Background()
+
+	archive, err := b.SaveImage(ctx, "nginx:alpine")
+	if err != nil {
+		t.Fatalf("SaveImage returned error: %v", err)
+	}
+	defer archive.Close()
+	image, err := layers.Read(archive)
+	if err != nil {
+		t.Fatalf("the saved archive could not be read: %v", err)
+	}
+
+	detail, _ := b.InspectImage(ctx, "nginx:alpine")
+	if len(image.Layers) != len(detail.RootFS.Layers) || image.Layers[0].Digest != detail.RootFS.Layers[0] {
+		t.Fatalf("expected the archive's layers to match the image's, got %d for %v", len(image.Layers), detail.RootFS.Layers)
+	}
+	if !strings.HasPrefix(image.Layers[1].Command, "RUN ") {
+		t.Errorf("expected the second layer to be the RUN step, got %q", image.Layers[1].Command)
+	}
+	var motd []string
+	for _, layer := range image.Layers {
+		for _, file := range layer.Files {
+			if file.Path == "etc/motd" {
+				motd = append(motd, fmt.Sprintf("%s %t", file.Change, file.Wasted))
+			}
+		}
+	}
+	if strings.Join(motd, ", ") != "added true, removed false" {
+		t.Errorf("expected etc/motd added and wasted by its removal, got %v", motd)
+	}
+}
+
+func TestPullAndBuildAddImages(t *testing.T) {
+	b := newTestBackend(t)
+	ctx := context.Background()
+
+	progress := make(chan string)
+	done := make(chan error, 1)
+	go func() { done <- b.PullImage(ctx, "redis", progress) }()
+	var lines []string
+	for line := range progress {
+		lines = append(lines, line)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("PullImage returned error: %v", err)
+	}
+	if !strings.Contains(lines[len(lines)-1], "Downloaded newer image for redis:latest") {
+		t.Fatalf("expected a final status line, got %v", lines)
+	}
+	if _, err := b.InspectImage(ctx, "redis"); err != nil {
+		t.Fatalf("expected the pulled image to exist, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\n# comment\nRUN echo hi\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output, err := b.BuildImage(ctx, "Dockerfile", "app:dev", dir, nil)
+	if err != nil {
+		t.Fatalf("BuildImage returned error: %v", err)
+	}
+	defer output.Close()
+	if _, err := b.InspectImage(ctx, "app:dev"); err != nil {
+		t.Fatalf("expected the built image to be tagged, got %v", err)
+	}
+}
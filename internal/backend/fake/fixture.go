@@ -0,0 +1,129 @@
+package fake
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed demo.yaml
+var demoFixture []byte
+
+// Fixture describes the resources a fake backend starts with.
+type Fixture struct {
+	Images     []FixtureImage     `yaml:"images"`
+	Networks   []FixtureNetwork   `yaml:"networks"`
+	Volumes    []FixtureVolume    `yaml:"volumes"`
+	Containers []FixtureContainer `yaml:"containers"`
+}
+
+// FixtureImage is an image in a fixture. Layers are listed oldest first and
+// make up the image history.
+type FixtureImage struct {
+	ID           string            `yaml:"id"`
+	Tags         []string          `yaml:"tags"`
+	Size         int64             `yaml:"size"`
+	Created      time.Time         `yaml:"created"`
+	Cmd          []string          `yaml:"cmd"`
+	Entrypoint   []string          `yaml:"entrypoint"`
+	Env          []string          `yaml:"env"`
+	WorkingDir   string            `yaml:"workdir"`
+	User         string            `yaml:"user"`
+	ExposedPorts []string          `yaml:"exposed-ports"`
+	Labels       map[string]string `yaml:"labels"`
+	Layers       []FixtureLayer    `yaml:"layers"`
+}
+
+// FixtureLayer is one step of an image's history. Steps with a size leave
+// a layer, holding the files listed; a layer without files is saved empty.
+type FixtureLayer struct {
+	CreatedBy string        `yaml:"created-by"`
+	Size      int64         `yaml:"size"`
+	Comment   string        `yaml:"comment"`
+	Files     []FixtureFile `yaml:"files"`
+}
+
+// FixtureFile is a file a layer writes, or removes when deleted is set.
+type FixtureFile struct {
+	Path    string `yaml:"path"`
+	Size    int64  `yaml:"size"`
+	Deleted bool   `yaml:"deleted"`
+}
+
+// FixtureNetwork is a user-defined network in a fixture. The default bridge,
+// host and none networks always exist.
+type FixtureNetwork struct {
+	ID       string            `yaml:"id"`
+	Name     string            `yaml:"name"`
+	Driver   string            `yaml:"driver"`
+	Subnet   string            `yaml:"subnet"`
+	Gateway  string            `yaml:"gateway"`
+	Internal bool              `yaml:"internal"`
+	Labels   map[string]string `yaml:"labels"`
+}
+
+// FixtureVolume is a volume in a fixture.
+type FixtureVolume struct {
+	Name   string            `yaml:"name"`
+	Driver string            `yaml:"driver"`
+	Labels map[string]string `yaml:"labels"`
+}
+
+// FixtureContainer is a container in a fixture. State is one of created,
+// running, paused or exited. Ports map host ports to container ports, and
+// volumes use the "source:destination" form of docker run -v.
+type FixtureContainer struct {
+	ID       string            `yaml:"id"`
+	Name     string            `yaml:"name"`
+	Image    string            `yaml:"image"`
+	State    string            `yaml:"state"`
+	ExitCode int               `yaml:"exit-code"`
+	Created  time.Time         `yaml:"created"`
+	Cmd      []string          `yaml:"cmd"`
+	Env      []string          `yaml:"env"`
+	Labels   map[string]string `yaml:"labels"`
+	Ports    map[string]string `yaml:"ports"`
+	Networks []string          `yaml:"networks"`
+	Volumes  []string          `yaml:"volumes"`
+	Restart  string            `yaml:"restart"`
+	Logs     []string          `yaml:"logs"`
+}
+
+// ParseFixture decodes a YAML fixture.
+func ParseFixture(data []byte) (Fixture, error) {
+	var fixture Fixture
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+	return fixture, nil
+}
+
+// LoadFixture reads a YAML fixture from path.
+func LoadFixture(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	return ParseFixture(data)
+}
+
+// DemoFixture returns the curated fixture used by demo mode.
+func DemoFixture() Fixture {
+	fixture, err := ParseFixture(demoFixture)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded demo fixture: %v", err))
+	}
+	return fixture
+}
+
+// NewDemo creates a fake backend seeded with the demo fixture.
+func NewDemo() *Backend {
+	b, err := New(DemoFixture())
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded demo fixture: %v", err))
+	}
+	return b
+}
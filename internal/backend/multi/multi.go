@@ -0,0 +1,815 @@
+// Package multi aggregates several named backends into a single
+// backend.Backend. Resource IDs are qualified with the name of the host they
+// live on, so operations can be routed back to the right daemon.
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// Separator joins a host name and a resource ID. Resource IDs and volume
+// names cannot contain it, although host addresses used as names can.
+const Separator = "/"
+
+// QualifyID returns id qualified with host.
+func QualifyID(host, id string) string {
+	return host + Separator + id
+}
+
+// SplitID splits a qualified ID into its host and the ID known to that host.
+func SplitID(qualified string) (host, id string, ok bool) {
+	i := strings.LastIndex(qualified, Separator)
+	if i < 0 {
+		return "", qualified, false
+	}
+	return qualified[:i], qualified[i+len(Separator):], true
+}
+
+// Host is a named backend taking part in the aggregate. Err records why the
+// backend could not be created; such hosts are reported as failing on every
+// call instead of preventing the others from being used.
+type Host struct {
+	Name    string
+	Backend backend.Backend
+	Err     error
+}
+
+// HostError is a failure of a single host.
+type HostError struct {
+	Host string
+	Err  error
+}
+
+func (e HostError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Host, e.Err)
+}
+
+func (e HostError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError reports the hosts that failed while the others answered. The
+// results returned alongside it are complete for every other host.
+type PartialError struct {
+	Failures []HostError
+}
+
+func (e *PartialError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		messages[i] = failure.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Hosts returns the names of the failed hosts.
+func (e *PartialError) Hosts() []string {
+	hosts := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		hosts[i] = failure.Host
+	}
+	return hosts
+}
+
+// IgnorePartial returns nil if err only reports some hosts failing, so
+// callers can use whatever the remaining hosts returned.
+func IgnorePartial(err error) error {
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		return nil
+	}
+	return err
+}
+
+// Backend fans calls out to every host and merges the results.
+type Backend struct {
+	hosts  []Host
+	byName map[string]Host
+}
+
+var _ backend.Backend = (*Backend)(nil)
+
+// New creates an aggregate of hosts, which are queried in the given order.
+func New(hosts []Host) *Backend {
+	byName := make(map[string]Host, len(hosts))
+	for _, host := range hosts {
+		byName[host.Name] = host
+	}
+	return &Backend{hosts: hosts, byName: byName}
+}
+
+// Hosts returns the names of the aggregated hosts.
+func (b *Backend) Hosts() []string {
+	names := make([]string, len(b.hosts))
+	for i, host := range b.hosts {
+		names[i] = host.Name
+	}
+	return names
+}
+
+// route resolves a qualified ID to the backend holding it.
+func (b *Backend) route(qualified string) (backend.Backend, string, string, error) {
+	name, id, ok := SplitID(qualified)
+	if !ok {
+		return nil, "", "", fmt.Errorf("%q is not qualified with a host", qualified)
+	}
+	host, exists := b.byName[name]
+	if !exists {
+		return nil, "", "", fmt.Errorf("unknown host %q", name)
+	}
+	if host.Err != nil {
+		return nil, "", "", HostError{Host: name, Err: host.Err}
+	}
+	return host.Backend, name, id, nil
+}
+
+// primary returns the first usable host, which receives requests that are not
+// tied to an existing resource.
+func (b *Backend) primary() (Host, error) {
+	for _, host := range b.hosts {
+		if host.Err == nil {
+			return host, nil
+		}
+	}
+	return Host{}, errors.New("no hosts available")
+}
+
+// each runs fn concurrently against every usable host and returns the
+// failures in host order.
+func (b *Backend) each(fn func(host Host) error) []HostError {
+	errs := make([]error, len(b.hosts))
+	var wg sync.WaitGroup
+	for i, host := range b.hosts {
+		if host.Err != nil {
+			errs[i] = host.Err
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = fn(host)
+		}()
+	}
+	wg.Wait()
+
+	var failures []HostError
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, HostError{Host: b.hosts[i].Name, Err: err})
+		}
+	}
+	return failures
+}
+
+// failure turns per-host failures into the error returned for a fanned-out
+// call: nil, a PartialError, or an error for every host.
+func (b *Backend) failure(failures []HostError) error {
+	switch {
+	case len(failures) == 0:
+		return nil
+	case len(failures) < len(b.hosts):
+		return &PartialError{Failures: failures}
+	}
+	errs := make([]error, len(failures))
+	for i, failure := range failures {
+		errs[i] = failure
+	}
+	return errors.Join(errs...)
+}
+
+// gather lists items from every host, qualifying each with its host name.
+func gather[T any](b *Backend, list func(host Host) ([]T, error), qualify func(host string, item T) T) ([]T, error) {
+	results := make([][]T, len(b.hosts))
+	failures := b.each(func(host Host) error {
+		items, err := list(host)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			items[i] = qualify(host.Name, items[i])
+		}
+		results[b.index(host.Name)] = items
+		return nil
+	})
+
+	var merged []T
+	for _, items := range results {
+		merged = append(merged, items...)
+	}
+	return merged, b.failure(failures)
+}
+
+func (b *Backend) index(name string) int {
+	for i, host := range b.hosts {
+		if host.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// groupByHost splits qualified IDs by host, keeping host order.
+func (b *Backend) groupByHost(qualified []string) ([]Host, map[string][]string, error) {
+	groups := make(map[string][]string)
+	for _, q := range qualified {
+		_, name, id, err := b.route(q)
+		if err != nil {
+			return nil, nil, err
+		}
+		groups[name] = append(groups[name], id)
+	}
+
+	var hosts []Host
+	for _, host := range b.hosts {
+		if _, ok := groups[host.Name]; ok {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, groups, nil
+}
+
+// bulk runs fn concurrently for each host's share of ids.
+func (b *Backend) bulk(ids []string, fn func(target backend.Backend, ids []string) error) error {
+	hosts, groups, err := b.groupByHost(ids)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(host.Backend, groups[host.Name]); err != nil {
+				errs[i] = HostError{Host: host.Name, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func qualifyIDs(host string, ids []string) []string {
+	qualified := make([]string, len(ids))
+	for i, id := range ids {
+		qualified[i] = QualifyID(host, id)
+	}
+	return qualified
+}
+
+func qualifyUsage(host string, usage map[string]bool, merged map[string]bool) {
+	for id, used := range usage {
+		merged[QualifyID(host, id)] = used
+	}
+}
+
+// Name returns the name of the first usable backend.
+func (b *Backend) Name() string {
+	host, err := b.primary()
+	if err != nil {
+		return "multi"
+	}
+	return host.Backend.Name()
+}
+
+// Version reports how many hosts are aggregated.
+func (b *Backend) Version(context.Context) string {
+	return fmt.Sprintf("%d hosts", len(b.hosts))
+}
+
+// Ping succeeds while at least one host is reachable.
+func (b *Backend) Ping(ctx context.Context) error {
+	failures := b.each(func(host Host) error {
+		return host.Backend.Ping(ctx)
+	})
+	return IgnorePartial(b.failure(failures))
+}
+
+// Close closes every host.
+func (b *Backend) Close() error {
+	var errs []error
+	for _, host := range b.hosts {
+		if host.Err != nil {
+			continue
+		}
+		if err := host.Backend.Close(); err != nil {
+			errs = append(errs, HostError{Host: host.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Container operations
+
+func (b *Backend) ListContainers(ctx context.Context) ([]backend.Container, error) {
+	return gather(b, func(host Host) ([]backend.Container, error) {
+		return host.Backend.ListContainers(ctx)
+	}, func(host string, container backend.Container) backend.Container {
+		container.ID = QualifyID(host, container.ID)
+		container.Host = host
+		return container
+	})
+}
+
+func (b *Backend) InspectContainer(ctx context.Context, id string) (backend.ContainerDetail, error) {
+	target, host, id, err := b.route(id)
+	if err != nil {
+		return backend.ContainerDetail{}, err
+	}
+	detail, err := target.InspectContainer(ctx, id)
+	if err != nil {
+		return detail, err
+	}
+	detail.ID = QualifyID(host, detail.ID)
+	detail.Host = host
+	return detail, nil
+}
+
+func (b *Backend) GetContainerState(ctx context.Context, id string) (string, error) {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return "", err
+	}
+	return target.GetContainerState(ctx, id)
+}
+
+// CreateContainer creates the container on the host of a qualified image ID,
+// or on the primary host otherwise.
+func (b *Backend) CreateContainer(ctx context.Context, config backend.ContainerConfig) (string, error) {
+	if _, _, ok := SplitID(config.Image); ok {
+		target, host, image, err := b.route(config.Image)
+		if err == nil {
+			config.Image = image
+			id, err := target.CreateContainer(ctx, config)
+			if err != nil {
+				return "", err
+			}
+			return QualifyID(host, id), nil
+		}
+	}
+
+	host, err := b.primary()
+	if err != nil {
+		return "", err
+	}
+	id, err := host.Backend.CreateContainer(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	return QualifyID(host.Name, id), nil
+}
+
+func (b *Backend) StartContainer(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.StartContainer(ctx, id)
+}
+
+func (b *Backend) StartContainers(ctx context.Context, ids []string) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.StartContainers(ctx, ids)
+	})
+}
+
+func (b *Backend) StopContainer(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.StopContainer(ctx, id)
+}
+
+func (b *Backend) StopContainers(ctx context.Context, ids []string) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.StopContainers(ctx, ids)
+	})
+}
+
+func (b *Backend) RestartContainer(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.RestartContainer(ctx, id)
+}
+
+func (b *Backend) RestartContainers(ctx context.Context, ids []string) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.RestartContainers(ctx, ids)
+	})
+}
+
+func (b *Backend) PauseContainer(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.PauseContainer(ctx, id)
+}
+
+func (b *Backend) PauseContainers(ctx context.Context, ids []string) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.PauseContainers(ctx, ids)
+	})
+}
+
+func (b *Backend) UnpauseContainer(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.UnpauseContainer(ctx, id)
+}
+
+func (b *Backend) UnpauseContainers(ctx context.Context, ids []string) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.UnpauseContainers(ctx, ids)
+	})
+}
+
+func (b *Backend) RemoveContainer(ctx context.Context, id string, force bool) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.RemoveContainer(ctx, id, force)
+}
+
+func (b *Backend) RemoveContainers(ctx context.Context, ids []string, force bool) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.RemoveContainers(ctx, ids, force)
+	})
+}
+
+func (b *Backend) RenameContainer(ctx context.Context, id, newName string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.RenameContainer(ctx, id, newName)
+}
+
+// PruneContainers prunes every host and returns the total space reclaimed.
+func (b *Backend) PruneContainers(ctx context.Context) (uint64, error) {
+	var mu sync.Mutex
+	var total uint64
+	failures := b.each(func(host Host) error {
+		reclaimed, err := host.Backend.PruneContainers(ctx)
+		mu.Lock()
+		total += reclaimed
+		mu.Unlock()
+		return err
+	})
+	return total, b.failure(failures)
+}
+
+// Container logs and exec
+
+func (b *Backend) OpenLogs(ctx context.Context, id string) (backend.Logs, error) {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return backend.Logs{}, err
+	}
+	return target.OpenLogs(ctx, id)
+}
+
+func (b *Backend) ExecShell(ctx context.Context, id string, shell []string) (io.ReadWriteCloser, error) {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return nil, err
+	}
+	return target.ExecShell(ctx, id, shell)
+}
+
+// Image operations
+
+func (b *Backend) ListImages(ctx context.Context) ([]backend.Image, error) {
+	return gather(b, func(host Host) ([]backend.Image, error) {
+		return host.Backend.ListImages(ctx)
+	}, func(host string, image backend.Image) backend.Image {
+		image.ID = QualifyID(host, image.ID)
+		return image
+	})
+}
+
+func (b *Backend) InspectImage(ctx context.Context, id string) (backend.ImageDetail, error) {
+	target, host, id, err := b.route(id)
+	if err != nil {
+		return backend.ImageDetail{}, err
+	}
+	detail, err := target.InspectImage(ctx, id)
+	if err != nil {
+		return detail, err
+	}
+	detail.ID = QualifyID(host, detail.ID)
+	return detail, nil
+}
+
+// PullImage pulls ref on the primary host.
+func (b *Backend) PullImage(ctx context.Context, ref string, progressChan chan<- string) error {
+	host, err := b.primary()
+	if err != nil {
+		return err
+	}
+	return host.Backend.PullImage(ctx, ref, progressChan)
+}
+
+// BuildImage builds on the primary host.
+func (b *Backend) BuildImage(ctx context.Context, dockerfilePath, tag, contextPath string, buildArgs map[string]*string) (io.ReadCloser, error) {
+	host, err := b.primary()
+	if err != nil {
+		return nil, err
+	}
+	return host.Backend.BuildImage(ctx, dockerfilePath, tag, contextPath, buildArgs)
+}
+
+func (b *Backend) TagImage(ctx context.Context, source, target string) error {
+	sourceBackend, _, source, err := b.route(source)
+	if err != nil {
+		return err
+	}
+	return sourceBackend.TagImage(ctx, source, target)
+}
+
+func (b *Backend) RemoveImage(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.RemoveImage(ctx, id)
+}
+
+func (b *Backend) RemoveImages(ctx context.Context, ids []string) error {
+	return b.bulk(ids, func(target backend.Backend, ids []string) error {
+		return target.RemoveImages(ctx, ids)
+	})
+}
+
+// PruneImages prunes every host and returns the total space reclaimed.
+func (b *Backend) PruneImages(ctx context.Context) (uint64, error) {
+	var mu sync.Mutex
+	var total uint64
+	failures := b.each(func(host Host) error {
+		reclaimed, err := host.Backend.PruneImages(ctx)
+		mu.Lock()
+		total += reclaimed
+		mu.Unlock()
+		return err
+	})
+	return total, b.failure(failures)
+}
+
+// Image history and usage
+
+func (b *Backend) ImageHistory(ctx context.Context, imageID string) ([]backend.ImageHistoryItem, error) {
+	target, _, imageID, err := b.route(imageID)
+	if err != nil {
+		return nil, err
+	}
+	return target.ImageHistory(ctx, imageID)
+}
+
+func (b *Backend) SaveImage(ctx context.Context, id string) (io.ReadCloser, error) {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return nil, err
+	}
+	return target.SaveImage(ctx, id)
+}
+
+func (b *Backend) GetAllNetworkUsage(ctx context.Context) (map[string]bool, error) {
+	var mu sync.Mutex
+	merged := make(map[string]bool)
+	failures := b.each(func(host Host) error {
+		usage, err := host.Backend.GetAllNetworkUsage(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		qualifyUsage(host.Name, usage, merged)
+		mu.Unlock()
+		return nil
+	})
+	return merged, b.failure(failures)
+}
+
+func (b *Backend) GetAllVolumeUsage(ctx context.Context) (map[string]bool, error) {
+	var mu sync.Mutex
+	merged := make(map[string]bool)
+	failures := b.each(func(host Host) error {
+		usage, err := host.Backend.GetAllVolumeUsage(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		qualifyUsage(host.Name, usage, merged)
+		mu.Unlock()
+		return nil
+	})
+	return merged, b.failure(failures)
+}
+
+// Network operations
+
+func (b *Backend) ListNetworks(ctx context.Context) ([]backend.Network, error) {
+	return gather(b, func(host Host) ([]backend.Network, error) {
+		return host.Backend.ListNetworks(ctx)
+	}, func(host string, network backend.Network) backend.Network {
+		network.ID = QualifyID(host, network.ID)
+		return network
+	})
+}
+
+func (b *Backend) InspectNetwork(ctx context.Context, id string) (backend.NetworkDetail, error) {
+	target, host, id, err := b.route(id)
+	if err != nil {
+		return backend.NetworkDetail{}, err
+	}
+	detail, err := target.InspectNetwork(ctx, id)
+	if err != nil {
+		return detail, err
+	}
+	detail.ID = QualifyID(host, detail.ID)
+	containers := make(map[string]backend.EndpointResource, len(detail.Containers))
+	for containerID, resource := range detail.Containers {
+		containers[QualifyID(host, containerID)] = resource
+	}
+	detail.Containers = containers
+	return detail, nil
+}
+
+// CreateNetwork creates the network on the primary host.
+func (b *Backend) CreateNetwork(ctx context.Context, name, driver, subnet, gateway string, enableIPv6 bool, labels map[string]string) (string, error) {
+	host, err := b.primary()
+	if err != nil {
+		return "", err
+	}
+	id, err := host.Backend.CreateNetwork(ctx, name, driver, subnet, gateway, enableIPv6, labels)
+	if err != nil {
+		return "", err
+	}
+	return QualifyID(host.Name, id), nil
+}
+
+func (b *Backend) RemoveNetwork(ctx context.Context, id string) error {
+	target, _, id, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return target.RemoveNetwork(ctx, id)
+}
+
+// PruneNetworks prunes every host and returns the total number removed.
+func (b *Backend) PruneNetworks(ctx context.Context) (int, error) {
+	var mu sync.Mutex
+	var total int
+	failures := b.each(func(host Host) error {
+		removed, err := host.Backend.PruneNetworks(ctx)
+		mu.Lock()
+		total += removed
+		mu.Unlock()
+		return err
+	})
+	return total, b.failure(failures)
+}
+
+// routePair resolves a container and a network that must live on the same host.
+func (b *Backend) routePair(containerID, networkID string) (backend.Backend, string, string, error) {
+	target, containerHost, containerID, err := b.route(containerID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	_, networkHost, networkID, err := b.route(networkID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if containerHost != networkHost {
+		return nil, "", "", fmt.Errorf("container on %s cannot join a network on %s", containerHost, networkHost)
+	}
+	return target, containerID, networkID, nil
+}
+
+func (b *Backend) ConnectContainerToNetwork(ctx context.Context, containerID, networkID string) error {
+	target, containerID, networkID, err := b.routePair(containerID, networkID)
+	if err != nil {
+		return err
+	}
+	return target.ConnectContainerToNetwork(ctx, containerID, networkID)
+}
+
+func (b *Backend) DisconnectContainerFromNetwork(ctx context.Context, containerID, networkID string, force bool) error {
+	target, containerID, networkID, err := b.routePair(containerID, networkID)
+	if err != nil {
+		return err
+	}
+	return target.DisconnectContainerFromNetwork(ctx, containerID, networkID, force)
+}
+
+// Volume operations
+
+func (b *Backend) ListVolumes(ctx context.Context) ([]backend.Volume, error) {
+	return gather(b, func(host Host) ([]backend.Volume, error) {
+		return host.Backend.ListVolumes(ctx)
+	}, func(host string, volume backend.Volume) backend.Volume {
+		volume.Name = QualifyID(host, volume.Name)
+		return volume
+	})
+}
+
+func (b *Backend) InspectVolume(ctx context.Context, name string) (backend.VolumeDetail, error) {
+	target, host, name, err := b.route(name)
+	if err != nil {
+		return backend.VolumeDetail{}, err
+	}
+	detail, err := target.InspectVolume(ctx, name)
+	if err != nil {
+		return detail, err
+	}
+	detail.Name = QualifyID(host, detail.Name)
+	return detail, nil
+}
+
+// CreateVolume creates the volume on the primary host.
+func (b *Backend) CreateVolume(ctx context.Context, name, driver string, labels map[string]string) (string, error) {
+	host, err := b.primary()
+	if err != nil {
+		return "", err
+	}
+	created, err := host.Backend.CreateVolume(ctx, name, driver, labels)
+	if err != nil {
+		return "", err
+	}
+	return QualifyID(host.Name, created), nil
+}
+
+func (b *Backend) RemoveVolume(ctx context.Context, name string) error {
+	target, _, name, err := b.route(name)
+	if err != nil {
+		return err
+	}
+	return target.RemoveVolume(ctx, name)
+}
+
+// PruneVolumes prunes every host and returns the total space reclaimed.
+func (b *Backend) PruneVolumes(ctx context.Context) (uint64, error) {
+	var mu sync.Mutex
+	var total uint64
+	failures := b.each(func(host Host) error {
+		reclaimed, err := host.Backend.PruneVolumes(ctx)
+		mu.Lock()
+		total += reclaimed
+		mu.Unlock()
+		return err
+	})
+	return total, b.failure(failures)
+}
+
+// Service operations
+
+func (b *Backend) ListServices(ctx context.Context) ([]backend.Service, error) {
+	return gather(b, func(host Host) ([]backend.Service, error) {
+		return host.Backend.ListServices(ctx)
+	}, func(host string, service backend.Service) backend.Service {
+		service.ID = QualifyID(host, service.ID)
+		return service
+	})
+}
+
+// Dependency checking
+
+func (b *Backend) GetContainersUsingImage(ctx context.Context, imageID string) ([]string, error) {
+	target, host, imageID, err := b.route(imageID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := target.GetContainersUsingImage(ctx, imageID)
+	return qualifyIDs(host, ids), err
+}
+
+func (b *Backend) GetContainersUsingVolume(ctx context.Context, volumeName string) ([]string, error) {
+	target, host, volumeName, err := b.route(volumeName)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := target.GetContainersUsingVolume(ctx, volumeName)
+	return qualifyIDs(host, ids), err
+}
+
+func (b *Backend) GetContainersUsingNetwork(ctx context.Context, networkID string) ([]string, error) {
+	target, host, networkID, err := b.route(networkID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := target.GetContainersUsingNetwork(ctx, networkID)
+	return qualifyIDs(host, ids), err
+}
@@ -0,0 +1,136 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// fakeBackend implements the calls exercised by these tests; any other call
+// panics through the nil embedded interface.
+type fakeBackend struct {
+	backend.Backend
+	containers []backend.Container
+	err        error
+
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (f *fakeBackend) Name() string { return "docker" }
+
+func (f *fakeBackend) Ping(context.Context) error { return f.err }
+
+func (f *fakeBackend) ListContainers(context.Context) ([]backend.Container, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return slices.Clone(f.containers), nil
+}
+
+func (f *fakeBackend) StopContainers(_ context.Context, ids []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = append(f.stopped, ids...)
+	return f.err
+}
+
+func newTestBackend() (*Backend, *fakeBackend, *fakeBackend) {
+	one := &fakeBackend{containers: []backend.Container{{ID: "a1", Name: "api-1"}}}
+	two := &fakeBackend{containers: []backend.Container{{ID: "b1", Name: "api-2"}, {ID: "b2", Name: "db"}}}
+	return New([]Host{{Name: "staging-1", Backend: one}, {Name: "staging-2", Backend: two}}), one, two
+}
+
+func TestSplitIDRoundTrip(t *testing.T) {
+	host, id, ok := SplitID(QualifyID("staging-1", "sha256:abc"))
+	if !ok || host != "staging-1" || id != "sha256:abc" {
+		t.Fatalf("unexpected split: %q %q %v", host, id, ok)
+	}
+	host, id, _ = SplitID(QualifyID("tcp://10.0.0.2:2376", "abc"))
+	if host != "tcp://10.0.0.2:2376" || id != "abc" {
+		t.Fatalf("expected a host address to survive the split, got %q %q", host, id)
+	}
+	if _, _, ok := SplitID("abc"); ok {
+		t.Fatal("expected unqualified ID not to split")
+	}
+}
+
+func TestListContainersMergesAndQualifies(t *testing.T) {
+	b, _, _ := newTestBackend()
+
+	containers, err := b.ListContainers(context.Background())
+	if err != nil {
+		t.Fatalf("ListContainers returned error: %v", err)
+	}
+
+	var ids []string
+	for _, container := range containers {
+		ids = append(ids, container.ID)
+	}
+	want := []string{"staging-1/a1", "staging-2/b1", "staging-2/b2"}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	if containers[0].Host != "staging-1" || containers[2].Host != "staging-2" {
+		t.Fatalf("expected host to be set, got %+v", containers)
+	}
+}
+
+func TestListContainersReportsPartialFailure(t *testing.T) {
+	b, _, two := newTestBackend()
+	two.err = errors.New("connection refused")
+
+	containers, err := b.ListContainers(context.Background())
+	var partial *PartialError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected a partial error, got %v", err)
+	}
+	if !slices.Equal(partial.Hosts(), []string{"staging-2"}) {
+		t.Fatalf("expected staging-2 to fail, got %v", partial.Hosts())
+	}
+	if len(containers) != 1 || containers[0].ID != "staging-1/a1" {
+		t.Fatalf("expected the healthy host's containers, got %+v", containers)
+	}
+	if IgnorePartial(err) != nil {
+		t.Fatal("expected IgnorePartial to drop a partial failure")
+	}
+}
+
+func TestListContainersFailsWhenEveryHostFails(t *testing.T) {
+	one := &fakeBackend{err: errors.New("down")}
+	b := New([]Host{
+		{Name: "staging-1", Backend: one},
+		{Name: "staging-2", Err: errors.New("no ssh-agent")},
+	})
+
+	_, err := b.ListContainers(context.Background())
+	if err == nil || IgnorePartial(err) == nil {
+		t.Fatalf("expected a total failure, got %v", err)
+	}
+	if err := b.Ping(context.Background()); err == nil {
+		t.Fatal("expected Ping to fail when no host is reachable")
+	}
+}
+
+func TestBulkOperationsRouteToEachHost(t *testing.T) {
+	b, one, two := newTestBackend()
+
+	err := b.StopContainers(context.Background(), []string{"staging-2/b1", "staging-1/a1", "staging-2/b2"})
+	if err != nil {
+		t.Fatalf("StopContainers returned error: %v", err)
+	}
+	if !slices.Equal(one.stopped, []string{"a1"}) {
+		t.Fatalf("expected staging-1 to stop a1, got %v", one.stopped)
+	}
+	if !slices.Equal(two.stopped, []string{"b1", "b2"}) {
+		t.Fatalf("expected staging-2 to stop b1 and b2, got %v", two.stopped)
+	}
+
+	if err := b.StopContainers(context.Background(), []string{"elsewhere/x"}); err == nil {
+		t.Fatal("expected an unknown host to be rejected")
+	}
+}
@@ -0,0 +1,523 @@
+// Package recording records backend sessions to JSONL files and replays them,
+// so a UI bug seen against one daemon can be reproduced without it.
+package recording
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// Options controls what a recording leaves out.
+type Options struct {
+	// RedactIDs replaces resource IDs with consistent stand-ins.
+	RedactIDs bool
+	// RedactEnv replaces the values of environment variables.
+	RedactEnv bool
+}
+
+// Entry is one recorded backend call. Context arguments are omitted.
+type Entry struct {
+	Seq    int               `json:"seq"`
+	Method string            `json:"method"`
+	Args   []json.RawMessage `json:"args,omitempty"`
+	Result json.RawMessage   `json:"result,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// Session writes the calls of every backend it wraps to one JSONL stream.
+// Backends recreated after a reconnect can be wrapped again and continue the
+// same recording.
+type Session struct {
+	mu       sync.Mutex
+	encoder  *json.Encoder
+	redactor *redactor
+	seq      int
+	err      error
+}
+
+// NewSession creates a session writing to w.
+func NewSession(w io.Writer, options Options) *Session {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &Session{encoder: encoder, redactor: newRedactor(options)}
+}
+
+// Wrap returns b with every call recorded to the session.
+func (s *Session) Wrap(b backend.Backend) backend.Backend {
+	return &Recorder{inner: b, session: s}
+}
+
+// Err returns the first error encountered while writing the recording.
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Session) record(method string, args []any, result any, callErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+
+	s.seq++
+	entry := Entry{Seq: s.seq, Method: method}
+	for _, arg := range args {
+		encoded, err := s.redactor.encode(arg)
+		if err != nil {
+			s.err = err
+			return
+		}
+		entry.Args = append(entry.Args, encoded)
+	}
+	if result != nil {
+		encoded, err := s.redactor.encode(result)
+		if err != nil {
+			s.err = err
+			return
+		}
+		entry.Result = encoded
+	}
+	if callErr != nil {
+		entry.Error = callErr.Error()
+	}
+	s.err = s.encoder.Encode(entry)
+}
+
+// teeReadCloser captures a stream as it is read and records it once it is
+// exhausted or closed.
+type teeReadCloser struct {
+	io.ReadCloser
+	buf    bytes.Buffer
+	once   sync.Once
+	record func(data []byte)
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	t.buf.Write(p[:n])
+	if err == io.EOF {
+		t.flush()
+	}
+	return n, err
+}
+
+func (t *teeReadCloser) Close() error {
+	t.flush()
+	return t.ReadCloser.Close()
+}
+
+func (t *teeReadCloser) flush() {
+	t.once.Do(func() { t.record(t.buf.Bytes()) })
+}
+
+// Recorder is a backend.Backend that records the calls made to another one.
+type Recorder struct {
+	inner   backend.Backend
+	session *Session
+}
+
+var _ backend.Backend = (*Recorder)(nil)
+
+func args(values ...any) []any {
+	return values
+}
+
+func (r *Recorder) Name() string {
+	name := r.inner.Name()
+	r.session.record("Name", nil, name, nil)
+	return name
+}
+
+func (r *Recorder) Version(ctx context.Context) string {
+	version := r.inner.Version(ctx)
+	r.session.record("Version", nil, version, nil)
+	return version
+}
+
+func (r *Recorder) Ping(ctx context.Context) error {
+	err := r.inner.Ping(ctx)
+	r.session.record("Ping", nil, nil, err)
+	return err
+}
+
+func (r *Recorder) Close() error {
+	return r.inner.Close()
+}
+
+// Container operations
+
+func (r *Recorder) ListContainers(ctx context.Context) ([]backend.Container, error) {
+	containers, err := r.inner.ListContainers(ctx)
+	r.session.record("ListContainers", nil, containers, err)
+	return containers, err
+}
+
+func (r *Recorder) InspectContainer(ctx context.Context, id string) (backend.ContainerDetail, error) {
+	detail, err := r.inner.InspectContainer(ctx, id)
+	r.session.record("InspectContainer", args(id), detail, err)
+	return detail, err
+}
+
+func (r *Recorder) GetContainerState(ctx context.Context, id string) (string, error) {
+	containerState, err := r.inner.GetContainerState(ctx, id)
+	r.session.record("GetContainerState", args(id), containerState, err)
+	return containerState, err
+}
+
+func (r *Recorder) CreateContainer(ctx context.Context, config backend.ContainerConfig) (string, error) {
+	id, err := r.inner.CreateContainer(ctx, config)
+	r.session.record("CreateContainer", args(config), id, err)
+	return id, err
+}
+
+func (r *Recorder) StartContainer(ctx context.Context, id string) error {
+	err := r.inner.StartContainer(ctx, id)
+	r.session.record("StartContainer", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) StartContainers(ctx context.Context, ids []string) error {
+	err := r.inner.StartContainers(ctx, ids)
+	r.session.record("StartContainers", args(ids), nil, err)
+	return err
+}
+
+func (r *Recorder) StopContainer(ctx context.Context, id string) error {
+	err := r.inner.StopContainer(ctx, id)
+	r.session.record("StopContainer", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) StopContainers(ctx context.Context, ids []string) error {
+	err := r.inner.StopContainers(ctx, ids)
+	r.session.record("StopContainers", args(ids), nil, err)
+	return err
+}
+
+func (r *Recorder) RestartContainer(ctx context.Context, id string) error {
+	err := r.inner.RestartContainer(ctx, id)
+	r.session.record("RestartContainer", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) RestartContainers(ctx context.Context, ids []string) error {
+	err := r.inner.RestartContainers(ctx, ids)
+	r.session.record("RestartContainers", args(ids), nil, err)
+	return err
+}
+
+func (r *Recorder) PauseContainer(ctx context.Context, id string) error {
+	err := r.inner.PauseContainer(ctx, id)
+	r.session.record("PauseContainer", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) PauseContainers(ctx context.Context, ids []string) error {
+	err := r.inner.PauseContainers(ctx, ids)
+	r.session.record("PauseContainers", args(ids), nil, err)
+	return err
+}
+
+func (r *Recorder) UnpauseContainer(ctx context.Context, id string) error {
+	err := r.inner.UnpauseContainer(ctx, id)
+	r.session.record("UnpauseContainer", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) UnpauseContainers(ctx context.Context, ids []string) error {
+	err := r.inner.UnpauseContainers(ctx, ids)
+	r.session.record("UnpauseContainers", args(ids), nil, err)
+	return err
+}
+
+func (r *Recorder) RemoveContainer(ctx context.Context, id string, force bool) error {
+	err := r.inner.RemoveContainer(ctx, id, force)
+	r.session.record("RemoveContainer", args(id, force), nil, err)
+	return err
+}
+
+func (r *Recorder) RemoveContainers(ctx context.Context, ids []string, force bool) error {
+	err := r.inner.RemoveContainers(ctx, ids, force)
+	r.session.record("RemoveContainers", args(ids, force), nil, err)
+	return err
+}
+
+func (r *Recorder) RenameContainer(ctx context.Context, id, newName string) error {
+	err := r.inner.RenameContainer(ctx, id, newName)
+	r.session.record("RenameContainer", args(id, newName), nil, err)
+	return err
+}
+
+func (r *Recorder) PruneContainers(ctx context.Context) (uint64, error) {
+	reclaimed, err := r.inner.PruneContainers(ctx)
+	r.session.record("PruneContainers", nil, reclaimed, err)
+	return reclaimed, err
+}
+
+// Container logs and exec
+
+// OpenLogs records the log output read by the caller once the stream ends.
+func (r *Recorder) OpenLogs(ctx context.Context, id string) (backend.Logs, error) {
+	logs, err := r.inner.OpenLogs(ctx, id)
+	if err != nil {
+		r.session.record("OpenLogs", args(id), nil, err)
+		return logs, err
+	}
+
+	stream := &teeReadCloser{ReadCloser: logs.Stream, record: func(data []byte) {
+		r.session.record("OpenLogs", args(id), string(data), nil)
+	}}
+	closeLogs := logs.Close
+	logs.Stream = stream
+	logs.Close = func() error {
+		stream.flush()
+		return closeLogs()
+	}
+	return logs, nil
+}
+
+// ExecShell records that a shell was opened; the interactive session itself
+// is not recorded.
+func (r *Recorder) ExecShell(ctx context.Context, id string, shell []string) (io.ReadWriteCloser, error) {
+	conn, err := r.inner.ExecShell(ctx, id, shell)
+	r.session.record("ExecShell", args(id, shell), nil, err)
+	return conn, err
+}
+
+// Image operations
+
+func (r *Recorder) ListImages(ctx context.Context) ([]backend.Image, error) {
+	images, err := r.inner.ListImages(ctx)
+	r.session.record("ListImages", nil, images, err)
+	return images, err
+}
+
+func (r *Recorder) InspectImage(ctx context.Context, id string) (backend.ImageDetail, error) {
+	detail, err := r.inner.InspectImage(ctx, id)
+	r.session.record("InspectImage", args(id), detail, err)
+	return detail, err
+}
+
+// PullImage records the progress lines sent while pulling.
+func (r *Recorder) PullImage(ctx context.Context, ref string, progressChan chan<- string) error {
+	relay := make(chan string)
+	done := make(chan []string)
+	go func() {
+		var lines []string
+		for line := range relay {
+			lines = append(lines, line)
+			progressChan <- line
+		}
+		done <- lines
+	}()
+
+	err := r.inner.PullImage(ctx, ref, relay)
+	if err != nil {
+		// The inner backend only closes the channel once the pull succeeds
+		close(relay)
+	}
+	lines := <-done
+	if err == nil {
+		close(progressChan)
+	}
+	r.session.record("PullImage", args(ref), lines, err)
+	return err
+}
+
+// BuildImage records the build output read by the caller once it ends.
+func (r *Recorder) BuildImage(ctx context.Context, dockerfilePath, tag, contextPath string, buildArgs map[string]*string) (io.ReadCloser, error) {
+	callArgs := args(dockerfilePath, tag, contextPath, buildArgs)
+	output, err := r.inner.BuildImage(ctx, dockerfilePath, tag, contextPath, buildArgs)
+	if err != nil {
+		r.session.record("BuildImage", callArgs, nil, err)
+		return nil, err
+	}
+	return &teeReadCloser{ReadCloser: output, record: func(data []byte) {
+		r.session.record("BuildImage", callArgs, string(data), nil)
+	}}, nil
+}
+
+func (r *Recorder) TagImage(ctx context.Context, source, target string) error {
+	err := r.inner.TagImage(ctx, source, target)
+	r.session.record("TagImage", args(source, target), nil, err)
+	return err
+}
+
+func (r *Recorder) RemoveImage(ctx context.Context, id string) error {
+	err := r.inner.RemoveImage(ctx, id)
+	r.session.record("RemoveImage", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) RemoveImages(ctx context.Context, ids []string) error {
+	err := r.inner.RemoveImages(ctx, ids)
+	r.session.record("RemoveImages", args(ids), nil, err)
+	return err
+}
+
+func (r *Recorder) PruneImages(ctx context.Context) (uint64, error) {
+	reclaimed, err := r.inner.PruneImages(ctx)
+	r.session.record("PruneImages", nil, reclaimed, err)
+	return reclaimed, err
+}
+
+// Image history and usage
+
+func (r *Recorder) ImageHistory(ctx context.Context, imageID string) ([]backend.ImageHistoryItem, error) {
+	history, err := r.inner.ImageHistory(ctx, imageID)
+	r.session.record("ImageHistory", args(imageID), history, err)
+	return history, err
+}
+
+// SaveImage records that an image was saved; the archive itself is too
+// large to keep in a recording.
+func (r *Recorder) SaveImage(ctx context.Context, id string) (io.ReadCloser, error) {
+	archive, err := r.inner.SaveImage(ctx, id)
+	r.session.record("SaveImage", args(id), nil, err)
+	return archive, err
+}
+
+func (r *Recorder) GetAllNetworkUsage(ctx context.Context) (map[string]bool, error) {
+	usage, err := r.inner.GetAllNetworkUsage(ctx)
+	r.session.record("GetAllNetworkUsage", nil, usage, err)
+	return usage, err
+}
+
+func (r *Recorder) GetAllVolumeUsage(ctx context.Context) (map[string]bool, error) {
+	usage, err := r.inner.GetAllVolumeUsage(ctx)
+	r.session.record("GetAllVolumeUsage", nil, usage, err)
+	return usage, err
+}
+
+// Network operations
+
+func (r *Recorder) ListNetworks(ctx context.Context) ([]backend.Network, error) {
+	networks, err := r.inner.ListNetworks(ctx)
+	r.session.record("ListNetworks", nil, networks, err)
+	return networks, err
+}
+
+func (r *Recorder) InspectNetwork(ctx context.Context, id string) (backend.NetworkDetail, error) {
+	detail, err := r.inner.InspectNetwork(ctx, id)
+	r.session.record("InspectNetwork", args(id), detail, err)
+	return detail, err
+}
+
+func (r *Recorder) CreateNetwork(ctx context.Context, name, driver, subnet, gateway string, enableIPv6 bool, labels map[string]string) (string, error) {
+	id, err := r.inner.CreateNetwork(ctx, name, driver, subnet, gateway, enableIPv6, labels)
+	r.session.record("CreateNetwork", args(name, driver, subnet, gateway, enableIPv6, labels), id, err)
+	return id, err
+}
+
+func (r *Recorder) RemoveNetwork(ctx context.Context, id string) error {
+	err := r.inner.RemoveNetwork(ctx, id)
+	r.session.record("RemoveNetwork", args(id), nil, err)
+	return err
+}
+
+func (r *Recorder) PruneNetworks(ctx context.Context) (int, error) {
+	removed, err := r.inner.PruneNetworks(ctx)
+	r.session.record("PruneNetworks", nil, removed, err)
+	return removed, err
+}
+
+func (r *Recorder) ConnectContainerToNetwork(ctx context.Context, containerID, networkID string) error {
+	err := r.inner.ConnectContainerToNetwork(ctx, containerID, networkID)
+	r.session.record("ConnectContainerToNetwork", args(containerID, networkID), nil, err)
+	return err
+}
+
+func (r *Recorder) DisconnectContainerFromNetwork(ctx context.Context, containerID, networkID string, force bool) error {
+	err := r.inner.DisconnectContainerFromNetwork(ctx, containerID, networkID, force)
+	r.session.record("DisconnectContainerFromNetwork", args(containerID, networkID, force), nil, err)
+	return err
+}
+
+// Volume operations
+
+func (r *Recorder) ListVolumes(ctx context.Context) ([]backend.Volume, error) {
+	volumes, err := r.inner.ListVolumes(ctx)
+	r.session.record("ListVolumes", nil, volumes, err)
+	return volumes, err
+}
+
+func (r *Recorder) InspectVolume(ctx context.Context, name string) (backend.VolumeDetail, error) {
+	detail, err := r.inner.InspectVolume(ctx, name)
+	r.session.record("InspectVolume", args(name), detail, err)
+	return detail, err
+}
+
+func (r *Recorder) CreateVolume(ctx context.Context, name, driver string, labels map[string]string) (string, error) {
+	created, err := r.inner.CreateVolume(ctx, name, driver, labels)
+	r.session.record("CreateVolume", args(name, driver, labels), created, err)
+	return created, err
+}
+
+func (r *Recorder) RemoveVolume(ctx context.Context, name string) error {
+	err := r.inner.RemoveVolume(ctx, name)
+	r.session.record("RemoveVolume", args(name), nil, err)
+	return err
+}
+
+func (r *Recorder) PruneVolumes(ctx context.Context) (uint64, error) {
+	reclaimed, err := r.inner.PruneVolumes(ctx)
+	r.session.record("PruneVolumes", nil, reclaimed, err)
+	return reclaimed, err
+}
+
+// Service operations
+
+func (r *Recorder) ListServices(ctx context.Context) ([]backend.Service, error) {
+	services, err := r.inner.ListServices(ctx)
+	r.session.record("ListServices", nil, services, err)
+	return services, err
+}
+
+// Dependency checking
+
+func (r *Recorder) GetContainersUsingImage(ctx context.Context, imageID string) ([]string, error) {
+	names, err := r.inner.GetContainersUsingImage(ctx, imageID)
+	r.session.record("GetContainersUsingImage", args(imageID), names, err)
+	return names, err
+}
+
+func (r *Recorder) GetContainersUsingVolume(ctx context.Context, volumeName string) ([]string, error) {
+	names, err := r.inner.GetContainersUsingVolume(ctx, volumeName)
+	r.session.record("GetContainersUsingVolume", args(volumeName), names, err)
+	return names, err
+}
+
+func (r *Recorder) GetContainersUsingNetwork(ctx context.Context, networkID string) ([]string, error) {
+	names, err := r.inner.GetContainersUsingNetwork(ctx, networkID)
+	r.session.record("GetContainersUsingNetwork", args(networkID), names, err)
+	return names, err
+}
+
+// ParseRedactions builds Options from redaction names: "ids", "env" or
+// "all".
+func ParseRedactions(names []string) (Options, error) {
+	var options Options
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "ids":
+			options.RedactIDs = true
+		case "env":
+			options.RedactEnv = true
+		case "all":
+			options.RedactIDs = true
+			options.RedactEnv = true
+		default:
+			return Options{}, fmt.Errorf("unknown redaction %q (expected ids, env or all)", name)
+		}
+	}
+	return options, nil
+}
@@ -0,0 +1,183 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/fake"
+)
+
+const testFixture = `
+images:
+  - tags: [alpine:latest]
+    env: [PATH=/usr/bin]
+containers:
+  - name: web
+    image: alpine
+    state: running
+    env: [API_TOKEN=hunter2]
+    logs: [listening on :80]
+`
+
+func newFakeBackend(t *testing.T) *fake.Backend {
+	t.Helper()
+	fixture, err := fake.ParseFixture([]byte(testFixture))
+	if err != nil {
+		t.Fatalf("ParseFixture returned error: %v", err)
+	}
+	b, err := fake.New(fixture)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return b
+}
+
+// replayOf reads back what a session wrote to buf.
+func replayOf(t *testing.T, buf *bytes.Buffer) *Replayer {
+	t.Helper()
+	entries, err := ReadEntries(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadEntries returned error: %v", err)
+	}
+	return NewReplayer(entries)
+}
+
+func TestReplayServesRecordedResponses(t *testing.T) {
+	var buf bytes.Buffer
+	recorded := NewSession(&buf, Options{}).Wrap(newFakeBackend(t))
+	ctx := context.Background()
+
+	before, err := recorded.ListContainers(ctx)
+	if err != nil || len(before) != 1 {
+		t.Fatalf("expected one container, got %+v (%v)", before, err)
+	}
+	if err := recorded.StopContainer(ctx, "web"); err != nil {
+		t.Fatalf("StopContainer returned error: %v", err)
+	}
+	if err := recorded.RemoveContainer(ctx, "missing", false); err == nil {
+		t.Fatal("expected removing a missing container to fail")
+	}
+	after, _ := recorded.ListContainers(ctx)
+	logs, err := recorded.OpenLogs(ctx, "web")
+	if err != nil {
+		t.Fatalf("OpenLogs returned error: %v", err)
+	}
+	output, _ := io.ReadAll(logs.Stream)
+	_ = logs.Close()
+
+	replayed := replayOf(t, &buf)
+	if got, _ := replayed.ListContainers(ctx); got[0].State != before[0].State {
+		t.Fatalf("expected the first listing to be %q, got %q", before[0].State, got[0].State)
+	}
+	if err := replayed.StopContainer(ctx, "web"); err != nil {
+		t.Fatalf("expected the recorded stop to succeed, got %v", err)
+	}
+	if err := replayed.RemoveContainer(ctx, "missing", false); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected the recorded error, got %v", err)
+	}
+	for range 2 {
+		if got, _ := replayed.ListContainers(ctx); got[0].State != after[0].State {
+			t.Fatalf("expected later listings to repeat %q, got %q", after[0].State, got[0].State)
+		}
+	}
+	replayedLogs, err := replayed.OpenLogs(ctx, "web")
+	if err != nil {
+		t.Fatalf("OpenLogs returned error: %v", err)
+	}
+	if got, _ := io.ReadAll(replayedLogs.Stream); string(got) != string(output) {
+		t.Fatalf("expected logs %q, got %q", output, got)
+	}
+
+	if _, err := replayed.ListImages(ctx); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("expected ErrNotRecorded for an unrecorded call, got %v", err)
+	}
+}
+
+func TestRedactionIsConsistent(t *testing.T) {
+	var buf bytes.Buffer
+	recorded := NewSession(&buf, Options{RedactIDs: true, RedactEnv: true}).Wrap(newFakeBackend(t))
+	ctx := context.Background()
+
+	containers, _ := recorded.ListContainers(ctx)
+	id := containers[0].ID
+	detail, err := recorded.InspectContainer(ctx, id[:12])
+	if err != nil {
+		t.Fatalf("InspectContainer returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), id[:12]) || strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("expected IDs and env values to be redacted, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "API_TOKEN=<redacted>") {
+		t.Fatalf("expected env names to be kept, got %s", buf.String())
+	}
+
+	replayed := replayOf(t, &buf)
+	listed, _ := replayed.ListContainers(ctx)
+	redactedID := listed[0].ID
+	if redactedID == id || len(redactedID) != len(id) {
+		t.Fatalf("expected a stand-in of the same length, got %q for %q", redactedID, id)
+	}
+	replayedDetail, err := replayed.InspectContainer(ctx, redactedID[:12])
+	if err != nil {
+		t.Fatalf("expected the short stand-in to match the recorded inspect, got %v", err)
+	}
+	if replayedDetail.ID != redactedID || replayedDetail.Name != detail.Name {
+		t.Fatalf("expected the redacted detail of %s, got %+v", detail.Name, replayedDetail)
+	}
+}
+
+func TestPullIsReplayedWithProgress(t *testing.T) {
+	var buf bytes.Buffer
+	var recorded backend.Backend = NewSession(&buf, Options{}).Wrap(newFakeBackend(t))
+	replayed := backend.Backend(nil)
+	ctx := context.Background()
+
+	pull := func(b backend.Backend) []string {
+		progress := make(chan string)
+		done := make(chan error, 1)
+		go func() { done <- b.PullImage(ctx, "redis", progress) }()
+		var lines []string
+		for line := range progress {
+			lines = append(lines, line)
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("PullImage returned error: %v", err)
+		}
+		return lines
+	}
+
+	want := pull(recorded)
+	replayed = replayOf(t, &buf)
+	if got := pull(replayed); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected progress %v, got %v", want, got)
+	}
+}
+
+func TestParseRedactions(t *testing.T) {
+	tests := []struct {
+		names   []string
+		want    Options
+		wantErr bool
+	}{
+		{names: nil, want: Options{}},
+		{names: []string{"ids"}, want: Options{RedactIDs: true}},
+		{names: []string{"env", "IDS"}, want: Options{RedactIDs: true, RedactEnv: true}},
+		{names: []string{"all"}, want: Options{RedactIDs: true, RedactEnv: true}},
+		{names: []string{"secrets"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRedactions(tt.names)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseRedactions(%v) error = %v, wantErr %v", tt.names, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseRedactions(%v) = %+v, want %+v", tt.names, got, tt.want)
+		}
+	}
+}
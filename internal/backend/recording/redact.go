@@ -0,0 +1,119 @@
+package recording
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces environment variable values.
+const redactedValue = "<redacted>"
+
+// idPattern matches container, image, network and volume IDs in full or
+// short form, with an optional digest algorithm prefix.
+var idPattern = regexp.MustCompile(`^(sha256:)?([0-9a-f]{12,64})$`)
+
+// redactor rewrites recorded values. IDs are replaced consistently for the
+// whole session, so short IDs stay prefixes of their full form and replayed
+// calls still line up with the results they came from.
+type redactor struct {
+	options Options
+	ids     map[string]string
+}
+
+func newRedactor(options Options) *redactor {
+	return &redactor{options: options, ids: make(map[string]string)}
+}
+
+// encode marshals value to JSON with the configured redactions applied.
+func (r *redactor) encode(value any) (json.RawMessage, error) {
+	data, err := marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if !r.options.RedactIDs && !r.options.RedactEnv {
+		return data, nil
+	}
+
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return marshal(r.walk(tree, ""))
+}
+
+// marshal encodes value without escaping HTML characters, keeping recorded
+// log lines and redaction markers readable.
+func marshal(value any) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func (r *redactor) walk(value any, key string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, child := range v {
+			redacted[r.redactString(k)] = r.walk(child, k)
+		}
+		return redacted
+	case []any:
+		for i, child := range v {
+			if s, ok := child.(string); ok && r.options.RedactEnv && key == "Env" {
+				v[i] = redactEnv(s)
+				continue
+			}
+			v[i] = r.walk(child, key)
+		}
+		return v
+	case string:
+		return r.redactString(v)
+	}
+	return value
+}
+
+func redactEnv(variable string) string {
+	name, _, ok := strings.Cut(variable, "=")
+	if !ok {
+		return variable
+	}
+	return name + "=" + redactedValue
+}
+
+func (r *redactor) redactString(s string) string {
+	if !r.options.RedactIDs {
+		return s
+	}
+	match := idPattern.FindStringSubmatch(s)
+	if match == nil {
+		return s
+	}
+	return match[1] + r.placeholder(match[2])
+}
+
+// placeholder returns the stand-in for id, reusing the stand-in of a known
+// longer ID when id is its short form.
+func (r *redactor) placeholder(id string) string {
+	if replacement, ok := r.ids[id]; ok {
+		return replacement
+	}
+	for known, replacement := range r.ids {
+		if len(known) > len(id) && strings.HasPrefix(known, id) {
+			return replacement[:len(id)]
+		}
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("redacted-%d", len(r.ids))))
+	replacement := hex.EncodeToString(sum[:])[:len(id)]
+	r.ids[id] = replacement
+	return replacement
+}
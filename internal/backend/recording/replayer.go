@@ -0,0 +1,452 @@
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// ErrNotRecorded is returned when a replayed session has no response for a
+// call.
+var ErrNotRecorded = errors.New("no recorded response")
+
+// responses is the recorded sequence of results for one kind of call. The
+// last response is served again once the sequence is exhausted, so polling
+// keeps showing the final recorded state.
+type responses struct {
+	entries []Entry
+	next    int
+}
+
+func (r *responses) take() Entry {
+	entry := r.entries[r.next]
+	if r.next < len(r.entries)-1 {
+		r.next++
+	}
+	return entry
+}
+
+// Replayer is a backend.Backend that serves the responses of a recorded
+// session. Calls are matched on their method and arguments first, and fall
+// back to the recorded order of the method when the arguments differ, for
+// example because they were redacted.
+type Replayer struct {
+	mu       sync.Mutex
+	byCall   map[string]*responses
+	byMethod map[string]*responses
+}
+
+var _ backend.Backend = (*Replayer)(nil)
+
+// NewReplayer creates a replayer from recorded entries.
+func NewReplayer(entries []Entry) *Replayer {
+	r := &Replayer{
+		byCall:   make(map[string]*responses),
+		byMethod: make(map[string]*responses),
+	}
+	for _, entry := range entries {
+		key := callKey(entry.Method, entry.Args)
+		if r.byCall[key] == nil {
+			r.byCall[key] = &responses{}
+		}
+		r.byCall[key].entries = append(r.byCall[key].entries, entry)
+		if r.byMethod[entry.Method] == nil {
+			r.byMethod[entry.Method] = &responses{}
+		}
+		r.byMethod[entry.Method].entries = append(r.byMethod[entry.Method].entries, entry)
+	}
+	return r
+}
+
+// ReadEntries decodes a JSONL recording.
+func ReadEntries(reader io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid recording on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return entries, nil
+}
+
+// Load reads the recording at path and returns a replayer for it.
+func Load(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := ReadEntries(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(entries), nil
+}
+
+func callKey(method string, args []json.RawMessage) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, method)
+	for _, arg := range args {
+		parts = append(parts, string(arg))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// replay serves the next response for a call, decoding its result into
+// result when it is non-nil.
+func (r *Replayer) replay(method string, callArgs []any, result any) error {
+	encoded := make([]json.RawMessage, 0, len(callArgs))
+	for _, arg := range callArgs {
+		data, err := marshal(arg)
+		if err != nil {
+			return err
+		}
+		encoded = append(encoded, data)
+	}
+
+	r.mu.Lock()
+	recorded, ok := r.byCall[callKey(method, encoded)]
+	if !ok {
+		recorded, ok = r.byMethod[method]
+	}
+	var entry Entry
+	if ok {
+		entry = recorded.take()
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w for %s", ErrNotRecorded, method)
+	}
+	if result != nil && len(entry.Result) > 0 {
+		if err := json.Unmarshal(entry.Result, result); err != nil {
+			return fmt.Errorf("invalid recorded result for %s: %w", method, err)
+		}
+	}
+	if entry.Error != "" {
+		return errors.New(entry.Error)
+	}
+	return nil
+}
+
+func (r *Replayer) Name() string {
+	name := "replay"
+	_ = r.replay("Name", nil, &name)
+	return name
+}
+
+func (r *Replayer) Version(context.Context) string {
+	var version string
+	_ = r.replay("Version", nil, &version)
+	return version
+}
+
+func (r *Replayer) Ping(ctx context.Context) error {
+	if err := r.replay("Ping", nil, nil); !errors.Is(err, ErrNotRecorded) {
+		return err
+	}
+	return nil
+}
+
+func (r *Replayer) Close() error {
+	return nil
+}
+
+// Container operations
+
+func (r *Replayer) ListContainers(ctx context.Context) ([]backend.Container, error) {
+	var containers []backend.Container
+	err := r.replay("ListContainers", nil, &containers)
+	return containers, err
+}
+
+func (r *Replayer) InspectContainer(ctx context.Context, id string) (backend.ContainerDetail, error) {
+	var detail backend.ContainerDetail
+	err := r.replay("InspectContainer", args(id), &detail)
+	return detail, err
+}
+
+func (r *Replayer) GetContainerState(ctx context.Context, id string) (string, error) {
+	var containerState string
+	err := r.replay("GetContainerState", args(id), &containerState)
+	return containerState, err
+}
+
+func (r *Replayer) CreateContainer(ctx context.Context, config backend.ContainerConfig) (string, error) {
+	var id string
+	err := r.replay("CreateContainer", args(config), &id)
+	return id, err
+}
+
+func (r *Replayer) StartContainer(ctx context.Context, id string) error {
+	return r.replay("StartContainer", args(id), nil)
+}
+
+func (r *Replayer) StartContainers(ctx context.Context, ids []string) error {
+	return r.replay("StartContainers", args(ids), nil)
+}
+
+func (r *Replayer) StopContainer(ctx context.Context, id string) error {
+	return r.replay("StopContainer", args(id), nil)
+}
+
+func (r *Replayer) StopContainers(ctx context.Context, ids []string) error {
+	return r.replay("StopContainers", args(ids), nil)
+}
+
+func (r *Replayer) RestartContainer(ctx context.Context, id string) error {
+	return r.replay("RestartContainer", args(id), nil)
+}
+
+func (r *Replayer) RestartContainers(ctx context.Context, ids []string) error {
+	return r.replay("RestartContainers", args(ids), nil)
+}
+
+func (r *Replayer) PauseContainer(ctx context.Context, id string) error {
+	return r.replay("PauseContainer", args(id), nil)
+}
+
+func (r *Replayer) PauseContainers(ctx context.Context, ids []string) error {
+	return r.replay("PauseContainers", args(ids), nil)
+}
+
+func (r *Replayer) UnpauseContainer(ctx context.Context, id string) error {
+	return r.replay("UnpauseContainer", args(id), nil)
+}
+
+func (r *Replayer) UnpauseContainers(ctx context.Context, ids []string) error {
+	return r.replay("UnpauseContainers", args(ids), nil)
+}
+
+func (r *Replayer) RemoveContainer(ctx context.Context, id string, force bool) error {
+	return r.replay("RemoveContainer", args(id, force), nil)
+}
+
+func (r *Replayer) RemoveContainers(ctx context.Context, ids []string, force bool) error {
+	return r.replay("RemoveContainers", args(ids, force), nil)
+}
+
+func (r *Replayer) RenameContainer(ctx context.Context, id, newName string) error {
+	return r.replay("RenameContainer", args(id, newName), nil)
+}
+
+func (r *Replayer) PruneContainers(ctx context.Context) (uint64, error) {
+	var reclaimed uint64
+	err := r.replay("PruneContainers", nil, &reclaimed)
+	return reclaimed, err
+}
+
+// Container logs and exec
+
+func (r *Replayer) OpenLogs(ctx context.Context, id string) (backend.Logs, error) {
+	var output string
+	if err := r.replay("OpenLogs", args(id), &output); err != nil {
+		return backend.Logs{}, err
+	}
+	stream := io.NopCloser(strings.NewReader(output))
+	return backend.Logs{Stream: stream, Close: stream.Close}, nil
+}
+
+// ExecShell always fails: interactive sessions are not part of a recording.
+func (r *Replayer) ExecShell(ctx context.Context, id string, shell []string) (io.ReadWriteCloser, error) {
+	return nil, errors.New("exec is not available when replaying a recording")
+}
+
+// Image operations
+
+func (r *Replayer) ListImages(ctx context.Context) ([]backend.Image, error) {
+	var images []backend.Image
+	err := r.replay("ListImages", nil, &images)
+	return images, err
+}
+
+func (r *Replayer) InspectImage(ctx context.Context, id string) (backend.ImageDetail, error) {
+	var detail backend.ImageDetail
+	err := r.replay("InspectImage", args(id), &detail)
+	return detail, err
+}
+
+// PullImage replays the recorded progress lines, closing progressChan when
+// the recorded pull succeeded.
+func (r *Replayer) PullImage(ctx context.Context, ref string, progressChan chan<- string) error {
+	var lines []string
+	err := r.replay("PullImage", args(ref), &lines)
+	for _, line := range lines {
+		select {
+		case progressChan <- line:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	close(progressChan)
+	return nil
+}
+
+func (r *Replayer) BuildImage(ctx context.Context, dockerfilePath, tag, contextPath string, buildArgs map[string]*string) (io.ReadCloser, error) {
+	var output string
+	if err := r.replay("BuildImage", args(dockerfilePath, tag, contextPath, buildArgs), &output); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(output)), nil
+}
+
+func (r *Replayer) TagImage(ctx context.Context, source, target string) error {
+	return r.replay("TagImage", args(source, target), nil)
+}
+
+func (r *Replayer) RemoveImage(ctx context.Context, id string) error {
+	return r.replay("RemoveImage", args(id), nil)
+}
+
+func (r *Replayer) RemoveImages(ctx context.Context, ids []string) error {
+	return r.replay("RemoveImages", args(ids), nil)
+}
+
+func (r *Replayer) PruneImages(ctx context.Context) (uint64, error) {
+	var reclaimed uint64
+	err := r.replay("PruneImages", nil, &reclaimed)
+	return reclaimed, err
+}
+
+// Image history and usage
+
+func (r *Replayer) ImageHistory(ctx context.Context, imageID string) ([]backend.ImageHistoryItem, error) {
+	var history []backend.ImageHistoryItem
+	err := r.replay("ImageHistory", args(imageID), &history)
+	return history, err
+}
+
+// SaveImage always fails: image contents are not part of a recording.
+func (r *Replayer) SaveImage(ctx context.Context, id string) (io.ReadCloser, error) {
+	return nil, errors.New("image contents are not available when replaying a recording")
+}
+
+func (r *Replayer) GetAllNetworkUsage(ctx context.Context) (map[string]bool, error) {
+	var usage map[string]bool
+	err := r.replay("GetAllNetworkUsage", nil, &usage)
+	return usage, err
+}
+
+func (r *Replayer) GetAllVolumeUsage(ctx context.Context) (map[string]bool, error) {
+	var usage map[string]bool
+	err := r.replay("GetAllVolumeUsage", nil, &usage)
+	return usage, err
+}
+
+// Network operations
+
+func (r *Replayer) ListNetworks(ctx context.Context) ([]backend.Network, error) {
+	var networks []backend.Network
+	err := r.replay("ListNetworks", nil, &networks)
+	return networks, err
+}
+
+func (r *Replayer) InspectNetwork(ctx context.Context, id string) (backend.NetworkDetail, error) {
+	var detail backend.NetworkDetail
+	err := r.replay("InspectNetwork", args(id), &detail)
+	return detail, err
+}
+
+func (r *Replayer) CreateNetwork(ctx context.Context, name, driver, subnet, gateway string, enableIPv6 bool, labels map[string]string) (string, error) {
+	var id string
+	err := r.replay("CreateNetwork", args(name, driver, subnet, gateway, enableIPv6, labels), &id)
+	return id, err
+}
+
+func (r *Replayer) RemoveNetwork(ctx context.Context, id string) error {
+	return r.replay("RemoveNetwork", args(id), nil)
+}
+
+func (r *Replayer) PruneNetworks(ctx context.Context) (int, error) {
+	var removed int
+	err := r.replay("PruneNetworks", nil, &removed)
+	return removed, err
+}
+
+func (r *Replayer) ConnectContainerToNetwork(ctx context.Context, containerID, networkID string) error {
+	return r.replay("ConnectContainerToNetwork", args(containerID, networkID), nil)
+}
+
+func (r *Replayer) DisconnectContainerFromNetwork(ctx context.Context, containerID, networkID string, force bool) error {
+	return r.replay("DisconnectContainerFromNetwork", args(containerID, networkID, force), nil)
+}
+
+// Volume operations
+
+func (r *Replayer) ListVolumes(ctx context.Context) ([]backend.Volume, error) {
+	var volumes []backend.Volume
+	err := r.replay("ListVolumes", nil, &volumes)
+	return volumes, err
+}
+
+func (r *Replayer) InspectVolume(ctx context.Context, name string) (backend.VolumeDetail, error) {
+	var detail backend.VolumeDetail
+	err := r.replay("InspectVolume", args(name), &detail)
+	return detail, err
+}
+
+func (r *Replayer) CreateVolume(ctx context.Context, name, driver string, labels map[string]string) (string, error) {
+	var created string
+	err := r.replay("CreateVolume", args(name, driver, labels), &created)
+	return created, err
+}
+
+func (r *Replayer) RemoveVolume(ctx context.Context, name string) error {
+	return r.replay("RemoveVolume", args(name), nil)
+}
+
+func (r *Replayer) PruneVolumes(ctx context.Context) (uint64, error) {
+	var reclaimed uint64
+	err := r.replay("PruneVolumes", nil, &reclaimed)
+	return reclaimed, err
+}
+
+// Service operations
+
+func (r *Replayer) ListServices(ctx context.Context) ([]backend.Service, error) {
+	var services []backend.Service
+	err := r.replay("ListServices", nil, &services)
+	return services, err
+}
+
+// Dependency checking
+
+func (r *Replayer) GetContainersUsingImage(ctx context.Context, imageID string) ([]string, error) {
+	var names []string
+	err := r.replay("GetContainersUsingImage", args(imageID), &names)
+	return names, err
+}
+
+func (r *Replayer) GetContainersUsingVolume(ctx context.Context, volumeName string) ([]string, error) {
+	var names []string
+	err := r.replay("GetContainersUsingVolume", args(volumeName), &names)
+	return names, err
+}
+
+func (r *Replayer) GetContainersUsingNetwork(ctx context.Context, networkID string) ([]string, error) {
+	var names []string
+	err := r.replay("GetContainersUsingNetwork", args(networkID), &names)
+	return names, err
+}
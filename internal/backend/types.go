@@ -13,11 +13,26 @@ type Container struct {
 	State   string
 	Status  string
 	Created time.Time
+	Labels  map[string]string
+	Ports   []Port
+	// IPAddresses maps each network the container is attached to to its
+	// address on that network
+	IPAddresses map[string]string
+	Host        string // Host the container lives on when several daemons are aggregated
+}
+
+// Port is a port a container exposes, with the host port it is published on
+// if any.
+type Port struct {
+	PrivatePort uint16
+	PublicPort  uint16
+	Type        string
 }
 
 // ContainerDetail contains detailed information about a container.
 type ContainerDetail struct {
 	Container
+	Lifecycle       ContainerLifecycle
 	Config          ContainerConfigDetail
 	HostConfig      HostConfig
 	NetworkSettings NetworkSettings
@@ -25,6 +40,15 @@ type ContainerDetail struct {
 	Raw             interface{} // Backend-specific raw data
 }
 
+// ContainerLifecycle records when a container last started and stopped.
+type ContainerLifecycle struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExitCode   int
+	// Health is the health check status, empty without a health check
+	Health string
+}
+
 // ContainerConfigDetail contains container configuration details.
 type ContainerConfigDetail struct {
 	Hostname     string
@@ -44,6 +68,20 @@ type ContainerConfigDetail struct {
 	Entrypoint   []string
 	Labels       map[string]string
 	ExposedPorts map[string]struct{}
+	// Healthcheck is nil when neither the container nor its image
+	// configures a health check
+	Healthcheck *Healthcheck
+}
+
+// Healthcheck is a container health check. Test is ["NONE"] when the check
+// is disabled, ["CMD", args...] for a command, or ["CMD-SHELL", command]
+// for a shell command; zero durations and retries use the engine defaults.
+type Healthcheck struct {
+	Test        []string
+	Interval    time.Duration
+	Timeout     time.Duration
+	StartPeriod time.Duration
+	Retries     int
 }
 
 // HostConfig contains host configuration for a container.
@@ -64,6 +102,7 @@ type HostConfig struct {
 	CapAdd            []string
 	CapDrop           []string
 	CpuShares         int64
+	NanoCPUs          int64
 	Memory            int64
 	MemorySwap        int64
 	MemoryReservation int64
@@ -114,6 +153,7 @@ type EndpointIPAMConfig struct {
 // Mount represents a volume mount.
 type Mount struct {
 	Type        string
+	Name        string // Volume name, empty for other mount types
 	Source      string
 	Destination string
 	Mode        string
@@ -128,6 +168,7 @@ type Image struct {
 	RepoDigests []string
 	Size        int64
 	Created     time.Time
+	Labels      map[string]string
 }
 
 // ImageDetail contains detailed information about an image.
@@ -156,6 +197,7 @@ type Network struct {
 	Name   string
 	Driver string
 	Scope  string
+	Labels map[string]string
 }
 
 // NetworkDetail contains detailed information about a network.
@@ -170,7 +212,6 @@ type NetworkDetail struct {
 	ConfigOnly bool
 	Containers map[string]EndpointResource
 	Options    map[string]string
-	Labels     map[string]string
 	Raw        interface{} // Backend-specific raw data
 }
 
@@ -209,12 +250,12 @@ type Volume struct {
 	Driver     string
 	Mountpoint string
 	CreatedAt  time.Time
+	Labels     map[string]string
 }
 
 // VolumeDetail contains detailed information about a volume.
 type VolumeDetail struct {
 	Volume
-	Labels  map[string]string
 	Scope   string
 	Options map[string]string
 	Raw     interface{} // Backend-specific raw data
@@ -246,6 +287,23 @@ type ContainerConfig struct {
 	AutoStart  bool
 	AutoRemove bool
 	Network    string // Network name (default: "bridge")
+
+	// The settings below are optional, for recreating a container as
+	// precisely as compose does
+	Labels       map[string]string
+	Entrypoint   []string // optional entrypoint override; nil = use image default
+	Hostname     string
+	Domainname   string
+	User         string
+	WorkingDir   string
+	ExposedPorts []string // "port/protocol" format
+	Tmpfs        []string // mount destinations
+	// Networks attaches the container to each network with its aliases and
+	// addresses, in place of Network
+	Networks map[string]EndpointSettings
+	// Host replaces the host settings Ports, Volumes, AutoStart and
+	// AutoRemove make up when set
+	Host *HostConfig
 }
 
 // ImageHistoryItem represents a single layer in an image's history.
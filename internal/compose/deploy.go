@@ -0,0 +1,593 @@
+package compose
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// Labels compose puts on what it creates, which tell its projects apart.
+const (
+	LabelProject     = "com.docker.compose.project"
+	LabelService     = "com.docker.compose.service"
+	LabelNetwork     = "com.docker.compose.network"
+	LabelVolume      = "com.docker.compose.volume"
+	LabelNumber      = "com.docker.compose.container-number"
+	LabelOneoff      = "com.docker.compose.oneoff"
+	LabelWorkingDir  = "com.docker.compose.project.working_dir"
+	LabelConfigFiles = "com.docker.compose.project.config_files"
+	LabelEnvFile     = "com.docker.compose.project.environment_file"
+	LabelConfigHash  = "com.docker.compose.config-hash"
+	LabelDependsOn   = "com.docker.compose.depends_on"
+	LabelImage       = "com.docker.compose.image"
+)
+
+// Report is told of the progress of each resource, such as a service
+// "Created" or a network "Removed".
+type Report func(resource, status string)
+
+// pollInterval is how often dependencies are checked while waiting on
+// their condition.
+var pollInterval = time.Second
+
+// Up creates the project's networks and volumes, pulls or builds missing
+// images, and creates and starts its services in dependency order.
+// Containers whose configuration and image are unchanged are kept and
+// started; the others are recreated with the anonymous volumes they had.
+func Up(ctx context.Context, b backend.Backend, project *Project, report Report) error {
+	report = orNothing(report)
+	if err := createNetworks(ctx, b, project, report); err != nil {
+		return err
+	}
+	if err := createVolumes(ctx, b, project, report); err != nil {
+		return err
+	}
+	if err := ensureImages(ctx, b, project, report); err != nil {
+		return err
+	}
+
+	existing, err := projectContainers(ctx, b, project.Name)
+	if err != nil {
+		return err
+	}
+	ids := make(map[string]string, len(project.Services))
+	for _, service := range project.Services {
+		if err := waitForDependencies(ctx, b, service, ids, report); err != nil {
+			return err
+		}
+		id, err := upService(ctx, b, project, service, existing[service.Name], ids, report)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", service.Name, err)
+		}
+		ids[service.Name] = id
+	}
+	return nil
+}
+
+// Down stops and removes the project's containers, latest started first,
+// then the networks it created and, with removeVolumes, its volumes and
+// the anonymous volumes of its containers.
+func Down(ctx context.Context, b backend.Backend, project *Project, removeVolumes bool, report Report) error {
+	report = orNothing(report)
+	existing, err := projectContainers(ctx, b, project.Name)
+	if err != nil {
+		return err
+	}
+
+	// Services of the file go in reverse start order, then leftovers
+	var order []string
+	for _, service := range slices.Backward(project.Services) {
+		order = append(order, service.Name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(existing)) {
+		if !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+
+	var anonymous []string
+	for _, name := range order {
+		for _, container := range existing[name] {
+			if removeVolumes {
+				if detail, err := b.InspectContainer(ctx, container.ID); err == nil {
+					for _, mount := range detail.Mounts {
+						if mount.Type == "volume" && isAnonymous(mount.Name) {
+							anonymous = append(anonymous, mount.Name)
+						}
+					}
+				}
+			}
+			containerName := strings.TrimPrefix(container.Name, "/")
+			if container.State == "running" || container.State == "paused" || container.State == "restarting" {
+				report(containerName, "Stopping")
+				if err := b.StopContainer(ctx, container.ID); err != nil {
+					return err
+				}
+			}
+			report(containerName, "Removing")
+			if err := b.RemoveContainer(ctx, container.ID, true); err != nil {
+				return err
+			}
+			report(containerName, "Removed")
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(project.Networks)) {
+		network := project.Networks[key]
+		if network.External {
+			continue
+		}
+		if _, err := b.InspectNetwork(ctx, network.Name); err != nil {
+			continue
+		}
+		report(network.Name, "Removing")
+		if err := b.RemoveNetwork(ctx, network.Name); err != nil {
+			return err
+		}
+		report(network.Name, "Removed")
+	}
+
+	if !removeVolumes {
+		return nil
+	}
+	var volumes []string
+	for _, key := range slices.Sorted(maps.Keys(project.Volumes)) {
+		if volume := project.Volumes[key]; !volume.External {
+			volumes = append(volumes, volume.Name)
+		}
+	}
+	for _, name := range append(volumes, anonymous...) {
+		if _, err := b.InspectVolume(ctx, name); err != nil {
+			continue
+		}
+		report(name, "Removing")
+		if err := b.RemoveVolume(ctx, name); err != nil {
+			return err
+		}
+		report(name, "Removed")
+	}
+	return nil
+}
+
+// Pull pulls the images of the project's services, skipping those that
+// are built or never pulled.
+func Pull(ctx context.Context, b backend.Backend, project *Project, report Report) error {
+	report = orNothing(report)
+	var failed []error
+	for _, service := range project.Services {
+		if service.PullPolicy == "never" || service.PullPolicy == "build" || service.Build != nil && service.PullPolicy == "" {
+			continue
+		}
+		report(service.Image, "Pulling")
+		if err := pullImage(ctx, b, service.Image); err != nil {
+			report(service.Image, "Error")
+			failed = append(failed, fmt.Errorf("service %s: %w", service.Name, err))
+			continue
+		}
+		report(service.Image, "Pulled")
+	}
+	return errors.Join(failed...)
+}
+
+func orNothing(report Report) Report {
+	if report == nil {
+		return func(string, string) {}
+	}
+	return report
+}
+
+// createNetworks creates the networks that do not exist yet, and checks
+// that the external ones do.
+func createNetworks(ctx context.Context, b backend.Backend, project *Project, report Report) error {
+	for _, key := range slices.Sorted(maps.Keys(project.Networks)) {
+		network := project.Networks[key]
+		if _, err := b.InspectNetwork(ctx, network.Name); err == nil {
+			continue
+		} else if network.External {
+			return fmt.Errorf("external network %s not found: %w", network.Name, err)
+		}
+
+		labels := maps.Clone(network.Labels)
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[LabelProject] = project.Name
+		labels[LabelNetwork] = key
+		report(network.Name, "Creating")
+		if _, err := b.CreateNetwork(ctx, network.Name, network.Driver, network.Subnet, network.Gateway, network.EnableIPv6, labels); err != nil {
+			return err
+		}
+		report(network.Name, "Created")
+	}
+	return nil
+}
+
+// createVolumes creates the volumes that do not exist yet, and checks that
+// the external ones do.
+func createVolumes(ctx context.Context, b backend.Backend, project *Project, report Report) error {
+	for _, key := range slices.Sorted(maps.Keys(project.Volumes)) {
+		volume := project.Volumes[key]
+		if _, err := b.InspectVolume(ctx, volume.Name); err == nil {
+			continue
+		} else if volume.External {
+			return fmt.Errorf("external volume %s not found: %w", volume.Name, err)
+		}
+
+		labels := maps.Clone(volume.Labels)
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[LabelProject] = project.Name
+		labels[LabelVolume] = key
+		report(volume.Name, "Creating")
+		if _, err := b.CreateVolume(ctx, volume.Name, volume.Driver, labels); err != nil {
+			return err
+		}
+		report(volume.Name, "Created")
+	}
+	return nil
+}
+
+// ensureImages pulls or builds the images services need, following their
+// pull policy: by default only missing images are pulled, or built when
+// the service has a build.
+func ensureImages(ctx context.Context, b backend.Backend, project *Project, report Report) error {
+	done := make(map[string]bool)
+	for _, service := range project.Services {
+		if done[service.Image] {
+			continue
+		}
+		done[service.Image] = true
+
+		_, err := b.InspectImage(ctx, service.Image)
+		missing := err != nil
+		switch policy := service.PullPolicy; {
+		case policy == "build" || service.Build != nil && missing:
+			if service.Build == nil {
+				return fmt.Errorf("service %s: pull_policy build without a build", service.Name)
+			}
+			report(service.Image, "Building")
+			if err := buildImage(ctx, b, service); err != nil {
+				return fmt.Errorf("service %s: %w", service.Name, err)
+			}
+			report(service.Image, "Built")
+		case policy == "always" || missing && policy != "never":
+			report(service.Image, "Pulling")
+			if err := pullImage(ctx, b, service.Image); err != nil {
+				return fmt.Errorf("service %s: %w", service.Name, err)
+			}
+			report(service.Image, "Pulled")
+		case missing:
+			return fmt.Errorf("service %s: image %s not found and pull_policy is never", service.Name, service.Image)
+		}
+	}
+	return nil
+}
+
+// pullImage pulls ref, draining the progress it reports.
+func pullImage(ctx context.Context, b backend.Backend, ref string) error {
+	progress := make(chan string, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- b.PullImage(ctx, ref, progress)
+	}()
+	for {
+		select {
+		case _, ok := <-progress:
+			if !ok {
+				return <-done
+			}
+		case err := <-done:
+			return err
+		}
+	}
+}
+
+// buildImage builds the service's image, failing on the first error the
+// build output reports.
+func buildImage(ctx context.Context, b backend.Backend, service Service) error {
+	output, err := b.BuildImage(ctx, service.Build.Dockerfile, service.Image, service.Build.Context, service.Build.Args)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	scanner := bufio.NewScanner(output)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &line) == nil && line.Error != "" {
+			return fmt.Errorf("failed to build image: %s", line.Error)
+		}
+	}
+	return scanner.Err()
+}
+
+// projectContainers returns the project's containers by service.
+func projectContainers(ctx context.Context, b backend.Backend, project string) (map[string][]backend.Container, error) {
+	containers, err := b.ListContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byService := make(map[string][]backend.Container)
+	for _, container := range containers {
+		if container.Labels[LabelProject] == project && container.Labels[LabelOneoff] != "True" {
+			service := container.Labels[LabelService]
+			byService[service] = append(byService[service], container)
+		}
+	}
+	return byService, nil
+}
+
+// upService brings a service's container up, keeping an existing one when
+// its configuration and image are unchanged, and returns its ID. A
+// recreated container keeps the anonymous volumes of the one it replaces.
+func upService(ctx context.Context, b backend.Backend, project *Project, service Service, existing []backend.Container, ids map[string]string, report Report) (string, error) {
+	config, err := containerConfig(ctx, b, project, service, ids)
+	if err != nil {
+		return "", err
+	}
+
+	anonymous := make(map[string]string)
+	for _, container := range existing {
+		if container.Labels[LabelConfigHash] == config.Labels[LabelConfigHash] &&
+			container.Labels[LabelImage] == config.Labels[LabelImage] &&
+			strings.TrimPrefix(container.Name, "/") == config.Name {
+			if container.State != "running" {
+				report(config.Name, "Starting")
+				if err := b.StartContainer(ctx, container.ID); err != nil {
+					return "", err
+				}
+			}
+			report(config.Name, "Running")
+			return container.ID, nil
+		}
+		if detail, err := b.InspectContainer(ctx, container.ID); err == nil {
+			for _, mount := range detail.Mounts {
+				if mount.Type == "volume" && isAnonymous(mount.Name) {
+					anonymous[mount.Destination] = mount.Name
+				}
+			}
+		}
+		report(strings.TrimPrefix(container.Name, "/"), "Recreating")
+		if err := b.RemoveContainer(ctx, container.ID, true); err != nil {
+			return "", err
+		}
+	}
+
+	// Anonymous volumes are created first so they can be bound by name
+	binds := config.Host.Binds[:0:0]
+	for _, bind := range config.Host.Binds {
+		if !strings.Contains(bind, ":") || strings.HasPrefix(bind, "/") && strings.Count(bind, ":") == 1 && isMode(bind[strings.Index(bind, ":")+1:]) {
+			destination, _, _ := strings.Cut(bind, ":")
+			name, ok := anonymous[destination]
+			if !ok {
+				if name, err = b.CreateVolume(ctx, "", "", nil); err != nil {
+					return "", err
+				}
+			}
+			bind = name + ":" + bind
+		}
+		binds = append(binds, bind)
+	}
+	config.Host.Binds = binds
+
+	report(config.Name, "Creating")
+	id, err := b.CreateContainer(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	report(config.Name, "Starting")
+	if err := b.StartContainer(ctx, id); err != nil {
+		return "", err
+	}
+	report(config.Name, "Started")
+	return id, nil
+}
+
+// containerConfig is the configuration of a service's container, labelled
+// as compose labels its containers.
+func containerConfig(ctx context.Context, b backend.Backend, project *Project, service Service, ids map[string]string) (backend.ContainerConfig, error) {
+	name := service.ContainerName
+	if name == "" {
+		name = project.Name + "-" + service.Name + "-1"
+	}
+
+	host := &backend.HostConfig{
+		Binds:             service.Volumes,
+		NetworkMode:       service.NetworkMode,
+		PortBindings:      service.Ports,
+		RestartPolicy:     service.Restart,
+		Privileged:        service.Privileged,
+		ReadonlyRootfs:    service.ReadOnly,
+		DNS:               service.DNS,
+		DNSOptions:        service.DNSOptions,
+		DNSSearch:         service.DNSSearch,
+		ExtraHosts:        service.ExtraHosts,
+		CapAdd:            service.CapAdd,
+		CapDrop:           service.CapDrop,
+		CpuShares:         service.CPUShares,
+		NanoCPUs:          service.NanoCPUs,
+		Memory:            service.Memory,
+		MemorySwap:        service.MemorySwap,
+		MemoryReservation: service.MemoryReservation,
+		OomKillDisable:    service.OomKillDisable,
+		PidsLimit:         service.PidsLimit,
+	}
+	if dependency, ok := strings.CutPrefix(service.NetworkMode, "service:"); ok {
+		host.NetworkMode = "container:" + ids[dependency]
+	}
+
+	var networks map[string]backend.EndpointSettings
+	if len(service.Networks) > 0 {
+		networks = make(map[string]backend.EndpointSettings, len(service.Networks))
+		for key, endpoint := range service.Networks {
+			endpoint.Aliases = slices.Concat([]string{name}, endpoint.Aliases)
+			networks[project.Networks[key].Name] = endpoint
+		}
+		// The first network is the one the container is created on
+		host.NetworkMode = project.Networks[slices.Sorted(maps.Keys(service.Networks))[0]].Name
+	}
+
+	labels := maps.Clone(service.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[LabelProject] = project.Name
+	labels[LabelService] = service.Name
+	labels[LabelNumber] = "1"
+	labels[LabelOneoff] = "False"
+	labels[LabelWorkingDir] = project.WorkingDir
+	labels[LabelConfigFiles] = strings.Join(project.ConfigFiles, ",")
+	if project.EnvFile != "" {
+		labels[LabelEnvFile] = project.EnvFile
+	}
+	labels[LabelConfigHash] = configHash(service)
+	labels[LabelDependsOn] = dependsOnLabel(service)
+	if image, err := b.InspectImage(ctx, service.Image); err == nil {
+		labels[LabelImage] = image.ID
+	}
+
+	return backend.ContainerConfig{
+		Name:         name,
+		Image:        service.Image,
+		Env:          service.Environment,
+		Cmd:          service.Command,
+		Entrypoint:   service.Entrypoint,
+		Tty:          service.Tty,
+		OpenStdin:    service.StdinOpen,
+		Labels:       labels,
+		Hostname:     service.Hostname,
+		Domainname:   service.Domainname,
+		User:         service.User,
+		WorkingDir:   service.WorkingDir,
+		ExposedPorts: service.Expose,
+		Tmpfs:        service.Tmpfs,
+		Networks:     networks,
+		Host:         host,
+	}, nil
+}
+
+// configHash fingerprints a service's configuration, so an unchanged
+// container can be kept.
+func configHash(service Service) string {
+	data, _ := json.Marshal(service)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// dependsOnLabel writes dependencies as compose does, such as
+// "db:service_healthy:false".
+func dependsOnLabel(service Service) string {
+	var dependencies []string
+	for _, name := range slices.Sorted(maps.Keys(service.DependsOn)) {
+		dependencies = append(dependencies, name+":"+service.DependsOn[name].Condition+":false")
+	}
+	return strings.Join(dependencies, ",")
+}
+
+// waitForDependencies waits until the service's dependencies meet their
+// conditions: started, healthy or exited successfully.
+func waitForDependencies(ctx context.Context, b backend.Backend, service Service, ids map[string]string, report Report) error {
+	for _, name := range slices.Sorted(maps.Keys(service.DependsOn)) {
+		dependency := service.DependsOn[name]
+		if dependency.Condition == ConditionStarted {
+			continue
+		}
+		id, ok := ids[name]
+		if !ok {
+			continue
+		}
+		report(name, "Waiting")
+		for {
+			detail, err := b.InspectContainer(ctx, id)
+			if err != nil {
+				return err
+			}
+			met, err := conditionMet(dependency.Condition, detail)
+			if err != nil {
+				if !dependency.Required {
+					break
+				}
+				return fmt.Errorf("dependency %s of service %s: %w", name, service.Name, err)
+			}
+			if met {
+				report(name, conditionStatus(dependency.Condition))
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+	return nil
+}
+
+func conditionMet(condition string, detail backend.ContainerDetail) (bool, error) {
+	switch condition {
+	case ConditionHealthy:
+		switch detail.Lifecycle.Health {
+		case "healthy":
+			return true, nil
+		case "unhealthy":
+			return false, errors.New("container is unhealthy")
+		case "":
+			return false, errors.New("container has no health check")
+		}
+		if detail.State == "exited" || detail.State == "dead" {
+			return false, fmt.Errorf("container exited with code %d", detail.Lifecycle.ExitCode)
+		}
+	case ConditionCompleted:
+		if detail.State == "exited" || detail.State == "dead" {
+			if detail.Lifecycle.ExitCode != 0 {
+				return false, fmt.Errorf("container exited with code %d", detail.Lifecycle.ExitCode)
+			}
+			return true, nil
+		}
+	default:
+		return false, fmt.Errorf("unknown condition %s", condition)
+	}
+	return false, nil
+}
+
+func conditionStatus(condition string) string {
+	if condition == ConditionHealthy {
+		return "Healthy"
+	}
+	return "Exited"
+}
+
+// isMode reports whether s is a mount mode such as "ro" or "rw,z".
+func isMode(s string) bool {
+	for _, option := range strings.Split(s, ",") {
+		switch option {
+		case "ro", "rw", "z", "Z", "shared", "rshared", "slave", "rslave", "private", "rprivate", "nocopy":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isAnonymous reports whether a volume has a generated name.
+func isAnonymous(name string) bool {
+	if len(name) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
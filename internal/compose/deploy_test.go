@@ -0,0 +1,308 @@
+package compose
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/fake"
+)
+
+const deployCompose = `
+services:
+  web:
+    image: nginx:alpine
+    depends_on: [api]
+    ports: ["8080:80"]
+    networks: [front]
+  api:
+    image: shop/api:latest
+    environment:
+      MODE: ${MODE:-production}
+    networks:
+      front:
+      back:
+        aliases: [backend]
+    volumes:
+      - data:/data
+      - /cache
+networks:
+  front:
+  back:
+volumes:
+  data:
+`
+
+func newFakeBackend(t *testing.T) *fake.Backend {
+	t.Helper()
+	fixture, err := fake.ParseFixture([]byte(`
+images:
+  - tags: [nginx:alpine]
+    cmd: [nginx]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := fake.New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func loadDeployProject(t *testing.T, path, mode string) *Project {
+	t.Helper()
+	project, err := Load(path, Options{Lookup: func(name string) (string, bool) {
+		if name == "MODE" && mode != "" {
+			return mode, true
+		}
+		return "", false
+	}})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	return project
+}
+
+func containersByName(t *testing.T, b backend.Backend) map[string]backend.Container {
+	t.Helper()
+	containers, err := b.ListContainers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]backend.Container)
+	for _, container := range containers {
+		byName[strings.TrimPrefix(container.Name, "/")] = container
+	}
+	return byName
+}
+
+func TestUpCreatesTheProjectWithComposeLabels(t *testing.T) {
+	ctx := context.Background()
+	b := newFakeBackend(t)
+	path := writeProject(t, map[string]string{"compose.yaml": deployCompose})
+	project := loadDeployProject(t, path, "")
+
+	var reported []string
+	if err := Up(ctx, b, project, func(resource, status string) {
+		reported = append(reported, resource+" "+status)
+	}); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+	// The missing image is pulled and the API starts before the web server
+	for _, want := range []string{"shop/api:latest Pulled", "shop_back Created", "shop_data Created", "shop-api-1 Started"} {
+		if !slices.Contains(reported, want) {
+			t.Errorf("expected %q reported, got %v", want, reported)
+		}
+	}
+	if slices.Index(reported, "shop-api-1 Started") > slices.Index(reported, "shop-web-1 Started") {
+		t.Errorf("expected api started before web, got %v", reported)
+	}
+
+	containers := containersByName(t, b)
+	api, ok := containers["shop-api-1"]
+	if !ok || api.State != "running" {
+		t.Fatalf("expected shop-api-1 running, got %+v", containers)
+	}
+	for label, want := range map[string]string{
+		LabelProject:     "shop",
+		LabelService:     "api",
+		LabelNumber:      "1",
+		LabelOneoff:      "False",
+		LabelConfigFiles: path,
+		LabelWorkingDir:  project.WorkingDir,
+	} {
+		if got := api.Labels[label]; got != want {
+			t.Errorf("label %s = %q, want %q", label, got, want)
+		}
+	}
+
+	detail, err := b.InspectContainer(ctx, api.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliases := detail.NetworkSettings.Networks["shop_back"].Aliases; !slices.Equal(aliases, []string{"shop-api-1", "api", "backend"}) {
+		t.Errorf("aliases on shop_back = %v", aliases)
+	}
+	if _, ok := detail.NetworkSettings.Networks["shop_front"]; !ok {
+		t.Errorf("expected api on shop_front, got %v", detail.NetworkSettings.Networks)
+	}
+	if !slices.Contains(detail.Config.Env, "MODE=production") {
+		t.Errorf("Env = %v", detail.Config.Env)
+	}
+	var mounted []string
+	for _, mount := range detail.Mounts {
+		mounted = append(mounted, mount.Name)
+	}
+	if !slices.Contains(mounted, "shop_data") || len(mounted) != 2 {
+		t.Errorf("expected the named and an anonymous volume mounted, got %v", mounted)
+	}
+
+	network, err := b.InspectNetwork(ctx, "shop_back")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if network.Labels[LabelProject] != "shop" || network.Labels[LabelNetwork] != "back" {
+		t.Errorf("network labels = %v", network.Labels)
+	}
+}
+
+func TestUpKeepsUnchangedContainersAndRecreatesChanged(t *testing.T) {
+	ctx := context.Background()
+	b := newFakeBackend(t)
+	path := writeProject(t, map[string]string{"compose.yaml": deployCompose})
+
+	if err := Up(ctx, b, loadDeployProject(t, path, ""), nil); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+	before := containersByName(t, b)
+
+	if err := Up(ctx, b, loadDeployProject(t, path, "debug"), nil); err != nil {
+		t.Fatalf("second Up returned error: %v", err)
+	}
+	after := containersByName(t, b)
+
+	if after["shop-web-1"].ID != before["shop-web-1"].ID {
+		t.Error("expected the unchanged web container kept")
+	}
+	if after["shop-api-1"].ID == before["shop-api-1"].ID {
+		t.Error("expected the api container recreated with its new environment")
+	}
+	if len(after) != 2 {
+		t.Errorf("expected two containers, got %d", len(after))
+	}
+}
+
+// anonymousVolume returns the anonymous volume a container mounts at
+// destination.
+func anonymousVolume(t *testing.T, b backend.Backend, id, destination string) string {
+	t.Helper()
+	detail, err := b.InspectContainer(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mount := range detail.Mounts {
+		if mount.Destination == destination && isAnonymous(mount.Name) {
+			return mount.Name
+		}
+	}
+	t.Fatalf("expected an anonymous volume at %s, got %+v", destination, detail.Mounts)
+	return ""
+}
+
+func TestUpKeepsAnonymousVolumesOfRecreatedContainers(t *testing.T) {
+	ctx := context.Background()
+	b := newFakeBackend(t)
+	path := writeProject(t, map[string]string{"compose.yaml": deployCompose})
+
+	if err := Up(ctx, b, loadDeployProject(t, path, ""), nil); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+	before := anonymousVolume(t, b, containersByName(t, b)["shop-api-1"].ID, "/cache")
+
+	if err := Up(ctx, b, loadDeployProject(t, path, "debug"), nil); err != nil {
+		t.Fatalf("second Up returned error: %v", err)
+	}
+	if after := anonymousVolume(t, b, containersByName(t, b)["shop-api-1"].ID, "/cache"); after != before {
+		t.Errorf("expected the recreated api to keep volume %s, got %s", before, after)
+	}
+}
+
+// newerRegistry pulls a newly built image for every reference, as a
+// registry would after the image was pushed again.
+type newerRegistry struct {
+	*fake.Backend
+	context string
+}
+
+func (b newerRegistry) PullImage(ctx context.Context, ref string, _ chan<- string) error {
+	output, err := b.BuildImage(ctx, "Dockerfile", ref, b.context, nil)
+	if err != nil {
+		return err
+	}
+	return output.Close()
+}
+
+func TestUpRecreatesContainersAfterPullingNewerImages(t *testing.T) {
+	ctx := context.Background()
+	path := writeProject(t, map[string]string{"compose.yaml": deployCompose, "Dockerfile": "FROM alpine\nCMD [\"api\"]\n"})
+	b := newerRegistry{Backend: newFakeBackend(t), context: filepath.Dir(path)}
+	project := loadDeployProject(t, path, "")
+
+	if err := Up(ctx, b, project, nil); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+	before := containersByName(t, b)
+
+	if err := Pull(ctx, b, project, nil); err != nil {
+		t.Fatalf("Pull returned error: %v", err)
+	}
+	if err := Up(ctx, b, project, nil); err != nil {
+		t.Fatalf("second Up returned error: %v", err)
+	}
+	after := containersByName(t, b)
+
+	for _, name := range []string{"shop-api-1", "shop-web-1"} {
+		if after[name].ID == before[name].ID {
+			t.Errorf("expected %s recreated on its pulled image", name)
+		}
+		image, err := b.InspectImage(ctx, after[name].Image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after[name].Labels[LabelImage] != image.ID {
+			t.Errorf("expected %s labelled with image %s, got %s", name, image.ID, after[name].Labels[LabelImage])
+		}
+	}
+}
+
+func TestDownRemovesTheProject(t *testing.T) {
+	ctx := context.Background()
+	b := newFakeBackend(t)
+	path := writeProject(t, map[string]string{"compose.yaml": deployCompose})
+	project := loadDeployProject(t, path, "")
+	if err := Up(ctx, b, project, nil); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+
+	if err := Down(ctx, b, project, false, nil); err != nil {
+		t.Fatalf("Down returned error: %v", err)
+	}
+	if containers := containersByName(t, b); len(containers) != 0 {
+		t.Errorf("expected no containers left, got %v", containers)
+	}
+	if _, err := b.InspectNetwork(ctx, "shop_front"); err == nil {
+		t.Error("expected shop_front removed")
+	}
+	if _, err := b.InspectVolume(ctx, "shop_data"); err != nil {
+		t.Error("expected shop_data kept without removing volumes")
+	}
+
+	if err := Down(ctx, b, project, true, nil); err != nil {
+		t.Fatalf("Down with volumes returned error: %v", err)
+	}
+	if _, err := b.InspectVolume(ctx, "shop_data"); err == nil {
+		t.Error("expected shop_data removed")
+	}
+}
+
+func TestPullPullsEveryImage(t *testing.T) {
+	ctx := context.Background()
+	b := newFakeBackend(t)
+	path := writeProject(t, map[string]string{"compose.yaml": deployCompose})
+
+	var pulled []string
+	if err := Pull(ctx, b, loadDeployProject(t, path, ""), func(resource, status string) {
+		if status == "Pulled" {
+			pulled = append(pulled, resource)
+		}
+	}); err != nil {
+		t.Fatalf("Pull returned error: %v", err)
+	}
+	if !slices.Equal(pulled, []string{"shop/api:latest", "nginx:alpine"}) {
+		t.Errorf("pulled %v", pulled)
+	}
+}
@@ -0,0 +1,221 @@
+package compose
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lookup finds the value of a variable, reporting whether it is set.
+type Lookup func(name string) (string, bool)
+
+// interpolateNode substitutes variables in every string value of a YAML
+// document, leaving mapping keys alone as compose does.
+func interpolateNode(node *yaml.Node, lookup Lookup) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := interpolateNode(child, lookup); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := interpolateNode(node.Content[i], lookup); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "$") {
+			return nil
+		}
+		value, err := Interpolate(node.Value, lookup)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		node.Value = value
+		// A substituted value is typed afresh, so "${PORT}" can be a number
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+	}
+	return nil
+}
+
+// Interpolate substitutes $VAR, ${VAR} and the ${VAR:-default},
+// ${VAR-default}, ${VAR:?error}, ${VAR?error}, ${VAR:+alternative} and
+// ${VAR+alternative} forms in s, with $$ for a literal dollar sign.
+func Interpolate(s string, lookup Lookup) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			out.WriteByte(s[i])
+			continue
+		}
+
+		switch next := s[i+1]; {
+		case next == '$':
+			out.WriteByte('$')
+			i++
+
+		case next == '{':
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable in %q", s)
+			}
+			value, err := substitute(s[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(value)
+			i = end
+
+		case isNameStart(next):
+			end := i + 1
+			for end < len(s) && isNameChar(s[end]) {
+				end++
+			}
+			value, _ := lookup(s[i+1 : end])
+			out.WriteString(value)
+			i = end - 1
+
+		default:
+			out.WriteByte('$')
+		}
+	}
+	return out.String(), nil
+}
+
+// closingBrace finds the brace closing the variable that starts at from,
+// skipping those of variables nested in its default.
+func closingBrace(s string, from int) int {
+	depth := 1
+	for i := from; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// substitute resolves the inside of a ${...} expression.
+func substitute(expr string, lookup Lookup) (string, error) {
+	end := 0
+	for end < len(expr) && isNameChar(expr[end]) {
+		end++
+	}
+	name, rest := expr[:end], expr[end:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid variable name in ${%s}", expr)
+	}
+	value, set := lookup(name)
+	if rest == "" {
+		return value, nil
+	}
+
+	// With a colon an empty value counts as unset
+	unset := !set
+	if strings.HasPrefix(rest, ":") {
+		unset = value == ""
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return "", fmt.Errorf("invalid variable expression ${%s}", expr)
+	}
+	operator, argument := rest[0], rest[1:]
+
+	switch operator {
+	case '-':
+		if unset {
+			return Interpolate(argument, lookup)
+		}
+		return value, nil
+	case '+':
+		if unset {
+			return "", nil
+		}
+		return Interpolate(argument, lookup)
+	case '?':
+		if unset {
+			message, err := Interpolate(argument, lookup)
+			if err != nil {
+				return "", err
+			}
+			return "", fmt.Errorf("required variable %s is missing a value: %s", name, message)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid variable expression ${%s}", expr)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || '0' <= c && c <= '9'
+}
+
+// readEnvFile reads the KEY=VALUE lines of an env file, skipping comments
+// and blank lines. Values may be quoted; a line with only a key reads the
+// variable from lookup.
+func readEnvFile(path string, lookup Lookup) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var env []string
+	scanner := bufio.NewScanner(file)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, hasValue := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("%s:%d: missing variable name", path, number)
+		}
+		if !hasValue {
+			if value, ok := lookup(name); ok {
+				env = append(env, name+"="+value)
+			}
+			continue
+		}
+		env = append(env, name+"="+envValue(strings.TrimSpace(value)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// envValue unquotes an env file value, or drops a trailing comment from an
+// unquoted one.
+func envValue(value string) string {
+	if len(value) >= 2 {
+		switch quote := value[0]; {
+		case quote == '\'' && value[len(value)-1] == '\'':
+			return value[1 : len(value)-1]
+		case quote == '"' && value[len(value)-1] == '"':
+			return strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
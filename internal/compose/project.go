@@ -0,0 +1,815 @@
+// Package compose deploys compose files through a backend.Backend, so
+// projects can be brought up, down and pulled on hosts without the compose
+// plugin. What it creates carries compose's own labels, so docker compose
+// sees the result as one of its projects.
+package compose
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"gopkg.in/yaml.v3"
+)
+
+// Conditions a dependency has to meet before a service starts.
+const (
+	ConditionStarted   = "service_started"
+	ConditionHealthy   = "service_healthy"
+	ConditionCompleted = "service_completed_successfully"
+)
+
+// Project is a compose file resolved for deployment: variables are
+// substituted, env files read, names given their project prefix and
+// services ordered so each comes after those it depends on.
+type Project struct {
+	Name        string
+	WorkingDir  string
+	ConfigFiles []string
+	// EnvFile is the .env file variables were read from, if there was one
+	EnvFile string
+	// Services are those the active profiles enable, in start order
+	Services []Service
+	Networks map[string]Network
+	Volumes  map[string]Volume
+}
+
+// Service is a service of a project and the container it runs.
+type Service struct {
+	Name          string
+	Image         string
+	Build         *Build
+	PullPolicy    string
+	ContainerName string
+	Hostname      string
+	Domainname    string
+	User          string
+	WorkingDir    string
+	// Entrypoint and Command are nil to keep the image's
+	Entrypoint  []string
+	Command     []string
+	Environment []string
+	Labels      map[string]string
+	// Ports map "port/protocol" to their host bindings
+	Ports  map[string][]backend.PortBinding
+	Expose []string
+	// Volumes are binds and volumes such as "/srv/app:/app:ro", named after
+	// their volume's full name; anonymous volumes have no source
+	Volumes []string
+	Tmpfs   []string
+	// Networks map the keys of the project's networks to the service's
+	// aliases and addresses on them; they are empty with NetworkMode set
+	Networks    map[string]backend.EndpointSettings
+	NetworkMode string
+	DependsOn   map[string]Dependency
+	Restart     backend.RestartPolicy
+	CapAdd      []string
+	CapDrop     []string
+	DNS         []string
+	DNSSearch   []string
+	DNSOptions  []string
+	ExtraHosts  []string
+	Privileged  bool
+	ReadOnly    bool
+	StdinOpen   bool
+	Tty         bool
+	// Resource limits, with memory in bytes
+	NanoCPUs          int64
+	CPUShares         int64
+	Memory            int64
+	MemorySwap        int64
+	MemoryReservation int64
+	PidsLimit         int64
+	OomKillDisable    bool
+}
+
+// Build describes how to build the image of a service.
+type Build struct {
+	Context    string
+	Dockerfile string
+	Args       map[string]*string
+}
+
+// Dependency is a service another depends on.
+type Dependency struct {
+	Condition string
+	// Required dependencies fail their dependents when they cannot start
+	Required bool
+}
+
+// Network is a network of a project.
+type Network struct {
+	// Name is the network's full name, prefixed with the project's
+	Name       string
+	External   bool
+	Driver     string
+	DriverOpts map[string]string
+	Internal   bool
+	Attachable bool
+	EnableIPv6 bool
+	Subnet     string
+	Gateway    string
+	Labels     map[string]string
+}
+
+// Volume is a named volume of a project.
+type Volume struct {
+	// Name is the volume's full name, prefixed with the project's
+	Name       string
+	External   bool
+	Driver     string
+	DriverOpts map[string]string
+	Labels     map[string]string
+}
+
+// Options change how a compose file is loaded.
+type Options struct {
+	// ProjectName overrides the name in the file and the directory's
+	ProjectName string
+	// Profiles enable the services with one of them, besides those listed
+	// in COMPOSE_PROFILES
+	Profiles []string
+	// Lookup finds variables; it defaults to the environment
+	Lookup Lookup
+}
+
+// DefaultFiles are the file names looked for in a directory, in order.
+var DefaultFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+var (
+	// projectNameInvalid matches what compose drops from project names
+	projectNameInvalid = regexp.MustCompile(`[^a-z0-9_-]`)
+	// byteSize reads sizes such as 512m or 1.5gb
+	byteSize = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([kmgt]?)b?$`)
+)
+
+// Load reads and resolves the compose file at path, or the default file
+// in it when path is a directory.
+func Load(path string, options Options) (*Project, error) {
+	path, err := findFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %w", err)
+	}
+	workingDir := filepath.Dir(path)
+
+	// Variables come from the environment first, then the .env file
+	environment := options.Lookup
+	if environment == nil {
+		environment = os.LookupEnv
+	}
+	lookup := environment
+	envFile := filepath.Join(workingDir, ".env")
+	dotenv, err := readEnvFile(envFile, environment)
+	switch {
+	case err == nil:
+		values := envMap(dotenv)
+		lookup = func(name string) (string, bool) {
+			if value, ok := environment(name); ok {
+				return value, true
+			}
+			value, ok := values[name]
+			return value, ok
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		envFile = ""
+	default:
+		return nil, fmt.Errorf("failed to read %s: %w", envFile, err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := interpolateNode(&document, lookup); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var raw rawProject
+	if err := document.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	name := cmp.Or(options.ProjectName, raw.Name)
+	if name == "" {
+		name, _ = lookup("COMPOSE_PROJECT_NAME")
+	}
+	name = projectName(cmp.Or(name, filepath.Base(workingDir)))
+	if name == "" {
+		return nil, fmt.Errorf("%s: cannot derive a project name from the directory", path)
+	}
+
+	profiles := options.Profiles
+	if value, ok := lookup("COMPOSE_PROFILES"); ok && value != "" {
+		profiles = append(slices.Clone(profiles), strings.Split(value, ",")...)
+	}
+
+	project := &Project{
+		Name:        name,
+		WorkingDir:  workingDir,
+		ConfigFiles: []string{path},
+		EnvFile:     envFile,
+		Networks:    make(map[string]Network),
+		Volumes:     make(map[string]Volume),
+	}
+	if err := project.resolve(raw, profiles, lookup); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return project, nil
+}
+
+// findFile returns path, or the default compose file in it when it is a
+// directory.
+func findFile(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read compose file: %w", err)
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	for _, name := range DefaultFiles {
+		candidate := filepath.Join(path, name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no compose file found in %s", path)
+}
+
+// projectName normalizes a name as compose does: lowercase, with only
+// letters, digits, dashes and underscores.
+func projectName(name string) string {
+	name = projectNameInvalid.ReplaceAllString(strings.ToLower(name), "")
+	return strings.TrimLeft(name, "_-")
+}
+
+func (p *Project) resolve(raw rawProject, profiles []string, lookup Lookup) error {
+	if len(raw.Services) == 0 {
+		return errors.New("no services defined")
+	}
+
+	for key, network := range raw.Networks {
+		p.Networks[key] = p.resolveNetwork(key, network)
+	}
+	for key, volume := range raw.Volumes {
+		p.Volumes[key] = p.resolveVolume(key, volume)
+	}
+
+	enabled := make(map[string]Service)
+	for name, rawService := range raw.Services {
+		if len(rawService.Profiles) > 0 && !slices.ContainsFunc(rawService.Profiles, func(profile string) bool {
+			return slices.Contains(profiles, profile)
+		}) {
+			continue
+		}
+		service, err := p.resolveService(name, rawService, lookup)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+		enabled[name] = service
+	}
+	if len(enabled) == 0 {
+		return errors.New("no services enabled by the active profiles")
+	}
+
+	// Dependencies outside the active profiles are an error unless optional
+	for name, service := range enabled {
+		for dependency, condition := range service.DependsOn {
+			if _, ok := enabled[dependency]; ok {
+				continue
+			}
+			if _, exists := raw.Services[dependency]; !exists {
+				return fmt.Errorf("service %s depends on undefined service %s", name, dependency)
+			}
+			if condition.Required {
+				return fmt.Errorf("service %s depends on %s, which no active profile enables", name, dependency)
+			}
+			delete(service.DependsOn, dependency)
+		}
+	}
+
+	services, err := startOrder(enabled)
+	if err != nil {
+		return err
+	}
+	p.Services = services
+
+	// Networks and volumes no enabled service uses are left out
+	used := map[string]bool{}
+	for _, service := range p.Services {
+		for key := range service.Networks {
+			used[key] = true
+		}
+	}
+	for key := range p.Networks {
+		if !used[key] {
+			delete(p.Networks, key)
+		}
+	}
+	return nil
+}
+
+func (p *Project) resolveNetwork(key string, raw *rawNetwork) Network {
+	if raw == nil {
+		raw = &rawNetwork{}
+	}
+	network := Network{
+		Name:       cmp.Or(raw.Name, p.Name+"_"+key),
+		External:   raw.External.External,
+		Driver:     raw.Driver,
+		DriverOpts: raw.DriverOpts,
+		Internal:   raw.Internal,
+		Attachable: raw.Attachable,
+		EnableIPv6: raw.EnableIPv6,
+		Labels:     labels(raw.Labels),
+	}
+	if network.External {
+		network.Name = cmp.Or(raw.External.Name, raw.Name, key)
+	}
+	if len(raw.IPAM.Config) > 0 {
+		network.Subnet = raw.IPAM.Config[0].Subnet
+		network.Gateway = raw.IPAM.Config[0].Gateway
+	}
+	return network
+}
+
+func (p *Project) resolveVolume(key string, raw *rawVolume) Volume {
+	if raw == nil {
+		raw = &rawVolume{}
+	}
+	volume := Volume{
+		Name:       cmp.Or(raw.Name, p.Name+"_"+key),
+		External:   raw.External.External,
+		Driver:     raw.Driver,
+		DriverOpts: raw.DriverOpts,
+		Labels:     labels(raw.Labels),
+	}
+	if volume.External {
+		volume.Name = cmp.Or(raw.External.Name, raw.Name, key)
+	}
+	return volume
+}
+
+func (p *Project) resolveService(name string, raw rawService, lookup Lookup) (Service, error) {
+	service := Service{
+		Name:           name,
+		Image:          raw.Image,
+		PullPolicy:     raw.PullPolicy,
+		ContainerName:  raw.ContainerName,
+		Hostname:       raw.Hostname,
+		Domainname:     raw.Domainname,
+		User:           raw.User,
+		WorkingDir:     raw.WorkingDir,
+		Labels:         labels(raw.Labels),
+		Tmpfs:          raw.Tmpfs,
+		NetworkMode:    raw.NetworkMode,
+		DependsOn:      maps.Clone(map[string]Dependency(raw.DependsOn)),
+		CapAdd:         raw.CapAdd,
+		CapDrop:        raw.CapDrop,
+		DNS:            raw.DNS,
+		DNSSearch:      raw.DNSSearch,
+		DNSOptions:     raw.DNSOpt,
+		ExtraHosts:     raw.ExtraHosts,
+		Privileged:     raw.Privileged,
+		ReadOnly:       raw.ReadOnly,
+		StdinOpen:      raw.StdinOpen,
+		Tty:            raw.Tty,
+		CPUShares:      raw.CPUShares,
+		PidsLimit:      raw.PidsLimit,
+		OomKillDisable: raw.OomKillDisable,
+	}
+	if service.DependsOn == nil {
+		service.DependsOn = make(map[string]Dependency)
+	}
+	if raw.Entrypoint != nil {
+		service.Entrypoint = *raw.Entrypoint
+	}
+	if raw.Command != nil {
+		service.Command = *raw.Command
+	}
+
+	if raw.Build != nil {
+		service.Build = &Build{
+			Context:    p.path(cmp.Or(raw.Build.Context, ".")),
+			Dockerfile: cmp.Or(raw.Build.Dockerfile, "Dockerfile"),
+			Args:       raw.Build.Args.values,
+		}
+		// Built images are named after the project and service
+		service.Image = cmp.Or(service.Image, p.Name+"-"+name)
+	}
+	if service.Image == "" {
+		return service, errors.New("neither an image nor a build is given")
+	}
+
+	var err error
+	if service.Environment, err = p.environment(raw, lookup); err != nil {
+		return service, err
+	}
+	if service.Restart, err = restartPolicy(raw.Restart); err != nil {
+		return service, err
+	}
+	if service.Ports, err = publishedPorts(raw.Ports); err != nil {
+		return service, err
+	}
+	for _, expose := range raw.Expose {
+		ports, err := portRange(string(expose))
+		if err != nil {
+			return service, err
+		}
+		service.Expose = append(service.Expose, ports...)
+	}
+	if service.Volumes, service.Tmpfs, err = p.mounts(raw.Volumes, service.Tmpfs); err != nil {
+		return service, err
+	}
+	if err := p.serviceNetworks(&service, raw.Networks); err != nil {
+		return service, err
+	}
+	if err := resources(&service, raw); err != nil {
+		return service, err
+	}
+	return service, nil
+}
+
+// environment reads the service's env files, then its environment, which
+// takes precedence; variables without a value are read from lookup.
+func (p *Project) environment(raw rawService, lookup Lookup) ([]string, error) {
+	var names []string
+	values := make(map[string]string)
+	set := func(name, value string) {
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = value
+	}
+
+	for _, file := range raw.EnvFile {
+		path := p.path(file.Path)
+		env, err := readEnvFile(path, lookup)
+		if errors.Is(err, fs.ErrNotExist) && file.Required != nil && !*file.Required {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file: %w", err)
+		}
+		for _, variable := range env {
+			name, value, _ := strings.Cut(variable, "=")
+			set(name, value)
+		}
+	}
+	for _, name := range raw.Environment.names {
+		if value := raw.Environment.values[name]; value != nil {
+			set(name, *value)
+		} else if value, ok := lookup(name); ok {
+			set(name, value)
+		}
+	}
+
+	env := make([]string, len(names))
+	for i, name := range names {
+		env[i] = name + "=" + values[name]
+	}
+	return env, nil
+}
+
+// mounts resolves the service's volumes to binds named after the full
+// names of project volumes, moving tmpfs mounts to tmpfs.
+func (p *Project) mounts(volumes []serviceVolume, tmpfs []string) ([]string, []string, error) {
+	var binds []string
+	for _, volume := range volumes {
+		mode := ""
+		if volume.short != "" {
+			parts := strings.Split(volume.short, ":")
+			// A Windows drive letter is part of the source
+			if len(parts) > 2 && len(parts[0]) == 1 {
+				parts = append([]string{parts[0] + ":" + parts[1]}, parts[2:]...)
+			}
+			switch len(parts) {
+			case 1:
+				volume.Target = parts[0]
+			case 2:
+				volume.Source, volume.Target = parts[0], parts[1]
+			case 3:
+				volume.Source, volume.Target, mode = parts[0], parts[1], parts[2]
+			default:
+				return nil, nil, fmt.Errorf("invalid volume %q", volume.short)
+			}
+		} else if volume.ReadOnly {
+			mode = "ro"
+		}
+		if volume.Target == "" {
+			return nil, nil, errors.New("volume without a target")
+		}
+
+		source := volume.Source
+		switch {
+		case volume.Type == "tmpfs":
+			tmpfs = append(tmpfs, volume.Target)
+			continue
+		case source == "":
+			// Anonymous volumes are created with the container
+		case volume.Type == "bind" || strings.ContainsAny(source[:1], "./~") || filepath.IsAbs(source):
+			source = p.path(source)
+		default:
+			named, ok := p.Volumes[source]
+			if !ok {
+				return nil, nil, fmt.Errorf("refers to undefined volume %s", source)
+			}
+			source = named.Name
+		}
+
+		bind := volume.Target
+		if source != "" {
+			bind = source + ":" + bind
+		}
+		if mode != "" {
+			bind += ":" + mode
+		}
+		binds = append(binds, bind)
+	}
+	return binds, tmpfs, nil
+}
+
+// serviceNetworks resolves the service's networks, attaching it to the
+// project's default network when it names none. Each attachment has the
+// service's name as an alias, so other services reach it by that name.
+func (p *Project) serviceNetworks(service *Service, networks serviceNetworks) error {
+	if service.NetworkMode != "" {
+		if len(networks) > 0 {
+			return errors.New("network_mode and networks cannot be combined")
+		}
+		if dependency, ok := strings.CutPrefix(service.NetworkMode, "service:"); ok {
+			if _, ok := service.DependsOn[dependency]; !ok {
+				service.DependsOn[dependency] = Dependency{Condition: ConditionStarted, Required: true}
+			}
+		}
+		return nil
+	}
+
+	if len(networks) == 0 {
+		networks = serviceNetworks{"default": {}}
+	}
+	service.Networks = make(map[string]backend.EndpointSettings, len(networks))
+	for key, network := range networks {
+		if _, ok := p.Networks[key]; !ok {
+			if key != "default" {
+				return fmt.Errorf("refers to undefined network %s", key)
+			}
+			p.Networks[key] = p.resolveNetwork(key, nil)
+		}
+		endpoint := backend.EndpointSettings{Aliases: slices.Concat([]string{service.Name}, network.Aliases)}
+		if network.IPv4Address != "" || network.IPv6Address != "" {
+			endpoint.IPAMConfig = &backend.EndpointIPAMConfig{IPv4Address: network.IPv4Address, IPv6Address: network.IPv6Address}
+		}
+		service.Networks[key] = endpoint
+	}
+	return nil
+}
+
+// path resolves a path relative to the project's directory, expanding ~.
+func (p *Project) path(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok && (rest == "" || rest[0] == '/') {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + rest
+		}
+	}
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(p.WorkingDir, path)
+}
+
+// startOrder sorts services so each comes after those it depends on,
+// alphabetically where the order is free.
+func startOrder(services map[string]Service) ([]Service, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	marks := make(map[string]int)
+	var order []Service
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		for _, dependency := range slices.Sorted(maps.Keys(services[name].DependsOn)) {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		order = append(order, services[name])
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(services)) {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// restartPolicy reads a restart policy such as "on-failure:3".
+func restartPolicy(value string) (backend.RestartPolicy, error) {
+	name, retries, hasRetries := strings.Cut(value, ":")
+	policy := backend.RestartPolicy{Name: cmp.Or(name, "no")}
+	switch policy.Name {
+	case "no", "always", "unless-stopped":
+		if hasRetries {
+			return policy, fmt.Errorf("invalid restart policy %q", value)
+		}
+	case "on-failure":
+		if hasRetries {
+			count, err := strconv.Atoi(retries)
+			if err != nil || count < 0 {
+				return policy, fmt.Errorf("invalid restart policy %q", value)
+			}
+			policy.MaximumRetryCount = count
+		}
+	default:
+		return policy, fmt.Errorf("invalid restart policy %q", value)
+	}
+	return policy, nil
+}
+
+// publishedPorts reads ports such as "127.0.0.1:8080-8081:80-81/udp" into
+// their host bindings.
+func publishedPorts(ports []port) (map[string][]backend.PortBinding, error) {
+	bindings := make(map[string][]backend.PortBinding)
+	for _, port := range ports {
+		if port.short != "" {
+			spec := port.short
+			spec, port.Protocol, _ = strings.Cut(spec, "/")
+			// The host address may be an IPv6 address in brackets
+			if strings.HasPrefix(spec, "[") {
+				end := strings.Index(spec, "]:")
+				if end < 0 {
+					return nil, fmt.Errorf("invalid port %q", port.short)
+				}
+				port.HostIP, spec = spec[1:end], spec[end+2:]
+			}
+			parts := strings.Split(spec, ":")
+			switch len(parts) {
+			case 1:
+				port.Target = parts[0]
+			case 2:
+				port.Published, port.Target = parts[0], parts[1]
+			case 3:
+				if port.HostIP != "" {
+					return nil, fmt.Errorf("invalid port %q", port.short)
+				}
+				port.HostIP, port.Published, port.Target = parts[0], parts[1], parts[2]
+			default:
+				return nil, fmt.Errorf("invalid port %q", port.short)
+			}
+		}
+
+		targets, err := portRange(port.Target + "/" + cmp.Or(port.Protocol, "tcp"))
+		if err != nil {
+			return nil, err
+		}
+		published := make([]string, len(targets))
+		if port.Published != "" {
+			hostPorts, err := portRange(port.Published)
+			if err != nil {
+				return nil, err
+			}
+			switch len(hostPorts) {
+			case 1:
+				// A single host port takes the first target
+				published[0] = strings.TrimSuffix(hostPorts[0], "/tcp")
+			case len(targets):
+				for i, hostPort := range hostPorts {
+					published[i] = strings.TrimSuffix(hostPort, "/tcp")
+				}
+			default:
+				return nil, fmt.Errorf("port ranges %s and %s differ in size", port.Published, port.Target)
+			}
+		}
+		for i, target := range targets {
+			bindings[target] = append(bindings[target], backend.PortBinding{HostIP: port.HostIP, HostPort: published[i]})
+		}
+	}
+	return bindings, nil
+}
+
+// portRange expands a port or range such as "8000-8002/udp" into ports
+// such as "8000/udp", with tcp when no protocol is given.
+func portRange(spec string) ([]string, error) {
+	spec, protocol, _ := strings.Cut(spec, "/")
+	protocol = cmp.Or(protocol, "tcp")
+	first, last, isRange := strings.Cut(spec, "-")
+	start, err := strconv.ParseUint(first, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", spec)
+	}
+	end := start
+	if isRange {
+		if end, err = strconv.ParseUint(last, 10, 16); err != nil || end < start {
+			return nil, fmt.Errorf("invalid port range %q", spec)
+		}
+	}
+	var ports []string
+	for port := start; port <= end; port++ {
+		ports = append(ports, strconv.FormatUint(port, 10)+"/"+protocol)
+	}
+	return ports, nil
+}
+
+// resources reads the service's limits, which deploy.resources can also
+// give.
+func resources(service *Service, raw rawService) error {
+	limits := rawDeploy{}
+	if raw.Deploy != nil {
+		limits = *raw.Deploy
+	}
+
+	cpus := cmp.Or(raw.CPUs, limits.Resources.Limits.CPUs)
+	if cpus != "" {
+		value, err := strconv.ParseFloat(string(cpus), 64)
+		if err != nil || value < 0 {
+			return fmt.Errorf("invalid cpus %q", cpus)
+		}
+		service.NanoCPUs = int64(value * 1e9)
+	}
+	for _, size := range []struct {
+		value  scalar
+		target *int64
+	}{
+		{cmp.Or(raw.MemLimit, limits.Resources.Limits.Memory), &service.Memory},
+		{raw.MemswapLimit, &service.MemorySwap},
+		{cmp.Or(raw.MemReservation, limits.Resources.Reservations.Memory), &service.MemoryReservation},
+	} {
+		bytes, err := parseBytes(string(size.value))
+		if err != nil {
+			return err
+		}
+		*size.target = bytes
+	}
+	service.PidsLimit = cmp.Or(service.PidsLimit, limits.Resources.Limits.Pids)
+	return nil
+}
+
+// parseBytes reads a size such as 512m, 1gb or a plain number of bytes;
+// -1 means unlimited.
+func parseBytes(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if value == "-1" {
+		return -1, nil
+	}
+	match := byteSize.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	number, _ := strconv.ParseFloat(match[1], 64)
+	shift := map[string]uint{"": 0, "k": 10, "m": 20, "g": 30, "t": 40}[match[2]]
+	return int64(number * float64(uint64(1)<<shift)), nil
+}
+
+// labels turns labels given without a value into empty ones.
+func labels(raw mappingOrList) map[string]string {
+	if len(raw.names) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(raw.names))
+	for _, name := range raw.names {
+		if value := raw.values[name]; value != nil {
+			labels[name] = *value
+		} else {
+			labels[name] = ""
+		}
+	}
+	return labels
+}
+
+// envMap indexes KEY=VALUE variables by name.
+func envMap(env []string) map[string]string {
+	values := make(map[string]string, len(env))
+	for _, variable := range env {
+		name, value, _ := strings.Cut(variable, "=")
+		values[name] = value
+	}
+	return values
+}
@@ -0,0 +1,223 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// writeProject writes files into a directory named Shop and returns the
+// path of its compose.yaml.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "Shop")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(dir, "compose.yaml")
+}
+
+func noEnvironment(string) (string, bool) { return "", false }
+
+const shopCompose = `
+services:
+  api:
+    image: shop/api:${API_TAG:-latest}
+    depends_on:
+      db:
+        condition: service_healthy
+    ports:
+      - "127.0.0.1:8080:80"
+      - 9000-9001:9000-9001/udp
+    env_file: api.env
+    environment:
+      DATABASE_URL: postgres://app@db:5432/shop
+      LOG_LEVEL:
+    restart: on-failure:3
+    networks: [front, back]
+    deploy:
+      resources:
+        limits:
+          cpus: "0.5"
+          memory: 256m
+  db:
+    image: postgres:16
+    volumes:
+      - pgdata:/var/lib/postgresql/data
+      - ./init:/docker-entrypoint-initdb.d:ro
+      - /scratch
+    networks:
+      back:
+        aliases: [database]
+    command: postgres -c 'max_connections=200'
+  debug:
+    image: busybox
+    profiles: [debug]
+networks:
+  front:
+  back:
+    internal: true
+volumes:
+  pgdata:
+`
+
+func TestLoadResolvesTheProject(t *testing.T) {
+	path := writeProject(t, map[string]string{
+		"compose.yaml": shopCompose,
+		"api.env":      "# comment\nSECRET_KEY='s3cret'\nLOG_LEVEL=info\n",
+		".env":         "API_TAG=1.2\n",
+	})
+
+	project, err := Load(path, Options{Lookup: noEnvironment})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if project.Name != "shop" {
+		t.Errorf("Name = %q, want the lowercased directory name", project.Name)
+	}
+
+	var names []string
+	for _, service := range project.Services {
+		names = append(names, service.Name)
+	}
+	if !slices.Equal(names, []string{"db", "api"}) {
+		t.Fatalf("Services = %v, want db before api and debug left out", names)
+	}
+
+	db, api := project.Services[0], project.Services[1]
+	if api.Image != "shop/api:1.2" {
+		t.Errorf("Image = %q, want the tag from .env", api.Image)
+	}
+	wantEnv := []string{"SECRET_KEY=s3cret", "LOG_LEVEL=info", "DATABASE_URL=postgres://app@db:5432/shop"}
+	if !slices.Equal(api.Environment, wantEnv) {
+		t.Errorf("Environment = %v, want %v", api.Environment, wantEnv)
+	}
+	if got := api.Ports["80/tcp"]; len(got) != 1 || got[0] != (backend.PortBinding{HostIP: "127.0.0.1", HostPort: "8080"}) {
+		t.Errorf("Ports[80/tcp] = %v", got)
+	}
+	if got := api.Ports["9001/udp"]; len(got) != 1 || got[0].HostPort != "9001" {
+		t.Errorf("Ports[9001/udp] = %v", got)
+	}
+	if api.Restart != (backend.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3}) {
+		t.Errorf("Restart = %+v", api.Restart)
+	}
+	if api.NanoCPUs != 5e8 || api.Memory != 256<<20 {
+		t.Errorf("limits = %d CPUs, %d bytes", api.NanoCPUs, api.Memory)
+	}
+	if api.DependsOn["db"].Condition != ConditionHealthy {
+		t.Errorf("DependsOn = %v", api.DependsOn)
+	}
+
+	wantVolumes := []string{"shop_pgdata:/var/lib/postgresql/data", filepath.Join(project.WorkingDir, "init") + ":/docker-entrypoint-initdb.d:ro", "/scratch"}
+	if !slices.Equal(db.Volumes, wantVolumes) {
+		t.Errorf("Volumes = %v, want %v", db.Volumes, wantVolumes)
+	}
+	if !slices.Equal(db.Command, []string{"postgres", "-c", "max_connections=200"}) {
+		t.Errorf("Command = %q", db.Command)
+	}
+	if aliases := db.Networks["back"].Aliases; !slices.Equal(aliases, []string{"db", "database"}) {
+		t.Errorf("Aliases = %v", aliases)
+	}
+
+	if network := project.Networks["back"]; network.Name != "shop_back" || !network.Internal {
+		t.Errorf("Networks[back] = %+v", network)
+	}
+	if _, ok := project.Networks["default"]; ok {
+		t.Error("expected no default network when every service names its own")
+	}
+}
+
+func TestLoadEnablesProfiles(t *testing.T) {
+	path := writeProject(t, map[string]string{"compose.yaml": shopCompose, "api.env": ""})
+	project, err := Load(path, Options{Profiles: []string{"debug"}, ProjectName: "Other Shop", Lookup: noEnvironment})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if project.Name != "othershop" {
+		t.Errorf("Name = %q, want the given name normalized", project.Name)
+	}
+	if len(project.Services) != 3 {
+		t.Fatalf("expected the debug profile to enable debug, got %d services", len(project.Services))
+	}
+	debug := project.Services[slices.IndexFunc(project.Services, func(s Service) bool { return s.Name == "debug" })]
+	if _, ok := debug.Networks["default"]; !ok || project.Networks["default"].Name != "othershop_default" {
+		t.Errorf("expected debug on the default network, got %v", debug.Networks)
+	}
+}
+
+func TestLoadRejectsInvalidProjects(t *testing.T) {
+	tests := map[string]string{
+		"cycle": `
+services:
+  a: {image: x, depends_on: [b]}
+  b: {image: x, depends_on: [a]}
+`,
+		"undefined volume": `
+services:
+  a: {image: x, volumes: ["data:/data"]}
+`,
+		"undefined network": `
+services:
+  a: {image: x, networks: [back]}
+`,
+		"disabled dependency": `
+services:
+  a: {image: x, depends_on: [b]}
+  b: {image: x, profiles: [extra]}
+`,
+		"missing variable": `
+services:
+  a: {image: "x:${TAG:?set a tag}"}
+`,
+		"no image": `
+services:
+  a: {command: ls}
+`,
+	}
+	for name, content := range tests {
+		path := writeProject(t, map[string]string{"compose.yaml": content})
+		if _, err := Load(path, Options{Lookup: noEnvironment}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"TAG": "1.2", "EMPTY": ""}[name]
+		return value, ok
+	}
+	tests := map[string]string{
+		"app:$TAG":                "app:1.2",
+		"app:${TAG}":              "app:1.2",
+		"${MISSING:-latest}":      "latest",
+		"${EMPTY:-fallback}":      "fallback",
+		"${EMPTY-fallback}":       "",
+		"${TAG:+set}":             "set",
+		"${MISSING:-${TAG}-slim}": "1.2-slim",
+		"costs $$5":               "costs $5",
+		"$":                       "$",
+	}
+	for input, want := range tests {
+		got, err := Interpolate(input, lookup)
+		if err != nil {
+			t.Errorf("Interpolate(%q) returned error: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Interpolate(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if _, err := Interpolate("${MISSING:?needed}", lookup); err == nil || !strings.Contains(err.Error(), "needed") {
+		t.Errorf("expected the required variable's message, got %v", err)
+	}
+}
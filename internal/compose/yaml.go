@@ -0,0 +1,448 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The types below read the compose file format, where many settings can
+// be written in a short and a long form.
+
+type rawProject struct {
+	Name     string                 `yaml:"name"`
+	Services map[string]rawService  `yaml:"services"`
+	Networks map[string]*rawNetwork `yaml:"networks"`
+	Volumes  map[string]*rawVolume  `yaml:"volumes"`
+}
+
+type rawService struct {
+	Image          string          `yaml:"image"`
+	Build          *rawBuild       `yaml:"build"`
+	ContainerName  string          `yaml:"container_name"`
+	Hostname       string          `yaml:"hostname"`
+	Domainname     string          `yaml:"domainname"`
+	User           string          `yaml:"user"`
+	WorkingDir     string          `yaml:"working_dir"`
+	Entrypoint     *command        `yaml:"entrypoint"`
+	Command        *command        `yaml:"command"`
+	Environment    mappingOrList   `yaml:"environment"`
+	EnvFile        envFiles        `yaml:"env_file"`
+	Ports          []port          `yaml:"ports"`
+	Expose         []scalar        `yaml:"expose"`
+	Volumes        []serviceVolume `yaml:"volumes"`
+	Tmpfs          stringOrList    `yaml:"tmpfs"`
+	Networks       serviceNetworks `yaml:"networks"`
+	NetworkMode    string          `yaml:"network_mode"`
+	DependsOn      dependsOn       `yaml:"depends_on"`
+	Restart        string          `yaml:"restart"`
+	Labels         mappingOrList   `yaml:"labels"`
+	CapAdd         []string        `yaml:"cap_add"`
+	CapDrop        []string        `yaml:"cap_drop"`
+	DNS            stringOrList    `yaml:"dns"`
+	DNSSearch      stringOrList    `yaml:"dns_search"`
+	DNSOpt         []string        `yaml:"dns_opt"`
+	ExtraHosts     extraHosts      `yaml:"extra_hosts"`
+	Privileged     bool            `yaml:"privileged"`
+	ReadOnly       bool            `yaml:"read_only"`
+	StdinOpen      bool            `yaml:"stdin_open"`
+	Tty            bool            `yaml:"tty"`
+	CPUs           scalar          `yaml:"cpus"`
+	CPUShares      int64           `yaml:"cpu_shares"`
+	MemLimit       scalar          `yaml:"mem_limit"`
+	MemswapLimit   scalar          `yaml:"memswap_limit"`
+	MemReservation scalar          `yaml:"mem_reservation"`
+	PidsLimit      int64           `yaml:"pids_limit"`
+	OomKillDisable bool            `yaml:"oom_kill_disable"`
+	Profiles       []string        `yaml:"profiles"`
+	PullPolicy     string          `yaml:"pull_policy"`
+	Deploy         *rawDeploy      `yaml:"deploy"`
+}
+
+type rawBuild struct {
+	Context    string        `yaml:"context"`
+	Dockerfile string        `yaml:"dockerfile"`
+	Args       mappingOrList `yaml:"args"`
+}
+
+// UnmarshalYAML reads a build given as just its context.
+func (b *rawBuild) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		b.Context = node.Value
+		return nil
+	}
+	type plain rawBuild
+	return node.Decode((*plain)(b))
+}
+
+type rawDeploy struct {
+	Resources struct {
+		Limits struct {
+			CPUs   scalar `yaml:"cpus"`
+			Memory scalar `yaml:"memory"`
+			Pids   int64  `yaml:"pids"`
+		} `yaml:"limits"`
+		Reservations struct {
+			Memory scalar `yaml:"memory"`
+		} `yaml:"reservations"`
+	} `yaml:"resources"`
+}
+
+type rawNetwork struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	External   external          `yaml:"external"`
+	Internal   bool              `yaml:"internal"`
+	Attachable bool              `yaml:"attachable"`
+	EnableIPv6 bool              `yaml:"enable_ipv6"`
+	IPAM       struct {
+		Driver string `yaml:"driver"`
+		Config []struct {
+			Subnet  string `yaml:"subnet"`
+			Gateway string `yaml:"gateway"`
+		} `yaml:"config"`
+	} `yaml:"ipam"`
+	Labels mappingOrList `yaml:"labels"`
+}
+
+type rawVolume struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+	External   external          `yaml:"external"`
+	Labels     mappingOrList     `yaml:"labels"`
+}
+
+// scalar is a string, number or boolean kept as written.
+type scalar string
+
+func (s *scalar) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: expected a single value", node.Line)
+	}
+	*s = scalar(node.Value)
+	return nil
+}
+
+// stringOrList is a list that may be written as a single string.
+type stringOrList []string
+
+func (l *stringOrList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = []string{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// command is a command line given as a list, or as a string split the
+// way a shell would.
+type command []string
+
+func (c *command) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		args, err := splitCommand(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*c = args
+		return nil
+	}
+	list := []string{}
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*c = list
+	return nil
+}
+
+// mappingOrList is a set of variables or labels given as a mapping or as
+// a list of NAME=value. A nil value has no value given.
+type mappingOrList struct {
+	names  []string
+	values map[string]*string
+}
+
+func (m *mappingOrList) UnmarshalYAML(node *yaml.Node) error {
+	m.values = make(map[string]*string)
+	add := func(name string, value *string) {
+		if _, ok := m.values[name]; !ok {
+			m.names = append(m.names, name)
+		}
+		m.values[name] = value
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value := node.Content[i+1]
+			if value.Tag == "!!null" {
+				add(node.Content[i].Value, nil)
+				continue
+			}
+			if value.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: expected a single value for %s", value.Line, node.Content[i].Value)
+			}
+			add(node.Content[i].Value, &value.Value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			name, value, ok := strings.Cut(item.Value, "=")
+			if !ok {
+				add(name, nil)
+				continue
+			}
+			add(name, &value)
+		}
+	default:
+		return fmt.Errorf("line %d: expected a mapping or a list", node.Line)
+	}
+	return nil
+}
+
+// envFile is an env file given as a path, or with whether it must exist.
+type envFile struct {
+	Path     string `yaml:"path"`
+	Required *bool  `yaml:"required"`
+}
+
+func (f *envFile) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f.Path = node.Value
+		return nil
+	}
+	type plain envFile
+	return node.Decode((*plain)(f))
+}
+
+// envFiles are the env files of a service, given as one path or a list.
+type envFiles []envFile
+
+func (f *envFiles) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*f = envFiles{{Path: node.Value}}
+		return nil
+	}
+	var files []envFile
+	if err := node.Decode(&files); err != nil {
+		return err
+	}
+	*f = files
+	return nil
+}
+
+// port is a published port in the short "[ip:][host:]container[/protocol]"
+// form or the long one.
+type port struct {
+	Target    string `yaml:"target"`
+	Published string `yaml:"published"`
+	HostIP    string `yaml:"host_ip"`
+	Protocol  string `yaml:"protocol"`
+	short     string
+}
+
+func (p *port) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.short = node.Value
+		return nil
+	}
+	type plain port
+	return node.Decode((*plain)(p))
+}
+
+// serviceVolume is a mount in the short "source:target[:mode]" form or
+// the long one.
+type serviceVolume struct {
+	Type     string `yaml:"type"`
+	Source   string `yaml:"source"`
+	Target   string `yaml:"target"`
+	ReadOnly bool   `yaml:"read_only"`
+	short    string
+}
+
+func (v *serviceVolume) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		v.short = node.Value
+		return nil
+	}
+	type plain serviceVolume
+	return node.Decode((*plain)(v))
+}
+
+// serviceNetworks are the networks of a service, as a list of names or a
+// mapping to their aliases and addresses.
+type serviceNetworks map[string]rawServiceNetwork
+
+type rawServiceNetwork struct {
+	Aliases     []string `yaml:"aliases"`
+	IPv4Address string   `yaml:"ipv4_address"`
+	IPv6Address string   `yaml:"ipv6_address"`
+}
+
+func (n *serviceNetworks) UnmarshalYAML(node *yaml.Node) error {
+	networks := make(serviceNetworks)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			networks[item.Value] = rawServiceNetwork{}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			var network rawServiceNetwork
+			if node.Content[i+1].Tag != "!!null" {
+				if err := node.Content[i+1].Decode(&network); err != nil {
+					return err
+				}
+			}
+			networks[node.Content[i].Value] = network
+		}
+	default:
+		return fmt.Errorf("line %d: expected a list or a mapping of networks", node.Line)
+	}
+	*n = networks
+	return nil
+}
+
+// dependsOn lists the services a service depends on, with the condition
+// each has to meet before it starts.
+type dependsOn map[string]Dependency
+
+func (d *dependsOn) UnmarshalYAML(node *yaml.Node) error {
+	dependencies := make(dependsOn)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			dependencies[item.Value] = Dependency{Condition: ConditionStarted, Required: true}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			var raw struct {
+				Condition string `yaml:"condition"`
+				Required  *bool  `yaml:"required"`
+			}
+			if err := node.Content[i+1].Decode(&raw); err != nil {
+				return err
+			}
+			dependency := Dependency{Condition: raw.Condition, Required: raw.Required == nil || *raw.Required}
+			if dependency.Condition == "" {
+				dependency.Condition = ConditionStarted
+			}
+			dependencies[node.Content[i].Value] = dependency
+		}
+	default:
+		return fmt.Errorf("line %d: expected a list or a mapping of services", node.Line)
+	}
+	*d = dependencies
+	return nil
+}
+
+// extraHosts are host entries as a list of "host:ip" or "host=ip", or a
+// mapping of hosts to addresses.
+type extraHosts []string
+
+func (h *extraHosts) UnmarshalYAML(node *yaml.Node) error {
+	var hosts []string
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			host, address, ok := strings.Cut(item.Value, "=")
+			if !ok {
+				hosts = append(hosts, item.Value)
+				continue
+			}
+			hosts = append(hosts, host+":"+address)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			hosts = append(hosts, node.Content[i].Value+":"+node.Content[i+1].Value)
+		}
+	default:
+		return fmt.Errorf("line %d: expected a list or a mapping of hosts", node.Line)
+	}
+	*h = hosts
+	return nil
+}
+
+// external marks a network or volume created outside the project, given
+// as a boolean or, in older files, as a mapping with its name.
+type external struct {
+	External bool
+	Name     string
+}
+
+func (e *external) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		value, err := strconv.ParseBool(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: external must be true or false", node.Line)
+		}
+		e.External = value
+		return nil
+	}
+	var raw struct {
+		Name string `yaml:"name"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	e.External, e.Name = true, raw.Name
+	return nil
+}
+
+// splitCommand splits a command line into arguments the way a POSIX shell
+// does, without expanding anything.
+func splitCommand(line string) ([]string, error) {
+	args := []string{}
+	var current strings.Builder
+	inArg := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in %q", line)
+			}
+			current.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+			inArg = true
+		case c == '"':
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) && strings.IndexByte(`"\$`+"`", line[i+1]) >= 0 {
+					i++
+				}
+				current.WriteByte(line[i])
+			}
+			if i == len(line) {
+				return nil, fmt.Errorf("unterminated quote in %q", line)
+			}
+			inArg = true
+		case c == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+			inArg = true
+		default:
+			current.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
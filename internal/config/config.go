@@ -11,19 +11,30 @@ import (
 
 // Config holds the application configuration.
 type Config struct {
-	NoNerdFonts      ConfigBool  `yaml:"no-nerd-fonts"`
-	Theme            ThemeConfig `yaml:"colors,omitempty"`
-	InspectionFormat string      `yaml:"inspection-format,omitempty"`
-	StartupTab       string      `yaml:"startup-tab,omitempty"`
+	NoNerdFonts       ConfigBool    `yaml:"no-nerd-fonts"`
+	Theme             ThemeConfig   `yaml:"colors,omitempty"`
+	InspectionFormat  string        `yaml:"inspection-format,omitempty"`
+	StartupTab        string        `yaml:"startup-tab,omitempty"`
+	MaxConcurrentJobs int           `yaml:"max-concurrent-jobs,omitempty"`
+	Timeouts          TimeoutConfig `yaml:"timeouts,omitempty"`
+	Views             []ViewConfig  `yaml:"views,omitempty"`
+	Tabs              TabsConfig    `yaml:"tabs,omitempty"`
+	Tables            TablesConfig  `yaml:"tables,omitempty"`
+	Secrets           SecretsConfig `yaml:"secrets,omitempty"`
+
+	// path is the file the configuration was loaded from
+	path string
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		NoNerdFonts:      false,
-		Theme:            emptyThemeConfig(),
-		InspectionFormat: "yaml",
-		StartupTab:       "containers",
+		NoNerdFonts:       false,
+		Theme:             emptyThemeConfig(),
+		InspectionFormat:  "yaml",
+		StartupTab:        "containers",
+		MaxConcurrentJobs: 3,
+		Timeouts:          DefaultTimeouts(),
 	}
 }
 
@@ -44,7 +55,9 @@ func LoadFromFile(path string) (*Config, error) {
 	file, err := os.Open(cleanPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return DefaultConfig(), nil
+			cfg := DefaultConfig()
+			cfg.path = cleanPath
+			return cfg, nil
 		}
 
 		return nil, fmt.Errorf("failed to open config file: %w", err)
@@ -62,6 +75,7 @@ func LoadFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to decode config file: %w", err)
 	}
 
+	cfg.path = cleanPath
 	return &cfg, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -78,3 +79,42 @@ func TestLoadFromFile(t *testing.T) {
 		t.Error("expected error for invalid YAML, got nil")
 	}
 }
+
+func TestTimeoutsFallBackToDefaults(t *testing.T) {
+	timeouts := TimeoutConfig{Action: 5 * time.Second, Prune: -1}
+
+	tests := []struct {
+		kind OperationKind
+		want time.Duration
+	}{
+		{OperationAction, 5 * time.Second},
+		{OperationList, DefaultTimeouts().List},
+		{OperationPrune, 0},
+		{OperationPull, 0},
+	}
+
+	for _, tt := range tests {
+		if got := timeouts.For(tt.kind); got != tt.want {
+			t.Fatalf("For(%d) = %s, want %s", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFromFileParsesTimeouts(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "config.yaml")
+	testConfig := "timeouts:\n  action: 90s\n  pull: 10m\n"
+	if err := os.WriteFile(tempFile, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFromFile(tempFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if got := cfg.Timeouts.For(OperationAction); got != 90*time.Second {
+		t.Fatalf("expected action timeout 90s, got %s", got)
+	}
+	if got := cfg.Timeouts.For(OperationPull); got != 10*time.Minute {
+		t.Fatalf("expected pull timeout 10m, got %s", got)
+	}
+}
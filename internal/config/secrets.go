@@ -0,0 +1,18 @@
+package config
+
+// SecretsConfig holds the rules that decide which values the details panel
+// masks. Names are environment variable, label and option keys.
+type SecretsConfig struct {
+	// Disabled shows every value in clear text
+	Disabled bool `yaml:"disabled,omitempty"`
+	// Patterns are extra case-insensitive regular expressions for the names
+	// of secret values, checked along with the built-in ones
+	Patterns []string `yaml:"patterns,omitempty"`
+	// Ignore are case-insensitive regular expressions for names that are
+	// never masked, overriding every other rule
+	Ignore []string `yaml:"ignore,omitempty"`
+	// Entropy is the Shannon entropy, in bits per character, above which a
+	// long token-like value is masked whatever its name; 0 uses the
+	// default and a negative value turns the check off
+	Entropy float64 `yaml:"entropy,omitempty"`
+}
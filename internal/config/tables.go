@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TableConfig holds a list's table mode settings.
+type TableConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Columns are the column keys shown, in order; empty shows every column
+	Columns []string `yaml:"columns,omitempty"`
+	// Sort is a column key, prefixed with "-" for descending order
+	Sort string `yaml:"sort,omitempty"`
+}
+
+// TablesConfig holds the table mode settings of each resource list.
+type TablesConfig struct {
+	Containers TableConfig `yaml:"containers,omitempty"`
+	Images     TableConfig `yaml:"images,omitempty"`
+	Volumes    TableConfig `yaml:"volumes,omitempty"`
+	Networks   TableConfig `yaml:"networks,omitempty"`
+}
+
+// For returns the settings of the named resource list, one of
+// ViewResources, or nil for any other name.
+func (tables *TablesConfig) For(resource string) *TableConfig {
+	switch resource {
+	case "containers":
+		return &tables.Containers
+	case "images":
+		return &tables.Images
+	case "volumes":
+		return &tables.Volumes
+	case "networks":
+		return &tables.Networks
+	}
+	return nil
+}
+
+// SortKey splits Sort into its column key and direction.
+func (table TableConfig) SortKey() (key string, descending bool) {
+	key, descending = strings.CutPrefix(table.Sort, "-")
+	return key, descending
+}
+
+// Path returns the file the configuration was loaded from, or "" if it was
+// not loaded from a file.
+func (cfg *Config) Path() string {
+	return cfg.path
+}
+
+// SaveTables writes tables to the config file at path, leaving the rest of
+// the file as it is.
+func SaveTables(path string, tables TablesConfig) error {
+	return saveKey(path, "tables", tables)
+}
+
+// saveKey sets one top-level key of the YAML file at path to value, creating
+// the file if needed and keeping its other keys and comments.
+func saveKey(path, key string, value any) error {
+	var document yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read config file: %w", err)
+	default:
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return fmt.Errorf("failed to decode config file: %w", err)
+		}
+	}
+
+	if len(document.Content) == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update config file: %s is not a mapping", path)
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1] = &encoded
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &encoded)
+	}
+
+	out, err := yaml.Marshal(&document)
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveTablesKeepsTheRestOfTheFile(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "config.yaml")
+	testConfig := `# my settings
+no-nerd-fonts: true
+tables:
+  images:
+    enabled: true
+`
+	if err := os.WriteFile(tempFile, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	tables := TablesConfig{Containers: TableConfig{Enabled: true, Columns: []string{"name", "state"}, Sort: "-created"}}
+	if err := SaveTables(tempFile, tables); err != nil {
+		t.Fatalf("SaveTables failed: %v", err)
+	}
+
+	data, err := os.ReadFile(tempFile)
+	if err != nil {
+		t.Fatalf("failed to read saved config: %v", err)
+	}
+	if !strings.Contains(string(data), "# my settings") {
+		t.Fatalf("expected the comment to be kept, got:\n%s", data)
+	}
+
+	cfg, err := LoadFromFile(tempFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if !cfg.NoNerdFonts {
+		t.Fatal("expected other settings to be kept")
+	}
+	if cfg.Tables.Images.Enabled {
+		t.Fatal("expected the tables key to be replaced")
+	}
+	if key, descending := cfg.Tables.Containers.SortKey(); key != "created" || !descending || len(cfg.Tables.Containers.Columns) != 2 {
+		t.Fatalf("unexpected saved settings %+v", cfg.Tables.Containers)
+	}
+}
+
+func TestSaveTablesCreatesTheFile(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	if err := SaveTables(tempFile, TablesConfig{Volumes: TableConfig{Enabled: true}}); err != nil {
+		t.Fatalf("SaveTables failed: %v", err)
+	}
+	cfg, err := LoadFromFile(tempFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if !cfg.Tables.Volumes.Enabled || cfg.Path() != tempFile {
+		t.Fatalf("expected the saved settings to load, got %+v from %q", cfg.Tables, cfg.Path())
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import "time"
+
+// OperationKind groups daemon calls that share a timeout.
+type OperationKind int
+
+const (
+	// OperationList covers listing resources and usage lookups.
+	OperationList OperationKind = iota
+	// OperationInspect covers inspecting a single resource.
+	OperationInspect
+	// OperationAction covers lifecycle and mutating calls such as start, stop,
+	// remove, create, rename, tag, connect and disconnect.
+	OperationAction
+	// OperationPrune covers prune calls.
+	OperationPrune
+	// OperationPull covers image pulls.
+	OperationPull
+	// OperationBuild covers image builds.
+	OperationBuild
+)
+
+// TimeoutConfig holds per-operation timeouts. An unset value falls back to
+// the default, and a negative value disables the timeout.
+type TimeoutConfig struct {
+	List    time.Duration `yaml:"list,omitempty"`
+	Inspect time.Duration `yaml:"inspect,omitempty"`
+	Action  time.Duration `yaml:"action,omitempty"`
+	Prune   time.Duration `yaml:"prune,omitempty"`
+	Pull    time.Duration `yaml:"pull,omitempty"`
+	Build   time.Duration `yaml:"build,omitempty"`
+}
+
+// DefaultTimeouts returns the timeouts used when none are configured.
+// Pulls and builds can legitimately take a long time, so they have none.
+func DefaultTimeouts() TimeoutConfig {
+	return TimeoutConfig{
+		List:    15 * time.Second,
+		Inspect: 15 * time.Second,
+		Action:  time.Minute,
+		Prune:   5 * time.Minute,
+		Pull:    -1,
+		Build:   -1,
+	}
+}
+
+// For returns the timeout for kind, or zero when it is disabled.
+func (timeouts TimeoutConfig) For(kind OperationKind) time.Duration {
+	timeout := timeouts.lookup(kind)
+	if timeout == 0 {
+		timeout = DefaultTimeouts().lookup(kind)
+	}
+	return max(timeout, 0)
+}
+
+func (timeouts TimeoutConfig) lookup(kind OperationKind) time.Duration {
+	switch kind {
+	case OperationList:
+		return timeouts.List
+	case OperationInspect:
+		return timeouts.Inspect
+	case OperationAction:
+		return timeouts.Action
+	case OperationPrune:
+		return timeouts.Prune
+	case OperationPull:
+		return timeouts.Pull
+	case OperationBuild:
+		return timeouts.Build
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ViewResources are the resource types a saved view can show.
+var ViewResources = []string{"containers", "images", "volumes", "networks"}
+
+// ViewSortKeys are the keys a saved view can sort by.
+var ViewSortKeys = []string{"name", "created", "state"}
+
+// DefaultViewRefresh is how often a saved view reloads when no interval is set.
+const DefaultViewRefresh = 5 * time.Second
+
+// ViewConfig defines a saved view, shown as an extra tab with a list for
+// each of its resources filtered by its query.
+type ViewConfig struct {
+	Name      string   `yaml:"name"`
+	Resources []string `yaml:"resources"`
+	Query     string   `yaml:"query,omitempty"`
+	// Sort is one of ViewSortKeys, prefixed with "-" for descending order
+	Sort    string        `yaml:"sort,omitempty"`
+	Refresh time.Duration `yaml:"refresh,omitempty"`
+	// Columns, when set, show the view's lists as tables with these
+	// columns; keys a resource has no column for are skipped
+	Columns []string `yaml:"columns,omitempty"`
+}
+
+// TabsConfig orders and hides tabs. Tabs missing from Order follow the
+// listed ones in their default order.
+type TabsConfig struct {
+	Order  []string `yaml:"order,omitempty"`
+	Hidden []string `yaml:"hidden,omitempty"`
+}
+
+// SortKey splits Sort into its key and direction.
+func (view ViewConfig) SortKey() (key string, descending bool) {
+	key, descending = strings.CutPrefix(view.Sort, "-")
+	return key, descending
+}
+
+// RefreshInterval returns how often the view reloads.
+func (view ViewConfig) RefreshInterval() time.Duration {
+	if view.Refresh <= 0 {
+		return DefaultViewRefresh
+	}
+	return view.Refresh
+}
+
+// ValidateViews checks that every view is complete, named uniquely and
+// does not shadow a built-in tab.
+func ValidateViews(views []ViewConfig, builtinTabs []string) error {
+	seen := make(map[string]bool)
+	for i, view := range views {
+		name := strings.ToLower(strings.TrimSpace(view.Name))
+		switch {
+		case name == "":
+			return fmt.Errorf("view %d has no name", i+1)
+		case slices.Contains(builtinTabs, name):
+			return fmt.Errorf("view %q has the same name as a built-in tab", view.Name)
+		case seen[name]:
+			return fmt.Errorf("view %q is defined twice", view.Name)
+		case len(view.Resources) == 0:
+			return fmt.Errorf("view %q lists no resources (expected some of %s)", view.Name, strings.Join(ViewResources, ", "))
+		}
+		seen[name] = true
+
+		for _, resource := range view.Resources {
+			if !slices.Contains(ViewResources, resource) {
+				return fmt.Errorf("view %q: unknown resource %q (expected some of %s)", view.Name, resource, strings.Join(ViewResources, ", "))
+			}
+		}
+		if key, _ := view.SortKey(); view.Sort != "" && !slices.Contains(ViewSortKeys, key) {
+			return fmt.Errorf("view %q: cannot sort by %q (expected one of %s)", view.Name, view.Sort, strings.Join(ViewSortKeys, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadFromFileParsesViews(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "config.yaml")
+	testConfig := `views:
+  - name: payments
+    resources: [containers, volumes]
+    query: label:team=payments
+    sort: -created
+    refresh: 10s
+tabs:
+  order: [payments]
+  hidden: [browse]
+`
+	if err := os.WriteFile(tempFile, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFromFile(tempFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if len(cfg.Views) != 1 {
+		t.Fatalf("expected 1 view, got %d", len(cfg.Views))
+	}
+	view := cfg.Views[0]
+	if key, descending := view.SortKey(); key != "created" || !descending {
+		t.Fatalf("expected descending created sort, got %q, %t", key, descending)
+	}
+	if view.RefreshInterval() != 10*time.Second {
+		t.Fatalf("expected a 10s refresh, got %s", view.RefreshInterval())
+	}
+	if len(cfg.Tabs.Order) != 1 || len(cfg.Tabs.Hidden) != 1 {
+		t.Fatalf("expected tab order and hidden tabs, got %+v", cfg.Tabs)
+	}
+	if err := ValidateViews(cfg.Views, []string{"containers"}); err != nil {
+		t.Fatalf("expected the view to be valid, got %v", err)
+	}
+}
+
+func TestValidateViewsRejectsBadViews(t *testing.T) {
+	builtin := []string{"containers", "images"}
+	payments := ViewConfig{Name: "payments", Resources: []string{"containers"}}
+	tests := []struct {
+		name  string
+		views []ViewConfig
+		want  string
+	}{
+		{"missing name", []ViewConfig{{Resources: []string{"images"}}}, "has no name"},
+		{"built-in name", []ViewConfig{{Name: "Images", Resources: []string{"images"}}}, "built-in tab"},
+		{"duplicate", []ViewConfig{payments, payments}, "defined twice"},
+		{"no resources", []ViewConfig{{Name: "empty"}}, "lists no resources"},
+		{"unknown resource", []ViewConfig{{Name: "pods", Resources: []string{"pods"}}}, "unknown resource"},
+		{"bad sort", []ViewConfig{{Name: "sized", Resources: []string{"images"}, Sort: "-size"}}, "cannot sort"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateViews(tt.views, builtin)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Fatalf("%s: expected an error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
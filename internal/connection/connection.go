@@ -0,0 +1,189 @@
+// Package connection supervises the connection to the container daemon,
+// detecting when it is lost and reconnecting with backoff.
+package connection
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultInterval is how often a healthy connection is checked.
+	DefaultInterval = 5 * time.Second
+	// DefaultMinBackoff is the delay before the first reconnect attempt.
+	DefaultMinBackoff = time.Second
+	// DefaultMaxBackoff caps the delay between reconnect attempts.
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultOfflineAfter is the number of failed attempts after which the
+	// daemon is reported offline rather than reconnecting.
+	DefaultOfflineAfter = 3
+
+	pingTimeout = 3 * time.Second
+)
+
+// Status represents the health of the daemon connection.
+type Status int
+
+const (
+	Connected Status = iota
+	Reconnecting
+	Offline
+)
+
+func (s Status) String() string {
+	return [...]string{
+		"connected",
+		"reconnecting",
+		"offline",
+	}[s]
+}
+
+// State is a point-in-time snapshot of the connection.
+type State struct {
+	Status   Status
+	Backend  string
+	Version  string
+	Err      error
+	Attempts int
+}
+
+// Target is the connection watched by a Supervisor.
+type Target interface {
+	// Ping checks that the daemon is reachable.
+	Ping(ctx context.Context) error
+	// Reconnect replaces the underlying client with a fresh one.
+	Reconnect() error
+	// Describe returns the backend name and daemon version.
+	Describe(ctx context.Context) (name, version string)
+}
+
+// Supervisor periodically checks a Target and reconnects it with
+// exponential backoff when the daemon goes away.
+type Supervisor struct {
+	target       Target
+	interval     time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	offlineAfter int
+
+	mu      sync.Mutex
+	state   State
+	changed chan struct{}
+}
+
+// NewSupervisor creates a supervisor for target. The connection is assumed
+// healthy until the first check says otherwise.
+func NewSupervisor(target Target) *Supervisor {
+	return &Supervisor{
+		target:       target,
+		interval:     DefaultInterval,
+		minBackoff:   DefaultMinBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+		offlineAfter: DefaultOfflineAfter,
+		changed:      make(chan struct{}, 1),
+	}
+}
+
+// State returns a snapshot of the connection.
+func (s *Supervisor) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Changed returns a channel that receives a value whenever the state changes.
+// Notifications are coalesced, so receivers should re-read State.
+func (s *Supervisor) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// Reset forgets the current state, e.g. after switching to another daemon,
+// so the next check describes the backend afresh.
+func (s *Supervisor) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = State{}
+}
+
+// Run checks the connection until ctx is cancelled.
+func (s *Supervisor) Run(ctx context.Context) {
+	for {
+		wait := s.interval
+		if state := s.Check(ctx); state.Status != Connected {
+			wait = s.backoff(state.Attempts)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Check pings the daemon once, reconnecting first if the previous check
+// failed, and returns the resulting state.
+func (s *Supervisor) Check(ctx context.Context) State {
+	previous := s.State()
+	if previous.Attempts > 0 {
+		if err := s.target.Reconnect(); err != nil {
+			return s.fail(previous, err)
+		}
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := s.target.Ping(pingCtx); err != nil {
+		return s.fail(previous, err)
+	}
+
+	next := previous
+	if previous.Attempts > 0 || previous.Version == "" {
+		next.Backend, next.Version = s.target.Describe(pingCtx)
+	}
+	next.Status = Connected
+	next.Err = nil
+	next.Attempts = 0
+	return s.set(previous, next)
+}
+
+func (s *Supervisor) fail(previous State, err error) State {
+	next := previous
+	next.Err = err
+	next.Attempts++
+	next.Status = Reconnecting
+	if next.Attempts >= s.offlineAfter {
+		next.Status = Offline
+	}
+	return s.set(previous, next)
+}
+
+func (s *Supervisor) set(previous, next State) State {
+	s.mu.Lock()
+	s.state = next
+	s.mu.Unlock()
+
+	if next.Status != previous.Status || next.Attempts != previous.Attempts || next.Version != previous.Version {
+		s.notify()
+	}
+	return next
+}
+
+// backoff returns the delay before reconnect attempt number attempts+1.
+func (s *Supervisor) backoff(attempts int) time.Duration {
+	delay := s.minBackoff
+	for i := 1; i < attempts && delay < s.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.maxBackoff)
+}
+
+func (s *Supervisor) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
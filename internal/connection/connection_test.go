@@ -0,0 +1,97 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeTarget struct {
+	pingErr    error
+	reconnects int
+}
+
+func (f *fakeTarget) Ping(context.Context) error { return f.pingErr }
+
+func (f *fakeTarget) Reconnect() error {
+	f.reconnects++
+	return nil
+}
+
+func (f *fakeTarget) Describe(context.Context) (string, string) { return "docker", "27.1.0" }
+
+func TestCheckReportsConnectedWithVersion(t *testing.T) {
+	supervisor := NewSupervisor(&fakeTarget{})
+
+	state := supervisor.Check(context.Background())
+	if state.Status != Connected {
+		t.Fatalf("expected connected, got %s", state.Status)
+	}
+	if state.Backend != "docker" || state.Version != "27.1.0" {
+		t.Fatalf("expected backend and version, got %q %q", state.Backend, state.Version)
+	}
+}
+
+func TestCheckGoesOfflineAfterRepeatedFailuresAndRecovers(t *testing.T) {
+	target := &fakeTarget{pingErr: errors.New("connection refused")}
+	supervisor := NewSupervisor(target)
+
+	state := supervisor.Check(context.Background())
+	if state.Status != Reconnecting || state.Attempts != 1 {
+		t.Fatalf("expected first failure to be reconnecting, got %s after %d", state.Status, state.Attempts)
+	}
+
+	for range DefaultOfflineAfter - 1 {
+		state = supervisor.Check(context.Background())
+	}
+	if state.Status != Offline {
+		t.Fatalf("expected offline after %d failures, got %s", DefaultOfflineAfter, state.Status)
+	}
+	if target.reconnects != DefaultOfflineAfter-1 {
+		t.Fatalf("expected a reconnect before each retry, got %d", target.reconnects)
+	}
+
+	target.pingErr = nil
+	state = supervisor.Check(context.Background())
+	if state.Status != Connected || state.Attempts != 0 || state.Err != nil {
+		t.Fatalf("expected recovery to reset state, got %+v", state)
+	}
+}
+
+func TestCheckNotifiesOnlyOnChange(t *testing.T) {
+	supervisor := NewSupervisor(&fakeTarget{})
+
+	supervisor.Check(context.Background())
+	select {
+	case <-supervisor.Changed():
+	default:
+		t.Fatal("expected notification for first connection")
+	}
+
+	supervisor.Check(context.Background())
+	select {
+	case <-supervisor.Changed():
+		t.Fatal("expected no notification when nothing changed")
+	default:
+	}
+}
+
+func TestBackoffDoublesUpToMax(t *testing.T) {
+	supervisor := NewSupervisor(&fakeTarget{})
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 10, want: DefaultMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := supervisor.backoff(tt.attempts); got != tt.want {
+			t.Fatalf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,509 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeResources are the containers a compose file is generated from,
+// with the images, networks and volumes they use as far as these could be
+// inspected. Images are keyed by ID, networks and volumes by name.
+type ComposeResources struct {
+	Containers []backend.ContainerDetail
+	Images     map[string]backend.ImageDetail
+	Networks   map[string]backend.NetworkDetail
+	Volumes    map[string]backend.VolumeDetail
+}
+
+// GatherCompose inspects the containers with the given IDs and the images,
+// networks and volumes they use. Only a failure to inspect a container is
+// an error; what else cannot be inspected is left to be declared external
+// or kept as set on the container.
+func GatherCompose(ctx context.Context, b backend.Backend, ids []string) (ComposeResources, error) {
+	resources := ComposeResources{
+		Images:   make(map[string]backend.ImageDetail),
+		Networks: make(map[string]backend.NetworkDetail),
+		Volumes:  make(map[string]backend.VolumeDetail),
+	}
+	for _, id := range ids {
+		container, err := b.InspectContainer(ctx, id)
+		if err != nil {
+			return resources, fmt.Errorf("failed to inspect container %s: %w", id, err)
+		}
+		resources.Containers = append(resources.Containers, container)
+
+		// Aggregated hosts need IDs qualified with the container's host
+		qualify := func(id string) string {
+			if container.Host == "" {
+				return id
+			}
+			return multi.QualifyID(container.Host, id)
+		}
+		if _, ok := resources.Images[container.Image]; !ok {
+			if image, err := b.InspectImage(ctx, qualify(container.Image)); err == nil {
+				resources.Images[container.Image] = image
+			}
+		}
+		_, names := networks(container)
+		for _, name := range names {
+			if _, ok := resources.Networks[name]; !ok {
+				if network, err := b.InspectNetwork(ctx, qualify(name)); err == nil {
+					resources.Networks[name] = network
+				}
+			}
+		}
+		for _, mount := range container.Mounts {
+			if _, ok := resources.Volumes[mount.Name]; !ok && isNamedVolume(mount) {
+				if volume, err := b.InspectVolume(ctx, qualify(mount.Name)); err == nil {
+					resources.Volumes[mount.Name] = volume
+				}
+			}
+		}
+	}
+	return resources, nil
+}
+
+// composeFile is a compose.yaml, with fields in the order they are written.
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+	Networks map[string]composeNetwork `yaml:"networks,omitempty"`
+	Volumes  map[string]composeVolume  `yaml:"volumes,omitempty"`
+}
+
+type composeService struct {
+	Image          string                           `yaml:"image"`
+	ContainerName  string                           `yaml:"container_name,omitempty"`
+	Hostname       string                           `yaml:"hostname,omitempty"`
+	Domainname     string                           `yaml:"domainname,omitempty"`
+	User           string                           `yaml:"user,omitempty"`
+	WorkingDir     string                           `yaml:"working_dir,omitempty"`
+	Entrypoint     any                              `yaml:"entrypoint,omitempty"`
+	Command        any                              `yaml:"command,omitempty"`
+	Restart        string                           `yaml:"restart,omitempty"`
+	DependsOn      []string                         `yaml:"depends_on,omitempty"`
+	NetworkMode    string                           `yaml:"network_mode,omitempty"`
+	Networks       map[string]composeServiceNetwork `yaml:"networks,omitempty"`
+	Ports          []string                         `yaml:"ports,omitempty"`
+	Expose         []string                         `yaml:"expose,omitempty"`
+	Volumes        []string                         `yaml:"volumes,omitempty"`
+	Tmpfs          []string                         `yaml:"tmpfs,omitempty"`
+	Environment    []string                         `yaml:"environment,omitempty"`
+	Labels         map[string]string                `yaml:"labels,omitempty"`
+	DNS            []string                         `yaml:"dns,omitempty"`
+	DNSOpt         []string                         `yaml:"dns_opt,omitempty"`
+	DNSSearch      []string                         `yaml:"dns_search,omitempty"`
+	ExtraHosts     []string                         `yaml:"extra_hosts,omitempty"`
+	Privileged     bool                             `yaml:"privileged,omitempty"`
+	ReadOnly       bool                             `yaml:"read_only,omitempty"`
+	StdinOpen      bool                             `yaml:"stdin_open,omitempty"`
+	Tty            bool                             `yaml:"tty,omitempty"`
+	CapAdd         []string                         `yaml:"cap_add,omitempty"`
+	CapDrop        []string                         `yaml:"cap_drop,omitempty"`
+	CPUs           string                           `yaml:"cpus,omitempty"`
+	CPUShares      int64                            `yaml:"cpu_shares,omitempty"`
+	MemLimit       string                           `yaml:"mem_limit,omitempty"`
+	MemswapLimit   string                           `yaml:"memswap_limit,omitempty"`
+	MemReservation string                           `yaml:"mem_reservation,omitempty"`
+	OomKillDisable bool                             `yaml:"oom_kill_disable,omitempty"`
+	PidsLimit      int64                            `yaml:"pids_limit,omitempty"`
+}
+
+type composeServiceNetwork struct {
+	Aliases     []string `yaml:"aliases,omitempty"`
+	IPv4Address string   `yaml:"ipv4_address,omitempty"`
+	IPv6Address string   `yaml:"ipv6_address,omitempty"`
+}
+
+type composeNetwork struct {
+	Name       string            `yaml:"name,omitempty"`
+	External   bool              `yaml:"external,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	Internal   bool              `yaml:"internal,omitempty"`
+	Attachable bool              `yaml:"attachable,omitempty"`
+	EnableIPv6 bool              `yaml:"enable_ipv6,omitempty"`
+	IPAM       *composeIPAM      `yaml:"ipam,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+}
+
+type composeIPAM struct {
+	Driver string              `yaml:"driver,omitempty"`
+	Config []composeIPAMConfig `yaml:"config,omitempty"`
+}
+
+type composeIPAMConfig struct {
+	Subnet  string `yaml:"subnet,omitempty"`
+	IPRange string `yaml:"ip_range,omitempty"`
+	Gateway string `yaml:"gateway,omitempty"`
+}
+
+type composeVolume struct {
+	Name       string            `yaml:"name,omitempty"`
+	External   bool              `yaml:"external,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+}
+
+// serviceNameInvalid matches the characters compose does not allow in
+// service names.
+var serviceNameInvalid = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// Compose generates a compose.yaml that recreates the containers with the
+// networks and named volumes they use. Each container becomes a service
+// named after its compose service or its own name, and depends on the
+// containers it links to or names in its environment or command on a
+// shared network. Secret values are masked with rules unless it is nil.
+func Compose(resources ComposeResources, rules *secrets.Detector) ([]byte, error) {
+	file := composeFile{
+		Services: make(map[string]composeService),
+		Networks: make(map[string]composeNetwork),
+		Volumes:  make(map[string]composeVolume),
+	}
+
+	names := serviceNames(resources.Containers)
+	for i, container := range resources.Containers {
+		var image *backend.ImageDetail
+		if detail, ok := resources.Images[container.Image]; ok {
+			image = &detail
+		}
+		file.Services[names[i]] = composeServiceOf(container, image, names, resources.Containers, rules)
+
+		_, networkNames := networks(container)
+		for _, name := range networkNames {
+			file.Networks[name] = composeNetworkOf(name, resources.Networks)
+		}
+		for _, mount := range container.Mounts {
+			if isNamedVolume(mount) {
+				file.Volumes[mount.Name] = composeVolumeOf(mount.Name, resources.Volumes)
+			}
+		}
+	}
+
+	for service, dependencies := range dependencies(resources.Containers, names) {
+		entry := file.Services[service]
+		entry.DependsOn = dependencies
+		file.Services[service] = entry
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// serviceNames names a service for each container, after its compose
+// service or else its name, numbering names that repeat.
+func serviceNames(containers []backend.ContainerDetail) []string {
+	names := make([]string, len(containers))
+	used := make(map[string]bool)
+	for i, container := range containers {
+		name := container.Config.Labels["com.docker.compose.service"]
+		if name == "" {
+			name = strings.TrimPrefix(container.Name, "/")
+		}
+		name = strings.Trim(serviceNameInvalid.ReplaceAllString(strings.ToLower(name), "-"), "-")
+		if name == "" {
+			name = "service"
+		}
+		names[i] = uniqueName(name, used)
+	}
+	return names
+}
+
+// uniqueName numbers name when it is already used, skipping numbered names
+// that are taken too, and marks the result used.
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for n := 2; used[unique]; n++ {
+		unique = name + "-" + strconv.Itoa(n)
+	}
+	used[unique] = true
+	return unique
+}
+
+func composeServiceOf(container backend.ContainerDetail, image *backend.ImageDetail, names []string, containers []backend.ContainerDetail, rules *secrets.Detector) composeService {
+	settings := settingsOf(container, image)
+	config := container.Config
+	host := container.HostConfig
+
+	service := composeService{
+		Image:          settings.image,
+		Hostname:       settings.hostname,
+		Domainname:     config.Domainname,
+		User:           settings.user,
+		WorkingDir:     settings.workdir,
+		Volumes:        settings.volumes,
+		Tmpfs:          settings.tmpfs,
+		Ports:          settings.published,
+		Expose:         settings.exposed,
+		Environment:    rules.Env(settings.env),
+		Labels:         rules.Map(settings.labels),
+		DNS:            host.DNS,
+		DNSOpt:         host.DNSOptions,
+		DNSSearch:      host.DNSSearch,
+		ExtraHosts:     host.ExtraHosts,
+		Privileged:     host.Privileged,
+		ReadOnly:       host.ReadonlyRootfs,
+		StdinOpen:      config.OpenStdin,
+		Tty:            config.Tty,
+		CapAdd:         host.CapAdd,
+		CapDrop:        host.CapDrop,
+		CPUShares:      host.CpuShares,
+		OomKillDisable: host.OomKillDisable,
+	}
+	// Containers compose created are named after their project, which
+	// compose names again when the file is brought up
+	if _, fromCompose := config.Labels["com.docker.compose.service"]; !fromCompose {
+		service.ContainerName = strings.TrimPrefix(container.Name, "/")
+	}
+
+	if settings.overridden {
+		service.Entrypoint = nonNil(settings.entrypoint)
+		service.Command = maskArgs(nonNil(settings.command), rules)
+	} else if settings.command != nil {
+		service.Command = maskArgs(settings.command, rules)
+	}
+
+	switch policy := host.RestartPolicy; {
+	case policy.Name == "on-failure" && policy.MaximumRetryCount > 0:
+		service.Restart = "on-failure:" + strconv.Itoa(policy.MaximumRetryCount)
+	case policy.Name != "no":
+		service.Restart = policy.Name
+	}
+
+	switch mode := settings.networkMode; {
+	case strings.HasPrefix(mode, "container:"):
+		// Point at the service when the container is exported too
+		target := strings.TrimPrefix(mode, "container:")
+		service.NetworkMode = mode
+		for i, other := range containers {
+			if strings.HasPrefix(hostID(other.ID), target) || strings.TrimPrefix(other.Name, "/") == target {
+				service.NetworkMode = "service:" + names[i]
+			}
+		}
+	case mode != "":
+		service.NetworkMode = mode
+	case len(settings.networks) == 0:
+		// Without networks compose would attach a network of its own
+		service.NetworkMode = "bridge"
+	default:
+		service.Networks = make(map[string]composeServiceNetwork)
+		for _, name := range settings.networks {
+			network := composeServiceNetwork{Aliases: aliases(container, name)}
+			if ipam := container.NetworkSettings.Networks[name].IPAMConfig; ipam != nil {
+				network.IPv4Address = ipam.IPv4Address
+				network.IPv6Address = ipam.IPv6Address
+			}
+			service.Networks[name] = network
+		}
+	}
+
+	if host.NanoCPUs > 0 {
+		service.CPUs = cpus(host.NanoCPUs)
+	}
+	if host.Memory > 0 {
+		service.MemLimit = memorySize(host.Memory)
+	}
+	if host.MemorySwap == -1 {
+		service.MemswapLimit = "-1"
+	} else if host.MemorySwap > 0 && host.MemorySwap != 2*host.Memory {
+		service.MemswapLimit = memorySize(host.MemorySwap)
+	}
+	if host.MemoryReservation > 0 {
+		service.MemReservation = memorySize(host.MemoryReservation)
+	}
+	if host.PidsLimit > 0 {
+		service.PidsLimit = host.PidsLimit
+	}
+	return service
+}
+
+// nonNil returns args as a non-nil slice, so that an empty entrypoint or
+// command is written as [] rather than left out.
+func nonNil(args []string) []string {
+	if args == nil {
+		return []string{}
+	}
+	return args
+}
+
+func maskArgs(args []string, rules *secrets.Detector) []string {
+	masked := make([]string, len(args))
+	for i, arg := range args {
+		masked[i] = rules.Text(arg)
+	}
+	return masked
+}
+
+// composeNetworkOf declares a network with the settings it was created
+// with, or as external when it could not be inspected.
+func composeNetworkOf(name string, inspected map[string]backend.NetworkDetail) composeNetwork {
+	detail, ok := inspected[name]
+	if !ok {
+		return composeNetwork{Name: name, External: true}
+	}
+	network := composeNetwork{
+		Name:       name,
+		DriverOpts: detail.Options,
+		Internal:   detail.Internal,
+		Attachable: detail.Attachable,
+		EnableIPv6: detail.EnableIPv6,
+		Labels:     withoutComposeLabels(detail.Labels),
+	}
+	if detail.Driver != "bridge" {
+		network.Driver = detail.Driver
+	}
+	if len(detail.IPAM.Config) > 0 || detail.IPAM.Driver != "" && detail.IPAM.Driver != "default" {
+		network.IPAM = &composeIPAM{}
+		if detail.IPAM.Driver != "default" {
+			network.IPAM.Driver = detail.IPAM.Driver
+		}
+		for _, config := range detail.IPAM.Config {
+			network.IPAM.Config = append(network.IPAM.Config, composeIPAMConfig{
+				Subnet:  config.Subnet,
+				IPRange: config.IPRange,
+				Gateway: config.Gateway,
+			})
+		}
+	}
+	return network
+}
+
+// composeVolumeOf declares a named volume with its driver and options, or
+// as external when it could not be inspected.
+func composeVolumeOf(name string, inspected map[string]backend.VolumeDetail) composeVolume {
+	detail, ok := inspected[name]
+	if !ok {
+		return composeVolume{Name: name, External: true}
+	}
+	volume := composeVolume{
+		Name:       name,
+		DriverOpts: detail.Options,
+		Labels:     withoutComposeLabels(detail.Labels),
+	}
+	if detail.Driver != "local" {
+		volume.Driver = detail.Driver
+	}
+	return volume
+}
+
+func withoutComposeLabels(labels map[string]string) map[string]string {
+	var own map[string]string
+	for name, value := range labels {
+		if strings.HasPrefix(name, "com.docker.compose.") {
+			continue
+		}
+		if own == nil {
+			own = make(map[string]string)
+		}
+		own[name] = value
+	}
+	return own
+}
+
+// dependencies infers depends_on for each service: a container depends on
+// the containers it links to, and on those sharing a network with it whose
+// name or alias appears as a host in its environment or command. Edges
+// that would close a cycle are dropped, since compose rejects them.
+func dependencies(containers []backend.ContainerDetail, names []string) map[string][]string {
+	edges := make(map[string][]string)
+	dependsOn := func(from, to string) bool {
+		// Reachability from to back to from would make a cycle
+		seen := map[string]bool{}
+		var reaches func(string) bool
+		reaches = func(service string) bool {
+			if service == from {
+				return true
+			}
+			if seen[service] {
+				return false
+			}
+			seen[service] = true
+			return slices.ContainsFunc(edges[service], reaches)
+		}
+		if from == to || slices.Contains(edges[from], to) || reaches(to) {
+			return false
+		}
+		edges[from] = append(edges[from], to)
+		return true
+	}
+
+	for i, container := range containers {
+		text := strings.Join(slices.Concat(container.Config.Env, container.Config.Entrypoint, container.Config.Cmd), "\n")
+		for j, other := range containers {
+			if i == j {
+				continue
+			}
+			if linksTo(container, other) || sharesNetwork(container, other) && mentions(text, hostNames(other, names[j])) {
+				dependsOn(names[i], names[j])
+			}
+		}
+	}
+
+	for service := range edges {
+		slices.Sort(edges[service])
+	}
+	return edges
+}
+
+// linksTo reports whether container has a legacy link to other.
+func linksTo(container, other backend.ContainerDetail) bool {
+	otherName := strings.TrimPrefix(other.Name, "/")
+	for _, endpoint := range container.NetworkSettings.Networks {
+		for _, link := range endpoint.Links {
+			// Links read "name:alias" or "/name:/container/alias"
+			target, _, _ := strings.Cut(link, ":")
+			if strings.TrimPrefix(target, "/") == otherName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sharesNetwork(a, b backend.ContainerDetail) bool {
+	_, aNetworks := networks(a)
+	_, bNetworks := networks(b)
+	return slices.ContainsFunc(aNetworks, func(name string) bool { return slices.Contains(bNetworks, name) })
+}
+
+// hostNames lists the names other can be reached by on a network.
+func hostNames(other backend.ContainerDetail, service string) []string {
+	names := []string{service, strings.TrimPrefix(other.Name, "/")}
+	for _, endpoint := range other.NetworkSettings.Networks {
+		names = append(names, endpoint.Aliases...)
+	}
+	return names
+}
+
+// mentions reports whether any of hosts appears in text as a whole host
+// name, such as db in "postgres://app@db:5432" or "DB_HOST=db".
+func mentions(text string, hosts []string) bool {
+	for _, host := range hosts {
+		if host == "" {
+			continue
+		}
+		re := regexp.MustCompile(`(^|[^A-Za-z0-9_.-])` + regexp.QuoteMeta(host) + `($|[^A-Za-z0-9_-])`)
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
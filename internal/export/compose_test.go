@@ -0,0 +1,143 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+func shopResources() ComposeResources {
+	db := postgresContainer()
+	db.Image = "sha256:postgres"
+
+	api := backend.ContainerDetail{
+		Container: backend.Container{ID: strings.Repeat("b", 64), Name: "/api", Image: "sha256:api"},
+		Config: backend.ContainerConfigDetail{
+			Image: "shop/api:latest",
+			Env:   []string{"DATABASE_URL=postgres://app:hunter2@db:5432/shop"},
+		},
+		HostConfig: backend.HostConfig{
+			NetworkMode:   "shop_default",
+			RestartPolicy: backend.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+			PortBindings: map[string][]backend.PortBinding{
+				"8080/tcp": {{HostIP: "0.0.0.0", HostPort: "80"}},
+			},
+		},
+		NetworkSettings: backend.NetworkSettings{
+			Networks: map[string]backend.EndpointSettings{"shop_default": {}},
+		},
+	}
+
+	return ComposeResources{
+		Containers: []backend.ContainerDetail{db, api},
+		Images:     map[string]backend.ImageDetail{"sha256:postgres": *postgresImage()},
+		Networks: map[string]backend.NetworkDetail{
+			"shop_default": {
+				Network: backend.Network{Name: "shop_default", Driver: "bridge", Labels: map[string]string{"com.docker.compose.network": "default"}},
+				IPAM:    backend.IPAM{Driver: "default", Config: []backend.IPAMConfig{{Subnet: "172.20.0.0/16", Gateway: "172.20.0.1"}}},
+			},
+		},
+	}
+}
+
+func TestComposeRecreatesTheContainers(t *testing.T) {
+	out, err := Compose(shopResources(), nil)
+	if err != nil {
+		t.Fatalf("Compose returned error: %v", err)
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal(out, &file); err != nil {
+		t.Fatalf("Compose wrote invalid YAML: %v\n%s", err, out)
+	}
+
+	db, ok := file.Services["db"]
+	if !ok {
+		t.Fatalf("expected a db service, got:\n%s", out)
+	}
+	if db.Image != "postgres:16" || db.Restart != "unless-stopped" || db.MemLimit != "512m" || db.CPUs != "1.5" {
+		t.Errorf("unexpected db service: %+v", db)
+	}
+	if db.Command != nil || db.Entrypoint != nil {
+		t.Errorf("expected the image's entrypoint and command left out, got %v %v", db.Entrypoint, db.Command)
+	}
+	if aliases := db.Networks["shop_default"].Aliases; len(aliases) != 1 || aliases[0] != "database" {
+		t.Errorf("expected the database alias, got %v", aliases)
+	}
+
+	api := file.Services["api"]
+	if api.Restart != "on-failure:3" || len(api.Ports) != 1 || api.Ports[0] != "80:8080" {
+		t.Errorf("unexpected api service: %+v", api)
+	}
+	if len(api.DependsOn) != 1 || api.DependsOn[0] != "db" {
+		t.Errorf("expected api to depend on db, got %v", api.DependsOn)
+	}
+	if len(db.DependsOn) != 0 {
+		t.Errorf("expected db to depend on nothing, got %v", db.DependsOn)
+	}
+
+	network := file.Networks["shop_default"]
+	if network.Name != "shop_default" || network.External || network.Driver != "" || network.Labels != nil {
+		t.Errorf("unexpected network: %+v", network)
+	}
+	if network.IPAM == nil || network.IPAM.Config[0].Subnet != "172.20.0.0/16" {
+		t.Errorf("expected the subnet carried over, got %+v", network.IPAM)
+	}
+	// The volume could not be inspected, so it must already exist
+	if volume := file.Volumes["shop_pgdata"]; !volume.External {
+		t.Errorf("expected an uninspected volume to be external, got %+v", volume)
+	}
+}
+
+func TestComposeMasksSecrets(t *testing.T) {
+	rules, err := secrets.New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("secrets.New returned error: %v", err)
+	}
+	out, err := Compose(shopResources(), rules)
+	if err != nil {
+		t.Fatalf("Compose returned error: %v", err)
+	}
+	if strings.Contains(string(out), "hunter2") {
+		t.Fatalf("expected secrets masked, got:\n%s", out)
+	}
+}
+
+func TestDependenciesSkipCycles(t *testing.T) {
+	a := backend.ContainerDetail{
+		Container:       backend.Container{Name: "/a"},
+		Config:          backend.ContainerConfigDetail{Env: []string{"PEER=b"}},
+		NetworkSettings: backend.NetworkSettings{Networks: map[string]backend.EndpointSettings{"mesh": {}}},
+	}
+	b := a
+	b.Container = backend.Container{Name: "/b"}
+	b.Config = backend.ContainerConfigDetail{Env: []string{"PEER=a", "NOTE=abc"}}
+
+	edges := dependencies([]backend.ContainerDetail{a, b}, []string{"a", "b"})
+	if len(edges["a"]) != 1 || len(edges["b"]) != 0 {
+		t.Errorf("expected a single edge from a to b, got %v", edges)
+	}
+}
+
+func TestServiceNames(t *testing.T) {
+	containers := []backend.ContainerDetail{
+		{Container: backend.Container{Name: "/Web_1"}},
+		{Container: backend.Container{Name: "/web 1"}},
+		{Container: backend.Container{Name: "/shop-api-1"}, Config: backend.ContainerConfigDetail{Labels: map[string]string{"com.docker.compose.service": "api"}}},
+		{Container: backend.Container{Name: "/web"}},
+		{Container: backend.Container{Name: "/web"}},
+		{Container: backend.Container{Name: "/web-2"}},
+	}
+	want := []string{"web_1", "web-1", "api", "web", "web-2", "web-2-2"}
+	got := serviceNames(containers)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("serviceNames = %v, want %v", got, want)
+			break
+		}
+	}
+}
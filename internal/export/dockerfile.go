@@ -0,0 +1,359 @@
+package export
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/secrets"
+)
+
+// DockerfileSource is what a Dockerfile is reconstructed from: an image and
+// its history, newest first as the engine reports it. Base names the local
+// image it was most likely built from, whose history makes up the oldest
+// BaseSteps steps; without one, every step is the image's own.
+type DockerfileSource struct {
+	Image     backend.ImageDetail
+	History   []backend.ImageHistoryItem
+	Base      string
+	BaseSteps int
+}
+
+// GatherDockerfile inspects an image and its history, and guesses its base
+// among the local images: the one sharing the most of its oldest layers
+// whose history the image's own history continues. Every local image no
+// larger than it is inspected to compare layers, so the guess is only as
+// good as what has been pulled. Running out of time while guessing leaves
+// the source without a base rather than failing.
+func GatherDockerfile(ctx context.Context, b backend.Backend, id string) (DockerfileSource, error) {
+	image, err := b.InspectImage(ctx, id)
+	if err != nil {
+		return DockerfileSource{}, fmt.Errorf("failed to inspect image %s: %w", id, err)
+	}
+	history, err := b.ImageHistory(ctx, id)
+	if err != nil {
+		return DockerfileSource{}, fmt.Errorf("failed to get history of image %s: %w", id, err)
+	}
+	source := DockerfileSource{Image: image, History: history}
+
+	// The base is a guess, so images that cannot be listed or inspected
+	// only make it a worse one
+	images, err := b.ListImages(ctx)
+	if err != nil {
+		return source, nil
+	}
+	type candidate struct {
+		id     string
+		detail backend.ImageDetail
+	}
+	var candidates []candidate
+	for _, listed := range images {
+		// A base's layers are part of the image, so it is never larger
+		if listed.Size > image.Size {
+			continue
+		}
+		if ctx.Err() != nil {
+			return source, nil
+		}
+		detail, err := b.InspectImage(ctx, listed.ID)
+		if err != nil || detail.ID == image.ID {
+			continue
+		}
+		layers := detail.RootFS.Layers
+		if len(layers) > 0 && len(layers) <= len(image.RootFS.Layers) && slices.Equal(layers, image.RootFS.Layers[:len(layers)]) {
+			candidates = append(candidates, candidate{id: listed.ID, detail: detail})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(len(b.detail.RootFS.Layers), len(a.detail.RootFS.Layers))
+	})
+
+	for _, base := range candidates {
+		if ctx.Err() != nil {
+			return source, nil
+		}
+		baseHistory, err := b.ImageHistory(ctx, base.id)
+		if err != nil || len(baseHistory) >= len(history) {
+			continue
+		}
+		if !slices.EqualFunc(baseHistory, history[len(history)-len(baseHistory):], func(a, b backend.ImageHistoryItem) bool {
+			return a.CreatedBy == b.CreatedBy
+		}) {
+			continue
+		}
+		source.Base = baseReference(base.detail)
+		source.BaseSteps = len(baseHistory)
+		break
+	}
+	return source, nil
+}
+
+// baseReference names an image for a FROM line: by its first tag, or else
+// by its short ID, which the engine resolves locally.
+func baseReference(image backend.ImageDetail) string {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return tag
+		}
+	}
+	id := strings.TrimPrefix(image.ID, "sha256:")
+	return id[:min(len(id), 12)]
+}
+
+var (
+	// copiedContent matches the source and destination of a classic
+	// builder's ADD or COPY, which names what it copied by digest.
+	copiedContent = regexp.MustCompile(`^(.*(?:file|dir|multi):[0-9a-f]+) in (.+?)\s*$`)
+	// buildArgs matches the build arguments the engine records before a
+	// RUN's command as |count NAME=value...
+	buildArgs = regexp.MustCompile(`^\|(\d+)\s+`)
+)
+
+// Dockerfile reconstructs an approximate Dockerfile from an image's
+// history. Steps the base accounts for are replaced by a FROM line, and an
+// image without a known base starts from scratch. Files added or copied by
+// the classic builder are named by their digest, since their source is not
+// recorded. Secret values are masked with rules unless it is nil.
+func Dockerfile(source DockerfileSource, rules *secrets.Detector) []byte {
+	var out strings.Builder
+	name := baseReference(source.Image)
+	fmt.Fprintf(&out, "# Reconstructed from the history of %s.\n", name)
+	if source.Base != "" {
+		fmt.Fprintf(&out, "FROM %s\n", source.Base)
+	} else {
+		out.WriteString("# No local image shares its base layers, so every step is listed.\n")
+		out.WriteString("FROM scratch\n")
+	}
+
+	// History is newest first, and the base's steps are the oldest
+	steps := source.History[:len(source.History)-min(source.BaseSteps, len(source.History))]
+	declared := make(map[string]bool)
+	for i := len(steps) - 1; i >= 0; i-- {
+		for _, line := range instructions(steps[i].CreatedBy, source.Image.Config, declared, rules) {
+			out.WriteString(line)
+			out.WriteString("\n")
+		}
+	}
+	return []byte(out.String())
+}
+
+// instructions turns one history step into the Dockerfile lines that made
+// it, declaring build arguments a RUN used that are not declared yet.
+func instructions(createdBy string, config backend.ContainerConfigDetail, declared map[string]bool, rules *secrets.Detector) []string {
+	step := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(createdBy), "# buildkit"))
+	if step == "" {
+		return []string{"# (a step without a recorded instruction)"}
+	}
+
+	// BuildKit writes RUN steps as RUN [|count args] command, and the
+	// classic builder as [|count args] /bin/sh -c command, with other
+	// instructions after #(nop)
+	isRun := false
+	if rest, ok := strings.CutPrefix(step, "RUN "); ok {
+		step, isRun = rest, true
+	}
+	var lines []string
+	if match := buildArgs.FindStringSubmatch(step); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		fields := strings.Fields(step[len(match[0]):])
+		count = min(count, len(fields))
+		for _, arg := range fields[:count] {
+			name, _, _ := strings.Cut(arg, "=")
+			if !declared[name] {
+				declared[name] = true
+				lines = append(lines, "ARG "+rules.Variable(arg))
+			}
+		}
+		step = strings.Join(fields[count:], " ")
+		isRun = true
+	}
+	if rest, ok := strings.CutPrefix(step, "/bin/sh -c "); ok {
+		step = strings.TrimSpace(rest)
+		if instruction, ok := strings.CutPrefix(step, "#(nop)"); ok {
+			step, isRun = strings.TrimSpace(instruction), false
+		} else {
+			isRun = true
+		}
+	}
+	if isRun {
+		return append(lines, "RUN "+continueLines(rules.Text(step)))
+	}
+
+	keyword, arguments, _ := strings.Cut(step, " ")
+	keyword, arguments = strings.ToUpper(keyword), strings.TrimSpace(arguments)
+	switch keyword {
+	case "CMD", "ENTRYPOINT", "SHELL", "VOLUME":
+		arguments = execForm(arguments)
+	case "EXPOSE":
+		// Ports may be written as the map the engine keeps them in
+		if inner, ok := strings.CutPrefix(arguments, "map["); ok {
+			var ports []string
+			for _, port := range strings.Fields(strings.TrimSuffix(inner, "]")) {
+				ports = append(ports, strings.TrimSuffix(port, ":{}"))
+			}
+			arguments = strings.Join(ports, " ")
+		}
+	case "ADD", "COPY":
+		if match := copiedContent.FindStringSubmatch(arguments); match != nil {
+			arguments = match[1] + " " + match[2]
+		}
+	case "ENV", "LABEL":
+		arguments = assignments(keyword, arguments, rules)
+	case "ARG":
+		name, _, _ := strings.Cut(arguments, "=")
+		declared[name] = true
+		arguments = rules.Variable(arguments)
+	case "HEALTHCHECK":
+		if healthcheck := healthcheckInstruction(config.Healthcheck); healthcheck != "" {
+			return append(lines, healthcheck)
+		}
+		return append(lines, "# "+step)
+	case "USER", "WORKDIR", "STOPSIGNAL", "ONBUILD", "MAINTAINER":
+	default:
+		// Anything else is a command the shell form did not prefix
+		return append(lines, "RUN "+continueLines(rules.Text(step)))
+	}
+	return append(lines, strings.TrimSpace(keyword+" "+arguments))
+}
+
+// execForm writes a list the engine recorded as [a b] or ["a" "b"] as the
+// JSON array of an exec form instruction.
+func execForm(list string) string {
+	inner, ok := strings.CutPrefix(list, "[")
+	if !ok || !strings.HasSuffix(inner, "]") {
+		return list
+	}
+	inner = strings.TrimSuffix(inner, "]")
+
+	var elements []string
+	if json.Unmarshal([]byte(list), &elements) != nil {
+		elements = nil
+		for rest := strings.TrimSpace(inner); rest != ""; rest = strings.TrimSpace(rest) {
+			if rest[0] == '"' {
+				if quoted, err := strconv.QuotedPrefix(rest); err == nil {
+					element, _ := strconv.Unquote(quoted)
+					elements = append(elements, element)
+					rest = rest[len(quoted):]
+					continue
+				}
+			}
+			element, remainder, _ := strings.Cut(rest, " ")
+			elements = append(elements, element)
+			rest = remainder
+		}
+	}
+	encoded, err := json.Marshal(elements)
+	if err != nil {
+		return list
+	}
+	return strings.ReplaceAll(string(encoded), `","`, `", "`)
+}
+
+// assignments writes the NAME=value pairs of an ENV or LABEL, masking
+// secret values. The engine records a single pair unquoted, so a value
+// with spaces is quoted when it is the only pair.
+func assignments(keyword, arguments string, rules *secrets.Detector) string {
+	name, value, ok := strings.Cut(arguments, "=")
+	if !ok {
+		// The legacy ENV NAME value form
+		name, value, _ = strings.Cut(arguments, " ")
+		return name + "=" + quoteValue(rules.Value(name, value))
+	}
+	if strings.Contains(value, "=") && !strings.HasPrefix(value, `"`) {
+		return rules.Text(arguments)
+	}
+	if keyword == "LABEL" || strings.ContainsAny(value, " \t") {
+		value = strings.Trim(value, `"`)
+		return name + "=" + quoteValue(rules.Value(name, value))
+	}
+	return name + "=" + rules.Value(name, value)
+}
+
+func quoteValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\"'\\$") {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// healthcheckInstruction writes an image's health check, which the history
+// only records in Go's debug format.
+func healthcheckInstruction(healthcheck *backend.Healthcheck) string {
+	if healthcheck == nil || len(healthcheck.Test) == 0 {
+		return ""
+	}
+	if healthcheck.Test[0] == "NONE" {
+		return "HEALTHCHECK NONE"
+	}
+
+	parts := []string{"HEALTHCHECK"}
+	for _, option := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"interval", healthcheck.Interval},
+		{"timeout", healthcheck.Timeout},
+		{"start-period", healthcheck.StartPeriod},
+	} {
+		if option.value > 0 {
+			parts = append(parts, "--"+option.name+"="+option.value.String())
+		}
+	}
+	if healthcheck.Retries > 0 {
+		parts = append(parts, "--retries="+strconv.Itoa(healthcheck.Retries))
+	}
+
+	switch healthcheck.Test[0] {
+	case "CMD-SHELL":
+		parts = append(parts, "CMD", strings.Join(healthcheck.Test[1:], " "))
+	case "CMD":
+		encoded, _ := json.Marshal(healthcheck.Test[1:])
+		parts = append(parts, "CMD", strings.ReplaceAll(string(encoded), `","`, `", "`))
+	default:
+		return ""
+	}
+	return strings.Join(parts, " ")
+}
+
+// continueLines breaks a shell command before each && or ; outside quotes,
+// so that long RUN steps read one command per line.
+func continueLines(command string) string {
+	var out strings.Builder
+	var quote byte
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' && i+1 < len(command) {
+				out.WriteByte(c)
+				i++
+				c = command[i]
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '\\' && i+1 < len(command):
+			out.WriteByte(c)
+			i++
+			c = command[i]
+		case strings.HasPrefix(command[i:], " && "):
+			out.WriteString(" \\\n    && ")
+			i += len(" && ") - 1
+			continue
+		case strings.HasPrefix(command[i:], "; "):
+			out.WriteString("; \\\n    ")
+			i += len("; ") - 1
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
@@ -0,0 +1,148 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/fake"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/secrets"
+)
+
+// nginxSource is an image built with BuildKit on top of a classic-built
+// base, with history newest first.
+func nginxSource() DockerfileSource {
+	steps := []string{
+		`CMD ["nginx" "-g" "daemon off;"]`,
+		`HEALTHCHECK &{["CMD-SHELL" "curl -f http://localhost/"] "10s" "0s" "0s" "0s" '\x00'}`,
+		"STOPSIGNAL SIGQUIT",
+		"EXPOSE map[80/tcp:{} 443/tcp:{}]",
+		`ENTRYPOINT ["/docker-entrypoint.sh"]`,
+		"COPY docker-entrypoint.sh / # buildkit",
+		"RUN |2 NGINX_VERSION=1.27.1 API_TOKEN=hunter2 /bin/sh -c set -x && apk add --no-cache nginx=${NGINX_VERSION} && echo 'a && b' # buildkit",
+		"ENV NGINX_VERSION=1.27.1",
+		"LABEL maintainer=NGINX Docker Maintainers <docker-maint@nginx.com>",
+		`/bin/sh -c #(nop)  CMD ["/bin/sh"]`,
+		"/bin/sh -c #(nop) ADD file:33ebe56b967747a97dcec01bc2559962bee8823686c9739d26be060381bbb3ca in / ",
+	}
+	history := make([]backend.ImageHistoryItem, len(steps))
+	for i, step := range steps {
+		history[i] = backend.ImageHistoryItem{CreatedBy: step}
+	}
+
+	image := backend.ImageDetail{Image: backend.Image{ID: "sha256:0123456789abcdef", RepoTags: []string{"nginx:alpine"}}}
+	image.Config.Healthcheck = &backend.Healthcheck{Test: []string{"CMD-SHELL", "curl -f http://localhost/"}, Interval: 10 * time.Second}
+	return DockerfileSource{Image: image, History: history, Base: "alpine:3.20", BaseSteps: 2}
+}
+
+func TestDockerfileFromHistory(t *testing.T) {
+	got := string(Dockerfile(nginxSource(), nil))
+	want := `# Reconstructed from the history of nginx:alpine.
+FROM alpine:3.20
+LABEL maintainer="NGINX Docker Maintainers <docker-maint@nginx.com>"
+ENV NGINX_VERSION=1.27.1
+ARG NGINX_VERSION=1.27.1
+ARG API_TOKEN=hunter2
+RUN set -x \
+    && apk add --no-cache nginx=${NGINX_VERSION} \
+    && echo 'a && b'
+COPY docker-entrypoint.sh /
+ENTRYPOINT ["/docker-entrypoint.sh"]
+EXPOSE 80/tcp 443/tcp
+STOPSIGNAL SIGQUIT
+HEALTHCHECK --interval=10s CMD curl -f http://localhost/
+CMD ["nginx", "-g", "daemon off;"]
+`
+	if got != want {
+		t.Errorf("unexpected Dockerfile:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDockerfileWithoutBaseListsEveryStep(t *testing.T) {
+	source := nginxSource()
+	source.Base, source.BaseSteps = "", 0
+	got := string(Dockerfile(source, nil))
+
+	for _, line := range []string{
+		"FROM scratch\nADD file:33ebe56b967747a97dcec01bc2559962bee8823686c9739d26be060381bbb3ca /\n",
+		`CMD ["/bin/sh"]`,
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("expected %q, got:\n%s", line, got)
+		}
+	}
+}
+
+func TestDockerfileMasksSecrets(t *testing.T) {
+	rules, err := secrets.New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("secrets.New returned error: %v", err)
+	}
+	got := string(Dockerfile(nginxSource(), rules))
+	if strings.Contains(got, "hunter2") || !strings.Contains(got, "ARG API_TOKEN="+secrets.Mask) {
+		t.Errorf("expected the token build argument masked, got:\n%s", got)
+	}
+}
+
+func TestExecForm(t *testing.T) {
+	for recorded, want := range map[string]string{
+		`["nginx" "-g" "daemon off;"]`: `["nginx", "-g", "daemon off;"]`,
+		`["/bin/sh","-c"]`:             `["/bin/sh", "-c"]`,
+		`[/data /logs]`:                `["/data", "/logs"]`,
+		`nginx -g daemon`:              `nginx -g daemon`,
+	} {
+		if got := execForm(recorded); got != want {
+			t.Errorf("execForm(%q) = %q, want %q", recorded, got, want)
+		}
+	}
+}
+
+func TestGatherDockerfileWithoutBase(t *testing.T) {
+	source, err := GatherDockerfile(context.Background(), fake.NewDemo(), "nginx:alpine")
+	if err != nil {
+		t.Fatalf("GatherDockerfile returned error: %v", err)
+	}
+	if source.Base != "" || len(source.History) == 0 {
+		t.Errorf("expected the history without a base, since no demo image shares layers, got %+v", source)
+	}
+}
+
+// expiringBackend runs out of time once the local images have been listed,
+// counting the images inspected afterwards.
+type expiringBackend struct {
+	backend.Backend
+	expire    context.CancelFunc
+	inspected int
+}
+
+func (b *expiringBackend) ListImages(ctx context.Context) ([]backend.Image, error) {
+	defer b.expire()
+	return b.Backend.ListImages(ctx)
+}
+
+func (b *expiringBackend) InspectImage(ctx context.Context, id string) (backend.ImageDetail, error) {
+	if ctx.Err() != nil {
+		b.inspected++
+	}
+	return b.Backend.InspectImage(ctx, id)
+}
+
+func TestGatherDockerfileWithoutTimeForTheBase(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := &expiringBackend{Backend: fake.NewDemo(), expire: cancel}
+
+	source, err := GatherDockerfile(ctx, b, "nginx:alpine")
+	if err != nil {
+		t.Fatalf("GatherDockerfile returned error: %v", err)
+	}
+	if source.Base != "" || len(source.History) == 0 {
+		t.Errorf("expected the history without a base, got %+v", source)
+	}
+	if b.inspected != 0 {
+		t.Errorf("inspected %d images after running out of time, want none", b.inspected)
+	}
+}
@@ -0,0 +1,611 @@
+package export
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultClaimSize is the storage requested for a volume, whose size the
+// engine does not track.
+const defaultClaimSize = "1Gi"
+
+// kubeObject is a Kubernetes object, with fields in the order they are
+// written.
+type kubeObject struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   kubeMetadata      `yaml:"metadata"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	Spec       any               `yaml:"spec,omitempty"`
+}
+
+type kubeMetadata struct {
+	Name        string            `yaml:"name,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type kubeDeploymentSpec struct {
+	Replicas int             `yaml:"replicas"`
+	Selector kubeSelector    `yaml:"selector"`
+	Strategy *kubeStrategy   `yaml:"strategy,omitempty"`
+	Template kubePodTemplate `yaml:"template"`
+}
+
+type kubeSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type kubeStrategy struct {
+	Type string `yaml:"type"`
+}
+
+type kubePodTemplate struct {
+	Metadata kubeMetadata `yaml:"metadata"`
+	Spec     kubePodSpec  `yaml:"spec"`
+}
+
+type kubePodSpec struct {
+	Hostname    string          `yaml:"hostname,omitempty"`
+	HostNetwork bool            `yaml:"hostNetwork,omitempty"`
+	HostAliases []kubeHostAlias `yaml:"hostAliases,omitempty"`
+	DNSConfig   *kubeDNSConfig  `yaml:"dnsConfig,omitempty"`
+	Containers  []kubeContainer `yaml:"containers"`
+	Volumes     []kubeVolume    `yaml:"volumes,omitempty"`
+}
+
+type kubeHostAlias struct {
+	IP        string   `yaml:"ip"`
+	Hostnames []string `yaml:"hostnames"`
+}
+
+type kubeDNSConfig struct {
+	Nameservers []string        `yaml:"nameservers,omitempty"`
+	Searches    []string        `yaml:"searches,omitempty"`
+	Options     []kubeDNSOption `yaml:"options,omitempty"`
+}
+
+type kubeDNSOption struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value,omitempty"`
+}
+
+type kubeContainer struct {
+	Name            string               `yaml:"name"`
+	Image           string               `yaml:"image"`
+	Command         []string             `yaml:"command,omitempty"`
+	Args            []string             `yaml:"args,omitempty"`
+	WorkingDir      string               `yaml:"workingDir,omitempty"`
+	Ports           []kubeContainerPort  `yaml:"ports,omitempty"`
+	EnvFrom         []kubeEnvFrom        `yaml:"envFrom,omitempty"`
+	VolumeMounts    []kubeVolumeMount    `yaml:"volumeMounts,omitempty"`
+	Resources       *kubeResources       `yaml:"resources,omitempty"`
+	LivenessProbe   *kubeProbe           `yaml:"livenessProbe,omitempty"`
+	SecurityContext *kubeSecurityContext `yaml:"securityContext,omitempty"`
+	Stdin           bool                 `yaml:"stdin,omitempty"`
+	TTY             bool                 `yaml:"tty,omitempty"`
+}
+
+type kubeContainerPort struct {
+	ContainerPort int    `yaml:"containerPort"`
+	Protocol      string `yaml:"protocol"`
+}
+
+type kubeEnvFrom struct {
+	ConfigMapRef kubeReference `yaml:"configMapRef"`
+}
+
+type kubeReference struct {
+	Name string `yaml:"name"`
+}
+
+type kubeVolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type kubeResources struct {
+	Limits   map[string]string `yaml:"limits,omitempty"`
+	Requests map[string]string `yaml:"requests,omitempty"`
+}
+
+type kubeProbe struct {
+	Exec                kubeExec `yaml:"exec"`
+	InitialDelaySeconds int      `yaml:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int      `yaml:"periodSeconds"`
+	TimeoutSeconds      int      `yaml:"timeoutSeconds"`
+	FailureThreshold    int      `yaml:"failureThreshold"`
+}
+
+type kubeExec struct {
+	Command []string `yaml:"command"`
+}
+
+type kubeSecurityContext struct {
+	Privileged             bool              `yaml:"privileged,omitempty"`
+	ReadOnlyRootFilesystem bool              `yaml:"readOnlyRootFilesystem,omitempty"`
+	RunAsUser              *int64            `yaml:"runAsUser,omitempty"`
+	RunAsGroup             *int64            `yaml:"runAsGroup,omitempty"`
+	Capabilities           *kubeCapabilities `yaml:"capabilities,omitempty"`
+}
+
+type kubeCapabilities struct {
+	Add  []string `yaml:"add,omitempty"`
+	Drop []string `yaml:"drop,omitempty"`
+}
+
+type kubeVolume struct {
+	Name                  string           `yaml:"name"`
+	PersistentVolumeClaim *kubeClaimSource `yaml:"persistentVolumeClaim,omitempty"`
+	HostPath              *kubeHostPath    `yaml:"hostPath,omitempty"`
+	EmptyDir              *kubeEmptyDir    `yaml:"emptyDir,omitempty"`
+}
+
+type kubeClaimSource struct {
+	ClaimName string `yaml:"claimName"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type kubeHostPath struct {
+	Path string `yaml:"path"`
+}
+
+type kubeEmptyDir struct {
+	Medium string `yaml:"medium,omitempty"`
+}
+
+type kubeClaimSpec struct {
+	AccessModes []string      `yaml:"accessModes"`
+	Resources   kubeResources `yaml:"resources"`
+}
+
+type kubeServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []kubeServicePort `yaml:"ports"`
+}
+
+type kubeServicePort struct {
+	Name       string `yaml:"name"`
+	Protocol   string `yaml:"protocol"`
+	Port       int    `yaml:"port"`
+	TargetPort int    `yaml:"targetPort"`
+}
+
+// kubeNameInvalid matches the characters Kubernetes does not allow in
+// object names.
+var kubeNameInvalid = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// labelName and labelPrefix match the parts of a valid label key, and
+// labelName valid label values too.
+var (
+	labelName   = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]{0,61})?[A-Za-z0-9]$`)
+	labelPrefix = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+)
+
+// Kube generates Kubernetes manifests that run the containers, as podman
+// generate kube does: a Deployment for each container, a Service for the
+// ports it publishes or exposes, a ConfigMap for its environment and a
+// PersistentVolumeClaim for each named volume. Published ports keep their
+// host port as the Service port, so clients reach a container by its name
+// and port as they did on the host. Secret values are masked with rules
+// unless it is nil.
+func Kube(resources ComposeResources, rules *secrets.Detector) ([]byte, error) {
+	var claims, objects []kubeObject
+	claimed := make(map[string]bool)
+
+	names := kubeNames(resources.Containers)
+	for i, container := range resources.Containers {
+		var image *backend.ImageDetail
+		if detail, ok := resources.Images[container.Image]; ok {
+			image = &detail
+		}
+		settings := settingsOf(container, image)
+		name := names[i]
+
+		pod := kubePodOf(container, settings, rules)
+		pod.Containers[0].Name = name
+		if len(settings.env) > 0 {
+			configMap := name + "-env"
+			objects = append(objects, kubeConfigMapOf(configMap, settings.env, rules))
+			pod.Containers[0].EnvFrom = []kubeEnvFrom{{ConfigMapRef: kubeReference{Name: configMap}}}
+		}
+
+		for _, volume := range pod.Volumes {
+			if volume.PersistentVolumeClaim != nil && !claimed[volume.Name] {
+				claimed[volume.Name] = true
+				claims = append(claims, kubeClaimOf(volume.Name))
+			}
+		}
+		objects = append(objects, kubeDeploymentOf(name, settings.labels, pod, rules))
+
+		if ports := kubeServicePorts(container); len(ports) > 0 {
+			objects = append(objects, kubeObject{
+				APIVersion: "v1",
+				Kind:       "Service",
+				Metadata:   kubeMetadata{Name: name, Labels: map[string]string{"app": name}},
+				Spec:       kubeServiceSpec{Selector: map[string]string{"app": name}, Ports: ports},
+			})
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	for _, object := range slices.Concat(claims, objects) {
+		if err := encoder.Encode(object); err != nil {
+			return nil, fmt.Errorf("failed to encode Kubernetes manifest: %w", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode Kubernetes manifest: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// kubeNames names the objects of each container after its compose service
+// or else its name, numbering names that repeat.
+func kubeNames(containers []backend.ContainerDetail) []string {
+	names := make([]string, len(containers))
+	used := make(map[string]bool)
+	for i, container := range containers {
+		name := container.Config.Labels["com.docker.compose.service"]
+		if name == "" {
+			name = strings.TrimPrefix(container.Name, "/")
+		}
+		names[i] = uniqueName(kubeName(name), used)
+	}
+	return names
+}
+
+// kubeName turns s into a DNS label, which every kind of object accepts as
+// a name.
+func kubeName(s string) string {
+	name := strings.Trim(kubeNameInvalid.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(name) > 50 {
+		// Leave room for the suffixes of ConfigMaps and repeated names
+		name = strings.TrimRight(name[:50], "-")
+	}
+	switch {
+	case name == "":
+		return "container"
+	case name[0] >= '0' && name[0] <= '9':
+		return "container-" + name
+	}
+	return name
+}
+
+// kubePodOf builds the pod a container runs in, leaving the container to
+// be named by the caller.
+func kubePodOf(container backend.ContainerDetail, settings settings, rules *secrets.Detector) kubePodSpec {
+	host := container.HostConfig
+	spec := kubeContainer{
+		Image:      settings.image,
+		WorkingDir: settings.workdir,
+		Ports:      kubeContainerPorts(container),
+		Stdin:      container.Config.OpenStdin,
+		TTY:        container.Config.Tty,
+	}
+	if settings.overridden {
+		spec.Command = settings.entrypoint
+	}
+	if len(settings.command) > 0 {
+		spec.Args = maskArgs(settings.command, rules)
+	}
+
+	pod := kubePodSpec{HostNetwork: settings.networkMode == "host"}
+	if labelName.MatchString(settings.hostname) && !strings.ContainsAny(settings.hostname, "._") {
+		pod.Hostname = strings.ToLower(settings.hostname)
+	}
+	for _, entry := range host.ExtraHosts {
+		hostname, ip, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		pod.HostAliases = append(pod.HostAliases, kubeHostAlias{IP: ip, Hostnames: []string{hostname}})
+	}
+	if len(host.DNS) > 0 || len(host.DNSSearch) > 0 || len(host.DNSOptions) > 0 {
+		pod.DNSConfig = &kubeDNSConfig{Nameservers: host.DNS, Searches: host.DNSSearch}
+		for _, option := range host.DNSOptions {
+			name, value, _ := strings.Cut(option, ":")
+			pod.DNSConfig.Options = append(pod.DNSConfig.Options, kubeDNSOption{Name: name, Value: value})
+		}
+	}
+
+	spec.VolumeMounts, pod.Volumes = kubeVolumesOf(container, settings)
+	spec.Resources = kubeResourcesOf(host)
+	spec.LivenessProbe = kubeProbeOf(container.Config.Healthcheck, rules)
+	spec.SecurityContext = kubeSecurityContextOf(host, settings.user)
+	pod.Containers = []kubeContainer{spec}
+	return pod
+}
+
+// kubeContainerPorts lists the ports a container publishes or exposes.
+func kubeContainerPorts(container backend.ContainerDetail) []kubeContainerPort {
+	var ports []kubeContainerPort
+	for _, port := range containerPorts(container) {
+		number, protocol := splitPort(port)
+		ports = append(ports, kubeContainerPort{ContainerPort: number, Protocol: protocol})
+	}
+	return ports
+}
+
+// kubeServicePorts lists the ports a Service forwards to a container: a
+// published port on its first host port, and an exposed one on itself.
+func kubeServicePorts(container backend.ContainerDetail) []kubeServicePort {
+	var ports []kubeServicePort
+	used := make(map[string]bool)
+	for _, port := range containerPorts(container) {
+		target, protocol := splitPort(port)
+		number := target
+		for _, binding := range container.HostConfig.PortBindings[port] {
+			if hostPort, err := strconv.Atoi(binding.HostPort); err == nil {
+				number = hostPort
+				break
+			}
+		}
+		// A Service port can only forward to one container port
+		if used[strconv.Itoa(number)+protocol] {
+			number = target
+		}
+		if used[strconv.Itoa(number)+protocol] {
+			continue
+		}
+		used[strconv.Itoa(number)+protocol] = true
+
+		ports = append(ports, kubeServicePort{
+			Name:       strings.ToLower(protocol) + "-" + strconv.Itoa(number),
+			Protocol:   protocol,
+			Port:       number,
+			TargetPort: target,
+		})
+	}
+	return ports
+}
+
+// containerPorts returns the ports such as "80/tcp" a container publishes
+// or exposes, in order.
+func containerPorts(container backend.ContainerDetail) []string {
+	set := make(map[string]struct{})
+	maps.Copy(set, container.Config.ExposedPorts)
+	for port := range container.HostConfig.PortBindings {
+		set[port] = struct{}{}
+	}
+	return sortedPorts(slices.Collect(maps.Keys(set)))
+}
+
+// splitPort splits a port such as "53/udp" into its number and the
+// protocol as Kubernetes writes it.
+func splitPort(port string) (int, string) {
+	number, protocol, _ := strings.Cut(port, "/")
+	value, _ := strconv.Atoi(number)
+	return value, strings.ToUpper(cmp.Or(protocol, "tcp"))
+}
+
+// kubeVolumesOf mounts a container's named volumes as claims, its bind
+// mounts as host paths, and its tmpfs mounts and anonymous volumes as
+// empty directories, the former in memory.
+func kubeVolumesOf(container backend.ContainerDetail, settings settings) ([]kubeVolumeMount, []kubeVolume) {
+	var mounts []kubeVolumeMount
+	var volumes []kubeVolume
+	used := make(map[string]int)
+	add := func(name string, mount backend.Mount, volume kubeVolume) {
+		used[name]++
+		if used[name] > 1 {
+			name += "-" + strconv.Itoa(used[name])
+		}
+		volume.Name = name
+		volumes = append(volumes, volume)
+		mounts = append(mounts, kubeVolumeMount{Name: name, MountPath: mount.Destination, ReadOnly: !mount.RW && mount.Type != "tmpfs"})
+	}
+
+	for _, mount := range container.Mounts {
+		switch {
+		case isNamedVolume(mount):
+			claim := kubeName(mount.Name)
+			// Claims are shared by name, so they must not be renumbered
+			volumes = append(volumes, kubeVolume{Name: claim, PersistentVolumeClaim: &kubeClaimSource{ClaimName: claim}})
+			mounts = append(mounts, kubeVolumeMount{Name: claim, MountPath: mount.Destination, ReadOnly: !mount.RW})
+			used[claim]++
+		case mount.Type == "bind":
+			add(kubeName(mount.Destination), mount, kubeVolume{HostPath: &kubeHostPath{Path: mount.Source}})
+		case mount.Type == "tmpfs":
+			add(kubeName(mount.Destination), mount, kubeVolume{EmptyDir: &kubeEmptyDir{Medium: "Memory"}})
+		case slices.Contains(settings.volumes, mount.Destination) || slices.Contains(settings.volumes, mount.Destination+":ro"):
+			// An anonymous volume the image does not declare
+			add(kubeName(mount.Destination), mount, kubeVolume{EmptyDir: &kubeEmptyDir{}})
+		}
+	}
+	return mounts, volumes
+}
+
+// kubeResourcesOf converts CPU and memory limits, and a memory reservation
+// as a request.
+func kubeResourcesOf(host backend.HostConfig) *kubeResources {
+	resources := kubeResources{}
+	if host.NanoCPUs > 0 {
+		resources.Limits = map[string]string{"cpu": kubeCPUs(host.NanoCPUs)}
+	}
+	if host.Memory > 0 {
+		if resources.Limits == nil {
+			resources.Limits = make(map[string]string)
+		}
+		resources.Limits["memory"] = kubeQuantity(host.Memory)
+	}
+	if host.MemoryReservation > 0 {
+		resources.Requests = map[string]string{"memory": kubeQuantity(host.MemoryReservation)}
+	}
+	if resources.Limits == nil && resources.Requests == nil {
+		return nil
+	}
+	return &resources
+}
+
+// kubeCPUs writes nano CPUs as whole CPUs or millicores.
+func kubeCPUs(nanoCPUs int64) string {
+	if nanoCPUs%1e9 == 0 {
+		return strconv.FormatInt(nanoCPUs/1e9, 10)
+	}
+	return strconv.FormatInt(max(nanoCPUs/1e6, 1), 10) + "m"
+}
+
+// kubeQuantity writes a byte count with the largest binary suffix that
+// divides it.
+func kubeQuantity(bytes int64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"Gi", 1 << 30}, {"Mi", 1 << 20}, {"Ki", 1 << 10}} {
+		if bytes%unit.size == 0 {
+			return strconv.FormatInt(bytes/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// kubeProbeOf converts a health check into a liveness probe, as podman
+// does, keeping the engine's defaults where the check leaves them out.
+func kubeProbeOf(check *backend.Healthcheck, rules *secrets.Detector) *kubeProbe {
+	if check == nil || len(check.Test) < 2 {
+		return nil
+	}
+	var command []string
+	switch check.Test[0] {
+	case "CMD":
+		command = check.Test[1:]
+	case "CMD-SHELL":
+		command = []string{"/bin/sh", "-c", check.Test[1]}
+	default:
+		return nil
+	}
+	return &kubeProbe{
+		Exec:                kubeExec{Command: maskArgs(command, rules)},
+		InitialDelaySeconds: seconds(check.StartPeriod, 0),
+		PeriodSeconds:       seconds(check.Interval, 30*time.Second),
+		TimeoutSeconds:      seconds(check.Timeout, 30*time.Second),
+		FailureThreshold:    cmp.Or(check.Retries, 3),
+	}
+}
+
+// seconds rounds a duration, or fallback when it is zero, up to whole
+// seconds.
+func seconds(d, fallback time.Duration) int {
+	if d == 0 {
+		d = fallback
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// kubeSecurityContextOf converts privileges, capabilities, a read-only root
+// and a numeric user. Users given by name have no equivalent, since the
+// cluster cannot look them up in the image.
+func kubeSecurityContextOf(host backend.HostConfig, user string) *kubeSecurityContext {
+	context := kubeSecurityContext{
+		Privileged:             host.Privileged,
+		ReadOnlyRootFilesystem: host.ReadonlyRootfs,
+	}
+	if uid, gid, hasGroup := strings.Cut(user, ":"); uid != "" {
+		if value, err := strconv.ParseInt(uid, 10, 64); err == nil {
+			context.RunAsUser = &value
+		}
+		if value, err := strconv.ParseInt(gid, 10, 64); err == nil && hasGroup {
+			context.RunAsGroup = &value
+		}
+	}
+	if len(host.CapAdd) > 0 || len(host.CapDrop) > 0 {
+		context.Capabilities = &kubeCapabilities{Add: capabilities(host.CapAdd), Drop: capabilities(host.CapDrop)}
+	}
+	if context == (kubeSecurityContext{}) {
+		return nil
+	}
+	return &context
+}
+
+// capabilities names capabilities without the CAP_ prefix Docker accepts.
+func capabilities(names []string) []string {
+	var trimmed []string
+	for _, name := range names {
+		trimmed = append(trimmed, strings.TrimPrefix(strings.ToUpper(name), "CAP_"))
+	}
+	return trimmed
+}
+
+// kubeDeploymentOf runs pod in a Deployment, recreating rather than rolling
+// it when it claims volumes, which a second pod could not mount. Labels
+// Kubernetes accepts are kept as labels, the rest as annotations.
+func kubeDeploymentOf(name string, labels map[string]string, pod kubePodSpec, rules *secrets.Detector) kubeObject {
+	selector := map[string]string{"app": name}
+	metadata := kubeMetadata{Name: name, Labels: map[string]string{"app": name}}
+	for key, value := range rules.Map(labels) {
+		// The selector's label is kept, so a container's own goes with
+		// the annotations
+		if _, reserved := selector[key]; !reserved && isLabel(key, value) {
+			metadata.Labels[key] = value
+			continue
+		}
+		if metadata.Annotations == nil {
+			metadata.Annotations = make(map[string]string)
+		}
+		metadata.Annotations[key] = value
+	}
+
+	spec := kubeDeploymentSpec{
+		Replicas: 1,
+		Selector: kubeSelector{MatchLabels: selector},
+		Template: kubePodTemplate{
+			Metadata: kubeMetadata{Labels: metadata.Labels, Annotations: metadata.Annotations},
+			Spec:     pod,
+		},
+	}
+	if slices.ContainsFunc(pod.Volumes, func(volume kubeVolume) bool { return volume.PersistentVolumeClaim != nil }) {
+		spec.Strategy = &kubeStrategy{Type: "Recreate"}
+	}
+	return kubeObject{APIVersion: "apps/v1", Kind: "Deployment", Metadata: metadata, Spec: spec}
+}
+
+// isLabel reports whether key and value make a valid Kubernetes label.
+func isLabel(key, value string) bool {
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok {
+		if !labelPrefix.MatchString(prefix) {
+			return false
+		}
+		name = rest
+	}
+	return labelName.MatchString(name) && (value == "" || labelName.MatchString(value))
+}
+
+// kubeConfigMapOf holds a container's environment.
+func kubeConfigMapOf(name string, env []string, rules *secrets.Detector) kubeObject {
+	data := make(map[string]string)
+	for _, variable := range rules.Env(env) {
+		key, value, _ := strings.Cut(variable, "=")
+		data[key] = value
+	}
+	return kubeObject{APIVersion: "v1", Kind: "ConfigMap", Metadata: kubeMetadata{Name: name}, Data: data}
+}
+
+// kubeClaimOf claims storage for a named volume.
+func kubeClaimOf(name string) kubeObject {
+	return kubeObject{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Metadata:   kubeMetadata{Name: name},
+		Spec: kubeClaimSpec{
+			AccessModes: []string{"ReadWriteOnce"},
+			Resources:   kubeResources{Requests: map[string]string{"storage": defaultClaimSize}},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// decodedObject is a generated object, with its spec decoded by kind.
+type decodedObject struct {
+	Kind     string            `yaml:"kind"`
+	Metadata kubeMetadata      `yaml:"metadata"`
+	Data     map[string]string `yaml:"data"`
+	Spec     yaml.Node         `yaml:"spec"`
+}
+
+// decodeKube decodes the objects of a manifest by kind and name.
+func decodeKube(t *testing.T, out []byte) map[string]*decodedObject {
+	t.Helper()
+	objects := make(map[string]*decodedObject)
+	decoder := yaml.NewDecoder(bytes.NewReader(out))
+	for {
+		var object decodedObject
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			return objects
+		}
+		if err != nil {
+			t.Fatalf("Kube wrote invalid YAML: %v\n%s", err, out)
+		}
+		objects[object.Kind+"/"+object.Metadata.Name] = &object
+	}
+}
+
+func TestKubeRunsTheContainers(t *testing.T) {
+	resources := shopResources()
+	resources.Containers[1].Config.Healthcheck = &backend.Healthcheck{
+		Test:     []string{"CMD-SHELL", "curl -f http://localhost:8080/health"},
+		Interval: 10 * time.Second,
+	}
+	out, err := Kube(resources, nil)
+	if err != nil {
+		t.Fatalf("Kube returned error: %v", err)
+	}
+	objects := decodeKube(t, out)
+
+	for _, name := range []string{"PersistentVolumeClaim/shop-pgdata", "ConfigMap/db-env", "Deployment/db", "Service/db", "ConfigMap/api-env", "Deployment/api", "Service/api"} {
+		if _, ok := objects[name]; !ok {
+			t.Fatalf("expected %s, got:\n%s", name, out)
+		}
+	}
+	if data := objects["ConfigMap/db-env"].Data; len(data) != 1 || data["POSTGRES_PASSWORD"] != "hunter2" {
+		t.Errorf("expected only the environment the image does not set, got %v", data)
+	}
+
+	var db kubeDeploymentSpec
+	if err := objects["Deployment/db"].Spec.Decode(&db); err != nil {
+		t.Fatalf("failed to decode db deployment: %v", err)
+	}
+	if db.Strategy == nil || db.Strategy.Type != "Recreate" {
+		t.Errorf("expected a deployment claiming volumes to be recreated, got %+v", db.Strategy)
+	}
+	if labels := db.Template.Metadata.Labels; labels["app"] != "db" || labels["team"] != "payments" || labels["maintainer"] != "" {
+		t.Errorf("expected the app and container labels, got %v", labels)
+	}
+	container := db.Template.Spec.Containers[0]
+	if container.Command != nil || container.Args != nil {
+		t.Errorf("expected the image's entrypoint and command left out, got %v %v", container.Command, container.Args)
+	}
+	if limits := container.Resources.Limits; limits["cpu"] != "1500m" || limits["memory"] != "512Mi" {
+		t.Errorf("unexpected limits: %v", limits)
+	}
+	if len(container.VolumeMounts) != 3 || !container.VolumeMounts[1].ReadOnly {
+		t.Errorf("expected the volume, bind and anonymous mounts, got %+v", container.VolumeMounts)
+	}
+	volumes := db.Template.Spec.Volumes
+	if len(volumes) != 3 || volumes[0].PersistentVolumeClaim == nil || volumes[1].HostPath == nil || volumes[2].EmptyDir == nil {
+		t.Errorf("unexpected volumes: %+v", volumes)
+	}
+
+	var api kubeDeploymentSpec
+	if err := objects["Deployment/api"].Spec.Decode(&api); err != nil {
+		t.Fatalf("failed to decode api deployment: %v", err)
+	}
+	probe := api.Template.Spec.Containers[0].LivenessProbe
+	if probe == nil || strings.Join(probe.Exec.Command, " ") != "/bin/sh -c curl -f http://localhost:8080/health" {
+		t.Fatalf("expected a liveness probe running the health check, got %+v", probe)
+	}
+	if probe.PeriodSeconds != 10 || probe.TimeoutSeconds != 30 || probe.FailureThreshold != 3 {
+		t.Errorf("expected the engine's defaults for what the check leaves out, got %+v", probe)
+	}
+
+	var service kubeServiceSpec
+	if err := objects["Service/api"].Spec.Decode(&service); err != nil {
+		t.Fatalf("failed to decode api service: %v", err)
+	}
+	if len(service.Ports) != 1 || service.Ports[0].Port != 80 || service.Ports[0].TargetPort != 8080 {
+		t.Errorf("expected the published host port to forward to the container port, got %+v", service.Ports)
+	}
+}
+
+func TestKubeMasksSecrets(t *testing.T) {
+	rules, err := secrets.New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("secrets.New returned error: %v", err)
+	}
+	out, err := Kube(shopResources(), rules)
+	if err != nil {
+		t.Fatalf("Kube returned error: %v", err)
+	}
+	if strings.Contains(string(out), "hunter2") {
+		t.Errorf("expected secrets masked, got:\n%s", out)
+	}
+}
+
+func TestKubeKeepsTheSelectorLabel(t *testing.T) {
+	resources := shopResources()
+	resources.Containers[0].Config.Labels["app"] = "shop"
+	out, err := Kube(resources, nil)
+	if err != nil {
+		t.Fatalf("Kube returned error: %v", err)
+	}
+	object := decodeKube(t, out)["Deployment/db"]
+
+	var db kubeDeploymentSpec
+	if err := object.Spec.Decode(&db); err != nil {
+		t.Fatalf("failed to decode db deployment: %v", err)
+	}
+	if db.Selector.MatchLabels["app"] != "db" || db.Template.Metadata.Labels["app"] != "db" || object.Metadata.Labels["app"] != "db" {
+		t.Errorf("expected the pods to match the selector, got selector %v and labels %v", db.Selector.MatchLabels, db.Template.Metadata.Labels)
+	}
+	if object.Metadata.Annotations["app"] != "shop" || db.Template.Metadata.Annotations["app"] != "shop" {
+		t.Errorf("expected the container's app label kept as an annotation, got %v", object.Metadata.Annotations)
+	}
+}
+
+func TestKubeNameAndLabels(t *testing.T) {
+	for input, want := range map[string]string{
+		"shop_api.1": "shop-api-1",
+		"/Web":       "web",
+		"1password":  "container-1password",
+		"___":        "container",
+	} {
+		if got := kubeName(input); got != want {
+			t.Errorf("kubeName(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	names := kubeNames([]backend.ContainerDetail{
+		{Container: backend.Container{Name: "/web"}},
+		{Container: backend.Container{Name: "/web"}},
+		{Container: backend.Container{Name: "/web-2"}},
+	})
+	if want := []string{"web", "web-2", "web-2-2"}; !slices.Equal(names, want) {
+		t.Errorf("kubeNames = %v, want %v", names, want)
+	}
+
+	for _, label := range []struct {
+		key, value string
+		want       bool
+	}{
+		{"team", "payments", true},
+		{"app.kubernetes.io/part-of", "shop", true},
+		{"org.opencontainers.image.source", "https://example.com/shop", false},
+		{"Invalid_Prefix/name", "value", false},
+	} {
+		if got := isLabel(label.key, label.value); got != label.want {
+			t.Errorf("isLabel(%q, %q) = %v, want %v", label.key, label.value, got, label.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package export reconstructs how to recreate a resource elsewhere, such
+// as the docker run command line of an existing container.
+package export
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/secrets"
+)
+
+// safeArg matches arguments that need no quoting in a POSIX shell.
+var safeArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// RunCommand returns the `<engine> run` command line that recreates
+// container, one flag per line. Settings equal to the defaults of image,
+// the container's image when it could be inspected, are left out, as are
+// those the engine sets itself. Secret values are masked with rules unless
+// it is nil.
+func RunCommand(engine string, container backend.ContainerDetail, image *backend.ImageDetail, rules *secrets.Detector) string {
+	settings := settingsOf(container, image)
+	config := container.Config
+	host := container.HostConfig
+
+	run := &command{}
+	run.flag("-d")
+	if name := strings.TrimPrefix(container.Name, "/"); name != "" {
+		run.flag("--name", name)
+	}
+	switch {
+	case config.OpenStdin && config.Tty:
+		run.flag("-it")
+	case config.OpenStdin:
+		run.flag("-i")
+	case config.Tty:
+		run.flag("-t")
+	}
+	if host.AutoRemove {
+		run.flag("--rm")
+	}
+	if policy := host.RestartPolicy; policy.Name != "" && policy.Name != "no" {
+		value := policy.Name
+		if policy.Name == "on-failure" && policy.MaximumRetryCount > 0 {
+			value += ":" + strconv.Itoa(policy.MaximumRetryCount)
+		}
+		run.flag("--restart", value)
+	}
+
+	run.flagEach("--hostname", settings.hostname)
+	run.flagEach("--domainname", config.Domainname)
+	run.flagEach("--user", settings.user)
+	run.flagEach("--workdir", settings.workdir)
+
+	run.flagEach("--network", settings.networkMode)
+	run.flagEach("--network", settings.networks...)
+	// Addresses and aliases can only be given for a single network
+	if len(settings.networks) == 1 {
+		if ipam := container.NetworkSettings.Networks[settings.networks[0]].IPAMConfig; ipam != nil {
+			run.flagEach("--ip", ipam.IPv4Address)
+			run.flagEach("--ip6", ipam.IPv6Address)
+		}
+		run.flagEach("--network-alias", aliases(container, settings.networks[0])...)
+	}
+
+	if host.PublishAllPorts {
+		run.flag("-P")
+	}
+	run.flagEach("-p", settings.published...)
+	run.flagEach("--expose", settings.exposed...)
+	run.flagEach("-v", settings.volumes...)
+	run.flagEach("--tmpfs", settings.tmpfs...)
+
+	for _, variable := range settings.env {
+		run.flag("-e", rules.Variable(variable))
+	}
+	for _, name := range slices.Sorted(maps.Keys(settings.labels)) {
+		run.flag("--label", name+"="+rules.Value(name, settings.labels[name]))
+	}
+
+	run.flagEach("--dns", host.DNS...)
+	run.flagEach("--dns-option", host.DNSOptions...)
+	run.flagEach("--dns-search", host.DNSSearch...)
+	run.flagEach("--add-host", host.ExtraHosts...)
+
+	if host.Privileged {
+		run.flag("--privileged")
+	}
+	if host.ReadonlyRootfs {
+		run.flag("--read-only")
+	}
+	run.flagEach("--cap-add", host.CapAdd...)
+	run.flagEach("--cap-drop", host.CapDrop...)
+
+	if host.NanoCPUs > 0 {
+		run.flag("--cpus", cpus(host.NanoCPUs))
+	}
+	if host.CpuShares > 0 {
+		run.flag("--cpu-shares", strconv.FormatInt(host.CpuShares, 10))
+	}
+	if host.Memory > 0 {
+		run.flag("--memory", memorySize(host.Memory))
+	}
+	// Docker sets the swap limit to twice the memory limit by default
+	if host.MemorySwap == -1 {
+		run.flag("--memory-swap", "-1")
+	} else if host.MemorySwap > 0 && host.MemorySwap != 2*host.Memory {
+		run.flag("--memory-swap", memorySize(host.MemorySwap))
+	}
+	if host.MemoryReservation > 0 {
+		run.flag("--memory-reservation", memorySize(host.MemoryReservation))
+	}
+	if host.OomKillDisable {
+		run.flag("--oom-kill-disable")
+	}
+	if host.PidsLimit > 0 {
+		run.flag("--pids-limit", strconv.FormatInt(host.PidsLimit, 10))
+	}
+
+	// --entrypoint takes a single executable; its arguments lead the command
+	args := settings.command
+	if settings.overridden {
+		if len(settings.entrypoint) == 0 {
+			run.flag("--entrypoint", "")
+		} else {
+			run.flag("--entrypoint", settings.entrypoint[0])
+			args = slices.Concat(settings.entrypoint[1:], settings.command)
+		}
+	}
+
+	last := []string{settings.image}
+	for _, arg := range args {
+		last = append(last, rules.Text(arg))
+	}
+	run.lines = append(run.lines, last)
+
+	return engine + " run " + run.String()
+}
+
+// command collects the lines of a shell command, each a flag with its
+// value or the final arguments.
+type command struct {
+	lines [][]string
+}
+
+func (c *command) flag(args ...string) {
+	c.lines = append(c.lines, args)
+}
+
+// flagEach adds the flag once for each of its non-empty values.
+func (c *command) flagEach(flag string, values ...string) {
+	for _, value := range values {
+		if value != "" {
+			c.flag(flag, value)
+		}
+	}
+}
+
+// String quotes the arguments for a POSIX shell and continues each line
+// onto the next with a backslash.
+func (c *command) String() string {
+	lines := make([]string, len(c.lines))
+	for i, line := range c.lines {
+		quoted := make([]string, len(line))
+		for j, arg := range line {
+			quoted[j] = shellQuote(arg)
+		}
+		lines[i] = strings.Join(quoted, " ")
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// shellQuote quotes s for a POSIX shell when it has special characters.
+func shellQuote(s string) string {
+	if safeArg.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
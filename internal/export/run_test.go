@@ -0,0 +1,134 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/secrets"
+)
+
+func postgresImage() *backend.ImageDetail {
+	return &backend.ImageDetail{
+		Image: backend.Image{Labels: map[string]string{"maintainer": "postgres"}},
+		Config: backend.ContainerConfigDetail{
+			Env:          []string{"PATH=/usr/local/bin:/usr/bin", "PGDATA=/var/lib/postgresql/data"},
+			Entrypoint:   []string{"docker-entrypoint.sh"},
+			Cmd:          []string{"postgres"},
+			ExposedPorts: map[string]struct{}{"5432/tcp": {}},
+			Volumes:      map[string]struct{}{"/var/lib/postgresql/data": {}},
+		},
+	}
+}
+
+func postgresContainer() backend.ContainerDetail {
+	id := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	return backend.ContainerDetail{
+		Container: backend.Container{ID: id, Name: "/db"},
+		Config: backend.ContainerConfigDetail{
+			Hostname:     id[:12],
+			Image:        "postgres:16",
+			Env:          []string{"PATH=/usr/local/bin:/usr/bin", "PGDATA=/var/lib/postgresql/data", "POSTGRES_PASSWORD=hunter2"},
+			Entrypoint:   []string{"docker-entrypoint.sh"},
+			Cmd:          []string{"postgres"},
+			ExposedPorts: map[string]struct{}{"5432/tcp": {}},
+			Labels: map[string]string{
+				"maintainer":                 "postgres",
+				"team":                       "payments",
+				"com.docker.compose.project": "shop",
+			},
+		},
+		HostConfig: backend.HostConfig{
+			NetworkMode:   "shop_default",
+			RestartPolicy: backend.RestartPolicy{Name: "unless-stopped"},
+			PortBindings: map[string][]backend.PortBinding{
+				"5432/tcp": {{HostIP: "127.0.0.1", HostPort: "5432"}},
+			},
+			CapAdd:     []string{"SYS_NICE"},
+			NanoCPUs:   1_500_000_000,
+			Memory:     512 << 20,
+			MemorySwap: 1024 << 20,
+			PidsLimit:  100,
+		},
+		NetworkSettings: backend.NetworkSettings{
+			Networks: map[string]backend.EndpointSettings{
+				"shop_default": {Aliases: []string{"db", id[:12], "database"}},
+			},
+		},
+		Mounts: []backend.Mount{
+			{Type: "volume", Name: "shop_pgdata", Destination: "/var/lib/postgresql/data", RW: true},
+			{Type: "bind", Source: "/srv/shop/init", Destination: "/docker-entrypoint-initdb.d", RW: false},
+			{Type: "volume", Name: strings.Repeat("a", 64), Destination: "/scratch", RW: true},
+		},
+	}
+}
+
+func TestRunCommandLeavesOutImageDefaults(t *testing.T) {
+	got := RunCommand("docker", postgresContainer(), postgresImage(), nil)
+	want := `docker run -d \
+  --name db \
+  --restart unless-stopped \
+  --network shop_default \
+  --network-alias database \
+  -p 127.0.0.1:5432:5432 \
+  -v shop_pgdata:/var/lib/postgresql/data \
+  -v /srv/shop/init:/docker-entrypoint-initdb.d:ro \
+  -v /scratch \
+  -e POSTGRES_PASSWORD=hunter2 \
+  --label team=payments \
+  --cap-add SYS_NICE \
+  --cpus 1.5 \
+  --memory 512m \
+  --pids-limit 100 \
+  postgres:16`
+	if got != want {
+		t.Fatalf("RunCommand =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRunCommandOverridesTheEntrypoint(t *testing.T) {
+	container := postgresContainer()
+	container.Config.Entrypoint = []string{"/bin/sh", "-c"}
+	container.Config.Cmd = []string{"echo 'hi' && sleep 1"}
+
+	got := RunCommand("podman", container, postgresImage(), nil)
+	if !strings.HasPrefix(got, "podman run -d") {
+		t.Errorf("expected a podman command, got:\n%s", got)
+	}
+	if want := `--entrypoint /bin/sh \` + "\n" + `  postgres:16 -c 'echo '\''hi'\'' && sleep 1'`; !strings.Contains(got, want) {
+		t.Errorf("expected %q in:\n%s", want, got)
+	}
+}
+
+func TestRunCommandWithoutTheImageKeepsEverything(t *testing.T) {
+	got := RunCommand("docker", postgresContainer(), nil, nil)
+	for _, want := range []string{"-e PGDATA=/var/lib/postgresql/data", "--label maintainer=postgres", "--entrypoint docker-entrypoint.sh", "postgres:16 postgres"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	// Published ports are not exposed again
+	if strings.Contains(got, "--expose") {
+		t.Errorf("expected no --expose for a published port, got:\n%s", got)
+	}
+}
+
+func TestRunCommandMasksSecrets(t *testing.T) {
+	rules, err := secrets.New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("secrets.New returned error: %v", err)
+	}
+	got := RunCommand("docker", postgresContainer(), postgresImage(), rules)
+	if strings.Contains(got, "hunter2") || !strings.Contains(got, "POSTGRES_PASSWORD="+secrets.Mask) {
+		t.Fatalf("expected the password masked, got:\n%s", got)
+	}
+}
+
+func TestMemorySize(t *testing.T) {
+	for bytes, want := range map[int64]string{1 << 30: "1g", 768 << 20: "768m", 3072: "3k", 1000: "1000"} {
+		if got := memorySize(bytes); got != want {
+			t.Errorf("memorySize(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package export
+
+import (
+	"cmp"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// anonymousVolume matches the generated names of anonymous volumes.
+var anonymousVolume = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// settings are what recreating a container has to set: its configuration
+// less the defaults of its image and what the engine assigns itself.
+type settings struct {
+	image    string
+	hostname string
+	user     string
+	workdir  string
+	env      []string
+	// labels leave out compose's own, which would make compose treat the
+	// copy as one of its containers
+	labels map[string]string
+	// published are port specs such as "127.0.0.1:8080:80"; exposed are
+	// ports the image does not already expose
+	published []string
+	exposed   []string
+	// volumes are binds and volumes such as "data:/var/lib/data:ro"
+	volumes []string
+	tmpfs   []string
+	// networkMode is host, none or container:<id>, which rule out networks
+	networkMode string
+	networks    []string
+	// entrypoint is set when the image's entrypoint is overridden, which
+	// also drops its command, so command is then always set
+	entrypoint []string
+	command    []string
+	overridden bool
+}
+
+// settingsOf works out the settings of container, leaving out what image,
+// when it could be inspected, sets by default.
+func settingsOf(container backend.ContainerDetail, image *backend.ImageDetail) settings {
+	defaults := backend.ContainerConfigDetail{}
+	var imageLabels map[string]string
+	if image != nil {
+		defaults = image.Config
+		imageLabels = image.Labels
+	}
+	config := container.Config
+	id := hostID(container.ID)
+
+	s := settings{image: cmp.Or(config.Image, container.Image)}
+	if config.Hostname != "" && !strings.HasPrefix(id, config.Hostname) && container.HostConfig.NetworkMode != "host" {
+		s.hostname = config.Hostname
+	}
+	if config.User != defaults.User {
+		s.user = config.User
+	}
+	if config.WorkingDir != defaults.WorkingDir {
+		s.workdir = config.WorkingDir
+	}
+
+	for _, variable := range config.Env {
+		if !slices.Contains(defaults.Env, variable) {
+			s.env = append(s.env, variable)
+		}
+	}
+	for name, value := range config.Labels {
+		if inherited, ok := imageLabels[name]; ok && inherited == value || strings.HasPrefix(name, "com.docker.compose.") {
+			continue
+		}
+		if s.labels == nil {
+			s.labels = make(map[string]string)
+		}
+		s.labels[name] = value
+	}
+
+	s.published, s.exposed = ports(container, defaults)
+	s.volumes, s.tmpfs = mounts(container.Mounts, defaults)
+	s.networkMode, s.networks = networks(container)
+
+	switch {
+	case !slices.Equal(config.Entrypoint, defaults.Entrypoint):
+		s.entrypoint = config.Entrypoint
+		s.command = config.Cmd
+		s.overridden = true
+	case !slices.Equal(config.Cmd, defaults.Cmd):
+		s.command = config.Cmd
+	}
+	return s
+}
+
+// hostID returns a container ID without the host name aggregated hosts
+// prefix it with.
+func hostID(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
+
+// ports returns the published ports as specs such as "127.0.0.1:8080:80",
+// and the exposed ports that are neither published nor exposed by the
+// image.
+func ports(container backend.ContainerDetail, defaults backend.ContainerConfigDetail) (published, exposed []string) {
+	bindings := container.HostConfig.PortBindings
+	for _, port := range sortedPorts(slices.Collect(maps.Keys(bindings))) {
+		target := strings.TrimSuffix(port, "/tcp")
+		for _, binding := range bindings[port] {
+			spec := target
+			hostIP := binding.HostIP
+			if hostIP == "0.0.0.0" || hostIP == "::" {
+				hostIP = ""
+			}
+			if binding.HostPort != "" || hostIP != "" {
+				spec = binding.HostPort + ":" + spec
+				if hostIP != "" {
+					spec = hostIP + ":" + spec
+				}
+			}
+			if !slices.Contains(published, spec) {
+				published = append(published, spec)
+			}
+		}
+	}
+
+	for _, port := range sortedPorts(slices.Collect(maps.Keys(container.Config.ExposedPorts))) {
+		_, imageExposes := defaults.ExposedPorts[port]
+		_, isPublished := bindings[port]
+		if !imageExposes && !isPublished {
+			exposed = append(exposed, strings.TrimSuffix(port, "/tcp"))
+		}
+	}
+	return published, exposed
+}
+
+// mounts returns bind mounts, named volumes and the anonymous volumes the
+// image does not declare as specs such as "data:/var/lib/data:ro", and the
+// destinations of tmpfs mounts.
+func mounts(mounts []backend.Mount, defaults backend.ContainerConfigDetail) (volumes, tmpfs []string) {
+	for _, mount := range mounts {
+		suffix := ""
+		if !mount.RW {
+			suffix = ":ro"
+		}
+		switch mount.Type {
+		case "bind":
+			volumes = append(volumes, mount.Source+":"+mount.Destination+suffix)
+		case "tmpfs":
+			tmpfs = append(tmpfs, mount.Destination)
+		case "volume":
+			if isNamedVolume(mount) {
+				volumes = append(volumes, mount.Name+":"+mount.Destination+suffix)
+			} else if _, declared := defaults.Volumes[mount.Destination]; !declared {
+				volumes = append(volumes, mount.Destination+suffix)
+			}
+		}
+	}
+	return volumes, tmpfs
+}
+
+// isNamedVolume reports whether mount is a volume with a chosen name rather
+// than an anonymous one.
+func isNamedVolume(mount backend.Mount) bool {
+	return mount.Type == "volume" && mount.Name != "" && !anonymousVolume.MatchString(mount.Name)
+}
+
+// networks returns the container's network mode when it is host, none or
+// another container's, or else the networks it is attached to other than
+// the default bridge, the one it was created on first.
+func networks(container backend.ContainerDetail) (string, []string) {
+	mode := container.HostConfig.NetworkMode
+	if mode == "host" || mode == "none" || strings.HasPrefix(mode, "container:") {
+		return mode, nil
+	}
+
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(container.NetworkSettings.Networks)) {
+		if name == "bridge" && (mode == "" || mode == "default" || mode == "bridge") {
+			continue
+		}
+		names = append(names, name)
+	}
+	if i := slices.Index(names, mode); i > 0 {
+		names = slices.Concat([]string{mode}, slices.Delete(names, i, i+1))
+	}
+	return "", names
+}
+
+// aliases returns the container's aliases on a network, less the name and
+// short ID Docker adds itself.
+func aliases(container backend.ContainerDetail, network string) []string {
+	name := strings.TrimPrefix(container.Name, "/")
+	id := hostID(container.ID)
+	var own []string
+	for _, alias := range container.NetworkSettings.Networks[network].Aliases {
+		if alias != name && !strings.HasPrefix(id, alias) {
+			own = append(own, alias)
+		}
+	}
+	return own
+}
+
+// sortedPorts sorts ports such as "443/tcp" by number, then protocol.
+func sortedPorts(ports []string) []string {
+	slices.SortFunc(ports, func(a, b string) int {
+		numberA, protocolA, _ := strings.Cut(a, "/")
+		numberB, protocolB, _ := strings.Cut(b, "/")
+		portA, _ := strconv.Atoi(numberA)
+		portB, _ := strconv.Atoi(numberB)
+		return cmp.Or(cmp.Compare(portA, portB), cmp.Compare(protocolA, protocolB))
+	})
+	return ports
+}
+
+// memorySize writes a byte count with the largest unit that divides it,
+// as docker run and compose accept.
+func memorySize(bytes int64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"g", 1 << 30}, {"m", 1 << 20}, {"k", 1 << 10}} {
+		if bytes%unit.size == 0 {
+			return strconv.FormatInt(bytes/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// cpus writes a CPU limit in nano CPUs as a number of CPUs.
+func cpus(nanoCPUs int64) string {
+	return strconv.FormatFloat(float64(nanoCPUs)/1e9, 'f', -1, 64)
+}
@@ -0,0 +1,157 @@
+// Package filter matches resources against the --filter expressions given on
+// the command line and the queries typed into a list's filter bar.
+package filter
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// Keys lists the --filter keys in the order they are documented.
+var Keys = []string{"id", "name", "state", "label"}
+
+// Fields are the parts of a resource that filters match against.
+type Fields struct {
+	ID      string
+	Names   []string
+	State   string
+	Image   string
+	Driver  string
+	Created time.Time
+	Ports   []backend.Port
+	Labels  map[string]string
+}
+
+// Filter is a parsed set of expressions. Expressions with the same key match
+// if any of them does; expressions with different keys must all match.
+type Filter struct {
+	terms map[string][]string
+}
+
+// Parse parses expressions of the form key=value.
+func Parse(expressions []string) (Filter, error) {
+	f := Filter{terms: make(map[string][]string)}
+	for _, expression := range expressions {
+		key, value, ok := strings.Cut(expression, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || value == "" {
+			return Filter{}, fmt.Errorf("invalid filter %q: expected key=value", expression)
+		}
+		switch key {
+		case "name":
+			if _, err := path.Match(value, ""); err != nil {
+				return Filter{}, fmt.Errorf("invalid filter %q: %w", expression, err)
+			}
+		case "id", "state", "label":
+		default:
+			return Filter{}, fmt.Errorf("unknown filter key %q (expected one of %s)", key, strings.Join(Keys, ", "))
+		}
+		f.terms[key] = append(f.terms[key], value)
+	}
+	return f, nil
+}
+
+// Empty reports whether the filter matches everything.
+func (f Filter) Empty() bool {
+	return len(f.terms) == 0
+}
+
+// Match reports whether fields satisfy the filter.
+func (f Filter) Match(fields Fields) bool {
+	for key, values := range f.terms {
+		matched := false
+		for _, value := range values {
+			if matchTerm(key, value, fields) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTerm matches one key and value, both already validated.
+func matchTerm(key, value string, fields Fields) bool {
+	switch key {
+	case "id":
+		value = strings.TrimPrefix(value, "sha256:")
+		id := strings.TrimPrefix(fields.ID, "sha256:")
+		// IDs qualified with a host also match on the bare ID
+		bare := strings.TrimPrefix(id[strings.LastIndex(id, "/")+1:], "sha256:")
+		return strings.HasPrefix(id, value) || strings.HasPrefix(bare, value)
+	case "name":
+		for _, name := range fields.Names {
+			if ok, _ := path.Match(value, name); ok {
+				return true
+			}
+		}
+		return false
+	case "state":
+		return strings.EqualFold(fields.State, value)
+	case "image":
+		ok, _ := path.Match(value, fields.Image)
+		return ok
+	case "driver":
+		return strings.EqualFold(fields.Driver, value)
+	case "label":
+		labelKey, labelValue, hasValue := strings.Cut(value, "=")
+		actual, ok := fields.Labels[labelKey]
+		return ok && (!hasValue || actual == labelValue)
+	case "port":
+		number, protocol, hasProtocol := strings.Cut(value, "/")
+		for _, port := range fields.Ports {
+			if hasProtocol && !strings.EqualFold(port.Type, protocol) {
+				continue
+			}
+			if strconv.Itoa(int(port.PrivatePort)) == number || (port.PublicPort != 0 && strconv.Itoa(int(port.PublicPort)) == number) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// ContainerFields returns the fields a container is filtered on.
+func ContainerFields(c backend.Container) Fields {
+	return Fields{
+		ID:      c.ID,
+		Names:   []string{c.Name},
+		State:   c.State,
+		Image:   c.Image,
+		Created: c.Created,
+		Ports:   c.Ports,
+		Labels:  c.Labels,
+	}
+}
+
+// ImageFields returns the fields an image is filtered on. An image matches a
+// name filter through any of its tags.
+func ImageFields(image backend.Image) Fields {
+	return Fields{ID: image.ID, Names: image.RepoTags, Created: image.Created, Labels: image.Labels}
+}
+
+// VolumeFields returns the fields a volume is filtered on. Volumes are
+// identified by name, so the name doubles as the ID.
+func VolumeFields(volume backend.Volume) Fields {
+	return Fields{
+		ID:      volume.Name,
+		Names:   []string{volume.Name},
+		Driver:  volume.Driver,
+		Created: volume.CreatedAt,
+		Labels:  volume.Labels,
+	}
+}
+
+// NetworkFields returns the fields a network is filtered on.
+func NetworkFields(network backend.Network) Fields {
+	return Fields{ID: network.ID, Names: []string{network.Name}, Driver: network.Driver, Labels: network.Labels}
+}
@@ -0,0 +1,54 @@
+package filter
+
+import "testing"
+
+func TestParseRejectsMalformedExpressions(t *testing.T) {
+	for _, expression := range []string{"running", "state=", "color=red", "name=[a"} {
+		if _, err := Parse([]string{expression}); err == nil {
+			t.Fatalf("expected %q to be rejected", expression)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	api := Fields{
+		ID:     "staging/0123456789ab",
+		Names:  []string{"api-billing"},
+		State:  "running",
+		Labels: map[string]string{"team": "payments", "svc": "billing"},
+	}
+	worker := Fields{
+		ID:     "fedcba987654",
+		Names:  []string{"worker"},
+		State:  "exited",
+		Labels: map[string]string{"team": "payments"},
+	}
+
+	tests := []struct {
+		expressions []string
+		api, worker bool
+	}{
+		{expressions: nil, api: true, worker: true},
+		{expressions: []string{"state=running"}, api: true},
+		{expressions: []string{"state=running", "state=exited"}, api: true, worker: true},
+		{expressions: []string{"label=team=payments", "name=api-*"}, api: true},
+		{expressions: []string{"label=svc"}, api: true},
+		{expressions: []string{"label=team=search"}},
+		{expressions: []string{"id=0123"}, api: true},
+		{expressions: []string{"id=staging/0123"}, api: true},
+		{expressions: []string{"id=fed"}, worker: true},
+	}
+
+	for _, tt := range tests {
+		f, err := Parse(tt.expressions)
+		if err != nil {
+			t.Fatalf("Parse(%v) returned error: %v", tt.expressions, err)
+		}
+		if got := f.Match(api); got != tt.api {
+			t.Fatalf("%v matching api = %v, want %v", tt.expressions, got, tt.api)
+		}
+		if got := f.Match(worker); got != tt.worker {
+			t.Fatalf("%v matching worker = %v, want %v", tt.expressions, got, tt.worker)
+		}
+	}
+}
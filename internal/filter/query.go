@@ -0,0 +1,221 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Keys each list's filter bar accepts, in the order they are suggested.
+var (
+	ContainerQueryKeys = []string{"id", "name", "state", "image", "label", "created", "port"}
+	ImageQueryKeys     = []string{"id", "name", "label", "created"}
+	VolumeQueryKeys    = []string{"name", "driver", "label", "created"}
+	NetworkQueryKeys   = []string{"id", "name", "driver", "label"}
+)
+
+// queryKeys are every key the query language knows.
+var queryKeys = []string{"id", "name", "state", "image", "driver", "label", "created", "port"}
+
+// ageUnits are the units accepted by created:.
+var ageUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+type queryTerm struct {
+	key   string
+	value string
+	// For created: the age bound and whether resources must be newer than it
+	age   time.Duration
+	newer bool
+}
+
+// Query is what was typed into a filter bar, such as
+// `state:exited image:postgres* label:env=prod created:<7d port:5432 web`.
+// Terms with the same key match if any of them does, except created, whose
+// bounds all apply; terms with different keys and every free text word must
+// all match.
+type Query struct {
+	terms []queryTerm
+	text  []string
+}
+
+// ParseQuery parses input, accepting only the given keys. Values and free
+// text containing spaces or colons can be double quoted.
+func ParseQuery(input string, keys []string) (Query, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	for _, token := range tokens {
+		if token.quoted || !strings.Contains(token.text, ":") {
+			q.text = append(q.text, strings.ToLower(token.text))
+			continue
+		}
+
+		key, value, _ := strings.Cut(token.text, ":")
+		key = strings.ToLower(key)
+		switch {
+		case !slices.Contains(queryKeys, key):
+			return Query{}, fmt.Errorf("unknown key %q (try %s)", key, strings.Join(keys, ", "))
+		case !slices.Contains(keys, key):
+			return Query{}, fmt.Errorf("%q does not apply to this list (try %s)", key, strings.Join(keys, ", "))
+		case value == "":
+			return Query{}, fmt.Errorf("%s: needs a value", key)
+		}
+
+		term := queryTerm{key: key, value: value}
+		switch key {
+		case "name", "image":
+			if _, err := path.Match(value, ""); err != nil {
+				return Query{}, fmt.Errorf("%s: bad pattern %q", key, value)
+			}
+		case "created":
+			if term.age, term.newer, err = parseAge(value); err != nil {
+				return Query{}, err
+			}
+		case "port":
+			number, _, _ := strings.Cut(value, "/")
+			if n, err := strconv.Atoi(number); err != nil || n < 1 || n > 65535 {
+				return Query{}, fmt.Errorf("port: %q is not a port number", number)
+			}
+		}
+		q.terms = append(q.terms, term)
+	}
+	return q, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// tokenize splits input on spaces outside double quotes.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	var current strings.Builder
+	inQuotes, quoted, started := false, false, false
+
+	flush := func() {
+		if started {
+			tokens = append(tokens, token{text: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		quoted, started = false, false
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			// A quote at the start of a token makes it free text
+			if !started {
+				quoted = true
+			}
+			started = true
+		case r == ' ' && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuotes {
+		return nil, errors.New("unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+// parseAge parses a created: bound such as <7d or >12h.
+func parseAge(value string) (time.Duration, bool, error) {
+	errFormat := errors.New("created: expected <N or >N with a unit of s, m, h, d or w, such as created:<7d")
+	if len(value) < 3 || (value[0] != '<' && value[0] != '>') {
+		return 0, false, errFormat
+	}
+	unit, ok := ageUnits[value[len(value)-1]]
+	if !ok {
+		return 0, false, errFormat
+	}
+	n, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || n < 0 {
+		return 0, false, errFormat
+	}
+	return time.Duration(n) * unit, value[0] == '<', nil
+}
+
+// Empty reports whether the query matches everything.
+func (q Query) Empty() bool {
+	return len(q.terms) == 0 && len(q.text) == 0
+}
+
+// Match reports whether fields satisfy the query at time now.
+func (q Query) Match(fields Fields, now time.Time) bool {
+	for _, word := range q.text {
+		if !slices.ContainsFunc(fields.Names, func(name string) bool {
+			return strings.Contains(strings.ToLower(name), word)
+		}) {
+			return false
+		}
+	}
+
+	matched := make(map[string]bool)
+	for _, term := range q.terms {
+		if term.key == "created" {
+			age := now.Sub(fields.Created)
+			if fields.Created.IsZero() || (term.newer && age >= term.age) || (!term.newer && age <= term.age) {
+				return false
+			}
+			continue
+		}
+		if !matched[term.key] {
+			matched[term.key] = matchTerm(term.key, term.value, fields)
+		}
+	}
+	for _, ok := range matched {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the parsed query: alternatives for a key are joined by
+// "|", and everything that must match by " · ".
+func (q Query) String() string {
+	var parts []string
+	for _, key := range queryKeys {
+		var values []string
+		for _, term := range q.terms {
+			if term.key != key {
+				continue
+			}
+			if key == "created" {
+				bound := "older than "
+				if term.newer {
+					bound = "newer than "
+				}
+				parts = append(parts, bound+term.value[1:])
+				continue
+			}
+			values = append(values, term.value)
+		}
+		if len(values) > 0 {
+			parts = append(parts, key+" "+strings.Join(values, "|"))
+		}
+	}
+	for _, word := range q.text {
+		parts = append(parts, strconv.Quote(word))
+	}
+	return strings.Join(parts, " · ")
+}
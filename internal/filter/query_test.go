@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+func TestParseQueryReportsErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "colour:red", want: "unknown key"},
+		{input: "port:5432", want: "does not apply"},
+		{input: "name:", want: "needs a value"},
+		{input: "created:7d", want: "created:"},
+		{input: `name:"api`, want: "unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.input, VolumeQueryKeys)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Fatalf("ParseQuery(%q) error = %v, want one mentioning %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	db := Fields{
+		Names:   []string{"billing-db"},
+		State:   "exited",
+		Image:   "postgres:16",
+		Created: now.Add(-48 * time.Hour),
+		Ports:   []backend.Port{{PrivatePort: 5432, PublicPort: 15432, Type: "tcp"}},
+		Labels:  map[string]string{"env": "prod"},
+	}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "", want: true},
+		{input: "state:exited image:postgres* label:env=prod created:<7d port:5432", want: true},
+		{input: "state:running state:exited", want: true},
+		{input: "state:running", want: false},
+		{input: "created:>1d created:<3d", want: true},
+		{input: "created:>3d", want: false},
+		{input: "port:15432/tcp", want: true},
+		{input: "port:5432/udp", want: false},
+		{input: "billing DB", want: true},
+		{input: "state:exited web", want: false},
+		{input: `"billing-db"`, want: true},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input, ContainerQueryKeys)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) returned error: %v", tt.input, err)
+		}
+		if got := q.Match(db, now); got != tt.want {
+			t.Fatalf("%q matching db = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	q, err := ParseQuery(`web state:running created:<7d state:paused`, ContainerQueryKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.String(), `state running|paused · newer than 7d · "web"`; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
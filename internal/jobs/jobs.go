@@ -0,0 +1,421 @@
+// Package jobs provides a background job queue for long-running operations
+// such as pulls, builds, prunes and bulk container actions.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is the number of jobs that run at once when no limit is configured.
+const DefaultConcurrency = 3
+
+// maxFinishedJobs bounds how many finished jobs are kept for the jobs panel.
+const maxFinishedJobs = 50
+
+// Status represents the lifecycle state of a job.
+type Status int
+
+const (
+	Queued Status = iota
+	Running
+	Succeeded
+	Failed
+	Cancelled
+)
+
+func (s Status) String() string {
+	return [...]string{
+		"queued",
+		"running",
+		"done",
+		"failed",
+		"cancelled",
+	}[s]
+}
+
+// Item tracks the progress of a single unit of work inside a job,
+// e.g. one image layer of a pull or one container of a bulk stop.
+type Item struct {
+	Name    string
+	Status  string
+	Current int64
+	Total   int64
+}
+
+// Percent returns the item progress in range [0, 1], or -1 when unknown.
+func (i Item) Percent() float64 {
+	if i.Total <= 0 {
+		return -1
+	}
+	return min(float64(i.Current)/float64(i.Total), 1)
+}
+
+// Job is a point-in-time snapshot of a job.
+type Job struct {
+	ID       int64
+	Title    string
+	Kind     string
+	Status   Status
+	Message  string
+	Percent  float64
+	Items    []Item
+	Result   any
+	Err      error
+	Created  time.Time
+	Started  time.Time
+	Finished time.Time
+}
+
+// Done reports whether the job has reached a terminal state.
+func (j Job) Done() bool {
+	return j.Status == Succeeded || j.Status == Failed || j.Status == Cancelled
+}
+
+// Func is the body of a job. It must return promptly once ctx is cancelled.
+type Func func(ctx context.Context, progress *Progress) error
+
+type job struct {
+	Job
+	fn     Func
+	cancel context.CancelFunc
+	items  map[string]int
+}
+
+// Manager runs submitted jobs with a concurrency limit and keeps
+// snapshots of running, queued and finished jobs.
+type Manager struct {
+	mu       sync.Mutex
+	limit    int
+	nextID   int64
+	jobs     []*job
+	running  int
+	finished []Job
+	changed  chan struct{}
+}
+
+// NewManager creates a job manager running at most limit jobs at once.
+func NewManager(limit int) *Manager {
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+	return &Manager{
+		limit:   limit,
+		nextID:  1,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Limit returns the concurrency limit.
+func (m *Manager) Limit() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.limit
+}
+
+// SetLimit changes the concurrency limit and starts queued jobs if there is room.
+func (m *Manager) SetLimit(limit int) {
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+	m.mu.Lock()
+	m.limit = limit
+	m.scheduleLocked()
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Submit queues a job and returns its ID. The job starts as soon as
+// fewer than Limit jobs are running.
+func (m *Manager) Submit(title, kind string, fn Func) int64 {
+	m.mu.Lock()
+	j := &job{
+		Job: Job{
+			ID:      m.nextID,
+			Title:   title,
+			Kind:    kind,
+			Status:  Queued,
+			Created: time.Now(),
+		},
+		fn:    fn,
+		items: make(map[string]int),
+	}
+	m.nextID++
+	m.jobs = append(m.jobs, j)
+	m.scheduleLocked()
+	m.mu.Unlock()
+	m.notify()
+	return j.ID
+}
+
+// Cancel cancels a queued or running job. It returns false if the job
+// does not exist or has already finished.
+func (m *Manager) Cancel(id int64) bool {
+	m.mu.Lock()
+	j := m.findLocked(id)
+	if j == nil || j.Done() {
+		m.mu.Unlock()
+		return false
+	}
+
+	if j.Status == Queued {
+		m.finishLocked(j, context.Canceled)
+		m.mu.Unlock()
+		m.notify()
+		return true
+	}
+
+	cancel := j.cancel
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	return true
+}
+
+// CancelAll cancels every queued and running job.
+func (m *Manager) CancelAll() {
+	for _, j := range m.Jobs() {
+		if !j.Done() {
+			m.Cancel(j.ID)
+		}
+	}
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (m *Manager) Get(id int64) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j := m.findLocked(id)
+	if j == nil {
+		return Job{}, false
+	}
+	return j.snapshot(), true
+}
+
+// Jobs returns snapshots of all jobs: running first, then queued,
+// then finished jobs with the most recent first.
+func (m *Manager) Jobs() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		result = append(result, j.snapshot())
+	}
+
+	sort.SliceStable(result, func(a, b int) bool {
+		ra, rb := statusRank(result[a].Status), statusRank(result[b].Status)
+		if ra != rb {
+			return ra < rb
+		}
+		if result[a].Done() {
+			return result[a].Finished.After(result[b].Finished)
+		}
+		return result[a].ID < result[b].ID
+	})
+
+	return result
+}
+
+// Counts returns the number of running, queued and finished jobs.
+func (m *Manager) Counts() (running, queued, finished int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, j := range m.jobs {
+		switch {
+		case j.Status == Running:
+			running++
+		case j.Status == Queued:
+			queued++
+		default:
+			finished++
+		}
+	}
+	return running, queued, finished
+}
+
+// ClearFinished removes all finished jobs from the list.
+func (m *Manager) ClearFinished() {
+	m.mu.Lock()
+	kept := m.jobs[:0]
+	for _, j := range m.jobs {
+		if !j.Done() {
+			kept = append(kept, j)
+		}
+	}
+	m.jobs = kept
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Changed returns a channel that receives a value whenever a job changes.
+// Notifications are coalesced, so a receiver should read the full state
+// with Jobs and DrainFinished rather than count notifications.
+func (m *Manager) Changed() <-chan struct{} {
+	return m.changed
+}
+
+// DrainFinished returns the jobs that finished since the last call.
+func (m *Manager) DrainFinished() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	finished := m.finished
+	m.finished = nil
+	return finished
+}
+
+func (m *Manager) notify() {
+	select {
+	case m.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) findLocked(id int64) *job {
+	for _, j := range m.jobs {
+		if j.ID == id {
+			return j
+		}
+	}
+	return nil
+}
+
+func (m *Manager) scheduleLocked() {
+	for _, j := range m.jobs {
+		if m.running >= m.limit {
+			return
+		}
+		if j.Status != Queued {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		j.cancel = cancel
+		j.Status = Running
+		j.Started = time.Now()
+		m.running++
+
+		go m.run(ctx, j)
+	}
+}
+
+func (m *Manager) run(ctx context.Context, j *job) {
+	progress := &Progress{manager: m, job: j}
+	err := j.fn(ctx, progress)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	m.mu.Lock()
+	j.cancel()
+	m.running--
+	m.finishLocked(j, err)
+	m.scheduleLocked()
+	m.mu.Unlock()
+	m.notify()
+}
+
+func (m *Manager) finishLocked(j *job, err error) {
+	j.Err = err
+	j.Finished = time.Now()
+	switch {
+	case errors.Is(err, context.Canceled):
+		j.Status = Cancelled
+		j.Err = nil
+	case err != nil:
+		j.Status = Failed
+	default:
+		j.Status = Succeeded
+		j.Percent = 1
+	}
+
+	m.finished = append(m.finished, j.snapshot())
+	m.trimLocked()
+}
+
+// trimLocked drops the oldest finished jobs beyond maxFinishedJobs.
+func (m *Manager) trimLocked() {
+	done := 0
+	for _, j := range m.jobs {
+		if j.Done() {
+			done++
+		}
+	}
+
+	excess := done - maxFinishedJobs
+	if excess <= 0 {
+		return
+	}
+
+	kept := m.jobs[:0]
+	for _, j := range m.jobs {
+		if excess > 0 && j.Done() {
+			excess--
+			continue
+		}
+		kept = append(kept, j)
+	}
+	m.jobs = kept
+}
+
+func (j *job) snapshot() Job {
+	snapshot := j.Job
+	snapshot.Items = append([]Item(nil), j.Items...)
+	return snapshot
+}
+
+func statusRank(status Status) int {
+	switch status {
+	case Running:
+		return 0
+	case Queued:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Progress is handed to a running job to report its progress.
+type Progress struct {
+	manager *Manager
+	job     *job
+}
+
+// SetMessage sets the job's current status line.
+func (p *Progress) SetMessage(message string) {
+	p.update(func(j *job) { j.Message = message })
+}
+
+// SetPercent sets the overall job progress in range [0, 1].
+func (p *Progress) SetPercent(percent float64) {
+	p.update(func(j *job) { j.Percent = max(0, min(percent, 1)) })
+}
+
+// SetItem creates or updates the progress of a named item.
+func (p *Progress) SetItem(name, status string, current, total int64) {
+	p.update(func(j *job) {
+		item := Item{Name: name, Status: status, Current: current, Total: total}
+		if index, ok := j.items[name]; ok {
+			j.Items[index] = item
+			return
+		}
+		j.items[name] = len(j.Items)
+		j.Items = append(j.Items, item)
+	})
+}
+
+// SetResult attaches a value to the job that is available once it finishes.
+func (p *Progress) SetResult(result any) {
+	p.update(func(j *job) { j.Result = result })
+}
+
+func (p *Progress) update(apply func(j *job)) {
+	p.manager.mu.Lock()
+	apply(p.job)
+	p.manager.mu.Unlock()
+	p.manager.notify()
+}
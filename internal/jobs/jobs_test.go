@@ -0,0 +1,173 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, m *Manager, id int64, status Status) Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if j, ok := m.Get(id); ok && j.Status == status {
+			return j
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	j, _ := m.Get(id)
+	t.Fatalf("job %d: expected status %s, got %s", id, status, j.Status)
+	return j
+}
+
+func TestManagerRespectsConcurrencyLimit(t *testing.T) {
+	m := NewManager(2)
+	release := make(chan struct{})
+
+	block := func(ctx context.Context, _ *Progress) error {
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	ids := []int64{
+		m.Submit("pull a", "pull", block),
+		m.Submit("pull b", "pull", block),
+		m.Submit("pull c", "pull", block),
+		m.Submit("pull d", "pull", block),
+		m.Submit("pull e", "pull", block),
+	}
+
+	waitFor(t, m, ids[0], Running)
+	waitFor(t, m, ids[1], Running)
+
+	running, queued, _ := m.Counts()
+	if running != 2 || queued != 3 {
+		t.Fatalf("expected 2 running and 3 queued, got %d running and %d queued", running, queued)
+	}
+
+	close(release)
+	for _, id := range ids {
+		waitFor(t, m, id, Succeeded)
+	}
+}
+
+func TestManagerCancelRunningAndQueuedJobs(t *testing.T) {
+	m := NewManager(1)
+
+	block := func(ctx context.Context, _ *Progress) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	running := m.Submit("running", "pull", block)
+	queued := m.Submit("queued", "pull", block)
+	waitFor(t, m, running, Running)
+
+	if !m.Cancel(queued) {
+		t.Fatal("expected queued job to be cancellable")
+	}
+	waitFor(t, m, queued, Cancelled)
+
+	if !m.Cancel(running) {
+		t.Fatal("expected running job to be cancellable")
+	}
+	j := waitFor(t, m, running, Cancelled)
+	if j.Err != nil {
+		t.Fatalf("expected cancelled job to have no error, got %v", j.Err)
+	}
+
+	if m.Cancel(running) {
+		t.Fatal("expected finished job not to be cancellable")
+	}
+}
+
+func TestManagerRecordsProgressAndResult(t *testing.T) {
+	m := NewManager(1)
+	id := m.Submit("prune", "prune", func(_ context.Context, p *Progress) error {
+		p.SetMessage("pruning")
+		p.SetItem("layer1", "Downloading", 5, 10)
+		p.SetItem("layer2", "Waiting", 0, 0)
+		p.SetItem("layer1", "Pull complete", 10, 10)
+		p.SetPercent(2)
+		p.SetResult(uint64(42))
+		return nil
+	})
+
+	j := waitFor(t, m, id, Succeeded)
+	if j.Message != "pruning" {
+		t.Fatalf("expected message %q, got %q", "pruning", j.Message)
+	}
+	if len(j.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(j.Items))
+	}
+	if j.Items[0].Status != "Pull complete" || j.Items[0].Percent() != 1 {
+		t.Fatalf("expected first item to be updated in place, got %+v", j.Items[0])
+	}
+	if j.Items[1].Percent() != -1 {
+		t.Fatalf("expected unknown item progress, got %f", j.Items[1].Percent())
+	}
+	if j.Percent != 1 {
+		t.Fatalf("expected percent to be clamped to 1, got %f", j.Percent)
+	}
+	if j.Result != uint64(42) {
+		t.Fatalf("expected result 42, got %v", j.Result)
+	}
+}
+
+func TestManagerDrainFinished(t *testing.T) {
+	m := NewManager(2)
+	failure := errors.New("boom")
+
+	ok := m.Submit("ok", "build", func(context.Context, *Progress) error { return nil })
+	bad := m.Submit("bad", "build", func(context.Context, *Progress) error { return failure })
+
+	waitFor(t, m, ok, Succeeded)
+	j := waitFor(t, m, bad, Failed)
+	if !errors.Is(j.Err, failure) {
+		t.Fatalf("expected job error %v, got %v", failure, j.Err)
+	}
+
+	finished := m.DrainFinished()
+	if len(finished) != 2 {
+		t.Fatalf("expected 2 finished jobs, got %d", len(finished))
+	}
+	if again := m.DrainFinished(); len(again) != 0 {
+		t.Fatalf("expected finished jobs to be drained, got %d", len(again))
+	}
+
+	m.ClearFinished()
+	if jobs := m.Jobs(); len(jobs) != 0 {
+		t.Fatalf("expected no jobs after clearing finished, got %d", len(jobs))
+	}
+}
+
+func TestManagerJobsOrdering(t *testing.T) {
+	m := NewManager(1)
+	release := make(chan struct{})
+
+	done := m.Submit("done", "pull", func(context.Context, *Progress) error { return nil })
+	waitFor(t, m, done, Succeeded)
+
+	running := m.Submit("running", "pull", func(ctx context.Context, _ *Progress) error {
+		<-release
+		return nil
+	})
+	queued := m.Submit("queued", "pull", func(context.Context, *Progress) error { return nil })
+	waitFor(t, m, running, Running)
+
+	jobs := m.Jobs()
+	want := []int64{running, queued, done}
+	for i, id := range want {
+		if jobs[i].ID != id {
+			t.Fatalf("expected job %d at position %d, got %d", id, i, jobs[i].ID)
+		}
+	}
+
+	close(release)
+	waitFor(t, m, queued, Succeeded)
+}
@@ -0,0 +1,340 @@
+// Package layers reads an image archive, as written by docker save, into
+// what each of the image's layers does to its filesystem: the files it
+// adds, changes and removes, and which of them are wasted because a later
+// layer overwrites or removes them again.
+package layers
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Whiteout markers in a layer: a removed file is written as an empty file
+// with the prefix, and a directory whose lower contents are hidden holds
+// the opaque marker.
+const (
+	whiteoutPrefix = ".wh."
+	opaqueWhiteout = ".wh..wh..opq"
+)
+
+// Change is what a layer does to a path.
+type Change int
+
+const (
+	Added Change = iota
+	Modified
+	Removed
+)
+
+func (change Change) String() string {
+	switch change {
+	case Added:
+		return "added"
+	case Modified:
+		return "modified"
+	case Removed:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// File is a path a layer changes. Paths are relative to the root, and the
+// size of a removed file is the size of what it removes. A file is wasted
+// when a later layer overwrites or removes it, so that its bytes are
+// shipped with the image without being seen in it.
+type File struct {
+	Path   string
+	Size   int64
+	Dir    bool
+	Change Change
+	Wasted bool
+}
+
+// Layer is one filesystem layer of an image, with its files in path order.
+type Layer struct {
+	Digest  string
+	Command string
+	Size    int64
+	Files   []File
+}
+
+// WastedFile totals the wasted versions of one path across layers.
+type WastedFile struct {
+	Path  string
+	Size  int64
+	Count int
+}
+
+// Image is the layers of an image, oldest first, with the space they waste.
+// Size counts the bytes of every layer, so that it includes WastedSize.
+type Image struct {
+	Layers     []Layer
+	Size       int64
+	WastedSize int64
+	Wasted     []WastedFile
+}
+
+// Efficiency is the share of the image's bytes that end up in its
+// filesystem, from 0 to 1. An image without files is fully efficient.
+func (image Image) Efficiency() float64 {
+	if image.Size == 0 {
+		return 1
+	}
+	return float64(image.Size-image.WastedSize) / float64(image.Size)
+}
+
+// manifest is an entry of an archive's manifest.json.
+type manifest struct {
+	Config string
+	Layers []string
+}
+
+// config is the part of an image's config describing its layers. History
+// includes steps that left no layer, which are marked empty.
+type config struct {
+	RootFS struct {
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+	History []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+// entry is a path in one layer's tar.
+type entry struct {
+	path string
+	size int64
+	dir  bool
+}
+
+// Read reads the first image of an archive as written by docker save, in
+// either the legacy or the OCI layout. The archive is read once, so layers
+// are listed as they stream past and put in order from the manifest at the
+// end; only their headers are kept.
+func Read(archive io.Reader) (Image, error) {
+	documents := make(map[string][]byte)
+	layerEntries := make(map[string][]entry)
+
+	reader := tar.NewReader(archive)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Image{}, fmt.Errorf("failed to read image archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Configs and manifests are JSON, and anything else may be a layer
+		buffered := bufio.NewReader(reader)
+		start, _ := buffered.Peek(2)
+		switch {
+		case len(start) > 0 && (start[0] == '{' || start[0] == '['):
+			document, err := io.ReadAll(buffered)
+			if err != nil {
+				return Image{}, fmt.Errorf("failed to read %s: %w", header.Name, err)
+			}
+			documents[header.Name] = document
+		default:
+			if entries, err := readLayer(buffered, bytes.Equal(start, []byte{0x1f, 0x8b})); err == nil {
+				layerEntries[header.Name] = entries
+			}
+		}
+	}
+
+	var manifests []manifest
+	if err := json.Unmarshal(documents["manifest.json"], &manifests); err != nil || len(manifests) == 0 {
+		return Image{}, errors.New("failed to read image archive: no manifest.json")
+	}
+	var imageConfig config
+	if err := json.Unmarshal(documents[manifests[0].Config], &imageConfig); err != nil {
+		return Image{}, fmt.Errorf("failed to read image config: %w", err)
+	}
+
+	var commands []string
+	for _, step := range imageConfig.History {
+		if !step.EmptyLayer {
+			commands = append(commands, step.CreatedBy)
+		}
+	}
+
+	layers := make([][]entry, len(manifests[0].Layers))
+	for i, name := range manifests[0].Layers {
+		entries, ok := layerEntries[name]
+		if !ok {
+			return Image{}, fmt.Errorf("failed to read layer %s", name)
+		}
+		layers[i] = entries
+	}
+
+	image := analyze(layers)
+	for i := range image.Layers {
+		if i < len(imageConfig.RootFS.DiffIDs) {
+			image.Layers[i].Digest = imageConfig.RootFS.DiffIDs[i]
+		}
+		if i < len(commands) {
+			image.Layers[i].Command = commands[i]
+		}
+	}
+	return image, nil
+}
+
+// readLayer lists the paths in a layer's tar, which may be compressed.
+func readLayer(layer io.Reader, compressed bool) ([]entry, error) {
+	if compressed {
+		decompressed, err := gzip.NewReader(layer)
+		if err != nil {
+			return nil, err
+		}
+		defer decompressed.Close()
+		layer = decompressed
+	}
+
+	var entries []entry
+	reader := tar.NewReader(layer)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if name == "" {
+			continue
+		}
+		var size int64
+		if header.Typeflag == tar.TypeReg {
+			size = header.Size
+		}
+		entries = append(entries, entry{path: name, size: size, dir: header.Typeflag == tar.TypeDir})
+	}
+}
+
+// location is where the visible version of a path was written.
+type location struct {
+	layer int
+	index int
+}
+
+// analyze applies the layers in order, recording what each changes and
+// marking the versions later layers overwrite or remove as wasted.
+func analyze(layers [][]entry) Image {
+	var image Image
+	visible := make(map[string]location)
+	wasted := make(map[string]*WastedFile)
+
+	waste := func(at location) {
+		file := &image.Layers[at.layer].Files[at.index]
+		file.Wasted = true
+		if file.Size == 0 {
+			return
+		}
+		total, ok := wasted[file.Path]
+		if !ok {
+			total = &WastedFile{Path: file.Path}
+			wasted[file.Path] = total
+		}
+		total.Size += file.Size
+		total.Count++
+		image.WastedSize += file.Size
+	}
+
+	// remove takes a path and everything under it out of the filesystem,
+	// returning the bytes removed
+	remove := func(removed string) int64 {
+		var size int64
+		for visiblePath, at := range visible {
+			if visiblePath == removed || strings.HasPrefix(visiblePath, removed+"/") {
+				size += image.Layers[at.layer].Files[at.index].Size
+				waste(at)
+				delete(visible, visiblePath)
+			}
+		}
+		return size
+	}
+
+	for i, entries := range layers {
+		image.Layers = append(image.Layers, Layer{})
+		layer := &image.Layers[i]
+
+		// Whiteouts hide what is below them, so they apply first
+		for _, entry := range entries {
+			dir, name := path.Split(entry.path)
+			dir = strings.TrimSuffix(dir, "/")
+			switch {
+			case name == opaqueWhiteout:
+				// Paths are relative to the root, so one at the root hides everything
+				for visiblePath, at := range visible {
+					if dir == "" || strings.HasPrefix(visiblePath, dir+"/") {
+						file := image.Layers[at.layer].Files[at.index]
+						layer.Files = append(layer.Files, File{Path: visiblePath, Size: file.Size, Dir: file.Dir, Change: Removed})
+						waste(at)
+						delete(visible, visiblePath)
+					}
+				}
+			case strings.HasPrefix(name, whiteoutPrefix):
+				removed := path.Join(dir, strings.TrimPrefix(name, whiteoutPrefix))
+				at, ok := visible[removed]
+				if !ok {
+					continue
+				}
+				file := image.Layers[at.layer].Files[at.index]
+				layer.Files = append(layer.Files, File{Path: removed, Size: remove(removed), Dir: file.Dir, Change: Removed})
+			}
+		}
+
+		for _, entry := range entries {
+			if strings.HasPrefix(path.Base(entry.path), whiteoutPrefix) {
+				continue
+			}
+			change := Added
+			if at, ok := visible[entry.path]; ok {
+				previous := image.Layers[at.layer].Files[at.index]
+				if previous.Dir && entry.dir {
+					// Parent directories are repeated in every layer writing below them
+					continue
+				}
+				change = Modified
+				if previous.Dir {
+					remove(entry.path)
+				} else {
+					waste(at)
+				}
+			}
+			visible[entry.path] = location{layer: i, index: len(layer.Files)}
+			layer.Files = append(layer.Files, File{Path: entry.path, Size: entry.size, Dir: entry.dir, Change: change})
+			layer.Size += entry.size
+		}
+		image.Size += layer.Size
+	}
+
+	// Sorting moves files, so the locations of visible ones are not used after
+	for i := range image.Layers {
+		slices.SortStableFunc(image.Layers[i].Files, func(a, b File) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+	for _, total := range wasted {
+		image.Wasted = append(image.Wasted, *total)
+	}
+	slices.SortFunc(image.Wasted, func(a, b WastedFile) int {
+		return cmp.Or(cmp.Compare(b.Size, a.Size), strings.Compare(a.Path, b.Path))
+	})
+	return image
+}
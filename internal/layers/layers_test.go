@@ -0,0 +1,231 @@
+package layers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// testFile is a path written to a test layer; a negative size makes it a
+// directory.
+type testFile struct {
+	path string
+	size int64
+}
+
+func layerTar(t *testing.T, compressed bool, files ...testFile) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	var gzipped *gzip.Writer
+	writer := tar.NewWriter(&out)
+	if compressed {
+		gzipped = gzip.NewWriter(&out)
+		writer = tar.NewWriter(gzipped)
+	}
+	for _, file := range files {
+		header := &tar.Header{Name: file.path, Mode: 0o644, Size: file.size, Typeflag: tar.TypeReg}
+		if file.size < 0 {
+			header = &tar.Header{Name: file.path + "/", Mode: 0o755, Typeflag: tar.TypeDir}
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write(make([]byte, max(file.size, 0))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gzipped != nil {
+		if err := gzipped.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return out.Bytes()
+}
+
+// imageArchive writes a docker save archive of the given layers, with the
+// manifest first or last as the OCI and legacy layouts do.
+func imageArchive(t *testing.T, manifestFirst bool, layerNames []string, layers [][]byte) []byte {
+	t.Helper()
+	config, err := json.Marshal(map[string]any{
+		"rootfs": map[string]any{"type": "layers", "diff_ids": []string{"sha256:one", "sha256:two", "sha256:three"}},
+		"history": []map[string]any{
+			{"created_by": "ADD rootfs.tar /"},
+			{"created_by": "ENV MODE=production", "empty_layer": true},
+			{"created_by": "RUN build"},
+			{"created_by": "COPY etc /etc"},
+			{"created_by": `CMD ["app"]`, "empty_layer": true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := json.Marshal([]map[string]any{{"Config": "config.json", "RepoTags": []string{"app:1"}, "Layers": layerNames}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	writer := tar.NewWriter(&out)
+	write := func(name string, data []byte) {
+		if err := writer.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if manifestFirst {
+		write("manifest.json", manifest)
+	}
+	write("VERSION", []byte("1.0"))
+	write("config.json", config)
+	for i, layer := range layers {
+		write(layerNames[i], layer)
+	}
+	if !manifestFirst {
+		write("manifest.json", manifest)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func testLayers(t *testing.T) [][]byte {
+	return [][]byte{
+		layerTar(t, false,
+			testFile{"etc", -1},
+			testFile{"etc/config", 100},
+			testFile{"bin", -1},
+			testFile{"bin/app", 1000},
+			testFile{"tmp", -1},
+			testFile{"tmp/cache", 500},
+		),
+		layerTar(t, false,
+			testFile{"bin", -1},
+			testFile{"bin/app", 1200},
+			testFile{"tmp/.wh.cache", 0},
+		),
+		layerTar(t, true,
+			testFile{"etc", -1},
+			testFile{"etc/.wh..wh..opq", 0},
+			testFile{"etc/new", 10},
+		),
+	}
+}
+
+func TestReadListsWhatEachLayerChanges(t *testing.T) {
+	// Digests and steps that left no layer must not shift commands onto the wrong layer
+	archive := imageArchive(t, false, []string{"one/layer.tar", "two/layer.tar", "three/layer.tar"}, testLayers(t))
+	image, err := Read(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if len(image.Layers) != 3 {
+		t.Fatalf("expected 3 layers, got %d", len(image.Layers))
+	}
+
+	want := []struct {
+		digest, command string
+		size            int64
+		files           []string
+	}{
+		{"sha256:one", "ADD rootfs.tar /", 1600, []string{"bin added", "bin/app added wasted", "etc added", "etc/config added wasted", "tmp added", "tmp/cache added wasted"}},
+		{"sha256:two", "RUN build", 1200, []string{"bin/app modified", "tmp/cache removed"}},
+		{"sha256:three", "COPY etc /etc", 10, []string{"etc/config removed", "etc/new added"}},
+	}
+	for i, layer := range image.Layers {
+		var files []string
+		for _, file := range layer.Files {
+			description := file.Path + " " + file.Change.String()
+			if file.Wasted {
+				description += " wasted"
+			}
+			files = append(files, description)
+		}
+		if layer.Digest != want[i].digest || layer.Command != want[i].command || layer.Size != want[i].size {
+			t.Errorf("layer %d: got %s %q of %d bytes, want %s %q of %d", i, layer.Digest, layer.Command, layer.Size, want[i].digest, want[i].command, want[i].size)
+		}
+		if strings.Join(files, ", ") != strings.Join(want[i].files, ", ") {
+			t.Errorf("layer %d: got files %v, want %v", i, files, want[i].files)
+		}
+	}
+
+	if image.Size != 2810 || image.WastedSize != 1600 {
+		t.Errorf("expected 1600 of 2810 bytes wasted, got %d of %d", image.WastedSize, image.Size)
+	}
+	if efficiency := image.Efficiency(); efficiency < 0.43 || efficiency > 0.44 {
+		t.Errorf("expected an efficiency of about 0.43, got %f", efficiency)
+	}
+	if len(image.Wasted) != 3 || image.Wasted[0].Path != "bin/app" || image.Wasted[0].Size != 1000 {
+		t.Errorf("expected wasted files largest first, got %+v", image.Wasted)
+	}
+}
+
+func TestReadOCILayout(t *testing.T) {
+	names := []string{"blobs/sha256/aaa", "blobs/sha256/bbb", "blobs/sha256/ccc"}
+	image, err := Read(bytes.NewReader(imageArchive(t, true, names, testLayers(t))))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if len(image.Layers) != 3 || image.WastedSize != 1600 {
+		t.Errorf("expected the same layers as the legacy layout, got %+v", image)
+	}
+}
+
+func TestReadOpaqueWhiteoutAtTheRoot(t *testing.T) {
+	layers := testLayers(t)
+	layers[2] = layerTar(t, false,
+		testFile{".wh..wh..opq", 0},
+		testFile{"app", 10},
+	)
+	image, err := Read(bytes.NewReader(imageArchive(t, false, []string{"one/layer.tar", "two/layer.tar", "three/layer.tar"}, layers)))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	var files []string
+	for _, file := range image.Layers[2].Files {
+		files = append(files, file.Path+" "+file.Change.String())
+	}
+	if want := "app added, bin removed, bin/app removed, etc removed, etc/config removed, tmp removed"; strings.Join(files, ", ") != want {
+		t.Errorf("got files %v, want %s", files, want)
+	}
+	if image.Size != 2810 || image.WastedSize != 2800 {
+		t.Errorf("expected 2800 of 2810 bytes wasted, got %d of %d", image.WastedSize, image.Size)
+	}
+}
+
+func TestReadRejectsArchivesWithoutManifest(t *testing.T) {
+	var out bytes.Buffer
+	writer := tar.NewWriter(&out)
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(&out); err == nil {
+		t.Error("expected an error for an archive without a manifest")
+	}
+}
+
+func TestTreeNestsFilesUnderDirectories(t *testing.T) {
+	files := []File{{Path: "etc/nginx/nginx.conf"}, {Path: "usr/bin", Dir: true}, {Path: "usr/bin/nginx"}}
+	root := Tree(files)
+
+	if len(root.Children) != 2 || root.Children[0].Name != "etc" || root.Children[0].File != nil {
+		t.Fatalf("expected etc as a directory implied by its files, got %+v", root.Children)
+	}
+	config := root.Children[0].Children[0].Children[0]
+	if config.Name != "nginx.conf" || config.File != &files[0] {
+		t.Errorf("expected etc/nginx/nginx.conf to point at its file, got %+v", config)
+	}
+	usr := root.Children[1]
+	if usr.Children[0].File != &files[1] || usr.Children[0].Children[0].Name != "nginx" {
+		t.Errorf("expected usr/bin to hold nginx, got %+v", usr.Children[0])
+	}
+}
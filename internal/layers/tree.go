@@ -0,0 +1,38 @@
+package layers
+
+import "strings"
+
+// Node is a path in a file tree. A node without a file is a directory the
+// layer only writes below.
+type Node struct {
+	Name     string
+	File     *File
+	Children []*Node
+}
+
+// Tree arranges files in path order into a tree under an unnamed root.
+func Tree(files []File) *Node {
+	root := &Node{}
+	nodes := map[string]*Node{"": root}
+
+	var place func(path string) *Node
+	place = func(path string) *Node {
+		if node, ok := nodes[path]; ok {
+			return node
+		}
+		parent, name := "", path
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			parent, name = path[:i], path[i+1:]
+		}
+		node := &Node{Name: name}
+		parentNode := place(parent)
+		parentNode.Children = append(parentNode.Children, node)
+		nodes[path] = node
+		return node
+	}
+
+	for i := range files {
+		place(files[i].Path).File = &files[i]
+	}
+	return root
+}
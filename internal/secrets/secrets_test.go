@@ -0,0 +1,134 @@
+package secrets
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/config"
+)
+
+func TestIsSecret(t *testing.T) {
+	d, err := New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	tests := []struct {
+		name, value string
+		secret      bool
+	}{
+		{"POSTGRES_PASSWORD", "hunter2", true},
+		{"AWS_SECRET_ACCESS_KEY", "abc", true},
+		{"GITHUB_TOKEN", "abc", true},
+		{"db.passwd", "abc", true},
+		{"NPM_AUTH", "abc", true},
+		{"POSTGRES_PASSWORD_FILE", "/run/secrets/db", false},
+		{"POSTGRES_PASSWORD", "", false},
+		{"PATH", "/usr/local/bin:/usr/bin", false},
+		{"org.opencontainers.image.authors", "someone@example.com", false},
+		{"STRIPE", "sk_test_4eC39HqLyjWDarjtT1zdp7dc", true},
+		{"AWS", "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY", true},
+		{"REVISION", "9f86d081884c7d659a2f00ea0bd5b7a3f7c0d6b6e8d7b3f8e8f1b4c2a9d0e1f3", false},
+		{"VERSION", "1.25.3-alpine3.20-bookworm-slim", false},
+		{"HOST", "my-service-12.default.svc.cluster.local", false},
+		{"GREETING", "Hello World From The Container 42", false},
+	}
+
+	for _, tt := range tests {
+		if got := d.IsSecret(tt.name, tt.value); got != tt.secret {
+			t.Errorf("IsSecret(%q, %q) = %v, want %v", tt.name, tt.value, got, tt.secret)
+		}
+	}
+}
+
+func TestConfiguredRules(t *testing.T) {
+	d, err := New(config.SecretsConfig{
+		Patterns: []string{`^stripe_`},
+		Ignore:   []string{`^public_token$`},
+		Entropy:  -1,
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if !d.IsSecret("STRIPE_ACCOUNT", "acct_1") {
+		t.Error("expected the configured pattern to mask STRIPE_ACCOUNT")
+	}
+	if d.IsSecret("PUBLIC_TOKEN", "abc") {
+		t.Error("expected the ignore pattern to override the built-in token pattern")
+	}
+	if d.IsSecret("AWS", "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY") {
+		t.Error("expected a negative entropy to turn the entropy check off")
+	}
+
+	if _, err := New(config.SecretsConfig{Patterns: []string{"("}}); err == nil {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+}
+
+func TestDisabledAndNilDetectorsMaskNothing(t *testing.T) {
+	disabled, err := New(config.SecretsConfig{Disabled: true})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	env := []string{"PASSWORD=hunter2"}
+	for _, d := range []*Detector{disabled, nil} {
+		if got := d.Env(env); !slices.Equal(got, env) {
+			t.Errorf("Env(%v) = %v, want it unchanged", env, got)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	d, err := New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	tests := map[string]string{
+		"postgres://app:hunter2@db:5432/app":   "postgres://app:" + Mask + "@db:5432/app",
+		"redis://cache:6379":                   "redis://cache:6379",
+		"server --db-password=hunter2 --debug": "server --db-password=" + Mask + " --debug",
+		`export API_KEY="a b" && run`:          "export API_KEY=" + Mask + " && run",
+		"--log-level=info":                     "--log-level=info",
+	}
+	for input, want := range tests {
+		if got := d.Text(input); got != want {
+			t.Errorf("Text(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestDocument(t *testing.T) {
+	d, err := New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	id := "9f86d081884c7d659a2f00ea0bd5b7a3f7c0d6b6e8d7b3f8e8f1b4c2a9d0e1f3"
+	document := map[string]any{
+		"Id": id,
+		"Config": map[string]any{
+			"Env":    []any{"PATH=/usr/bin", "DB_PASSWORD=hunter2", "DATABASE_URL=mysql://root:hunter2@db/app"},
+			"Labels": map[string]any{"deploy.token": "abc", "team": "payments", "sentry": "sk_test_4eC39HqLyjWDarjtT1zdp7dc"},
+		},
+		"Password": "hunter2",
+	}
+
+	masked := d.Document(document).(map[string]any)
+	cfg := masked["Config"].(map[string]any)
+	wantEnv := []any{"PATH=/usr/bin", "DB_PASSWORD=" + Mask, "DATABASE_URL=mysql://root:" + Mask + "@db/app"}
+	if env := cfg["Env"].([]any); !slices.Equal(env, wantEnv) {
+		t.Errorf("Env = %v, want %v", env, wantEnv)
+	}
+	labels := cfg["Labels"].(map[string]any)
+	if labels["deploy.token"] != Mask || labels["sentry"] != Mask || labels["team"] != "payments" {
+		t.Errorf("Labels = %v, want the token and the random value masked", labels)
+	}
+	if masked["Id"] != id {
+		t.Errorf("Id = %v, want it left alone", masked["Id"])
+	}
+	if masked["Password"] != Mask {
+		t.Errorf("Password = %v, want it masked", masked["Password"])
+	}
+}
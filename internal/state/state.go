@@ -2,18 +2,34 @@
 package state
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
 	"sync"
 
 	"github.com/givensuman/containertui/internal/backend"
 	dockerbackend "github.com/givensuman/containertui/internal/backend/docker"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/backend/recording"
 	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/connection"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/registry"
 )
 
 var (
-	// Shared backend instance
+	// Shared backend instance and the endpoints it connects to. Several
+	// endpoints are aggregated into one multi-host backend.
 	backendInstance backend.Backend
+	endpoints       = []dockerbackend.Endpoint{{}}
 	backendMu       sync.Mutex
+	// backendFixed is set when the backend was provided with UseBackend and
+	// must not be replaced by reconnects or context switches
+	backendFixed bool
+
+	// recordingSession, when set, records every backend created from now on
+	recordingSession *recording.Session
 
 	// Shared registry client instances
 	registryClient     *registry.Client
@@ -30,27 +46,176 @@ var (
 		Height int
 	}
 
-	// Ensures backend is only initialized once
-	clientOnce sync.Once
+	// Shared background job manager
+	jobManager *jobs.Manager
+	jobsMu     sync.Mutex
+
+	// Shared daemon connection supervisor
+	connectionSupervisor *connection.Supervisor
+	connectionMu         sync.Mutex
 )
 
 // InitializeClient initializes the shared backend and registry client instances.
+// It is a no-op once a backend has been created, but may be retried after a failure.
 func InitializeClient() error {
-	var err error
-	clientOnce.Do(func() {
-		backendMu.Lock()
-		defer backendMu.Unlock()
+	backendMu.Lock()
+	defer backendMu.Unlock()
+
+	if backendInstance != nil {
+		return nil
+	}
+
+	b, err := newBackend(endpoints)
+	if err != nil {
+		return err
+	}
+	backendInstance = b
+	registryClient = registry.NewClient()
+	quayRegistryClient = registry.NewQuayClient()
+	return nil
+}
 
-		var b *dockerbackend.DockerBackend
-		b, err = dockerbackend.New()
+// newBackend creates a backend for a single endpoint, or a multi-host
+// backend named after each endpoint. A host that cannot be created is kept
+// as failing so the others remain usable.
+func newBackend(es []dockerbackend.Endpoint) (backend.Backend, error) {
+	if len(es) == 1 {
+		b, err := dockerbackend.NewForEndpoint(es[0])
 		if err != nil {
-			return
+			return nil, err
 		}
-		backendInstance = b
-		registryClient = registry.NewClient()
-		quayRegistryClient = registry.NewQuayClient()
-	})
-	return err
+		return record(b), nil
+	}
+
+	hosts := make([]multi.Host, len(es))
+	usable := false
+	var firstErr error
+	for i, e := range es {
+		b, err := dockerbackend.NewForEndpoint(e)
+		hosts[i] = multi.Host{Name: e.Name(), Err: err}
+		if err != nil {
+			firstErr = cmp.Or(firstErr, err)
+			continue
+		}
+		hosts[i].Backend = b
+		usable = true
+	}
+	if !usable {
+		return nil, firstErr
+	}
+	return record(multi.New(hosts)), nil
+}
+
+// record wraps b in the recording session, if there is one.
+func record(b backend.Backend) backend.Backend {
+	if recordingSession == nil {
+		return b
+	}
+	return recordingSession.Wrap(b)
+}
+
+// RecordTo records the calls of every backend created after it is called to
+// session, across reconnects and context switches.
+func RecordTo(session *recording.Session) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	recordingSession = session
+}
+
+// UseBackend installs b as the shared backend instead of connecting to a
+// daemon, e.g. for demo mode. It is never reconnected or switched.
+func UseBackend(b backend.Backend) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	backendInstance = record(b)
+	backendFixed = true
+	registryClient = registry.NewClient()
+	quayRegistryClient = registry.NewQuayClient()
+}
+
+// ReconnectClient replaces the shared backend with a freshly created one,
+// closing the previous instance.
+func ReconnectClient() error {
+	backendMu.Lock()
+	fixed := backendFixed
+	backendMu.Unlock()
+	if fixed {
+		return nil
+	}
+	return SwitchEndpoints(GetEndpoints())
+}
+
+// SetEndpoint sets the daemon endpoint used by InitializeClient.
+func SetEndpoint(e dockerbackend.Endpoint) {
+	SetEndpoints([]dockerbackend.Endpoint{e})
+}
+
+// SetEndpoints sets the daemon endpoints used by InitializeClient. More than
+// one endpoint connects to all of them at once.
+func SetEndpoints(es []dockerbackend.Endpoint) {
+	if len(es) == 0 {
+		es = []dockerbackend.Endpoint{{}}
+	}
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	endpoints = slices.Clone(es)
+}
+
+// GetEndpoint returns the daemon endpoint of the shared backend, or the
+// first one when several hosts are aggregated.
+func GetEndpoint() dockerbackend.Endpoint {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	return endpoints[0]
+}
+
+// GetEndpoints returns every daemon endpoint of the shared backend.
+func GetEndpoints() []dockerbackend.Endpoint {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	return slices.Clone(endpoints)
+}
+
+// SwitchContext rebuilds the shared backend for the named Docker context.
+func SwitchContext(name string) error {
+	e, err := dockerbackend.EndpointForContext(name)
+	if err != nil {
+		return err
+	}
+	return SwitchEndpoint(e)
+}
+
+// SwitchEndpoint tears down the shared backend and rebuilds it against e.
+// The previous backend is kept if the new one cannot be created.
+func SwitchEndpoint(e dockerbackend.Endpoint) error {
+	return SwitchEndpoints([]dockerbackend.Endpoint{e})
+}
+
+// SwitchEndpoints tears down the shared backend and rebuilds it against es.
+// The previous backend is kept if the new one cannot be created.
+func SwitchEndpoints(es []dockerbackend.Endpoint) error {
+	backendMu.Lock()
+	fixed := backendFixed
+	backendMu.Unlock()
+	if fixed {
+		return errors.New("the backend cannot be switched in this mode")
+	}
+
+	b, err := newBackend(es)
+	if err != nil {
+		return err
+	}
+
+	backendMu.Lock()
+	previous := backendInstance
+	backendInstance = b
+	endpoints = slices.Clone(es)
+	backendMu.Unlock()
+
+	if previous != nil {
+		_ = previous.Close()
+	}
+	return nil
 }
 
 // GetBackend returns the shared backend instance.
@@ -98,6 +263,69 @@ func GetConfig() *config.Config {
 	return configInstance
 }
 
+// GetJobs returns the shared background job manager, creating it on first
+// use with the concurrency limit from the config.
+func GetJobs() *jobs.Manager {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	if jobManager == nil {
+		limit := 0
+		if cfg := GetConfig(); cfg != nil {
+			limit = cfg.MaxConcurrentJobs
+		}
+		jobManager = jobs.NewManager(limit)
+	}
+	return jobManager
+}
+
+// GetConnection returns the shared daemon connection supervisor, creating it
+// on first use. Callers start it with Run.
+func GetConnection() *connection.Supervisor {
+	connectionMu.Lock()
+	defer connectionMu.Unlock()
+	if connectionSupervisor == nil {
+		connectionSupervisor = connection.NewSupervisor(backendTarget{})
+	}
+	return connectionSupervisor
+}
+
+// backendTarget exposes the shared backend to the connection supervisor.
+type backendTarget struct{}
+
+func (backendTarget) Ping(ctx context.Context) error {
+	b := GetBackend()
+	if b == nil {
+		return errors.New("no container backend initialized")
+	}
+	return b.Ping(ctx)
+}
+
+func (backendTarget) Reconnect() error {
+	return ReconnectClient()
+}
+
+func (backendTarget) Describe(ctx context.Context) (string, string) {
+	b := GetBackend()
+	if b == nil {
+		return "", ""
+	}
+	return b.Name(), b.Version(ctx)
+}
+
+// OperationContext returns a context derived from parent that is bounded by
+// the configured timeout for kind.
+func OperationContext(parent context.Context, kind config.OperationKind) (context.Context, context.CancelFunc) {
+	timeouts := config.DefaultTimeouts()
+	if cfg := GetConfig(); cfg != nil {
+		timeouts = cfg.Timeouts
+	}
+
+	if timeout := timeouts.For(kind); timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
 // SetWindowSize sets the current window size.
 func SetWindowSize(width, height int) {
 	windowSize.mu.Lock()
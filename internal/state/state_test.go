@@ -1,8 +1,11 @@
 package state
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/givensuman/containertui/internal/backend/fake"
 	"github.com/givensuman/containertui/internal/config"
 )
 
@@ -13,3 +16,59 @@ func TestSetConfig(t *testing.T) {
 		t.Error("SetConfig did not set the config correctly")
 	}
 }
+
+func TestGetJobsUsesConfiguredLimit(t *testing.T) {
+	SetConfig(&config.Config{MaxConcurrentJobs: 5})
+	jobManager = nil
+	t.Cleanup(func() { jobManager = nil })
+
+	manager := GetJobs()
+	if manager.Limit() != 5 {
+		t.Fatalf("expected job limit 5, got %d", manager.Limit())
+	}
+	if GetJobs() != manager {
+		t.Fatal("expected GetJobs to return the shared manager")
+	}
+}
+
+func TestOperationContextAppliesConfiguredTimeout(t *testing.T) {
+	SetConfig(&config.Config{Timeouts: config.TimeoutConfig{Action: time.Minute, Pull: -1}})
+
+	ctx, cancel := OperationContext(context.Background(), config.OperationAction)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected action context to have a deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Fatalf("expected deadline within a minute, got %s", remaining)
+	}
+
+	pullCtx, cancelPull := OperationContext(context.Background(), config.OperationPull)
+	defer cancelPull()
+	if _, ok := pullCtx.Deadline(); ok {
+		t.Fatal("expected disabled pull timeout to have no deadline")
+	}
+}
+
+func TestUseBackendIsNeverSwitched(t *testing.T) {
+	t.Cleanup(func() {
+		backendInstance = nil
+		backendFixed = false
+	})
+
+	demo := fake.NewDemo()
+	UseBackend(demo)
+	if GetBackend() != demo {
+		t.Fatal("expected UseBackend to install the backend")
+	}
+	if err := ReconnectClient(); err != nil {
+		t.Fatalf("expected reconnect to be a no-op, got %v", err)
+	}
+	if err := SwitchContext("default"); err == nil {
+		t.Fatal("expected switching away from a fixed backend to fail")
+	}
+	if GetBackend() != demo {
+		t.Fatal("expected the fixed backend to be kept")
+	}
+}
@@ -1,5 +1,10 @@
 package base
 
+import (
+	"github.com/givensuman/containertui/internal/connection"
+	"github.com/givensuman/containertui/internal/jobs"
+)
+
 // WindowSize holds the current terminal dimensions for a UI model.
 type WindowSize struct {
 	WindowWidth  int
@@ -101,3 +106,23 @@ type MsgResourceChanged struct {
 
 // MsgRestoreScroll is sent to restore scroll position after content is set.
 type MsgRestoreScroll struct{}
+
+// MsgJobsUpdated is broadcast to every tab whenever a background job changes.
+// Finished holds the jobs that reached a terminal state since the last update,
+// so the tab that submitted a job can react to its outcome exactly once.
+type MsgJobsUpdated struct {
+	Jobs     []jobs.Job
+	Finished []jobs.Job
+}
+
+// MsgConnectionChanged is broadcast whenever the daemon connection state changes.
+type MsgConnectionChanged struct {
+	State connection.State
+}
+
+// MsgContextSwitched is broadcast after the shared backend was rebuilt for
+// another Docker context.
+type MsgContextSwitched struct {
+	Context string
+	Err     error
+}
@@ -14,11 +14,13 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/atotto/clipboard"
 	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/registry"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 )
 
@@ -56,8 +58,8 @@ func newKeybindings() *keybindings {
 			key.WithHelp("ctrl+a", "toggle selection of all"),
 		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "5"),
-			key.WithHelp("1-5", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "switch tab"),
 		),
 	}
 }
@@ -86,24 +88,6 @@ type Model struct {
 
 	// Scroll position memory
 	scrollPositions map[string]int
-
-	// Pull state
-	isPulling      bool
-	progressChan   <-chan string
-	currentPulling string // Name of image currently being pulled
-	pendingPulls   []pullTarget
-	batchPullTotal int
-	batchPulled    int
-
-	// Pull progress tracking
-	pullLayers     map[string]pullLayerProgress
-	pullPercent    float64
-	progressDialog *components.ProgressDialog
-}
-
-type pullLayerProgress struct {
-	current int64
-	total   int64
 }
 
 type pullTarget struct {
@@ -152,19 +136,15 @@ func New() Model {
 
 	// Add custom keybindings to help
 	resourceView.AdditionalHelp = additionalHelpBindings(browseKeybindings)
+	resourceView.MutatingKeys = []key.Binding{browseKeybindings.pull}
 
 	return Model{
 		ResourceView:       *resourceView,
 		keybindings:        browseKeybindings,
 		detailsKeybindings: components.NewDetailsKeybindings(),
 		scrollPositions:    make(map[string]int),
-		isPulling:          false,
 		currentSearchQuery: "",
 		currentRegistry:    registryDockerHub,
-		pendingPulls:       nil,
-		batchPullTotal:     0,
-		batchPulled:        0,
-		pullLayers:         make(map[string]pullLayerProgress),
 	}
 }
 
@@ -231,64 +211,20 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case MsgRestoreScroll:
 		model.restoreScrollPosition()
 
-	case MsgPullProgress:
-		var progressCmd tea.Cmd
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			progressDialog.SetStatus(parsePullStatusMessage(msg.Status))
-			if percent, hasPercent := model.estimatePullProgress(msg.Status); hasPercent {
-				progressCmd = progressDialog.SetPercent(percent)
-			}
-			model.Foreground = progressDialog
-		}
-		// Continue listening to the progress channel
-		if msg.ProgressChan != nil && msg.DoneChan != nil {
-			return model, tea.Batch(progressCmd, listenToProgressChannelWithDone(msg.ImageName, msg.ProgressChan, msg.DoneChan))
-		}
-		return model, progressCmd
+	case base.MsgJobsUpdated:
+		cmds = append(cmds, model.handleFinishedJobs(msg.Finished))
 
 	case MsgPullComplete:
-		model.isPulling = false
-		model.progressChan = nil
-		model.progressDialog = nil
-		model.pullLayers = make(map[string]pullLayerProgress)
-		model.pullPercent = 0
-
-		// Close the progress overlay
-		model.CloseOverlay()
-
 		// Stop the spinner for the pulled image
-		if model.currentPulling != "" {
-			model.setWorkingState([]string{model.currentPulling}, false)
-			model.currentPulling = ""
-		}
+		model.setWorkingState([]string{msg.ImageName}, false)
 
 		if msg.Err != nil {
-			model.pendingPulls = nil
-			model.batchPullTotal = 0
-			model.batchPulled = 0
 			return model, notifications.ShowError(fmt.Errorf("pull failed: %w", msg.Err))
 		}
 
-		model.batchPulled++
-		if len(model.pendingPulls) > 0 {
-			next := model.pendingPulls[0]
-			model.pendingPulls = model.pendingPulls[1:]
-			return model, tea.Batch(
-				notifications.ShowInfo(fmt.Sprintf("Pulled %s (%d/%d)", msg.ImageName, model.batchPulled, model.batchPullTotal)),
-				model.startPull(next),
-			)
-		}
-
-		successMsg := fmt.Sprintf("Pulled %s successfully", msg.ImageName)
-		if model.batchPullTotal > 1 {
-			successMsg = fmt.Sprintf("Pulled %d images successfully", model.batchPullTotal)
-		}
-		model.batchPullTotal = 0
-		model.batchPulled = 0
-
 		// Send message to refresh Images tab
 		return model, tea.Batch(
-			notifications.ShowSuccess(successMsg),
+			notifications.ShowSuccess(fmt.Sprintf("Pulled %s successfully", msg.ImageName)),
 			func() tea.Msg {
 				return base.MsgImagePulled{ImageName: msg.ImageName}
 			},
@@ -310,10 +246,11 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					return model, nil
 				}
 
-				model.batchPullTotal = len(payload)
-				model.batchPulled = 0
-				model.pendingPulls = append([]pullTarget(nil), payload[1:]...)
-				return model, model.startPull(payload[0])
+				pullCmds := make([]tea.Cmd, 0, len(payload))
+				for _, target := range payload {
+					pullCmds = append(pullCmds, model.startPull(target))
+				}
+				return model, tea.Batch(pullCmds...)
 
 			case "SearchRegistry":
 				// Extract query from form values
@@ -579,34 +516,37 @@ func (model *Model) startPull(target pullTarget) tea.Cmd {
 	registryName := normalizeRegistry(target.Registry)
 
 	spinnerCmd := model.setWorkingState([]string{imageName}, true)
-
-	model.isPulling = true
-	model.currentPulling = imageName
-	model.pullLayers = make(map[string]pullLayerProgress)
-	model.pullPercent = 0
-
-	progressDialog := components.NewProgressDialogWithBar("Pulling " + imageName)
-	model.progressDialog = &progressDialog
-	model.SetOverlay(progressDialog)
-
-	progressChan := make(chan string, 100)
-	doneChan := make(chan error, 1)
-	model.progressChan = progressChan
-
-	go func() {
-		ctx := stdcontext.Background()
-		err := state.GetBackend().PullImage(ctx, imageName, progressChan)
-		doneChan <- err
-		close(doneChan)
-	}()
+	title := fmt.Sprintf("Pull %s from %s", imageName, displayRegistryName(registryName))
+	_, submitCmd := jobpanel.Submit(title, jobKindPull, jobpanel.PullImage(imageName, parsePullStatusMessage))
 
 	return tea.Batch(
 		spinnerCmd,
-		notifications.ShowInfo(fmt.Sprintf("Pulling from %s", displayRegistryName(registryName))),
-		listenToProgressChannelWithDone(imageName, progressChan, doneChan),
+		submitCmd,
 	)
 }
 
+// handleFinishedJobs translates finished pull jobs into completion messages.
+func (model *Model) handleFinishedJobs(finished []jobs.Job) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range finished {
+		if job.Kind != jobKindPull {
+			continue
+		}
+
+		imageName, _ := job.Result.(string)
+		if job.Status == jobs.Cancelled {
+			model.setWorkingState([]string{imageName}, false)
+			cmds = append(cmds, jobpanel.CancelledNotice(job))
+			continue
+		}
+
+		cmds = append(cmds, func() tea.Msg {
+			return MsgPullComplete{ImageName: imageName, Err: job.Err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
 // handleToggleSelection toggles selection of the current item.
 func (model *Model) handleToggleSelection() {
 	selectedItem := model.GetSelectedItem()
@@ -757,65 +697,6 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-func (model *Model) estimatePullProgress(raw string) (float64, bool) {
-	layerID, current, total, ok := parsePullLayerProgress(raw)
-	if !ok {
-		return 0, false
-	}
-
-	model.pullLayers[layerID] = pullLayerProgress{current: current, total: total}
-
-	var sumCurrent int64
-	var sumTotal int64
-	for _, layer := range model.pullLayers {
-		sumCurrent += layer.current
-		sumTotal += layer.total
-	}
-
-	if sumTotal <= 0 {
-		return 0, false
-	}
-
-	percent := float64(sumCurrent) / float64(sumTotal)
-	if percent > 0.98 {
-		percent = 0.98
-	}
-	if percent < model.pullPercent {
-		percent = model.pullPercent
-	}
-
-	model.pullPercent = percent
-	return percent, true
-}
-
-func parsePullLayerProgress(raw string) (string, int64, int64, bool) {
-	type pullProgressDetail struct {
-		Current int64 `json:"current"`
-		Total   int64 `json:"total"`
-	}
-	type pullStatus struct {
-		ID             string             `json:"id"`
-		ProgressDetail pullProgressDetail `json:"progressDetail"`
-	}
-
-	var status pullStatus
-	if err := json.Unmarshal([]byte(raw), &status); err != nil {
-		return "", 0, 0, false
-	}
-	if status.ID == "" || status.ProgressDetail.Total <= 0 {
-		return "", 0, 0, false
-	}
-
-	current := status.ProgressDetail.Current
-	if current < 0 {
-		current = 0
-	}
-	if current > status.ProgressDetail.Total {
-		current = status.ProgressDetail.Total
-	}
-	return status.ID, current, status.ProgressDetail.Total, true
-}
-
 // parsePullStatusMessage extracts a human-readable status string from raw pull JSON.
 func parsePullStatusMessage(raw string) string {
 	type pullMsg struct {
@@ -839,37 +720,6 @@ func min(a, b int) int {
 	return b
 }
 
-func listenToProgressChannelWithDone(imageName string, progressChan <-chan string, doneChan <-chan error) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case status, ok := <-progressChan:
-			if !ok {
-				// Progress channel closed, check for completion error
-				err := <-doneChan
-				return MsgPullComplete{
-					ImageName: imageName,
-					Err:       err,
-				}
-			}
-
-			// Regular progress update
-			return MsgPullProgress{
-				ImageName:    imageName,
-				Status:       status,
-				ProgressChan: progressChan,
-				DoneChan:     doneChan,
-			}
-
-		case err := <-doneChan:
-			// Completion received before progress channel closed
-			return MsgPullComplete{
-				ImageName: imageName,
-				Err:       err,
-			}
-		}
-	}
-}
-
 // ShortHelp returns keybindings to be shown in the mini help view.
 func (model Model) ShortHelp() []key.Binding {
 	// If detail pane is focused, show detail keybindings
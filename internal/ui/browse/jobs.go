@@ -0,0 +1,4 @@
+package browse
+
+// jobKindPull identifies pull jobs submitted by the browse tab.
+const jobKindPull = "browse/pull"
@@ -27,14 +27,6 @@ type MsgImageInspection struct {
 	Err      error
 }
 
-// MsgPullProgress provides progress updates during image pull.
-type MsgPullProgress struct {
-	ImageName    string
-	Status       string
-	ProgressChan <-chan string
-	DoneChan     <-chan error // Channel to signal completion with error status
-}
-
 // MsgPullComplete indicates pull operation completed.
 type MsgPullComplete struct {
 	ImageName string
@@ -2,25 +2,38 @@
 package components
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"github.com/atotto/clipboard"
+	"github.com/givensuman/containertui/internal/secrets"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 )
 
 // DetailsPanel handles common details panel functionality including:
-// - Format toggling (JSON/YAML)
+// - Format cycling (summary/YAML/JSON/tree)
 // - Clipboard copying
+// - Secret masking and revealing
 // - Scroll position management
 // - Content inspection display
 type DetailsPanel struct {
 	currentFormat   string
 	scrollPositions map[string]int
-	currentID       string // Current resource ID being displayed
+	currentID       string    // Current resource ID being displayed
+	revealedUntil   time.Time // When revealed secrets are masked again
 }
 
+// revealDuration is how long secrets stay revealed.
+const revealDuration = 30 * time.Second
+
+// MsgRevealExpired is sent when revealed secrets are due to be masked again.
+type MsgRevealExpired struct{}
+
 // NewDetailsPanel creates a new details panel.
 func NewDetailsPanel() DetailsPanel {
 	return DetailsPanel{
@@ -30,7 +43,7 @@ func NewDetailsPanel() DetailsPanel {
 	}
 }
 
-// GetCurrentFormat returns the current format (json or yaml).
+// GetCurrentFormat returns the current format (summary, yaml, json or tree).
 func (dp *DetailsPanel) GetCurrentFormat() string {
 	if dp.currentFormat == "" {
 		cfg := state.GetConfig()
@@ -79,27 +92,35 @@ func (dp *DetailsPanel) RestoreScrollPosition(vp *viewport.Model) {
 	}
 }
 
-// HandleToggleFormat toggles between JSON and YAML format.
-// Returns the new format and a notification command.
-func (dp *DetailsPanel) HandleToggleFormat() (string, tea.Cmd) {
-	currentFormat := dp.GetCurrentFormat()
+// detailsFormats are the formats the details panel cycles through, in order.
+var detailsFormats = []string{"summary", "yaml", "json", "tree"}
 
-	if currentFormat == "json" {
-		dp.currentFormat = "yaml"
-	} else {
-		dp.currentFormat = "json"
+// HandleToggleFormat switches to the next of the summary, YAML, JSON and
+// tree formats. Returns the new format and a notification command.
+func (dp *DetailsPanel) HandleToggleFormat() (string, tea.Cmd) {
+	next := 0
+	if i := slices.Index(detailsFormats, dp.GetCurrentFormat()); i >= 0 {
+		next = (i + 1) % len(detailsFormats)
 	}
+	dp.currentFormat = detailsFormats[next]
 
 	return dp.currentFormat, notifications.ShowSuccess("Switched to " + dp.currentFormat)
 }
 
-// HandleCopyToClipboard copies the inspection data to clipboard.
+// HandleCopyToClipboard copies the inspection data to clipboard, as YAML
+// when the summary is shown, with secrets masked unless they are revealed.
+// The tree copies its own nodes, so nothing is copied in tree format.
 // Returns a notification command (success or error).
 func (dp *DetailsPanel) HandleCopyToClipboard(data any) tea.Cmd {
-	if data == nil {
+	if data == nil || dp.IsTreeFormat() {
 		return nil
 	}
 
+	data, err := infopanel.MaskSecrets(data, dp.secretRules())
+	if err != nil {
+		return notifications.ShowError(err)
+	}
+
 	var format infopanel.OutputFormat
 	if dp.GetCurrentFormat() == "json" {
 		format = infopanel.FormatJSON
@@ -119,11 +140,59 @@ func (dp *DetailsPanel) HandleCopyToClipboard(data any) tea.Cmd {
 	return notifications.ShowSuccess("Copied to clipboard")
 }
 
+// SecretsRevealed reports whether secret values are shown in clear text.
+func (dp *DetailsPanel) SecretsRevealed() bool {
+	return time.Now().Before(dp.revealedUntil)
+}
+
+// secretRules returns the rules secrets are masked with, or nil while they
+// are revealed.
+func (dp *DetailsPanel) secretRules() *secrets.Detector {
+	if dp.SecretsRevealed() {
+		return nil
+	}
+	return secrets.Current()
+}
+
+// HandleRevealSecrets reveals secret values for a short while, or masks
+// them again if they are revealed. The returned command sends
+// MsgRevealExpired when the reveal runs out.
+func (dp *DetailsPanel) HandleRevealSecrets() tea.Cmd {
+	if dp.SecretsRevealed() {
+		dp.revealedUntil = time.Time{}
+		return notifications.ShowSuccess("Secrets masked")
+	}
+
+	dp.revealedUntil = time.Now().Add(revealDuration)
+	return tea.Batch(
+		notifications.ShowSuccess(fmt.Sprintf("Secrets revealed for %s", revealDuration)),
+		tea.Tick(revealDuration, func(time.Time) tea.Msg { return MsgRevealExpired{} }),
+	)
+}
+
+// ExpireReveal masks secrets again once their reveal has run out. It
+// reports whether the panel showed them, so its content must be rebuilt.
+func (dp *DetailsPanel) ExpireReveal() bool {
+	if dp.revealedUntil.IsZero() || dp.SecretsRevealed() {
+		return false
+	}
+	dp.revealedUntil = time.Time{}
+	return true
+}
+
+// IsTreeFormat reports whether the data is shown as an interactive tree,
+// with ResourceView.ShowInspectTree, rather than built as text.
+func (dp *DetailsPanel) IsTreeFormat() bool {
+	return dp.GetCurrentFormat() == "tree"
+}
+
 // GetFormatForDisplay returns the infopanel format constant for the current format.
 func (dp *DetailsPanel) GetFormatForDisplay() infopanel.OutputFormat {
-	currentFormat := dp.GetCurrentFormat()
-	if currentFormat == "json" {
+	switch dp.GetCurrentFormat() {
+	case "json":
 		return infopanel.FormatJSON
+	case "summary":
+		return infopanel.FormatSummary
 	}
 	return infopanel.FormatYAML
 }
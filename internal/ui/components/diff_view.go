@@ -0,0 +1,106 @@
+package components
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/layout"
+)
+
+// DiffView is an overlay comparing two resources. Its content is rendered
+// for the overlay's width, scrolls with the usual keys, and esc or q close
+// it.
+type DiffView struct {
+	title    string
+	render   func(width int) string
+	viewport viewport.Model
+	style    lipgloss.Style
+	width    int
+	height   int
+}
+
+var (
+	_ fmt.Stringer = (*DiffView)(nil)
+)
+
+// NewDiffView creates a diff overlay whose content render draws at a given
+// width.
+func NewDiffView(title string, render func(width int) string) DiffView {
+	width, height := state.GetWindowSize()
+	view := DiffView{
+		title:    title,
+		render:   render,
+		viewport: viewport.New(),
+		width:    width,
+		height:   height,
+	}
+	view.updateStyle()
+	return view
+}
+
+// updateStyle sizes the overlay to the window and renders its content for
+// the new width.
+func (view *DiffView) updateStyle() {
+	view.style = lipgloss.NewStyle().
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	dimensions := layout.NewLayoutManager(view.width, view.height).CalculateLargeOverlay(view.style)
+	view.style = view.style.Width(dimensions.Width).Height(dimensions.Height)
+
+	// The title and help lines take two rows of the content
+	view.viewport.SetWidth(max(dimensions.ContentWidth, 1))
+	view.viewport.SetHeight(max(dimensions.ContentHeight-2, 1))
+	view.viewport.SetContent(view.render(view.viewport.Width()))
+}
+
+func (view *DiffView) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	view.width = msg.Width
+	view.height = msg.Height
+	view.updateStyle()
+}
+
+func (view DiffView) Init() tea.Cmd {
+	return nil
+}
+
+func (view DiffView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		view.UpdateWindowDimensions(msg)
+		return view, nil
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return view, func() tea.Msg { return base.CloseDialogMessage{} }
+		}
+	}
+
+	var cmd tea.Cmd
+	view.viewport, cmd = view.viewport.Update(msg)
+	return view, cmd
+}
+
+func (view DiffView) View() tea.View {
+	return tea.NewView(view.String())
+}
+
+func (view DiffView) String() string {
+	title := lipgloss.NewStyle().Foreground(colors.Primary()).Bold(true).Render(view.title)
+	help := lipgloss.NewStyle().Foreground(colors.Muted()).Render(
+		fmt.Sprintf("↑/↓ scroll • esc close • %3.f%%", view.viewport.ScrollPercent()*100))
+
+	return view.style.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		view.viewport.View(),
+		help,
+	))
+}
@@ -7,18 +7,36 @@ import (
 
 	"github.com/givensuman/containertui/internal/backend"
 	"github.com/givensuman/containertui/internal/registry"
+	"github.com/givensuman/containertui/internal/secrets"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel"
 )
 
-// BuildContainerPanel builds a raw inspection panel for a container.
-func BuildContainerPanel(container backend.ContainerDetail, width int, expandEnv bool, format infopanel.OutputFormat) string {
+// secretRules returns the rules secret values are masked with, or nil when
+// they are revealed.
+func secretRules(reveal bool) *secrets.Detector {
+	if reveal {
+		return nil
+	}
+	return secrets.Current()
+}
+
+// BuildContainerPanel builds an inspection panel for a container as a summary
+// or raw YAML/JSON output, with secrets masked unless reveal is set.
+func BuildContainerPanel(container backend.ContainerDetail, width int, reveal bool, format infopanel.OutputFormat) string {
 	// If format is empty, use default from config
 	if format == "" {
 		format = infopanel.GetOutputFormat()
 	}
+	if format == infopanel.FormatSummary {
+		return containerSummary(container, width, secretRules(reveal))
+	}
 
 	// Marshal the container data
-	rawData, err := infopanel.MarshalToFormat(container, format)
+	data, err := infopanel.MaskSecrets(container, secretRules(reveal))
+	if err != nil {
+		return fmt.Sprintf("Error marshaling data: %v", err)
+	}
+	rawData, err := infopanel.MarshalToFormat(data, format)
 	if err != nil {
 		return fmt.Sprintf("Error marshaling data: %v", err)
 	}
@@ -36,6 +54,26 @@ func BuildContainerPanel(container backend.ContainerDetail, width int, expandEnv
 	return rendered
 }
 
+// BuildExportPanel builds a panel previewing a generated compose file or
+// Kubernetes manifest.
+func BuildExportPanel(content []byte, width int) string {
+	rendered, err := infopanel.WrapInMarkdownCodeBlock(string(content), infopanel.FormatYAML, width)
+	if err != nil {
+		return infopanel.ColorizeYAML(string(content))
+	}
+	return rendered
+}
+
+// BuildDockerfilePanel builds a panel previewing a Dockerfile reconstructed
+// from an image's history.
+func BuildDockerfilePanel(content []byte, width int) string {
+	rendered, err := infopanel.RenderMarkdown(fmt.Sprintf("```dockerfile\n%s\n```", content), width)
+	if err != nil {
+		return string(content)
+	}
+	return rendered
+}
+
 // BuildBrowsePanel builds a panel for Docker Hub registry image details.
 func BuildBrowsePanel(detail registry.RegistryImageDetail, width int) string {
 	// Get description
@@ -72,15 +110,23 @@ func normalizeReadmeWhitespace(text string) string {
 	return strings.TrimSpace(joined)
 }
 
-// BuildImagePanel builds an informational panel for an image with raw YAML/JSON output.
-func BuildImagePanel(image backend.ImageDetail, width int, format infopanel.OutputFormat) string {
+// BuildImagePanel builds an informational panel for an image as a summary or
+// raw YAML/JSON output, with secrets masked unless reveal is set.
+func BuildImagePanel(image backend.ImageDetail, width int, reveal bool, format infopanel.OutputFormat) string {
 	// If format is empty, use default from config
 	if format == "" {
 		format = infopanel.GetOutputFormat()
 	}
+	if format == infopanel.FormatSummary {
+		return imageSummary(image, width, secretRules(reveal))
+	}
 
 	// Marshal the image data
-	rawData, err := infopanel.MarshalToFormat(image, format)
+	data, err := infopanel.MaskSecrets(image, secretRules(reveal))
+	if err != nil {
+		return fmt.Sprintf("Error marshaling data: %v", err)
+	}
+	rawData, err := infopanel.MarshalToFormat(data, format)
 	if err != nil {
 		return fmt.Sprintf("Error marshaling data: %v", err)
 	}
@@ -98,15 +144,23 @@ func BuildImagePanel(image backend.ImageDetail, width int, format infopanel.Outp
 	return rendered
 }
 
-// BuildNetworkPanel builds an informational panel for a network with raw YAML/JSON output.
-func BuildNetworkPanel(network backend.NetworkDetail, width int, format infopanel.OutputFormat) string {
+// BuildNetworkPanel builds an informational panel for a network as a summary
+// or raw YAML/JSON output, with secrets masked unless reveal is set.
+func BuildNetworkPanel(network backend.NetworkDetail, width int, reveal bool, format infopanel.OutputFormat) string {
 	// If format is empty, use default from config
 	if format == "" {
 		format = infopanel.GetOutputFormat()
 	}
+	if format == infopanel.FormatSummary {
+		return networkSummary(network, width, secretRules(reveal))
+	}
 
 	// Marshal the network data
-	rawData, err := infopanel.MarshalToFormat(network, format)
+	data, err := infopanel.MaskSecrets(network, secretRules(reveal))
+	if err != nil {
+		return fmt.Sprintf("Error marshaling data: %v", err)
+	}
+	rawData, err := infopanel.MarshalToFormat(data, format)
 	if err != nil {
 		return fmt.Sprintf("Error marshaling data: %v", err)
 	}
@@ -124,15 +178,23 @@ func BuildNetworkPanel(network backend.NetworkDetail, width int, format infopane
 	return rendered
 }
 
-// BuildVolumePanel builds an informational panel for a volume with raw YAML/JSON output.
-func BuildVolumePanel(vol backend.VolumeDetail, width int, format infopanel.OutputFormat) string {
+// BuildVolumePanel builds an informational panel for a volume as a summary or
+// raw YAML/JSON output, with secrets masked unless reveal is set.
+func BuildVolumePanel(vol backend.VolumeDetail, width int, reveal bool, format infopanel.OutputFormat) string {
 	// If format is empty, use default from config
 	if format == "" {
 		format = infopanel.GetOutputFormat()
 	}
+	if format == infopanel.FormatSummary {
+		return volumeSummary(vol, width, secretRules(reveal))
+	}
 
 	// Marshal the volume data
-	rawData, err := infopanel.MarshalToFormat(vol, format)
+	data, err := infopanel.MaskSecrets(vol, secretRules(reveal))
+	if err != nil {
+		return fmt.Sprintf("Error marshaling data: %v", err)
+	}
+	rawData, err := infopanel.MarshalToFormat(data, format)
 	if err != nil {
 		return fmt.Sprintf("Error marshaling data: %v", err)
 	}
@@ -150,12 +212,16 @@ func BuildVolumePanel(vol backend.VolumeDetail, width int, format infopanel.Outp
 	return rendered
 }
 
-// BuildServicePanel builds an informational panel for a service with raw YAML/JSON output.
+// BuildServicePanel builds an informational panel for a service as a summary
+// or raw YAML/JSON output.
 func BuildServicePanel(service backend.Service, width int, showFullCompose bool, format infopanel.OutputFormat) string {
 	// If format is empty, use default from config
 	if format == "" {
 		format = infopanel.GetOutputFormat()
 	}
+	if format == infopanel.FormatSummary {
+		return serviceSummary(service, width)
+	}
 
 	// Marshal the service data
 	rawData, err := infopanel.MarshalToFormat(service, format)
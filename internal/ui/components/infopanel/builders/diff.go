@@ -0,0 +1,78 @@
+package builders
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+)
+
+// diffGap separates the two columns of a diff.
+const diffGap = " │ "
+
+// BuildDiffPanel compares two inspect documents side by side, showing only
+// the values that differ, with secrets masked unless reveal is set. The
+// backend's raw document is left out, since it repeats the rest.
+func BuildDiffPanel(leftName, rightName string, left, right any, width int, reveal bool) string {
+	documents := make([]any, 2)
+	for i, data := range []any{left, right} {
+		normalized, err := infopanel.Normalize(data)
+		if err != nil {
+			return fmt.Sprintf("Error marshaling data: %v", err)
+		}
+		if fields, ok := normalized.(map[string]any); ok {
+			delete(fields, "Raw")
+		}
+		documents[i] = normalized
+	}
+
+	// Secrets are masked after comparing, so that differing ones still show
+	differences := infopanel.Diff(documents[0], documents[1])
+	infopanel.MaskDifferences(differences, secretRules(reveal))
+	return renderDiff(leftName, rightName, differences, width)
+}
+
+func renderDiff(leftName, rightName string, differences []infopanel.Difference, width int) string {
+	columnWidth := max((width-len([]rune(diffGap)))/2, 10)
+	column := lipgloss.NewStyle().Width(columnWidth)
+	gap := lipgloss.NewStyle().Foreground(colors.Border()).Render(diffGap)
+	muted := lipgloss.NewStyle().Foreground(colors.Muted())
+	removed := lipgloss.NewStyle().Foreground(colors.Error())
+	added := lipgloss.NewStyle().Foreground(colors.Success())
+	heading := lipgloss.NewStyle().Foreground(colors.Primary()).Bold(true)
+
+	// row lays two columns side by side with the gap running their height
+	row := func(left, right string) string {
+		lines := max(lipgloss.Height(left), lipgloss.Height(right))
+		return lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Repeat(gap+"\n", lines-1)+gap, right)
+	}
+	side := func(value any, present bool, style lipgloss.Style) string {
+		if !present {
+			return column.Render(muted.Render("(not set)"))
+		}
+		return column.Render(style.Render(infopanel.FormatValue(value)))
+	}
+
+	var out strings.Builder
+	out.WriteString(row(column.Render(heading.Render(leftName)), column.Render(heading.Render(rightName))))
+	out.WriteString("\n")
+	switch len(differences) {
+	case 0:
+		out.WriteString(muted.Render("No differences"))
+		return out.String()
+	case 1:
+		out.WriteString(muted.Render("1 difference"))
+	default:
+		out.WriteString(muted.Render(fmt.Sprintf("%d differences", len(differences))))
+	}
+
+	for _, difference := range differences {
+		out.WriteString("\n\n")
+		out.WriteString(lipgloss.NewStyle().Bold(true).Render(difference.Path.String()))
+		out.WriteString("\n")
+		out.WriteString(row(side(difference.Left, difference.InLeft, removed), side(difference.Right, difference.InRight, added)))
+	}
+	return out.String()
+}
@@ -0,0 +1,363 @@
+package builders
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/secrets"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+)
+
+// labelWidth is the width of the field labels in a summary.
+const labelWidth = 12
+
+// summary builds a readable panel out of sections of labelled fields and
+// plain lines.
+type summary struct {
+	out   strings.Builder
+	width int
+}
+
+func newSummary(width int) *summary {
+	return &summary{width: max(width, labelWidth+10)}
+}
+
+// heading starts the summary with the resource's name and a muted
+// subtitle such as its short ID.
+func (s *summary) heading(title, subtitle string) {
+	s.out.WriteString(lipgloss.NewStyle().Foreground(colors.Primary()).Bold(true).Render(title))
+	if subtitle != "" {
+		s.out.WriteString("  " + lipgloss.NewStyle().Foreground(colors.Muted()).Render(subtitle))
+	}
+	s.out.WriteString("\n")
+}
+
+// section starts a titled group of fields or lines.
+func (s *summary) section(title string) {
+	s.out.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render(title) + "\n")
+}
+
+// field writes a labelled value, wrapping it under itself. Empty values are
+// left out.
+func (s *summary) field(label, value string) {
+	if value == "" {
+		return
+	}
+	wrapped := strings.Split(ansi.Wrap(value, s.width-labelWidth, ""), "\n")
+	s.out.WriteString(lipgloss.NewStyle().Foreground(colors.Muted()).Width(labelWidth).Render(label) + wrapped[0] + "\n")
+	for _, line := range wrapped[1:] {
+		s.out.WriteString(strings.Repeat(" ", labelWidth) + line + "\n")
+	}
+}
+
+// lines writes a titled section with one entry per line, or nothing when
+// there are no entries.
+func (s *summary) lines(title string, entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+	s.section(title)
+	for _, entry := range entries {
+		s.out.WriteString(ansi.Wrap(entry, s.width, "") + "\n")
+	}
+}
+
+func (s *summary) String() string {
+	return strings.TrimRight(s.out.String(), "\n")
+}
+
+// containerSummary renders the state, image, ports, mounts, networks,
+// environment and labels of a container, masking secrets with rules.
+func containerSummary(container backend.ContainerDetail, width int, rules *secrets.Detector) string {
+	s := newSummary(width)
+	s.heading(strings.TrimPrefix(container.Name, "/"), infopanel.TruncateID(container.ID))
+
+	s.section("State")
+	s.field("State", styledState(container))
+	lifecycle := container.Lifecycle
+	switch container.State {
+	case "running", "paused":
+		if !lifecycle.StartedAt.IsZero() {
+			s.field("Uptime", infopanel.FormatDuration(time.Since(lifecycle.StartedAt)))
+		}
+	case "exited", "dead":
+		exit := strconv.Itoa(lifecycle.ExitCode)
+		if !lifecycle.FinishedAt.IsZero() {
+			exit += ", " + infopanel.FormatTimeAgo(lifecycle.FinishedAt)
+		}
+		s.field("Exit code", exit)
+	}
+	s.field("Health", lifecycle.Health)
+	s.field("Restart", restartPolicy(container.HostConfig.RestartPolicy))
+	if !container.Created.IsZero() {
+		s.field("Created", infopanel.FormatTimeAgo(container.Created))
+	}
+
+	s.section("Image")
+	s.field("Image", cmp.Or(container.Config.Image, container.Image))
+	s.field("Command", rules.Text(commandLine(container.Config.Entrypoint, container.Config.Cmd)))
+	s.field("Workdir", container.Config.WorkingDir)
+	s.field("User", container.Config.User)
+
+	s.lines("Ports", containerPorts(container))
+	s.lines("Mounts", containerMounts(container.Mounts))
+	s.lines("Networks", containerNetworks(container.NetworkSettings.Networks))
+	s.lines("Environment", rules.Env(container.Config.Env))
+	s.lines("Labels", keyValues(rules.Map(container.Labels)))
+	return s.String()
+}
+
+// imageSummary renders the tags, size, platform, default command and labels
+// of an image, masking secrets with rules.
+func imageSummary(image backend.ImageDetail, width int, rules *secrets.Detector) string {
+	s := newSummary(width)
+	title := infopanel.TruncateID(image.ID)
+	if len(image.RepoTags) > 0 {
+		title = image.RepoTags[0]
+	}
+	s.heading(title, infopanel.TruncateID(image.ID))
+
+	s.section("Image")
+	s.field("Tags", strings.Join(image.RepoTags, ", "))
+	s.field("Size", infopanel.FormatBytes(cmp.Or(image.Size, image.Image.Size)))
+	if !image.Created.IsZero() {
+		s.field("Created", infopanel.FormatTimeAgo(image.Created))
+	}
+	if image.Os != "" || image.Architecture != "" {
+		s.field("Platform", strings.Trim(image.Os+"/"+image.Architecture, "/"))
+	}
+	if len(image.RootFS.Layers) > 0 {
+		s.field("Layers", strconv.Itoa(len(image.RootFS.Layers)))
+	}
+	s.field("Author", image.Author)
+
+	s.section("Defaults")
+	s.field("Command", rules.Text(commandLine(image.Config.Entrypoint, image.Config.Cmd)))
+	s.field("Workdir", image.Config.WorkingDir)
+	s.field("User", image.Config.User)
+	s.field("Ports", strings.Join(sortPorts(slices.Collect(maps.Keys(image.Config.ExposedPorts))), ", "))
+
+	s.lines("Environment", rules.Env(image.Config.Env))
+	s.lines("Labels", keyValues(rules.Map(image.Labels)))
+	return s.String()
+}
+
+// volumeSummary renders the driver, mountpoint, options and labels of a
+// volume, masking secrets with rules.
+func volumeSummary(volume backend.VolumeDetail, width int, rules *secrets.Detector) string {
+	s := newSummary(width)
+	s.heading(volume.Name, "")
+
+	s.section("Volume")
+	s.field("Driver", volume.Driver)
+	s.field("Scope", volume.Scope)
+	s.field("Mountpoint", volume.Mountpoint)
+	if !volume.CreatedAt.IsZero() {
+		s.field("Created", infopanel.FormatTimeAgo(volume.CreatedAt))
+	}
+
+	s.lines("Options", keyValues(rules.Map(volume.Options)))
+	s.lines("Labels", keyValues(rules.Map(volume.Labels)))
+	return s.String()
+}
+
+// networkSummary renders the driver, subnets, attached containers, options
+// and labels of a network, masking secrets with rules.
+func networkSummary(network backend.NetworkDetail, width int, rules *secrets.Detector) string {
+	s := newSummary(width)
+	s.heading(network.Name, infopanel.TruncateID(network.ID))
+
+	s.section("Network")
+	s.field("Driver", network.Driver)
+	s.field("Scope", network.Scope)
+	var flags []string
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"internal", network.Internal},
+		{"attachable", network.Attachable},
+		{"ingress", network.Ingress},
+		{"IPv6", network.EnableIPv6},
+	} {
+		if flag.set {
+			flags = append(flags, flag.name)
+		}
+	}
+	s.field("Flags", strings.Join(flags, ", "))
+
+	var subnets []string
+	for _, config := range network.IPAM.Config {
+		subnet := config.Subnet
+		if config.Gateway != "" {
+			subnet += " via " + config.Gateway
+		}
+		subnets = append(subnets, subnet)
+	}
+	s.lines("Subnets", subnets)
+
+	var containers []string
+	for _, endpoint := range network.Containers {
+		containers = append(containers, strings.TrimSpace(endpoint.Name+"  "+cmp.Or(endpoint.IPv4Address, endpoint.IPv6Address)))
+	}
+	slices.Sort(containers)
+	s.lines("Containers", containers)
+
+	s.lines("Options", keyValues(rules.Map(network.Options)))
+	s.lines("Labels", keyValues(rules.Map(network.Labels)))
+	return s.String()
+}
+
+// serviceSummary renders a service's name and state.
+func serviceSummary(service backend.Service, width int) string {
+	s := newSummary(width)
+	s.heading(service.Name, infopanel.TruncateID(service.ID))
+	s.section("Service")
+	s.field("State", service.State)
+	return s.String()
+}
+
+// styledState colours a container's state by how healthy it is.
+func styledState(container backend.ContainerDetail) string {
+	switch {
+	case container.State == "running":
+		return lipgloss.NewStyle().Foreground(colors.Success()).Render(container.State)
+	case container.State == "dead", container.State == "exited" && container.Lifecycle.ExitCode != 0:
+		return lipgloss.NewStyle().Foreground(colors.Error()).Render(container.State)
+	}
+	return container.State
+}
+
+// restartPolicy describes a restart policy, or returns "" for the default of
+// never restarting.
+func restartPolicy(policy backend.RestartPolicy) string {
+	switch policy.Name {
+	case "", "no":
+		return ""
+	case "on-failure":
+		if policy.MaximumRetryCount > 0 {
+			return fmt.Sprintf("on-failure, up to %d times", policy.MaximumRetryCount)
+		}
+	}
+	return policy.Name
+}
+
+// commandLine joins an entrypoint and command the way a shell would show
+// them, quoting arguments with spaces.
+func commandLine(entrypoint, command []string) string {
+	args := slices.Concat(entrypoint, command)
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			args[i] = strconv.Quote(arg)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// containerPorts lists where each of a container's ports is published, such
+// as "8080 → 80/tcp", followed by the ports it only exposes.
+func containerPorts(container backend.ContainerDetail) []string {
+	bindings := container.NetworkSettings.Ports
+	if len(bindings) == 0 {
+		bindings = container.HostConfig.PortBindings
+	}
+	ports := slices.Collect(maps.Keys(bindings))
+	for port := range container.Config.ExposedPorts {
+		if _, ok := bindings[port]; !ok {
+			ports = append(ports, port)
+		}
+	}
+
+	var lines []string
+	for _, port := range sortPorts(ports) {
+		published := false
+		for _, binding := range bindings[port] {
+			host := binding.HostPort
+			// Bindings on every address are shown once, without the address
+			if binding.HostIP != "" && binding.HostIP != "0.0.0.0" && binding.HostIP != "::" {
+				host = binding.HostIP + ":" + host
+			}
+			line := host + " → " + port
+			if !slices.Contains(lines, line) {
+				lines = append(lines, line)
+			}
+			published = true
+		}
+		if !published {
+			lines = append(lines, port+" (not published)")
+		}
+	}
+	return lines
+}
+
+// sortPorts sorts ports such as "443/tcp" by number, then protocol.
+func sortPorts(ports []string) []string {
+	slices.SortFunc(ports, func(a, b string) int {
+		numberA, protocolA, _ := strings.Cut(a, "/")
+		numberB, protocolB, _ := strings.Cut(b, "/")
+		portA, _ := strconv.Atoi(numberA)
+		portB, _ := strconv.Atoi(numberB)
+		return cmp.Or(cmp.Compare(portA, portB), cmp.Compare(protocolA, protocolB))
+	})
+	return ports
+}
+
+// containerMounts describes each mount as "source → destination", noting
+// its type and whether it is read-only.
+func containerMounts(mounts []backend.Mount) []string {
+	lines := make([]string, 0, len(mounts))
+	for _, mount := range mounts {
+		notes := []string{}
+		if mount.Type != "" {
+			notes = append(notes, mount.Type)
+		}
+		if !mount.RW {
+			notes = append(notes, "read-only")
+		}
+		line := mount.Source + " → " + mount.Destination
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// containerNetworks lists the container's address and gateway on each of
+// its networks, in name order.
+func containerNetworks(networks map[string]backend.EndpointSettings) []string {
+	lines := make([]string, 0, len(networks))
+	for _, name := range slices.Sorted(maps.Keys(networks)) {
+		endpoint := networks[name]
+		line := name
+		if endpoint.IPAddress != "" {
+			line += "  " + endpoint.IPAddress
+			if endpoint.IPPrefixLen > 0 {
+				line += "/" + strconv.Itoa(endpoint.IPPrefixLen)
+			}
+		}
+		if endpoint.Gateway != "" {
+			line += " via " + endpoint.Gateway
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// keyValues lists a map as "key=value" lines in key order.
+func keyValues(values map[string]string) []string {
+	lines := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		lines = append(lines, key+"="+values[key])
+	}
+	return lines
+}
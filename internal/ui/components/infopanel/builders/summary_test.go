@@ -0,0 +1,82 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+)
+
+func TestContainerSummaryShowsTheEssentials(t *testing.T) {
+	container := backend.ContainerDetail{
+		Container: backend.Container{
+			ID:     "0123456789abcdef",
+			Name:   "/web",
+			State:  "exited",
+			Labels: map[string]string{"team": "payments"},
+		},
+		Lifecycle: backend.ContainerLifecycle{ExitCode: 137, FinishedAt: time.Now().Add(-2 * time.Hour)},
+		Config: backend.ContainerConfigDetail{
+			Image:        "nginx:latest",
+			Cmd:          []string{"nginx", "-g", "daemon off;"},
+			Env:          []string{"MODE=prod"},
+			ExposedPorts: map[string]struct{}{"80/tcp": {}, "443/tcp": {}},
+		},
+		NetworkSettings: backend.NetworkSettings{
+			Ports: map[string][]backend.PortBinding{
+				"80/tcp": {{HostIP: "0.0.0.0", HostPort: "8080"}, {HostIP: "::", HostPort: "8080"}},
+			},
+			Networks: map[string]backend.EndpointSettings{
+				"bridge": {IPAddress: "172.17.0.2", IPPrefixLen: 16, Gateway: "172.17.0.1"},
+			},
+		},
+		Mounts: []backend.Mount{{Type: "bind", Source: "/srv/www", Destination: "/usr/share/nginx/html"}},
+	}
+
+	got := ansi.Strip(BuildContainerPanel(container, 80, false, infopanel.FormatSummary))
+	for _, want := range []string{
+		"web  0123456789ab",
+		"137, 2 hours ago",
+		`nginx -g "daemon off;"`,
+		"8080 → 80/tcp\n443/tcp (not published)",
+		"/srv/www → /usr/share/nginx/html (bind, read-only)",
+		"bridge  172.17.0.2/16 via 172.17.0.1",
+		"MODE=prod",
+		"team=payments",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the summary:\n%s", want, got)
+		}
+	}
+}
+
+func TestSummaryLeavesOutEmptySections(t *testing.T) {
+	got := ansi.Strip(BuildVolumePanel(backend.VolumeDetail{Volume: backend.Volume{Name: "data", Driver: "local"}}, 80, false, infopanel.FormatSummary))
+	if strings.Contains(got, "Labels") || strings.Contains(got, "Options") || strings.Contains(got, "Created") {
+		t.Fatalf("expected only the volume's set fields, got:\n%s", got)
+	}
+	if !strings.Contains(got, "Driver      local") {
+		t.Fatalf("expected an aligned driver field, got:\n%s", got)
+	}
+}
+
+func TestPanelsMaskSecretsUnlessRevealed(t *testing.T) {
+	container := backend.ContainerDetail{
+		Container: backend.Container{ID: "0123456789abcdef", Name: "/db"},
+		Config:    backend.ContainerConfigDetail{Env: []string{"POSTGRES_PASSWORD=hunter2", "POSTGRES_USER=app"}},
+		Raw:       map[string]any{"Config": map[string]any{"Env": []string{"POSTGRES_PASSWORD=hunter2"}}},
+	}
+
+	for _, format := range []infopanel.OutputFormat{infopanel.FormatSummary, infopanel.FormatYAML, infopanel.FormatJSON} {
+		masked := BuildContainerPanel(container, 80, false, format)
+		if strings.Contains(masked, "hunter2") || !strings.Contains(masked, "POSTGRES_USER=app") {
+			t.Errorf("expected only the password masked in %s:\n%s", format, masked)
+		}
+		if revealed := BuildContainerPanel(container, 80, true, format); !strings.Contains(revealed, "hunter2") {
+			t.Errorf("expected the password revealed in %s:\n%s", format, revealed)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package infopanel
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Difference is a value that differs between two normalized documents. A
+// side the value is missing from has its In flag unset.
+type Difference struct {
+	Path    Path
+	Left    any
+	Right   any
+	InLeft  bool
+	InRight bool
+}
+
+// setKeys names the lists Diff compares as sets rather than by position,
+// with how their elements are keyed: variables by name, mounts by where
+// they are mounted, and the rest by their whole value.
+var setKeys = map[string]func(any) string{
+	"Env":         variableName,
+	"Mounts":      func(mount any) string { return elementKey(field(mount, "Destination")) },
+	"Ports":       elementKey,
+	"Binds":       elementKey,
+	"ExtraHosts":  elementKey,
+	"CapAdd":      elementKey,
+	"CapDrop":     elementKey,
+	"DNS":         elementKey,
+	"DNSOptions":  elementKey,
+	"DNSSearch":   elementKey,
+	"Aliases":     elementKey,
+	"RepoTags":    elementKey,
+	"RepoDigests": elementKey,
+}
+
+// Diff lists the values that differ between two documents normalized by
+// Normalize, in path order. Objects are compared key by key, the lists in
+// setKeys as sets keyed by element, and other lists by position.
+func Diff(left, right any) []Difference {
+	var differences []Difference
+	diff(nil, left, right, &differences)
+	return differences
+}
+
+func diff(path Path, left, right any, differences *[]Difference) {
+	if list, ok := left.([]any); ok && len(path) > 0 {
+		if other, ok := right.([]any); ok {
+			if keyOf, ok := setKeys[path[len(path)-1].Key]; ok {
+				left, right = keyedBy(list, keyOf), keyedBy(other, keyOf)
+			}
+		}
+	}
+
+	switch l := left.(type) {
+	case map[string]any:
+		r, ok := right.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := slices.Collect(maps.Keys(l))
+		for key := range r {
+			if _, ok := l[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			leftValue, inLeft := l[key]
+			rightValue, inRight := r[key]
+			if inLeft && inRight {
+				diff(path.Key(key), leftValue, rightValue, differences)
+				continue
+			}
+			*differences = append(*differences, Difference{
+				Path:    path.Key(key),
+				Left:    leftValue,
+				Right:   rightValue,
+				InLeft:  inLeft,
+				InRight: inRight,
+			})
+		}
+		return
+
+	case []any:
+		r, ok := right.([]any)
+		if !ok {
+			break
+		}
+		for i := range max(len(l), len(r)) {
+			switch {
+			case i < len(l) && i < len(r):
+				diff(path.Index(i), l[i], r[i], differences)
+			case i < len(l):
+				*differences = append(*differences, Difference{Path: path.Index(i), Left: l[i], InLeft: true})
+			default:
+				*differences = append(*differences, Difference{Path: path.Index(i), Right: r[i], InRight: true})
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(left, right) {
+		*differences = append(*differences, Difference{Path: path, Left: left, Right: right, InLeft: true, InRight: true})
+	}
+}
+
+// keyedBy turns a list into an object keyed by keyOf, numbering elements
+// whose keys repeat.
+func keyedBy(list []any, keyOf func(any) string) map[string]any {
+	keyed := make(map[string]any, len(list))
+	for _, element := range list {
+		key := keyOf(element)
+		for n := 2; ; n++ {
+			if _, taken := keyed[key]; !taken {
+				break
+			}
+			key = keyOf(element) + "#" + strconv.Itoa(n)
+		}
+		keyed[key] = element
+	}
+	return keyed
+}
+
+// variableName keys a NAME=value variable by its name, so that a changed
+// value shows as one difference rather than an addition and a removal.
+func variableName(variable any) string {
+	s, ok := variable.(string)
+	if !ok {
+		return elementKey(variable)
+	}
+	name, _, _ := strings.Cut(s, "=")
+	return name
+}
+
+// elementKey keys a string by itself and anything else by its JSON.
+func elementKey(element any) string {
+	if s, ok := element.(string); ok {
+		return s
+	}
+	encoded, _ := json.Marshal(element)
+	return string(encoded)
+}
+
+func field(object any, key string) any {
+	if fields, ok := object.(map[string]any); ok {
+		if value, ok := fields[key]; ok {
+			return value
+		}
+	}
+	return object
+}
+
+// FormatValue writes a normalized value on one line: strings as they are,
+// and objects and lists as JSON.
+func FormatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
@@ -0,0 +1,115 @@
+package infopanel
+
+import (
+	"testing"
+
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/secrets"
+)
+
+func TestDiffShowsOnlyDifferingValues(t *testing.T) {
+	left, err := Normalize(map[string]any{
+		"Name": "/web-1",
+		"Config": map[string]any{
+			"Image":  "shop/web:1.4",
+			"Env":    []string{"PORT=8080", "MODE=production", "DEBUG=0"},
+			"Cmd":    []string{"serve", "--fast"},
+			"Labels": map[string]string{"team": "shop"},
+		},
+		"HostConfig": map[string]any{"CapAdd": []string{"NET_ADMIN", "SYS_TIME"}},
+		"Mounts": []map[string]any{
+			{"Destination": "/data", "Source": "/srv/web-1"},
+			{"Destination": "/cache", "Source": "/tmp/cache"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := Normalize(map[string]any{
+		"Name": "/web-2",
+		"Config": map[string]any{
+			"Image":  "shop/web:1.4",
+			"Env":    []string{"DEBUG=0", "MODE=staging", "PORT=8080"},
+			"Cmd":    []string{"serve", "--safe"},
+			"Labels": map[string]string{"team": "shop", "canary": "true"},
+		},
+		"HostConfig": map[string]any{"CapAdd": []string{"SYS_TIME", "NET_ADMIN"}},
+		"Mounts": []map[string]any{
+			{"Destination": "/cache", "Source": "/tmp/cache"},
+			{"Destination": "/data", "Source": "/srv/web-2"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]Difference)
+	for _, difference := range Diff(left, right) {
+		got[difference.Path.String()] = difference
+	}
+
+	// Reordered sets compare equal, while the command compares by position
+	want := map[string][2]any{
+		".Config.Cmd[1]":          {"--fast", "--safe"},
+		".Config.Env.MODE":        {"MODE=production", "MODE=staging"},
+		".Config.Labels.canary":   {nil, "true"},
+		`.Mounts["/data"].Source`: {"/srv/web-1", "/srv/web-2"},
+		".Name":                   {"/web-1", "/web-2"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d differences, got %d: %+v", len(want), len(got), got)
+	}
+	for path, values := range want {
+		difference, ok := got[path]
+		if !ok {
+			t.Errorf("expected a difference at %s", path)
+			continue
+		}
+		if difference.Left != values[0] || difference.Right != values[1] {
+			t.Errorf("%s: got %v and %v, want %v and %v", path, difference.Left, difference.Right, values[0], values[1])
+		}
+	}
+	if canary := got[".Config.Labels.canary"]; canary.InLeft || !canary.InRight {
+		t.Errorf("expected the canary label only on the right, got %+v", canary)
+	}
+}
+
+func TestDiffNumbersRepeatedSetElements(t *testing.T) {
+	left := map[string]any{"RepoTags": []any{"web:1", "web:1"}}
+	right := map[string]any{"RepoTags": []any{"web:1"}}
+
+	differences := Diff(left, right)
+	if len(differences) != 1 || differences[0].Path.String() != `.RepoTags["web:1#2"]` || !differences[0].InLeft || differences[0].InRight {
+		t.Errorf("expected the repeated tag to show as only on the left, got %+v", differences)
+	}
+}
+
+func TestMaskDifferencesKeepsDifferingSecrets(t *testing.T) {
+	rules, err := secrets.New(config.SecretsConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	left := map[string]any{"Config": map[string]any{
+		"Env":    []any{"API_TOKEN=first"},
+		"Labels": map[string]any{"db.password": "hunter2"},
+	}}
+	right := map[string]any{"Config": map[string]any{
+		"Env":    []any{"API_TOKEN=second"},
+		"Labels": map[string]any{"db.password": "hunter3"},
+	}}
+
+	differences := Diff(left, right)
+	MaskDifferences(differences, rules)
+	if len(differences) != 2 {
+		t.Fatalf("expected both secrets to differ, got %+v", differences)
+	}
+	for _, difference := range differences {
+		want := secrets.Mask
+		if difference.Path.String() == ".Config.Env.API_TOKEN" {
+			want = "API_TOKEN=" + secrets.Mask
+		}
+		if difference.Left != want || difference.Right != want {
+			t.Errorf("%s: expected %q on both sides, got %v and %v", difference.Path, want, difference.Left, difference.Right)
+		}
+	}
+}
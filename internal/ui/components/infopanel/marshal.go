@@ -21,6 +21,9 @@ const (
 	FormatYAML OutputFormat = "yaml"
 	// FormatJSON represents JSON output format.
 	FormatJSON OutputFormat = "json"
+	// FormatSummary represents a readable overview of the most useful
+	// fields, rendered by the panel builders rather than marshalled.
+	FormatSummary OutputFormat = "summary"
 )
 
 // GetOutputFormat returns the configured output format for inspection data.
@@ -35,6 +38,8 @@ func GetOutputFormat() OutputFormat {
 	switch format {
 	case "json":
 		return FormatJSON
+	case "summary":
+		return FormatSummary
 	case "yaml", "":
 		return FormatYAML
 	default:
@@ -58,19 +63,11 @@ func MarshalToFormat(data any, format OutputFormat) (string, error) {
 // marshalToYAML converts data to YAML format.
 // Uses JSON as intermediate format to respect JSON struct tags.
 func marshalToYAML(data any) (string, error) {
-	// First marshal to JSON to respect JSON tags
-	jsonBytes, err := json.Marshal(data)
+	intermediate, err := Normalize(data)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
-	}
-
-	// Then unmarshal JSON to generic map/slice structure
-	var intermediate any
-	if err := json.Unmarshal(jsonBytes, &intermediate); err != nil {
-		return "", fmt.Errorf("failed to unmarshal JSON: %w", err)
+		return "", err
 	}
 
-	// Finally marshal to YAML with proper formatting
 	yamlBytes, err := yaml.Marshal(intermediate)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to YAML: %w", err)
@@ -0,0 +1,250 @@
+package infopanel
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Normalize converts data to the maps, slices and scalars encoding/json
+// decodes into, so that it has the keys MarshalToFormat prints.
+func Normalize(data any) (any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to JSON: %w", err)
+	}
+	var normalized any
+	if err := json.Unmarshal(jsonBytes, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return normalized, nil
+}
+
+// PathStep is one step of a Path: an object key, an array index, or every
+// element when Iterate is set.
+type PathStep struct {
+	Key     string
+	Index   int
+	IsIndex bool
+	Iterate bool
+}
+
+// Path is a jq-like path into normalized data, such as
+// .NetworkSettings.Networks[].IPAddress.
+type Path []PathStep
+
+// identifier matches keys that can be written as .Key.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParsePath parses a path made of .key, ."key", ["key"], [n] and [] steps.
+// An empty path or "." is the whole document.
+func ParsePath(expr string) (Path, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || expr == "." {
+		return nil, nil
+	}
+	if expr[0] != '.' && expr[0] != '[' {
+		return nil, fmt.Errorf("path must start with . or [")
+	}
+
+	var path Path
+	for rest := expr; rest != ""; {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			switch {
+			case rest == "" || rest[0] == '[':
+				// A lone dot before brackets, as in .[0]
+			case rest[0] == '"':
+				key, tail, err := cutQuoted(rest)
+				if err != nil {
+					return nil, err
+				}
+				path, rest = append(path, PathStep{Key: key}), tail
+			default:
+				end := strings.IndexAny(rest, ".[")
+				if end < 0 {
+					end = len(rest)
+				}
+				if !identifier.MatchString(rest[:end]) {
+					return nil, fmt.Errorf("invalid key %q (quote keys with other characters)", rest[:end])
+				}
+				path, rest = append(path, PathStep{Key: rest[:end]}), rest[end:]
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if rest[1:] != "" && rest[1] == '"' {
+				key, tail, err := cutQuoted(rest[1:])
+				if err != nil {
+					return nil, err
+				}
+				if !strings.HasPrefix(tail, "]") {
+					return nil, fmt.Errorf("expected ] after %q", key)
+				}
+				path, rest = append(path, PathStep{Key: key}), tail[1:]
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [")
+			}
+			inside := strings.TrimSpace(rest[1:end])
+			if inside == "" {
+				path = append(path, PathStep{Iterate: true})
+			} else {
+				index, err := strconv.Atoi(inside)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q", inside)
+				}
+				path = append(path, PathStep{Index: index, IsIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+	}
+	return path, nil
+}
+
+// cutQuoted reads the quoted string s starts with, returning it and what
+// follows it.
+func cutQuoted(s string) (string, string, error) {
+	prefix, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", fmt.Errorf("unterminated quote")
+	}
+	key, err := strconv.Unquote(prefix)
+	if err != nil {
+		return "", "", err
+	}
+	return key, s[len(prefix):], nil
+}
+
+// Eval returns the values the path selects from normalized data. Missing
+// keys and out of range indexes select null, as in jq.
+func (path Path) Eval(data any) ([]any, error) {
+	values := []any{data}
+	for _, step := range path {
+		var next []any
+		for _, value := range values {
+			selected, err := step.eval(value)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (step PathStep) eval(value any) ([]any, error) {
+	switch {
+	case step.Iterate:
+		switch value := value.(type) {
+		case []any:
+			return value, nil
+		case map[string]any:
+			values := make([]any, 0, len(value))
+			for _, key := range slices.Sorted(maps.Keys(value)) {
+				values = append(values, value[key])
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("cannot iterate over %s", typeName(value))
+	case step.IsIndex:
+		switch value := value.(type) {
+		case nil:
+			return []any{nil}, nil
+		case []any:
+			index := step.Index
+			if index < 0 {
+				index += len(value)
+			}
+			if index < 0 || index >= len(value) {
+				return []any{nil}, nil
+			}
+			return []any{value[index]}, nil
+		}
+		return nil, fmt.Errorf("cannot index %s with a number", typeName(value))
+	default:
+		switch value := value.(type) {
+		case nil:
+			return []any{nil}, nil
+		case map[string]any:
+			return []any{value[step.Key]}, nil
+		}
+		return nil, fmt.Errorf("cannot index %s with %q", typeName(value), step.Key)
+	}
+}
+
+// typeName names the JSON type of a normalized value for error messages.
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// Key returns the path extended by an object key.
+func (path Path) Key(key string) Path {
+	return append(slices.Clip(path), PathStep{Key: key})
+}
+
+// Index returns the path extended by an array index.
+func (path Path) Index(index int) Path {
+	return append(slices.Clip(path), PathStep{Index: index, IsIndex: true})
+}
+
+// String writes the path the way ParsePath reads it, or "." for the whole
+// document.
+func (path Path) String() string {
+	if len(path) == 0 {
+		return "."
+	}
+	return path.render(false)
+}
+
+// Suffix writes the path for appending to another expression, such as
+// "[0].Name" rather than ".[0].Name".
+func (path Path) Suffix() string {
+	return path.render(true)
+}
+
+func (path Path) render(prefixed bool) string {
+	var out strings.Builder
+	for _, step := range path {
+		switch {
+		case !step.IsIndex && !step.Iterate && identifier.MatchString(step.Key):
+			out.WriteString("." + step.Key)
+			prefixed = true
+			continue
+		case !prefixed:
+			out.WriteString(".")
+		}
+		switch {
+		case step.Iterate:
+			out.WriteString("[]")
+		case step.IsIndex:
+			out.WriteString("[" + strconv.Itoa(step.Index) + "]")
+		default:
+			out.WriteString("[" + strconv.Quote(step.Key) + "]")
+		}
+		prefixed = true
+	}
+	return out.String()
+}
@@ -0,0 +1,66 @@
+package infopanel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePathRoundTrips(t *testing.T) {
+	for _, expr := range []string{
+		".",
+		".NetworkSettings.Networks[].IPAddress",
+		`.Config.Labels["com.docker.compose.project"]`,
+		".Mounts[0].Source",
+		".[1]",
+		`.["odd key"][]`,
+	} {
+		path, err := ParsePath(expr)
+		if err != nil {
+			t.Fatalf("ParsePath(%q) failed: %v", expr, err)
+		}
+		if got := path.String(); got != expr {
+			t.Errorf("ParsePath(%q).String() = %q", expr, got)
+		}
+	}
+
+	for _, expr := range []string{"Config", ".a-b", ".a[", `.["a]`, ".a[x]"} {
+		if _, err := ParsePath(expr); err == nil {
+			t.Errorf("expected ParsePath(%q) to fail", expr)
+		}
+	}
+}
+
+func TestPathEvalSelectsLikeJq(t *testing.T) {
+	data, err := Normalize(map[string]any{
+		"NetworkSettings": map[string]any{"Networks": map[string]any{
+			"bridge":  map[string]any{"IPAddress": "172.17.0.2"},
+			"backend": map[string]any{"IPAddress": "10.0.0.5"},
+		}},
+		"Env": []string{"A=1", "B=2"},
+	})
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+
+	tests := map[string][]any{
+		".NetworkSettings.Networks[].IPAddress": {"10.0.0.5", "172.17.0.2"},
+		".Env[-1]":                              {"B=2"},
+		".Env[5]":                               {nil},
+		".Missing.Key":                          {nil},
+	}
+	for expr, want := range tests {
+		path, _ := ParsePath(expr)
+		got, err := path.Eval(data)
+		if err != nil {
+			t.Fatalf("Eval(%q) failed: %v", expr, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Eval(%q) = %v, want %v", expr, got, want)
+		}
+	}
+
+	path, _ := ParsePath(".Env.Name")
+	if _, err := path.Eval(data); err == nil {
+		t.Fatal("expected indexing an array with a key to fail")
+	}
+}
@@ -0,0 +1,51 @@
+package infopanel
+
+import "github.com/givensuman/containertui/internal/secrets"
+
+// MaskSecrets returns data normalized with its secret values masked by
+// rules, or data unchanged when rules is nil because secrets are revealed.
+func MaskSecrets(data any, rules *secrets.Detector) (any, error) {
+	if rules == nil {
+		return data, nil
+	}
+	normalized, err := Normalize(data)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Document(normalized), nil
+}
+
+// MaskDifferences masks the secret values of differences found by Diff in
+// place, as MaskSecrets would mask them in their documents, so that secrets
+// which differ still show as a difference. Nothing is masked when rules is
+// nil.
+func MaskDifferences(differences []Difference, rules *secrets.Detector) {
+	for i := range differences {
+		differences[i].Left = maskAt(differences[i].Path, differences[i].Left, rules)
+		differences[i].Right = maskAt(differences[i].Path, differences[i].Right, rules)
+	}
+}
+
+// maskAt masks a value found at path by putting it back under the key it
+// was found at, which decides how rules masks it.
+func maskAt(path Path, value any, rules *secrets.Detector) any {
+	if rules == nil || value == nil || len(path) == 0 {
+		return rules.Document(value)
+	}
+	last := path[len(path)-1]
+	parent := ""
+	if len(path) > 1 {
+		parent = path[len(path)-2].Key
+	}
+
+	// Elements of lists, and of the lists Diff keys as sets, are masked
+	// as list elements
+	if _, isSet := setKeys[parent]; last.IsIndex || isSet {
+		document := map[string]any{parent: []any{value}}
+		rules.Document(document)
+		return document[parent].([]any)[0]
+	}
+	document := map[string]any{parent: map[string]any{last.Key: value}}
+	rules.Document(document)
+	return document[parent].(map[string]any)[last.Key]
+}
@@ -0,0 +1,501 @@
+package components
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/secrets"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// inspectTree shows inspect data in the detail pane as a tree of
+// collapsible nodes, optionally narrowed down to what a jq-like path
+// selects.
+type inspectTree struct {
+	shown bool
+	data  any // the normalized document
+	// root is what the path selects from data; when the path selects
+	// several values, root is an array of them and multiple is set
+	root     any
+	multiple bool
+	path     infopanel.Path
+	// expanded holds the expanded nodes by their path from root
+	expanded map[string]bool
+	rows     []treeRow
+	cursor   int
+
+	input   textinput.Model
+	editing bool
+	// err is why the typed path does not parse, and evalErr why the last
+	// valid one cannot be applied to the document
+	err     error
+	evalErr error
+
+	up        key.Binding
+	down      key.Binding
+	expand    key.Binding
+	collapse  key.Binding
+	toggle    key.Binding
+	copyValue key.Binding
+	copyPath  key.Binding
+	open      key.Binding
+	apply     key.Binding
+	clear     key.Binding
+}
+
+// treeRow is a node of the tree as it is currently expanded.
+type treeRow struct {
+	path  infopanel.Path // from the tree's root
+	label string
+	value any
+	depth int
+}
+
+func newInspectTree() *inspectTree {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "path such as .Config.Env or .Mounts[].Source"
+
+	return &inspectTree{
+		expanded: map[string]bool{},
+		input:    input,
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		expand: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "expand"),
+		),
+		collapse: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "collapse"),
+		),
+		toggle: key.NewBinding(
+			key.WithKeys("enter", "space"),
+			key.WithHelp("enter", "expand/collapse"),
+		),
+		copyValue: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy value"),
+		),
+		copyPath: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy path"),
+		),
+		open: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "path"),
+		),
+		apply: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply path"),
+		),
+		clear: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear path"),
+		),
+	}
+}
+
+// ShowInspectTree shows data in the detail pane as an interactive tree, in
+// place of its content, with secrets masked unless reveal is set. Nodes
+// stay expanded when data is replaced, so the same fields can be compared
+// across resources.
+func (rv *ResourceView[ID, Item]) ShowInspectTree(data any, reveal bool) {
+	if rv.inspect == nil {
+		rv.inspect = newInspectTree()
+	}
+	t := rv.inspect
+	normalized, err := infopanel.Normalize(data)
+	if err != nil {
+		rv.SetContent(fmt.Sprintf("Error marshaling data: %v", err))
+		return
+	}
+	if !reveal {
+		normalized = secrets.Current().Document(normalized)
+	}
+	t.shown = true
+	t.data = normalized
+	t.evaluate(false)
+	rv.renderInspectTree()
+}
+
+// HideInspectTree stops showing the tree, leaving the detail pane to its
+// usual content.
+func (rv *ResourceView[ID, Item]) HideInspectTree() {
+	if rv.inspect != nil {
+		rv.inspect.shown = false
+		rv.inspect.editing = false
+		rv.inspect.input.Blur()
+	}
+}
+
+// IsInspectTreeShown reports whether the detail pane shows the tree.
+func (rv *ResourceView[ID, Item]) IsInspectTreeShown() bool {
+	return rv.inspect != nil && rv.inspect.shown
+}
+
+// isEditingInspectPath reports whether keys are going to the tree's path
+// prompt.
+func (rv *ResourceView[ID, Item]) isEditingInspectPath() bool {
+	return rv.IsInspectTreeShown() && rv.inspect.editing
+}
+
+// InspectTreeHelp returns the tree's bindings for the help view, or nothing
+// when it is not shown.
+func (rv *ResourceView[ID, Item]) InspectTreeHelp() []key.Binding {
+	if !rv.IsInspectTreeShown() {
+		return nil
+	}
+	t := rv.inspect
+	if t.editing {
+		return []key.Binding{t.apply, t.clear}
+	}
+	bindings := []key.Binding{t.expand, t.collapse, t.copyValue, t.copyPath, t.open}
+	if t.input.Value() != "" {
+		bindings = append(bindings, t.clear)
+	}
+	return bindings
+}
+
+// evaluate applies the path to the document and rebuilds the rows. Nodes
+// expanded under another path are forgotten when reset is set.
+func (t *inspectTree) evaluate(reset bool) {
+	var cursorPath string
+	if t.cursor < len(t.rows) && !reset {
+		cursorPath = t.rows[t.cursor].path.String()
+	}
+	if reset {
+		t.expanded = map[string]bool{}
+		t.cursor = 0
+	}
+
+	t.root, t.multiple, t.evalErr = t.data, false, nil
+	if len(t.path) > 0 {
+		values, err := t.path.Eval(t.data)
+		switch {
+		case err != nil:
+			t.evalErr, t.root = err, nil
+		case len(values) == 1:
+			t.root = values[0]
+		default:
+			t.root, t.multiple = values, true
+		}
+	}
+
+	t.rows = nil
+	t.addChildren(t.root, nil, 0)
+	if len(t.rows) == 0 {
+		t.rows = append(t.rows, treeRow{value: t.root})
+	}
+
+	for i, row := range t.rows {
+		if cursorPath != "" && row.path.String() == cursorPath {
+			t.cursor = i
+		}
+	}
+	t.cursor = min(t.cursor, len(t.rows)-1)
+}
+
+// addChildren adds a row for each child of value, and the rows under each
+// expanded child.
+func (t *inspectTree) addChildren(value any, path infopanel.Path, depth int) {
+	switch value := value.(type) {
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(value)) {
+			t.addRow(treeRow{path: path.Key(k), label: k, value: value[k], depth: depth})
+		}
+	case []any:
+		for i, element := range value {
+			t.addRow(treeRow{path: path.Index(i), label: "[" + strconv.Itoa(i) + "]", value: element, depth: depth})
+		}
+	}
+}
+
+func (t *inspectTree) addRow(row treeRow) {
+	t.rows = append(t.rows, row)
+	if isContainer(row.value) && t.expanded[row.path.String()] {
+		t.addChildren(row.value, row.path, row.depth+1)
+	}
+}
+
+// isContainer reports whether a normalized value has children.
+func isContainer(value any) bool {
+	switch value := value.(type) {
+	case map[string]any:
+		return len(value) > 0
+	case []any:
+		return len(value) > 0
+	}
+	return false
+}
+
+// fullPath returns the path of row in the whole document, written so that
+// it can be pasted into jq.
+func (t *inspectTree) fullPath(row treeRow) string {
+	if t.multiple {
+		return "[" + t.path.String() + "]" + row.path.Suffix()
+	}
+	return append(slices.Clip(t.path), row.path...).String()
+}
+
+// updateInspectTree handles a message for the tree, reporting whether it
+// was consumed.
+func (rv *ResourceView[ID, Item]) updateInspectTree(msg tea.Msg) (tea.Cmd, bool) {
+	t := rv.inspect
+	keyMsg, isKey := msg.(tea.KeyPressMsg)
+
+	if t.editing {
+		if isKey {
+			switch {
+			case key.Matches(keyMsg, t.apply):
+				t.editing = false
+				t.input.Blur()
+				rv.renderInspectTree()
+				return nil, true
+			case key.Matches(keyMsg, t.clear):
+				t.editing = false
+				t.input.Blur()
+				t.input.Reset()
+				rv.applyInspectPath()
+				return nil, true
+			}
+		}
+		// Other messages, such as pastes and cursor blinks, also reach the
+		// input but are not consumed
+		previous := t.input.Value()
+		var cmd tea.Cmd
+		t.input, cmd = t.input.Update(msg)
+		if t.input.Value() != previous {
+			rv.applyInspectPath()
+		} else {
+			rv.renderInspectTree()
+		}
+		return cmd, isKey
+	}
+
+	if !isKey {
+		return nil, false
+	}
+	var cmd tea.Cmd
+	switch {
+	case key.Matches(keyMsg, t.open):
+		t.editing = true
+		cmd = t.input.Focus()
+	case key.Matches(keyMsg, t.clear) && t.input.Value() != "":
+		t.input.Reset()
+		rv.applyInspectPath()
+		return nil, true
+	case key.Matches(keyMsg, t.up):
+		t.cursor = max(t.cursor-1, 0)
+	case key.Matches(keyMsg, t.down):
+		t.cursor = min(t.cursor+1, len(t.rows)-1)
+	case key.Matches(keyMsg, t.expand):
+		row := t.rows[t.cursor]
+		switch {
+		case !isContainer(row.value):
+		case !t.expanded[row.path.String()]:
+			t.setExpanded(row, true)
+		case t.cursor+1 < len(t.rows):
+			// Already expanded, so move on to the first child
+			t.cursor++
+		}
+	case key.Matches(keyMsg, t.collapse):
+		row := t.rows[t.cursor]
+		if isContainer(row.value) && t.expanded[row.path.String()] {
+			t.setExpanded(row, false)
+			break
+		}
+		for i := t.cursor - 1; i >= 0; i-- {
+			if t.rows[i].depth < row.depth {
+				t.cursor = i
+				break
+			}
+		}
+	case key.Matches(keyMsg, t.toggle):
+		row := t.rows[t.cursor]
+		if isContainer(row.value) {
+			t.setExpanded(row, !t.expanded[row.path.String()])
+		}
+	case key.Matches(keyMsg, t.copyValue):
+		return copyToClipboard(valueText(t.rows[t.cursor].value), "Copied value"), true
+	case key.Matches(keyMsg, t.copyPath):
+		return copyToClipboard(t.fullPath(t.rows[t.cursor]), "Copied path"), true
+	default:
+		return nil, false
+	}
+	rv.renderInspectTree()
+	return cmd, true
+}
+
+// setExpanded expands or collapses row and rebuilds the rows under it.
+func (t *inspectTree) setExpanded(row treeRow, expanded bool) {
+	if expanded {
+		t.expanded[row.path.String()] = true
+	} else {
+		delete(t.expanded, row.path.String())
+	}
+	t.evaluate(false)
+}
+
+// applyInspectPath parses the typed path and shows what it selects. A path
+// with an error leaves the last valid one in effect until it is fixed.
+func (rv *ResourceView[ID, Item]) applyInspectPath() {
+	t := rv.inspect
+	path, err := infopanel.ParsePath(t.input.Value())
+	t.err = err
+	if err == nil {
+		t.path = path
+		t.evaluate(true)
+	}
+	rv.renderInspectTree()
+}
+
+// renderInspectTree draws the tree into the detail pane, scrolling it to
+// keep the cursor in view.
+func (rv *ResourceView[ID, Item]) renderInspectTree() {
+	vp, ok := rv.SplitView.Detail.(*ViewportPane)
+	if !rv.IsInspectTreeShown() || !ok {
+		return
+	}
+	t := rv.inspect
+	width := vp.Viewport.Width()
+
+	var lines []string
+	if t.editing || t.input.Value() != "" {
+		var header strings.Builder
+		if t.editing {
+			header.WriteString(t.input.View())
+		} else {
+			header.WriteString(lipgloss.NewStyle().Foreground(colors.Primary()).Render(t.input.Prompt + t.input.Value()))
+		}
+		switch {
+		case t.err != nil || t.evalErr != nil:
+			header.WriteString("  " + lipgloss.NewStyle().Foreground(colors.Error()).Render(cmp.Or(t.err, t.evalErr).Error()))
+		case t.multiple:
+			header.WriteString("  " + lipgloss.NewStyle().Foreground(colors.Muted()).Render(fmt.Sprintf("%d results", len(t.root.([]any)))))
+		}
+		lines = append(lines, header.String(), "")
+	}
+
+	cursorLine := len(lines) + t.cursor
+	for i, row := range t.rows {
+		line := ansi.Truncate(t.renderRow(row), width, "…")
+		if i == t.cursor {
+			line = lipgloss.NewStyle().
+				Foreground(colors.PrimaryText()).
+				Background(colors.Primary()).
+				Render(ansi.Strip(line))
+		}
+		lines = append(lines, line)
+	}
+
+	vp.SetContent(strings.Join(lines, "\n"))
+	height := vp.Viewport.Height()
+	switch {
+	case cursorLine < vp.Viewport.YOffset():
+		vp.Viewport.SetYOffset(cursorLine)
+	case height > 0 && cursorLine >= vp.Viewport.YOffset()+height:
+		vp.Viewport.SetYOffset(cursorLine - height + 1)
+	}
+}
+
+// renderRow draws a node as its key and either its value or, for objects
+// and arrays, how much is inside.
+func (t *inspectTree) renderRow(row treeRow) string {
+	var line strings.Builder
+	line.WriteString(strings.Repeat("  ", row.depth))
+
+	expanded := t.expanded[row.path.String()]
+	switch {
+	case !isContainer(row.value):
+		line.WriteString("  ")
+	case expanded:
+		line.WriteString("▾ ")
+	default:
+		line.WriteString("▸ ")
+	}
+
+	if row.label != "" {
+		if strings.HasPrefix(row.label, "[") {
+			line.WriteString(lipgloss.NewStyle().Foreground(colors.Muted()).Render(row.label))
+		} else {
+			line.WriteString(lipgloss.NewStyle().Foreground(colors.Primary()).Render(row.label))
+		}
+		line.WriteString(": ")
+	}
+
+	muted := lipgloss.NewStyle().Foreground(colors.Muted())
+	switch value := row.value.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			line.WriteString(muted.Render("{}"))
+		} else if expanded {
+			line.WriteString(muted.Render(plural(len(value), "key")))
+		} else {
+			line.WriteString(muted.Render("{…} " + plural(len(value), "key")))
+		}
+	case []any:
+		if len(value) == 0 {
+			line.WriteString(muted.Render("[]"))
+		} else if expanded {
+			line.WriteString(muted.Render(plural(len(value), "item")))
+		} else {
+			line.WriteString(muted.Render("[…] " + plural(len(value), "item")))
+		}
+	case string:
+		line.WriteString(lipgloss.NewStyle().Foreground(colors.Success()).Render(strconv.Quote(value)))
+	case float64:
+		line.WriteString(lipgloss.NewStyle().Foreground(colors.Warning()).Render(valueText(value)))
+	default:
+		line.WriteString(muted.Render(valueText(value)))
+	}
+	return line.String()
+}
+
+// plural writes n with the noun, adding an "s" unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// valueText returns a value as it is copied: strings as they are, and
+// anything else as JSON.
+func valueText(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// copyToClipboard copies text, notifying with message when it worked.
+func copyToClipboard(text, message string) tea.Cmd {
+	if err := clipboard.WriteAll(text); err != nil {
+		return notifications.ShowError(err)
+	}
+	return notifications.ShowSuccess(message)
+}
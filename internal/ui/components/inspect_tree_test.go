@@ -0,0 +1,113 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type inspectTestData struct {
+	Name   string
+	Mounts []struct{ Source string }
+	Labels map[string]string
+}
+
+func newInspectTestView(t *testing.T) *ResourceView[string, testListItem] {
+	t.Helper()
+	rv := NewResourceView[string, testListItem](
+		"Test",
+		func() ([]testListItem, error) { return nil, nil },
+		func(item testListItem) string { return item.value },
+		func(item testListItem) string { return item.value },
+		nil,
+	)
+	rv.SplitView.SetSize(120, 30)
+	rv.SplitView.Focus = FocusDetail
+
+	data := inspectTestData{Name: "web", Labels: map[string]string{"com.example.team": "payments"}}
+	data.Mounts = append(data.Mounts, struct{ Source string }{"/srv/a"}, struct{ Source string }{"/srv/b"})
+	rv.ShowInspectTree(data, false)
+	return rv
+}
+
+func pressKeys(rv *ResourceView[string, testListItem], keys ...string) {
+	for _, k := range keys {
+		msg := tea.KeyPressMsg{Code: []rune(k)[0], Text: k}
+		switch k {
+		case "enter":
+			msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyPressMsg{Code: tea.KeyEscape}
+		}
+		updated, _ := rv.Update(msg)
+		*rv = updated
+	}
+}
+
+func detailText(rv *ResourceView[string, testListItem]) string {
+	return ansi.Strip(rv.SplitView.Detail.View())
+}
+
+func TestInspectTreeExpandsAndCollapses(t *testing.T) {
+	rv := newInspectTestView(t)
+	if got := detailText(rv); !strings.Contains(got, "▸ Labels: {…} 1 key") || strings.Contains(got, "payments") {
+		t.Fatalf("expected collapsed top-level nodes, got:\n%s", got)
+	}
+
+	// Labels is the first key; expand it and step onto its child
+	pressKeys(rv, "l", "l")
+	if got := detailText(rv); !strings.Contains(got, `com.example.team: "payments"`) {
+		t.Fatalf("expected l to expand Labels, got:\n%s", got)
+	}
+	if got := rv.inspect.fullPath(rv.inspect.rows[rv.inspect.cursor]); got != `.Labels["com.example.team"]` {
+		t.Fatalf("copied path = %q", got)
+	}
+
+	// h goes back to the parent, then collapses it
+	pressKeys(rv, "h", "h")
+	if got := detailText(rv); strings.Contains(got, "payments") {
+		t.Fatalf("expected h to collapse Labels, got:\n%s", got)
+	}
+}
+
+func TestInspectTreePathShowsOnlyTheResult(t *testing.T) {
+	rv := newInspectTestView(t)
+	pressKeys(rv, "/")
+	if !rv.IsFiltering() {
+		t.Fatal("expected / to open the path prompt")
+	}
+	pressKeys(rv, strings.Split(".Mounts[].Source", "")...)
+	pressKeys(rv, "enter")
+
+	got := detailText(rv)
+	if !strings.Contains(got, "2 results") || !strings.Contains(got, `[1]: "/srv/b"`) || strings.Contains(got, "Labels") {
+		t.Fatalf("expected only the mount sources, got:\n%s", got)
+	}
+	pressKeys(rv, "j")
+	if got := rv.inspect.fullPath(rv.inspect.rows[rv.inspect.cursor]); got != "[.Mounts[].Source][1]" {
+		t.Fatalf("copied path = %q", got)
+	}
+
+	pressKeys(rv, "esc")
+	if got := detailText(rv); !strings.Contains(got, "Labels") {
+		t.Fatalf("expected esc to clear the path, got:\n%s", got)
+	}
+}
+
+func TestInspectTreeMasksSecretsUnlessRevealed(t *testing.T) {
+	rv := newInspectTestView(t)
+	data := inspectTestData{Name: "db", Labels: map[string]string{"db.password": "hunter2"}}
+
+	rv.ShowInspectTree(data, false)
+	pressKeys(rv, "l")
+	if got := detailText(rv); strings.Contains(got, "hunter2") {
+		t.Fatalf("expected the password masked, got:\n%s", got)
+	}
+
+	rv.ShowInspectTree(data, true)
+	if got := detailText(rv); !strings.Contains(got, "hunter2") {
+		t.Fatalf("expected the password revealed, got:\n%s", got)
+	}
+}
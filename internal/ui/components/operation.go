@@ -0,0 +1,81 @@
+package components
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// Operation is the cancellable operation a tab is running, such as removing
+// a volume, which esc cancels while it works. A tab runs one at a time.
+type Operation struct {
+	name   string
+	ctx    stdcontext.Context
+	cancel stdcontext.CancelFunc
+}
+
+// Start begins the operation called name, such as "Remove volume data",
+// bounded by the configured timeout for kind, and returns its context.
+// While another operation is running it returns the notice saying so
+// instead.
+func (operation *Operation) Start(name string, kind config.OperationKind) (stdcontext.Context, tea.Cmd) {
+	if operation.Active() {
+		return nil, notifications.ShowInfo(fmt.Sprintf("Already running: %s (esc to cancel)", operation.name))
+	}
+	operation.name = name
+	operation.ctx, operation.cancel = state.OperationContext(stdcontext.Background(), kind)
+	return operation.ctx, nil
+}
+
+// Active reports whether an operation is running.
+func (operation *Operation) Active() bool {
+	return operation.cancel != nil
+}
+
+// Name returns the name of the running operation.
+func (operation *Operation) Name() string {
+	return operation.name
+}
+
+// Context returns the context of the running operation, or the background
+// context when none is running.
+func (operation *Operation) Context() stdcontext.Context {
+	if operation.ctx != nil {
+		return operation.ctx
+	}
+	return stdcontext.Background()
+}
+
+// Cancel cancels the running operation. Its result still arrives, and is
+// reported as cancelled by OperationOutcome.
+func (operation *Operation) Cancel() {
+	if operation.cancel != nil {
+		operation.cancel()
+	}
+}
+
+// Finish releases the running operation once its result has arrived.
+func (operation *Operation) Finish() {
+	operation.Cancel()
+	*operation = Operation{}
+}
+
+// OperationOutcome reports how the operation called name ended: cancelled
+// and timed out operations are their own outcomes rather than failures, and
+// success is shown when it succeeded.
+func OperationOutcome(name string, err error, success string) tea.Cmd {
+	switch {
+	case errors.Is(err, stdcontext.Canceled):
+		return notifications.ShowInfo(fmt.Sprintf("%s cancelled", name))
+	case errors.Is(err, stdcontext.DeadlineExceeded):
+		return notifications.ShowError(fmt.Errorf("%s timed out", name))
+	case err != nil:
+		return notifications.ShowError(err)
+	}
+	return notifications.ShowSuccess(success)
+}
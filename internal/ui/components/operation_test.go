@@ -0,0 +1,56 @@
+package components
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+func TestOperationOutcomeSeparatesCancelAndTimeout(t *testing.T) {
+	tests := []struct {
+		err       error
+		wantLevel notifications.Level
+		wantText  string
+	}{
+		{fmt.Errorf("remove: %w", stdcontext.Canceled), notifications.Info, "Remove volume data cancelled"},
+		{fmt.Errorf("remove: %w", stdcontext.DeadlineExceeded), notifications.Error, "Remove volume data timed out"},
+		{errors.New("volume is in use"), notifications.Error, "volume is in use"},
+		{nil, notifications.Success, "Volume removed: data"},
+	}
+
+	for _, tt := range tests {
+		msg, ok := OperationOutcome("Remove volume data", tt.err, "Volume removed: data")().(notifications.AddNotificationMsg)
+		if !ok {
+			t.Fatalf("outcome of %v is not a notification", tt.err)
+		}
+		if msg.Level != tt.wantLevel || msg.Message != tt.wantText {
+			t.Fatalf("outcome of %v = %v %q, want %v %q", tt.err, msg.Level, msg.Message, tt.wantLevel, tt.wantText)
+		}
+	}
+}
+
+func TestOperationRunsOneAtATime(t *testing.T) {
+	var operation Operation
+	ctx, notice := operation.Start("Remove volume data", config.OperationAction)
+	if notice != nil || !operation.Active() {
+		t.Fatal("expected the operation to start")
+	}
+
+	if _, notice := operation.Start("Create volume cache", config.OperationAction); notice == nil {
+		t.Fatal("expected a notice while another operation runs")
+	}
+
+	operation.Cancel()
+	if !errors.Is(ctx.Err(), stdcontext.Canceled) {
+		t.Fatalf("context error = %v, want cancelled", ctx.Err())
+	}
+
+	operation.Finish()
+	if operation.Active() || operation.Name() != "" {
+		t.Fatal("expected the operation to be released")
+	}
+}
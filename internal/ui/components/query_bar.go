@@ -0,0 +1,197 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/filter"
+)
+
+// queryBar is the filter bar that replaces the list's fuzzy filter when a
+// view enables structured queries.
+type queryBar[Item any] struct {
+	keys    []string
+	fields  func(Item) filter.Fields
+	input   textinput.Model
+	query   filter.Query
+	err     error
+	editing bool
+
+	open  key.Binding
+	apply key.Binding
+	clear key.Binding
+}
+
+// EnableQuery replaces the list's fuzzy filter with a filter bar that
+// accepts queries over the given keys, reading each item's fields with
+// fields.
+func (rv *ResourceView[ID, Item]) EnableQuery(keys []string, fields func(Item) filter.Fields) {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "try " + strings.Join(keys, ": ") + ": or free text"
+
+	rv.query = &queryBar[Item]{
+		keys:   keys,
+		fields: fields,
+		input:  input,
+		open: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		apply: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply filter"),
+		),
+		clear: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+	}
+
+	rv.SplitView.List.SetFilteringEnabled(false)
+	rv.SplitView.List.Styles.Title = lipgloss.NewStyle()
+	rv.SplitView.List.Styles.TitleBar = lipgloss.NewStyle().Padding(0, 0, 1, 0)
+}
+
+// QueryText returns what is typed in the filter bar.
+func (rv *ResourceView[ID, Item]) QueryText() string {
+	if rv.query == nil {
+		return ""
+	}
+	return rv.query.input.Value()
+}
+
+// SetQueryText replaces the filter bar's query and filters the list with it.
+func (rv *ResourceView[ID, Item]) SetQueryText(text string) error {
+	if rv.query == nil {
+		return nil
+	}
+	rv.query.input.SetValue(text)
+	rv.parseQuery()
+	rv.refilter()
+	return rv.query.err
+}
+
+// RestrictToQuery hides the items that do not match text for good, on top of
+// Filter and whatever is typed in the filter bar.
+func (rv *ResourceView[ID, Item]) RestrictToQuery(text string) error {
+	if rv.query == nil {
+		return nil
+	}
+	query, err := filter.ParseQuery(text, rv.query.keys)
+	if err != nil {
+		return err
+	}
+	fields, previous := rv.query.fields, rv.Filter
+	rv.Filter = func(item Item) bool {
+		return (previous == nil || previous(item)) && query.Match(fields(item), time.Now())
+	}
+	return nil
+}
+
+// isEditingQuery reports whether keys are going to the filter bar.
+func (rv *ResourceView[ID, Item]) isEditingQuery() bool {
+	return rv.query != nil && rv.query.editing
+}
+
+// matchesQuery reports whether item passes the filter bar's query.
+func (rv *ResourceView[ID, Item]) matchesQuery(item Item) bool {
+	return rv.query == nil || rv.query.query.Match(rv.query.fields(item), time.Now())
+}
+
+// updateQuery handles a message for the filter bar, reporting whether it
+// was consumed.
+func (rv *ResourceView[ID, Item]) updateQuery(msg tea.Msg) (tea.Cmd, bool) {
+	bar := rv.query
+	keyMsg, isKey := msg.(tea.KeyPressMsg)
+
+	if !bar.editing {
+		if !isKey || !key.Matches(keyMsg, bar.open) {
+			return nil, false
+		}
+		bar.editing = true
+		cmd := bar.input.Focus()
+		rv.renderTitle()
+		return cmd, true
+	}
+
+	if isKey {
+		switch {
+		case key.Matches(keyMsg, bar.apply):
+			bar.editing = false
+			bar.input.Blur()
+			rv.renderTitle()
+			return nil, true
+		case key.Matches(keyMsg, bar.clear):
+			bar.editing = false
+			bar.input.Blur()
+			bar.input.Reset()
+			rv.parseQuery()
+			return rv.refilter(), true
+		}
+	}
+
+	// Other messages, such as pastes and cursor blinks, also reach the
+	// input but are not consumed
+	previous := bar.input.Value()
+	var cmd tea.Cmd
+	bar.input, cmd = bar.input.Update(msg)
+	if bar.input.Value() != previous {
+		rv.parseQuery()
+		cmd = tea.Batch(cmd, rv.refilter())
+	} else {
+		rv.renderTitle()
+	}
+	return cmd, isKey
+}
+
+// parseQuery parses the typed query. A query with an error leaves the last
+// valid one in effect until it is fixed.
+func (rv *ResourceView[ID, Item]) parseQuery() {
+	bar := rv.query
+	query, err := filter.ParseQuery(bar.input.Value(), bar.keys)
+	bar.err = err
+	if err == nil {
+		bar.query = query
+	}
+}
+
+// queryBarView renders the query and how it was understood, or nothing
+// when the filter bar is closed and empty.
+func (rv *ResourceView[ID, Item]) queryBarView() string {
+	bar := rv.query
+	if bar == nil || (!bar.editing && bar.input.Value() == "") {
+		return ""
+	}
+
+	var view strings.Builder
+	if bar.editing {
+		view.WriteString(bar.input.View())
+	} else {
+		view.WriteString(lipgloss.NewStyle().Foreground(colors.Primary()).Render(bar.input.Prompt + bar.input.Value()))
+	}
+
+	switch {
+	case bar.err != nil:
+		view.WriteString("  " + lipgloss.NewStyle().Foreground(colors.Error()).Render(bar.err.Error()))
+	case !bar.query.Empty():
+		view.WriteString("  " + lipgloss.NewStyle().Foreground(colors.Muted()).Render(bar.query.String()))
+	}
+	return view.String()
+}
+
+// queryHelp returns the filter bar's bindings for the help view.
+func (rv *ResourceView[ID, Item]) queryHelp() []key.Binding {
+	if rv.query == nil {
+		return nil
+	}
+	if rv.query.editing {
+		return []key.Binding{rv.query.apply, rv.query.clear}
+	}
+	return []key.Binding{rv.query.open}
+}
@@ -1,18 +1,28 @@
 package components
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
+	"github.com/givensuman/containertui/internal/ui/notifications"
 )
 
 // msgItemsLoaded is delivered to the update loop when async item loading completes.
 type msgItemsLoaded[ID comparable, Item list.Item] struct {
-	items []Item
-	err   error
+	source *int
+	items  []Item
+	err    error
 }
 
 type SessionState int
@@ -35,9 +45,32 @@ type ResourceView[ID comparable, Item list.Item] struct {
 	DetailsKeyBinds DetailsKeybindings
 	Foreground      any
 	loadErr         error
+	// failingHosts lists the aggregated hosts that failed the last load.
+	failingHosts string
+
+	// Filter, when set, hides the items it rejects from the list, as does
+	// the filter bar's query. allItems keeps everything that was last loaded.
+	Filter     func(Item) bool
+	query      *queryBar[Item]
+	table      *table[Item]
+	allItems   []Item
+	pendingSel string
+	sortKey    string
+	sortDesc   bool
+
+	// source tags this view's loads; see LoadSource
+	source *int
+	// jobs holds the IDs of the background jobs this view submitted
+	jobs map[int64]struct{}
+
+	// inspect shows the detail pane's data as a tree; see ShowInspectTree
+	inspect *inspectTree
 
 	Title          string
 	AdditionalHelp []key.Binding
+	// MutatingKeys are the bindings that change daemon state. They are
+	// disabled while the daemon is unreachable.
+	MutatingKeys []key.Binding
 
 	LoadItems     func() ([]Item, error)
 	GetItemID     func(Item) ID
@@ -71,6 +104,8 @@ func NewResourceView[ID comparable, Item list.Item](
 		GetItemID:       getItemID,
 		GetItemTitle:    getItemTitle,
 		OnResize:        onResize,
+		source:          new(int),
+		jobs:            make(map[int64]struct{}),
 	}
 
 	return rv
@@ -89,9 +124,10 @@ func (rv *ResourceView[ID, Item]) Refresh() tea.Cmd {
 		return nil
 	}
 	loadFn := rv.LoadItems // capture to avoid closure data race
+	source := rv.source
 	return func() tea.Msg {
 		items, err := loadFn()
-		return msgItemsLoaded[ID, Item]{items: items, err: err}
+		return msgItemsLoaded[ID, Item]{source: source, items: items, err: err}
 	}
 }
 
@@ -121,8 +157,13 @@ func (rv *ResourceView[ID, Item]) IsExtraFocused() bool {
 	return rv.SplitView.Focus == FocusExtra
 }
 
+// IsMutatingKey reports whether msg triggers one of the view's MutatingKeys.
+func (rv *ResourceView[ID, Item]) IsMutatingKey(msg tea.KeyPressMsg) bool {
+	return len(rv.MutatingKeys) > 0 && key.Matches(msg, rv.MutatingKeys...)
+}
+
 func (rv *ResourceView[ID, Item]) IsFiltering() bool {
-	return rv.SplitView.List.FilterState() == list.Filtering
+	return rv.SplitView.List.FilterState() == list.Filtering || rv.isEditingQuery() || rv.isEditingInspectPath()
 }
 
 func (rv *ResourceView[ID, Item]) GetSelectedItem() *Item {
@@ -151,8 +192,214 @@ func (rv *ResourceView[ID, Item]) GetItems() []Item {
 	return items
 }
 
+// AllItems returns every loaded item, including those hidden by Filter or
+// the filter bar.
+func (rv *ResourceView[ID, Item]) AllItems() []Item {
+	if rv.Filter == nil && rv.query == nil {
+		return rv.GetItems()
+	}
+	return rv.allItems
+}
+
+// isVisible reports whether item passes Filter and the filter bar.
+func (rv *ResourceView[ID, Item]) isVisible(item Item) bool {
+	return (rv.Filter == nil || rv.Filter(item)) && rv.matchesQuery(item)
+}
+
+// refilter shows the loaded items that pass the current filters, keeping
+// the cursor on the same item where it is still shown.
+func (rv *ResourceView[ID, Item]) refilter() tea.Cmd {
+	if selected := rv.GetSelectedItem(); selected != nil && rv.pendingSel == "" {
+		rv.pendingSel = fmt.Sprint(rv.GetItemID(*selected))
+	}
+	return rv.SetListItems(rv.allItems)
+}
+
+// SetListItems replaces the list contents with the items that pass Filter.
+// The first call after SelectOnLoad also moves the cursor to that item.
+func (rv *ResourceView[ID, Item]) SetListItems(items []Item) tea.Cmd {
+	if rv.sortKey != "" {
+		items = slices.Clone(items)
+		slices.SortStableFunc(items, rv.compareItems)
+	}
+	rv.allItems = items
+	listItems := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		if rv.isVisible(item) {
+			listItems = append(listItems, item)
+		}
+	}
+	cmd := rv.SplitView.List.SetItems(listItems)
+	rv.renderTitle()
+
+	if rv.pendingSel != "" {
+		for i, raw := range listItems {
+			item := raw.(Item)
+			if rv.isNamed(item, rv.pendingSel) {
+				rv.SplitView.List.Select(i)
+				break
+			}
+		}
+		// Only the first load moves the cursor, so later refreshes leave it be
+		rv.pendingSel = ""
+	}
+	return cmd
+}
+
+// SubmitJob queues a background job on behalf of this view, which alone
+// reacts to its outcome; see OwnFinished.
+func (rv *ResourceView[ID, Item]) SubmitJob(title, kind string, fn jobs.Func) tea.Cmd {
+	id, cmd := jobpanel.Submit(title, kind, fn)
+	if rv.jobs == nil {
+		rv.jobs = make(map[int64]struct{})
+	}
+	rv.jobs[id] = struct{}{}
+	return cmd
+}
+
+// OwnFinished returns the jobs among finished that this view submitted.
+// Every view of a resource type sees the same finished jobs, so each reacts
+// only to its own.
+func (rv *ResourceView[ID, Item]) OwnFinished(finished []jobs.Job) []jobs.Job {
+	var own []jobs.Job
+	for _, job := range finished {
+		if _, ok := rv.jobs[job.ID]; ok {
+			delete(rv.jobs, job.ID)
+			own = append(own, job)
+		}
+	}
+	return own
+}
+
+// ReportHostFailures reports aggregated hosts that stopped answering, and
+// those that answer again, only when that set changes. hostErr is the
+// partial error a load returned alongside the other hosts' items.
+func (rv *ResourceView[ID, Item]) ReportHostFailures(hostErr error) tea.Cmd {
+	failing := ""
+	var partial *multi.PartialError
+	if errors.As(hostErr, &partial) {
+		failing = strings.Join(partial.Hosts(), ", ")
+	}
+	if failing == rv.failingHosts {
+		return nil
+	}
+
+	previous := rv.failingHosts
+	rv.failingHosts = failing
+	if hostErr != nil {
+		return notifications.ShowError(fmt.Errorf("some hosts are unreachable: %w", hostErr))
+	}
+	return notifications.ShowSuccess(fmt.Sprintf("Hosts reachable again: %s", previous))
+}
+
+// LoadSource identifies this view's loads. Views that load items through
+// their own messages tag them with it, so that other views of the same
+// resource type can ignore them.
+func (rv *ResourceView[ID, Item]) LoadSource() *int {
+	return rv.source
+}
+
+// SortBy orders the list by a table column or by the name, created or
+// state field the filter bar reads from each item.
+func (rv *ResourceView[ID, Item]) SortBy(key string, descending bool) {
+	rv.sortKey, rv.sortDesc = key, descending
+}
+
+func (rv *ResourceView[ID, Item]) compareItems(a, b Item) int {
+	var order int
+	if column := rv.sortColumn(); column != nil {
+		order = column.compare(a, b)
+	} else if rv.query != nil {
+		fieldsA, fieldsB := rv.query.fields(a), rv.query.fields(b)
+		switch rv.sortKey {
+		case "created":
+			order = fieldsA.Created.Compare(fieldsB.Created)
+		case "state":
+			order = cmp.Compare(fieldsA.State, fieldsB.State)
+		}
+	}
+	order = cmp.Or(order, cmp.Compare(rv.sortName(a), rv.sortName(b)))
+	if rv.sortDesc {
+		return -order
+	}
+	return order
+}
+
+// sortColumn returns the table column the list is sorted by, if any.
+func (rv *ResourceView[ID, Item]) sortColumn() *Column[Item] {
+	if rv.table == nil {
+		return nil
+	}
+	return rv.table.column(rv.sortKey)
+}
+
+// sortName returns the name ties are broken by.
+func (rv *ResourceView[ID, Item]) sortName(item Item) string {
+	if rv.query != nil {
+		if names := rv.query.fields(item).Names; len(names) > 0 {
+			return strings.ToLower(names[0])
+		}
+		return ""
+	}
+	if rv.GetItemTitle == nil {
+		return ""
+	}
+	return strings.ToLower(rv.GetItemTitle(item))
+}
+
+// renderTitle shows the filter bar and the table header above the list,
+// hiding the title when neither is shown.
+func (rv *ResourceView[ID, Item]) renderTitle() {
+	var lines []string
+	if line := rv.queryBarView(); line != "" {
+		lines = append(lines, line)
+	}
+	if rv.IsTableMode() {
+		lines = append(lines, "  "+rv.table.header(rv.SplitView.List.Width()-2, rv.sortKey, rv.sortDesc))
+	}
+	if len(lines) == 0 {
+		if rv.query != nil || rv.table != nil {
+			rv.SplitView.List.SetShowTitle(false)
+		}
+		return
+	}
+
+	rv.SplitView.List.Title = strings.Join(lines, "\n")
+	// The table header sits right above its rows
+	gap := 1
+	if rv.IsTableMode() {
+		gap = 0
+	}
+	rv.SplitView.List.Styles.TitleBar = lipgloss.NewStyle().Padding(0, 0, gap, 0)
+	// Showing the title again also recalculates the list's height
+	rv.SplitView.List.SetShowTitle(true)
+}
+
+// isNamed reports whether item has the given ID, title or, when the filter
+// bar knows its fields, name.
+func (rv *ResourceView[ID, Item]) isNamed(item Item, name string) bool {
+	if fmt.Sprint(rv.GetItemID(item)) == name || rv.GetItemTitle(item) == name {
+		return true
+	}
+	return rv.query != nil && slices.Contains(rv.query.fields(item).Names, name)
+}
+
+// SelectOnLoad places the cursor on the item with the given name or ID once
+// it is loaded.
+func (rv *ResourceView[ID, Item]) SelectOnLoad(name string) {
+	rv.pendingSel = name
+}
+
 func (rv *ResourceView[ID, Item]) SetItem(index int, item Item) {
 	rv.SplitView.List.SetItem(index, item)
+
+	// Keep the unfiltered copy current so refiltering does not revert it
+	for i, existing := range rv.allItems {
+		if rv.GetItemID(existing) == rv.GetItemID(item) {
+			rv.allItems[i] = item
+			break
+		}
+	}
 }
 
 func (rv *ResourceView[ID, Item]) GetSelectedIDs() []ID {
@@ -274,11 +521,12 @@ func (rv *ResourceView[ID, Item]) HandleToggleAll() {
 }
 
 type DetailsKeybindings struct {
-	Up         key.Binding
-	Down       key.Binding
-	Switch     key.Binding
-	ToggleJSON key.Binding
-	CopyOutput key.Binding
+	Up            key.Binding
+	Down          key.Binding
+	Switch        key.Binding
+	ToggleJSON    key.Binding
+	CopyOutput    key.Binding
+	RevealSecrets key.Binding
 }
 
 func NewDetailsKeybindings() DetailsKeybindings {
@@ -297,12 +545,16 @@ func NewDetailsKeybindings() DetailsKeybindings {
 		),
 		ToggleJSON: key.NewBinding(
 			key.WithKeys("J"),
-			key.WithHelp("J", "toggle JSON/YAML"),
+			key.WithHelp("J", "cycle summary/YAML/JSON"),
 		),
 		CopyOutput: key.NewBinding(
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy to clipboard"),
 		),
+		RevealSecrets: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "reveal secrets"),
+		),
 	}
 }
 
@@ -311,17 +563,17 @@ func (rv *ResourceView[ID, Item]) Update(msg tea.Msg) (ResourceView[ID, Item], t
 
 	// Handle async item load result
 	if loaded, ok := msg.(msgItemsLoaded[ID, Item]); ok {
-		if loaded.err != nil {
+		if loaded.source != rv.source {
+			return *rv, nil
+		}
+		// Hosts that failed while the others answered leave their items shown
+		var partial *multi.PartialError
+		if loaded.err != nil && !errors.As(loaded.err, &partial) {
 			rv.loadErr = loaded.err
-		} else {
-			rv.loadErr = nil
-			listItems := make([]list.Item, len(loaded.items))
-			for i, item := range loaded.items {
-				listItems[i] = item
-			}
-			return *rv, rv.SplitView.List.SetItems(listItems)
+			return *rv, nil
 		}
-		return *rv, nil
+		rv.loadErr = nil
+		return *rv, tea.Batch(rv.SetListItems(loaded.items), rv.ReportHostFailures(loaded.err))
 	}
 
 	if sizeMsg, ok := msg.(tea.WindowSizeMsg); ok {
@@ -347,6 +599,28 @@ func (rv *ResourceView[ID, Item]) Update(msg tea.Msg) (ResourceView[ID, Item], t
 		return *rv, nil
 	}
 
+	if rv.query != nil && rv.IsListFocused() {
+		cmd, consumed := rv.updateQuery(msg)
+		if consumed {
+			return *rv, cmd
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	if rv.IsInspectTreeShown() && rv.IsDetailFocused() {
+		cmd, consumed := rv.updateInspectTree(msg)
+		if consumed {
+			return *rv, cmd
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && rv.table != nil && rv.IsListFocused() && !rv.IsFiltering() {
+		if cmd, consumed := rv.updateTable(keyMsg); consumed {
+			return *rv, tea.Batch(append(cmds, cmd)...)
+		}
+	}
+
 	var cmd tea.Cmd
 	rv.SplitView, cmd = rv.SplitView.Update(msg)
 	cmds = append(cmds, cmd)
@@ -358,6 +632,10 @@ func (rv *ResourceView[ID, Item]) UpdateWindowDimensions(msg tea.WindowSizeMsg)
 	rv.WindowWidth = msg.Width
 	rv.WindowHeight = msg.Height
 	rv.SplitView.SetSize(msg.Width, msg.Height)
+	if rv.IsTableMode() {
+		rv.renderTitle()
+	}
+	rv.renderInspectTree()
 
 	if rv.SessionState == ViewOverlay && rv.Foreground != nil {
 		if ws, ok := rv.Foreground.(windowSizer); ok {
@@ -405,7 +683,12 @@ func (rv *ResourceView[ID, Item]) View() string {
 
 func (rv *ResourceView[ID, Item]) ShortHelp() []key.Binding {
 	if rv.SplitView.Focus == FocusList {
+		if rv.isEditingQuery() {
+			return rv.queryHelp()
+		}
 		help := rv.SplitView.List.ShortHelp()
+		help = append(help, rv.queryHelp()...)
+		help = append(help, rv.tableHelp()...)
 		help = append(help, rv.DetailsKeyBinds.Switch)
 		return help
 	}
@@ -423,6 +706,8 @@ func (rv *ResourceView[ID, Item]) FullHelp() [][]key.Binding {
 		} else {
 			help[0] = append(help[0], rv.DetailsKeyBinds.Switch)
 		}
+		help[0] = append(help[0], rv.queryHelp()...)
+		help[0] = append(help[0], rv.tableHelp()...)
 		if len(rv.AdditionalHelp) > 0 {
 			help = append(help, rv.AdditionalHelp)
 		}
@@ -1,12 +1,20 @@
 package components
 
 import (
+	stdcontext "context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/layout"
 )
 
 type testListItem struct {
@@ -68,6 +76,48 @@ func TestResourceViewRefreshTracksLoadErrors(t *testing.T) {
 	}
 }
 
+func TestResourceViewKeepsItemsWhenSomeHostsFail(t *testing.T) {
+	hostErr := &multi.PartialError{Failures: []multi.HostError{{Host: "staging-2", Err: fmt.Errorf("connection refused")}}}
+	rv := NewResourceView[string, testListItem](
+		"Test",
+		func() ([]testListItem, error) {
+			return []testListItem{{value: "item-1"}}, hostErr
+		},
+		func(item testListItem) string { return item.value },
+		func(item testListItem) string { return item.value },
+		nil,
+	)
+
+	updated, cmd := rv.Update(rv.Refresh()())
+	if updated.loadErr != nil {
+		t.Fatalf("loadErr = %v, want none while other hosts answer", updated.loadErr)
+	}
+	if len(updated.SplitView.List.Items()) != 1 {
+		t.Fatalf("item count = %d, want the answering hosts' item", len(updated.SplitView.List.Items()))
+	}
+	if cmd == nil || updated.failingHosts != "staging-2" {
+		t.Fatalf("expected a notice naming the failing host, got %q", updated.failingHosts)
+	}
+}
+
+func TestHostFailuresAreReportedOnlyWhenTheyChange(t *testing.T) {
+	rv := NewResourceView[string, testListItem]("Test", nil, nil, nil, nil)
+	hostErr := &multi.PartialError{Failures: []multi.HostError{{Host: "staging-2", Err: fmt.Errorf("connection refused")}}}
+
+	if cmd := rv.ReportHostFailures(hostErr); cmd == nil {
+		t.Fatal("expected a notice when a host starts failing")
+	}
+	if cmd := rv.ReportHostFailures(hostErr); cmd != nil {
+		t.Fatal("expected no repeated notice while the same host keeps failing")
+	}
+	if cmd := rv.ReportHostFailures(nil); cmd == nil {
+		t.Fatal("expected a notice when the host recovers")
+	}
+	if rv.failingHosts != "" {
+		t.Fatalf("expected no failing hosts after recovery, got %q", rv.failingHosts)
+	}
+}
+
 func TestResourceViewViewShowsLoadErrorWhenEmpty(t *testing.T) {
 	errLoad := fmt.Errorf("daemon not reachable")
 
@@ -100,6 +150,150 @@ func TestResourceViewViewShowsLoadErrorWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestResourceViewFilterHidesItemsAndSelectsOnLoad(t *testing.T) {
+	rv := NewResourceView[string, testListItem](
+		"Test",
+		func() ([]testListItem, error) {
+			return []testListItem{{value: "api-1"}, {value: "db"}, {value: "api-2"}}, nil
+		},
+		func(item testListItem) string { return item.value },
+		func(item testListItem) string { return item.value },
+		nil,
+	)
+	rv.Filter = func(item testListItem) bool { return strings.HasPrefix(item.value, "api-") }
+	rv.SelectOnLoad("api-2")
+
+	deliverRefresh(rv)
+
+	if got := len(rv.GetItems()); got != 2 {
+		t.Fatalf("visible item count = %d, want 2", got)
+	}
+	if got := len(rv.AllItems()); got != 3 {
+		t.Fatalf("all item count = %d, want 3", got)
+	}
+	if selected := rv.GetSelectedItem(); selected == nil || selected.value != "api-2" {
+		t.Fatalf("selected item = %v, want api-2", selected)
+	}
+
+	rv.SplitView.List.Select(0)
+	deliverRefresh(rv)
+	if selected := rv.GetSelectedItem(); selected == nil || selected.value != "api-1" {
+		t.Fatalf("expected a later refresh to keep the cursor, got %v", selected)
+	}
+}
+
+func TestResourceViewQueryBarFiltersAsYouType(t *testing.T) {
+	rv := NewResourceView[string, testListItem](
+		"Test",
+		func() ([]testListItem, error) {
+			return []testListItem{{value: "api-1"}, {value: "db"}, {value: "api-2"}}, nil
+		},
+		func(item testListItem) string { return item.value },
+		func(item testListItem) string { return item.value },
+		nil,
+	)
+	rv.EnableQuery([]string{"name"}, func(item testListItem) filter.Fields {
+		return filter.Fields{Names: []string{item.value}}
+	})
+	deliverRefresh(rv)
+
+	typeKeys := func(keys ...tea.KeyPressMsg) {
+		for _, msg := range keys {
+			updated, _ := rv.Update(msg)
+			*rv = updated
+		}
+	}
+	typeText := func(text string) {
+		for _, r := range text {
+			typeKeys(tea.KeyPressMsg{Code: r, Text: string(r)})
+		}
+	}
+
+	typeKeys(tea.KeyPressMsg{Code: '/', Text: "/"})
+	if !rv.IsFiltering() {
+		t.Fatal("expected / to open the filter bar")
+	}
+	typeText("name:api-*")
+	if got := len(rv.GetItems()); got != 2 {
+		t.Fatalf("visible item count = %d, want 2", got)
+	}
+	if !strings.Contains(rv.SplitView.List.Title, "name api-*") {
+		t.Fatalf("expected the parsed query in the filter bar, got %q", rv.SplitView.List.Title)
+	}
+
+	typeText(` "`)
+	if !strings.Contains(rv.SplitView.List.Title, "unterminated quote") {
+		t.Fatalf("expected the error in the filter bar, got %q", rv.SplitView.List.Title)
+	}
+	if got := len(rv.GetItems()); got != 2 {
+		t.Fatalf("expected the last valid query to stay applied, got %d items", got)
+	}
+
+	typeKeys(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if rv.IsFiltering() || len(rv.GetItems()) != 3 || rv.QueryText() != "" {
+		t.Fatalf("expected esc to clear the filter, got %d items and %q", len(rv.GetItems()), rv.QueryText())
+	}
+}
+
+func TestResourceViewTableTogglesSortsAndSaves(t *testing.T) {
+	rv := NewResourceView[string, testListItem](
+		"Test",
+		func() ([]testListItem, error) {
+			return []testListItem{{value: "b"}, {value: "c"}, {value: "a"}}, nil
+		},
+		func(item testListItem) string { return item.value },
+		func(item testListItem) string { return item.value },
+		nil,
+	)
+	var saved []config.TableConfig
+	rv.EnableTable([]Column[testListItem]{
+		{Key: "value", Title: "Value", ColumnSpec: layout.ColumnSpec{MinWidth: 8}, Value: func(item testListItem) string { return item.value }},
+		{Key: "length", Title: "Length", ColumnSpec: layout.ColumnSpec{MinWidth: 8}, Value: func(item testListItem) string { return fmt.Sprint(len(item.value)) }},
+	}, config.TableConfig{}, func(settings config.TableConfig) tea.Cmd {
+		saved = append(saved, settings)
+		return nil
+	})
+	rv.SplitView.SetSize(80, 20)
+	deliverRefresh(rv)
+
+	press := func(r rune) {
+		updated, _ := rv.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		*rv = updated
+	}
+	order := func() string {
+		var values []string
+		for _, item := range rv.GetItems() {
+			values = append(values, item.value)
+		}
+		return strings.Join(values, "")
+	}
+
+	press('o')
+	if rv.IsTableMode() || len(saved) != 0 {
+		t.Fatal("expected sorting to wait for table mode")
+	}
+	press('v')
+	if !rv.IsTableMode() || !strings.Contains(rv.SplitView.List.Title, "Length") {
+		t.Fatalf("expected v to show the table header, got %q", rv.SplitView.List.Title)
+	}
+	press('o')
+	if got := order(); got != "abc" {
+		t.Fatalf("order after sorting = %q, want abc", got)
+	}
+	press('O')
+	if got := order(); got != "cba" {
+		t.Fatalf("order after reversing = %q, want cba", got)
+	}
+	if !strings.Contains(rv.SplitView.List.Title, "Value ↓") {
+		t.Fatalf("expected the header to mark the sort column, got %q", rv.SplitView.List.Title)
+	}
+
+	last := saved[len(saved)-1]
+	if !last.Enabled || last.Sort != "-value" || strings.Join(last.Columns, ",") != "value,length" {
+		t.Fatalf("unexpected saved settings %+v", last)
+	}
+}
+
 func contains(s, needle string) bool {
 	return strings.Contains(s, needle)
 }
@@ -203,3 +397,21 @@ func hasHelpDescInGroups(groups [][]key.Binding, desc string) bool {
 
 	return false
 }
+
+func TestResourceViewOwnsOnlyTheJobsItSubmitted(t *testing.T) {
+	first := NewResourceView[string, testListItem]("First", nil, nil, nil, nil)
+	second := NewResourceView[string, testListItem]("Second", nil, nil, nil, nil)
+
+	first.SubmitJob("Prune", "test/prune", func(stdcontext.Context, *jobs.Progress) error { return nil })
+	submitted := state.GetJobs().Jobs()
+
+	if own := second.OwnFinished(submitted); len(own) != 0 {
+		t.Fatalf("expected another view's job to be ignored, got %d", len(own))
+	}
+	if own := first.OwnFinished(submitted); len(own) != 1 {
+		t.Fatalf("expected the view's own job, got %d", len(own))
+	}
+	if own := first.OwnFinished(submitted); len(own) != 0 {
+		t.Fatal("expected a finished job to be handled once")
+	}
+}
@@ -265,6 +265,8 @@ type SplitView struct {
 	focusedDelegate    list.DefaultDelegate
 	unfocusedDelegate  list.DefaultDelegate
 	hasCachedDelegates bool
+	// wrapDelegate, when set, renders rows in place of the styled delegates
+	wrapDelegate func(list.DefaultDelegate) list.ItemDelegate
 
 	extraRatio  float64 // Ratio of height for Extra pane (0 means no extra pane)
 	detailTitle string  // Title for detail pane border
@@ -302,7 +304,36 @@ func (s *SplitView) SetDelegates(baseDelegate list.DefaultDelegate) {
 	s.focusedDelegate = styles.ChangeDelegateStyles(baseDelegate)
 	s.unfocusedDelegate = styles.UnfocusDelegateStyles(baseDelegate)
 	s.hasCachedDelegates = true
-	s.List.SetDelegate(s.focusedDelegate)
+	s.applyDelegate()
+}
+
+// SetDelegateWrapper renders the list with the delegate wrap builds from the
+// focused or unfocused delegate styles, or with those delegates directly
+// when wrap is nil.
+func (s *SplitView) SetDelegateWrapper(wrap func(list.DefaultDelegate) list.ItemDelegate) {
+	s.wrapDelegate = wrap
+	s.applyDelegate()
+}
+
+// applyDelegate sets the list's delegate for the current focus.
+func (s *SplitView) applyDelegate() {
+	var delegate list.DefaultDelegate
+	switch {
+	case s.hasCachedDelegates && s.Focus == FocusList:
+		delegate = s.focusedDelegate
+	case s.hasCachedDelegates:
+		delegate = s.unfocusedDelegate
+	case s.Focus == FocusList:
+		delegate = styles.ChangeDelegateStyles(list.NewDefaultDelegate())
+	default:
+		delegate = styles.UnfocusDelegateStyles(list.NewDefaultDelegate())
+	}
+
+	if s.wrapDelegate != nil {
+		s.List.SetDelegate(s.wrapDelegate(delegate))
+		return
+	}
+	s.List.SetDelegate(delegate)
 }
 
 func (s SplitView) Init() tea.Cmd {
@@ -359,21 +390,7 @@ func (s SplitView) Update(msg tea.Msg) (SplitView, tea.Cmd) {
 	}
 
 	if _, ok := msg.(base.MsgFocusChanged); ok {
-		if s.Focus == FocusDetail || s.Focus == FocusExtra {
-			if s.hasCachedDelegates {
-				s.List.SetDelegate(s.unfocusedDelegate)
-			} else {
-				base := list.NewDefaultDelegate()
-				s.List.SetDelegate(styles.UnfocusDelegateStyles(base))
-			}
-		} else {
-			if s.hasCachedDelegates {
-				s.List.SetDelegate(s.focusedDelegate)
-			} else {
-				base := list.NewDefaultDelegate()
-				s.List.SetDelegate(styles.ChangeDelegateStyles(base))
-			}
-		}
+		s.applyDelegate()
 	}
 
 	if msg, ok := msg.(tea.WindowSizeMsg); ok {
@@ -0,0 +1,315 @@
+package components
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/layout"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// columnGap is the number of cells between table columns.
+const columnGap = 2
+
+// Column is a column of a list's table mode.
+type Column[Item any] struct {
+	Key   string
+	Title string
+	layout.ColumnSpec
+	Value func(Item) string
+	// Compare orders items by the column; when nil, values are compared as
+	// text
+	Compare func(a, b Item) int
+}
+
+// compare orders a and b by the column.
+func (column Column[Item]) compare(a, b Item) int {
+	if column.Compare != nil {
+		return column.Compare(a, b)
+	}
+	return cmp.Compare(strings.ToLower(ansi.Strip(column.Value(a))), strings.ToLower(ansi.Strip(column.Value(b))))
+}
+
+// table renders a list as rows of columns.
+type table[Item any] struct {
+	columns []Column[Item]
+	shown   []Column[Item]
+	enabled bool
+	// save persists the settings after they change; nil leaves them unsaved
+	save func(config.TableConfig) tea.Cmd
+
+	toggle  key.Binding
+	sort    key.Binding
+	reverse key.Binding
+}
+
+// EnableTable offers a table mode showing the given columns, set up from
+// settings. save, when not nil, is called with the new settings whenever
+// they change.
+func (rv *ResourceView[ID, Item]) EnableTable(columns []Column[Item], settings config.TableConfig, save func(config.TableConfig) tea.Cmd) {
+	rv.table = &table[Item]{
+		columns: columns,
+		save:    save,
+		toggle: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "toggle table"),
+		),
+		sort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "sort by next column"),
+		),
+		reverse: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "reverse sort"),
+		),
+	}
+	rv.SplitView.List.Styles.Title = lipgloss.NewStyle()
+	rv.table.show(settings.Columns)
+	if sortKey, descending := settings.SortKey(); rv.table.column(sortKey) != nil {
+		rv.SortBy(sortKey, descending)
+	}
+	rv.setTableMode(settings.Enabled)
+}
+
+// ShowTable switches to table mode with the given columns, for lists whose
+// columns are fixed by their caller. Its settings are not saved.
+func (rv *ResourceView[ID, Item]) ShowTable(columnKeys []string) {
+	if rv.table == nil {
+		return
+	}
+	rv.table.save = nil
+	rv.table.show(columnKeys)
+	rv.setTableMode(true)
+}
+
+// TableColumnKeys returns the keys of the columns the table can show.
+func (rv *ResourceView[ID, Item]) TableColumnKeys() []string {
+	if rv.table == nil {
+		return nil
+	}
+	keys := make([]string, len(rv.table.columns))
+	for i, column := range rv.table.columns {
+		keys[i] = column.Key
+	}
+	return keys
+}
+
+// show shows the columns with the given keys in order, ignoring unknown
+// ones, or every column when none are given.
+func (t *table[Item]) show(keys []string) {
+	t.shown = nil
+	for _, k := range keys {
+		if column := t.column(k); column != nil {
+			t.shown = append(t.shown, *column)
+		}
+	}
+	if len(t.shown) == 0 {
+		t.shown = slices.Clone(t.columns)
+	}
+}
+
+// column returns the column with the given key, or nil.
+func (t *table[Item]) column(key string) *Column[Item] {
+	for i := range t.columns {
+		if t.columns[i].Key == key {
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// widths lays the shown columns out across width.
+func (t *table[Item]) widths(width int) []int {
+	specs := make([]layout.ColumnSpec, len(t.shown))
+	for i, column := range t.shown {
+		specs[i] = column.ColumnSpec
+	}
+	return layout.ColumnWidths(width, columnGap, specs)
+}
+
+// row renders the cells of item that fit in width.
+func (t *table[Item]) row(item Item, width int) string {
+	widths := t.widths(width)
+	cells := make([]string, 0, len(t.shown))
+	for i, column := range t.shown {
+		if widths[i] == 0 {
+			break
+		}
+		cells = append(cells, fitCell(column.Value(item), widths[i]))
+	}
+	return strings.Join(cells, strings.Repeat(" ", columnGap))
+}
+
+// header renders the column titles, marking the sort column.
+func (t *table[Item]) header(width int, sortKey string, descending bool) string {
+	widths := t.widths(width)
+	cells := make([]string, 0, len(t.shown))
+	for i, column := range t.shown {
+		if widths[i] == 0 {
+			break
+		}
+		title := column.Title
+		if column.Key == sortKey {
+			if descending {
+				title += " ↓"
+			} else {
+				title += " ↑"
+			}
+		}
+		cells = append(cells, fitCell(title, widths[i]))
+	}
+	return lipgloss.NewStyle().Foreground(colors.Muted()).Bold(true).Render(strings.Join(cells, strings.Repeat(" ", columnGap)))
+}
+
+// fitCell truncates or pads s to exactly width cells.
+func fitCell(s string, width int) string {
+	s = ansi.Truncate(s, width, "…")
+	return s + strings.Repeat(" ", max(0, width-ansi.StringWidth(s)))
+}
+
+// tableDelegate renders one row per item with the styles of the delegate it
+// wraps, which still handles updates.
+type tableDelegate[Item any] struct {
+	list.DefaultDelegate
+	table *table[Item]
+}
+
+func (d tableDelegate[Item]) Height() int {
+	return 1
+}
+
+func (d tableDelegate[Item]) Spacing() int {
+	return 0
+}
+
+func (d tableDelegate[Item]) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(Item)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+	style := d.Styles.NormalTitle
+	if index == m.Index() {
+		style = d.Styles.SelectedTitle
+	}
+	fmt.Fprint(w, style.Render(d.table.row(item, m.Width()-style.GetHorizontalFrameSize()))) //nolint: errcheck
+}
+
+// IsTableMode reports whether the list is shown as a table.
+func (rv *ResourceView[ID, Item]) IsTableMode() bool {
+	return rv.table != nil && rv.table.enabled
+}
+
+// setTableMode switches between the table and the regular list.
+func (rv *ResourceView[ID, Item]) setTableMode(enabled bool) {
+	rv.table.enabled = enabled
+	if enabled {
+		t := rv.table
+		rv.SplitView.SetDelegateWrapper(func(delegate list.DefaultDelegate) list.ItemDelegate {
+			return tableDelegate[Item]{DefaultDelegate: delegate, table: t}
+		})
+	} else {
+		rv.SplitView.SetDelegateWrapper(nil)
+	}
+	rv.renderTitle()
+}
+
+// tableSettings returns the table's current settings.
+func (rv *ResourceView[ID, Item]) tableSettings() config.TableConfig {
+	settings := config.TableConfig{Enabled: rv.table.enabled}
+	for _, column := range rv.table.shown {
+		settings.Columns = append(settings.Columns, column.Key)
+	}
+	if rv.sortKey != "" {
+		settings.Sort = rv.sortKey
+		if rv.sortDesc {
+			settings.Sort = "-" + rv.sortKey
+		}
+	}
+	return settings
+}
+
+// updateTable handles the table keys, reporting whether msg was one.
+func (rv *ResourceView[ID, Item]) updateTable(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	t := rv.table
+	switch {
+	case key.Matches(msg, t.toggle):
+		rv.setTableMode(!t.enabled)
+	case t.enabled && key.Matches(msg, t.sort):
+		next := 0
+		for i, column := range t.shown {
+			if column.Key == rv.sortKey {
+				next = (i + 1) % len(t.shown)
+			}
+		}
+		rv.SortBy(t.shown[next].Key, false)
+	case t.enabled && key.Matches(msg, t.reverse):
+		if rv.sortKey == "" {
+			rv.SortBy(t.shown[0].Key, true)
+		} else {
+			rv.SortBy(rv.sortKey, !rv.sortDesc)
+		}
+	default:
+		return nil, false
+	}
+
+	cmd := rv.refilter()
+	rv.renderTitle()
+	if t.save != nil {
+		cmd = tea.Batch(cmd, t.save(rv.tableSettings()))
+	}
+	return cmd, true
+}
+
+// tableHelp returns the table's bindings for the help view.
+func (rv *ResourceView[ID, Item]) tableHelp() []key.Binding {
+	if rv.table == nil {
+		return nil
+	}
+	if rv.table.enabled {
+		return []key.Binding{rv.table.toggle, rv.table.sort, rv.table.reverse}
+	}
+	return []key.Binding{rv.table.toggle}
+}
+
+// SaveTableSettings returns a save function for EnableTable that keeps the
+// settings of the named resource list in the config file.
+func SaveTableSettings(resource string) func(config.TableConfig) tea.Cmd {
+	return func(settings config.TableConfig) tea.Cmd {
+		cfg := state.GetConfig()
+		if cfg == nil || cfg.Tables.For(resource) == nil {
+			return nil
+		}
+		*cfg.Tables.For(resource) = settings
+		path, tables := cfg.Path(), cfg.Tables
+		if path == "" {
+			return nil
+		}
+		return func() tea.Msg {
+			if err := config.SaveTables(path, tables); err != nil {
+				return notifications.ShowError(fmt.Errorf("failed to save table settings: %w", err))()
+			}
+			return nil
+		}
+	}
+}
+
+// TableSettings returns the configured table settings of the named resource
+// list.
+func TableSettings(resource string) config.TableConfig {
+	cfg := state.GetConfig()
+	if cfg == nil || cfg.Tables.For(resource) == nil {
+		return config.TableConfig{}
+	}
+	return *cfg.Tables.For(resource)
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/connection"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/icons"
+)
+
+// listenForConnection waits for the next change of the daemon connection
+// and delivers it as a MsgConnectionChanged. It must be re-issued after
+// each message to keep listening.
+func listenForConnection() tea.Cmd {
+	supervisor := state.GetConnection()
+	return func() tea.Msg {
+		<-supervisor.Changed()
+		return base.MsgConnectionChanged{State: supervisor.State()}
+	}
+}
+
+// connectionIndicator renders the daemon connection state for the tab bar,
+// or an empty string before the first check has completed.
+func connectionIndicator(conn connection.State) string {
+	iconSet := icons.Get()
+	switch conn.Status {
+	case connection.Reconnecting:
+		return lipgloss.NewStyle().Foreground(colors.Warning()).
+			Render(fmt.Sprintf("%s reconnecting (attempt %d)", iconSet.Restarting, conn.Attempts))
+	case connection.Offline:
+		return lipgloss.NewStyle().Foreground(colors.Error()).
+			Render(fmt.Sprintf("%s offline", iconSet.Stopped))
+	}
+
+	if conn.Backend == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colors.Success()).
+		Render(fmt.Sprintf("%s %s %s", iconSet.Running, conn.Backend, conn.Version))
+}
+
+// contextIndicator renders the active Docker context, or every host when
+// several are aggregated, for the tab bar.
+func contextIndicator(names []string) string {
+	label := "context: "
+	if len(names) > 1 {
+		label = "hosts: "
+	}
+	return lipgloss.NewStyle().Foreground(colors.Muted()).Render(label + strings.Join(names, ", "))
+}
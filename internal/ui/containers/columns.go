@@ -0,0 +1,162 @@
+package containers
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/layout"
+)
+
+// composeProjectLabel is the label Compose puts its project name in.
+const composeProjectLabel = "com.docker.compose.project"
+
+// tableColumns are the columns the containers table can show.
+func tableColumns() []components.Column[ContainerItem] {
+	return []components.Column[ContainerItem]{
+		{
+			Key: "name", Title: "Name", ColumnSpec: layout.ColumnSpec{MinWidth: 18, Flex: 3},
+			// The title keeps the selection, status and host markers
+			Value:   func(item ContainerItem) string { return item.Title() },
+			Compare: func(a, b ContainerItem) int { return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)) },
+		},
+		{
+			Key: "image", Title: "Image", ColumnSpec: layout.ColumnSpec{MinWidth: 14, Flex: 2},
+			Value: func(item ContainerItem) string { return item.Image },
+		},
+		{
+			Key: "state", Title: "State", ColumnSpec: layout.ColumnSpec{MinWidth: 10},
+			Value: func(item ContainerItem) string { return item.State },
+		},
+		{
+			Key: "uptime", Title: "Uptime", ColumnSpec: layout.ColumnSpec{MinWidth: 12},
+			Value: func(item ContainerItem) string { return cmp.Or(uptime(item.Status), "-") },
+			Compare: func(a, b ContainerItem) int {
+				return cmp.Compare(approximateDuration(uptime(a.Status)), approximateDuration(uptime(b.Status)))
+			},
+		},
+		{
+			Key: "ports", Title: "Ports", ColumnSpec: layout.ColumnSpec{MinWidth: 12, Flex: 2},
+			Value: func(item ContainerItem) string { return formatPorts(item) },
+		},
+		{
+			Key: "ip", Title: "IP", ColumnSpec: layout.ColumnSpec{MinWidth: 15},
+			Value: func(item ContainerItem) string { return ipAddress(item) },
+		},
+		{
+			Key: "project", Title: "Project", ColumnSpec: layout.ColumnSpec{MinWidth: 10, Flex: 1},
+			Value: func(item ContainerItem) string { return item.Labels[composeProjectLabel] },
+		},
+		{
+			Key: "health", Title: "Health", ColumnSpec: layout.ColumnSpec{MinWidth: 9},
+			Value: func(item ContainerItem) string { return health(item.Status) },
+		},
+		{
+			Key: "created", Title: "Created", ColumnSpec: layout.ColumnSpec{MinWidth: 14},
+			Value:   func(item ContainerItem) string { return infopanel.FormatTimeAgo(item.Created) },
+			Compare: func(a, b ContainerItem) int { return a.Created.Compare(b.Created) },
+		},
+		{
+			Key: "id", Title: "ID", ColumnSpec: layout.ColumnSpec{MinWidth: 12},
+			Value: func(item ContainerItem) string { return infopanel.TruncateID(item.ID) },
+		},
+	}
+}
+
+// uptime returns how long the daemon's status says a container has been up,
+// such as "2 hours" for "Up 2 hours (healthy)", or "" if it is not up.
+func uptime(status string) string {
+	up, ok := strings.CutPrefix(status, "Up ")
+	if !ok {
+		return ""
+	}
+	if i := strings.Index(up, " ("); i >= 0 {
+		up = up[:i]
+	}
+	return up
+}
+
+// health returns the health check status in the daemon's status, such as
+// "healthy" for "Up 2 hours (healthy)", or "" without a health check.
+func health(status string) string {
+	for _, state := range []string{"unhealthy", "healthy"} {
+		if strings.Contains(status, "("+state+")") {
+			return state
+		}
+	}
+	if strings.Contains(status, "(health: starting)") {
+		return "starting"
+	}
+	return ""
+}
+
+// durationUnits are the units of the daemon's human readable durations.
+var durationUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+	"month":  30 * 24 * time.Hour,
+	"year":   365 * 24 * time.Hour,
+}
+
+// approximateDuration reads a daemon duration such as "3 days" or "About an
+// hour" well enough to sort by, returning -1 for anything else.
+func approximateDuration(text string) time.Duration {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) < 2 {
+		return -1
+	}
+	unit, ok := durationUnits[strings.TrimSuffix(fields[len(fields)-1], "s")]
+	if !ok {
+		return -1
+	}
+	if n, err := strconv.Atoi(fields[len(fields)-2]); err == nil {
+		return time.Duration(n) * unit
+	}
+	// "Less than a second", "About a minute" and "About an hour"
+	if fields[0] == "less" {
+		return 0
+	}
+	return unit
+}
+
+// formatPorts lists the container's ports, showing where published ones are
+// published, such as "8080→80/tcp".
+func formatPorts(item ContainerItem) string {
+	ports := make([]string, 0, len(item.Ports))
+	for _, port := range item.Ports {
+		text := fmt.Sprintf("%d/%s", port.PrivatePort, port.Type)
+		if port.PublicPort != 0 {
+			text = fmt.Sprintf("%d→%s", port.PublicPort, text)
+		}
+		if !slices.Contains(ports, text) {
+			ports = append(ports, text)
+		}
+	}
+	return strings.Join(ports, ", ")
+}
+
+// ipAddress returns the container's addresses on its networks, in network
+// name order.
+func ipAddress(item ContainerItem) string {
+	networks := make([]string, 0, len(item.IPAddresses))
+	for network := range item.IPAddresses {
+		networks = append(networks, network)
+	}
+	slices.Sort(networks)
+
+	addresses := make([]string, 0, len(networks))
+	for _, network := range networks {
+		if address := item.IPAddresses[network]; address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return strings.Join(addresses, ", ")
+}
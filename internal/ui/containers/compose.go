@@ -0,0 +1,106 @@
+package containers
+
+import (
+	stdcontext "context"
+	"fmt"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/compose"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/components"
+)
+
+// Compose actions offered by the compose dialog.
+const (
+	composeUp          = "up"
+	composeDown        = "down"
+	composeDownVolumes = "down with volumes"
+	composePull        = "pull"
+)
+
+// showComposeDialog asks for a compose file and what to do with it.
+func (model *Model) showComposeDialog() {
+	fields := []components.FormField{
+		{
+			Label:       "Compose File",
+			Placeholder: "compose.yaml",
+			Value:       "compose.yaml",
+			Required:    true,
+		},
+		{
+			Label:   "Action",
+			Value:   composeUp,
+			Options: []string{composeUp, composeDown, composeDownVolumes, composePull},
+		},
+		{
+			Label:       "Profiles",
+			Placeholder: "debug,tools (optional)",
+		},
+		{
+			Label:       "Project Name",
+			Placeholder: "defaults to the directory name",
+		},
+	}
+
+	dialog := components.NewFormDialog(
+		"Compose",
+		fields,
+		base.SmartDialogAction{Type: "Compose"},
+		nil,
+	)
+
+	model.SetOverlay(dialog)
+}
+
+// composeJob loads the compose file and runs action on it, reporting each
+// network, volume, image and container as an item of the job.
+func composeJob(path, action string, profiles []string, projectName string) jobs.Func {
+	return func(ctx stdcontext.Context, progress *jobs.Progress) error {
+		progress.SetMessage("Loading " + path + "...")
+		project, err := compose.Load(path, compose.Options{ProjectName: projectName, Profiles: profiles})
+		if err != nil {
+			return err
+		}
+
+		kind := config.OperationPull
+		if action == composeDown || action == composeDownVolumes {
+			kind = config.OperationAction
+		}
+		ctx, cancel := state.OperationContext(ctx, kind)
+		defer cancel()
+
+		report := func(resource, status string) {
+			progress.SetMessage(resource + ": " + status)
+			progress.SetItem(resource, status, 0, 0)
+		}
+		switch action {
+		case composeUp:
+			err = compose.Up(ctx, state.GetBackend(), project, report)
+		case composeDown, composeDownVolumes:
+			err = compose.Down(ctx, state.GetBackend(), project, action == composeDownVolumes, report)
+		case composePull:
+			err = compose.Pull(ctx, state.GetBackend(), project, report)
+		default:
+			return fmt.Errorf("unknown compose action %q", action)
+		}
+		if err != nil {
+			return err
+		}
+		progress.SetResult(fmt.Sprintf("Compose %s finished for project %s", action, project.Name))
+		return nil
+	}
+}
+
+// splitProfiles reads a comma-separated list of profiles.
+func splitProfiles(value string) []string {
+	var profiles []string
+	for _, profile := range strings.Split(value, ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
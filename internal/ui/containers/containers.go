@@ -3,22 +3,31 @@ package containers
 
 import (
 	stdcontext "context"
+	"errors"
 	"fmt"
-	"os/exec"
+	"os"
 	"slices"
+	"strings"
 	"time"
 
 	"charm.land/bubbles/v2/key"
-	"charm.land/bubbles/v2/list"
 	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/atotto/clipboard"
 	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/export"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/secrets"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/safety"
 	"github.com/givensuman/containertui/internal/ui/utils"
@@ -59,6 +68,34 @@ type MsgRenameComplete struct {
 	Err         error
 }
 
+// msgExportGenerated carries a file generated from containers, masked for
+// the preview and as written.
+type msgExportGenerated struct {
+	format  exportFormat
+	preview []byte
+	content []byte
+	err     error
+}
+
+// msgExportWritten is sent when an exported file has been written.
+type msgExportWritten struct {
+	format exportFormat
+	path   string
+	err    error
+}
+
+// exportFormat is a kind of file containers can be exported as.
+type exportFormat struct {
+	title    string
+	path     string
+	generate func(export.ComposeResources, *secrets.Detector) ([]byte, error)
+}
+
+var (
+	composeExport = exportFormat{title: "Compose File", path: "compose.yaml", generate: export.Compose}
+	kubeExport    = exportFormat{title: "Kubernetes Manifest", path: "kube.yaml", generate: export.Kube}
+)
+
 type keybindings struct {
 	pauseContainer       key.Binding
 	unpauseContainer     key.Binding
@@ -73,6 +110,13 @@ type keybindings struct {
 	toggleSelection      key.Binding
 	toggleSelectionOfAll key.Binding
 	renameContainer      key.Binding
+	copyDockerRun        key.Binding
+	copyPodmanRun        key.Binding
+	exportCompose        key.Binding
+	exportKube           key.Binding
+	diff                 key.Binding
+	compose              key.Binding
+	cancelOperation      key.Binding
 	switchTab            key.Binding
 }
 
@@ -130,9 +174,37 @@ func newKeybindings() *keybindings {
 			key.WithKeys("e"),
 			key.WithHelp("e", "rename container"),
 		),
+		copyDockerRun: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy as docker run"),
+		),
+		copyPodmanRun: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "copy as podman run"),
+		),
+		exportCompose: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export compose"),
+		),
+		exportKube: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "export kubernetes"),
+		),
+		diff: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "compare selected"),
+		),
+		compose: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "compose up/down/pull"),
+		),
+		cancelOperation: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel operation"),
+		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "5"),
-			key.WithHelp("1-5", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "switch tab"),
 		),
 	}
 }
@@ -146,6 +218,13 @@ type Model struct {
 	detailsKeybindings components.DetailsKeybindings
 	detailsPanel       components.DetailsPanel
 
+	// operations holds the cancel functions of in-flight operations by container ID.
+	operations map[string]stdcontext.CancelFunc
+
+	// exportPreview is the compose file shown in the details panel while
+	// its path is asked for.
+	exportPreview []byte
+
 	WindowWidth  int
 	WindowHeight int
 }
@@ -155,8 +234,10 @@ func New() Model {
 
 	// Initialize ResourceView
 	fetchContainers := func() ([]ContainerItem, error) {
-		containers, err := state.GetBackend().ListContainers(stdcontext.Background())
-		if err != nil {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+		defer cancel()
+		containers, hostErr := state.GetBackend().ListContainers(ctx)
+		if err := multi.IgnorePartial(hostErr); err != nil {
 			return nil, err
 		}
 		items := make([]ContainerItem, 0, len(containers))
@@ -168,7 +249,8 @@ func New() Model {
 				spinner:    newSpinner(),
 			})
 		}
-		return items, nil
+		// Hosts that failed are reported by the view, which keeps the rest
+		return items, hostErr
 	}
 
 	resourceView := components.NewResourceView[string, ContainerItem](
@@ -181,8 +263,11 @@ func New() Model {
 		},
 	)
 
-	// Disable filtering for containers tab to preserve color rendering
-	resourceView.SplitView.List.SetFilteringEnabled(false)
+	// The filter bar leaves titles alone, so their status colors survive filtering
+	resourceView.EnableQuery(filter.ContainerQueryKeys, func(item ContainerItem) filter.Fields {
+		return filter.ContainerFields(item.Container)
+	})
+	resourceView.EnableTable(tableColumns(), components.TableSettings("containers"), components.SaveTableSettings("containers"))
 
 	// Set detail panel title
 	resourceView.SplitView.SetDetailTitle("Inspect")
@@ -196,6 +281,7 @@ func New() Model {
 		keybindings:        containerKeybindings,
 		detailsKeybindings: components.NewDetailsKeybindings(),
 		detailsPanel:       components.NewDetailsPanel(),
+		operations:         make(map[string]stdcontext.CancelFunc),
 	}
 
 	// Add custom keybindings to help
@@ -210,26 +296,56 @@ func New() Model {
 		containerKeybindings.forceRemoveContainer,
 		containerKeybindings.pruneContainers,
 		containerKeybindings.renameContainer,
+		containerKeybindings.cancelOperation,
 		containerKeybindings.showLogs,
 		containerKeybindings.execShell,
+		containerKeybindings.copyDockerRun,
+		containerKeybindings.copyPodmanRun,
+		containerKeybindings.exportCompose,
+		containerKeybindings.exportKube,
+		containerKeybindings.diff,
+		containerKeybindings.compose,
 		containerKeybindings.toggleSelection,
 		containerKeybindings.toggleSelectionOfAll,
 	}
 
+	// Actions that change daemon state are disabled while it is unreachable
+	model.MutatingKeys = []key.Binding{
+		containerKeybindings.pauseContainer,
+		containerKeybindings.unpauseContainer,
+		containerKeybindings.startContainer,
+		containerKeybindings.stopContainer,
+		containerKeybindings.restartContainer,
+		containerKeybindings.removeContainer,
+		containerKeybindings.forceRemoveContainer,
+		containerKeybindings.pruneContainers,
+		containerKeybindings.renameContainer,
+		containerKeybindings.execShell,
+		containerKeybindings.compose,
+	}
+
 	return model
 }
 
 // refreshWithState refreshes the container list while preserving isWorking and isSelected states
 func (model *Model) refreshWithState() tea.Cmd {
+	source := model.LoadSource()
 	return func() tea.Msg {
 		// Fetch fresh container data from Docker
-		containers, err := state.GetBackend().ListContainers(stdcontext.Background())
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+		defer cancel()
+		containers, err := state.GetBackend().ListContainers(ctx)
+		var hostErr error
 		if err != nil {
-			return MsgContainersRefreshed{Err: err}
+			var partial *multi.PartialError
+			if !errors.As(err, &partial) {
+				return MsgContainersRefreshed{Err: err, source: source}
+			}
+			hostErr = partial
 		}
 
 		// Get current items to preserve state
-		currentItems := model.GetItems()
+		currentItems := model.AllItems()
 		stateMap := make(map[string]struct {
 			isWorking  bool
 			isSelected bool
@@ -265,7 +381,7 @@ func (model *Model) refreshWithState() tea.Cmd {
 			items = append(items, item)
 		}
 
-		return MsgContainersRefreshed{Items: items}
+		return MsgContainersRefreshed{Items: items, HostErr: hostErr, source: source}
 	}
 }
 
@@ -275,6 +391,15 @@ func tickCmd() tea.Cmd {
 	})
 }
 
+// SetLaunchFilter limits the list to the containers matching f and, once they
+// load, puts the cursor on the one called selectName.
+func (model *Model) SetLaunchFilter(f filter.Filter, selectName string) {
+	if !f.Empty() {
+		model.Filter = func(item ContainerItem) bool { return f.Match(filter.ContainerFields(item.Container)) }
+	}
+	model.SelectOnLoad(selectName)
+}
+
 func (model Model) Init() tea.Cmd {
 	return tea.Batch(model.ResourceView.Init(), tickCmd())
 }
@@ -303,12 +428,14 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 	case MsgContainersRefreshed:
+		if msg.source != nil && msg.source != model.LoadSource() {
+			break
+		}
 		if msg.Err == nil {
-			listItems := make([]list.Item, len(msg.Items))
-			for i, item := range msg.Items {
-				listItems[i] = item
+			cmds = append(cmds, model.SetListItems(msg.Items))
+			if cmd := model.ReportHostFailures(msg.HostErr); cmd != nil {
+				cmds = append(cmds, cmd)
 			}
-			cmds = append(cmds, model.SplitView.List.SetItems(listItems))
 		}
 
 	case base.MsgContainerCreated:
@@ -329,8 +456,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case msgExecShellReady:
 		// Container state was freshly verified as running — hand off the terminal.
-		command := exec.Command("docker", "exec", "-it", msg.containerID, "/bin/sh")
-		return model, tea.ExecProcess(command, func(err error) tea.Msg {
+		return model, tea.ExecProcess(ShellCommand(msg.containerID), func(err error) tea.Msg {
 			if err != nil {
 				return notifications.AddNotificationMsg{
 					Message:  fmt.Sprintf("shell exited with error: %v", err),
@@ -352,11 +478,15 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// Restore scroll position after viewport has processed content
 		model.detailsPanel.RestoreScrollPosition(model.getViewport())
 
-	case MsgPruneComplete:
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			_ = progressDialog.SetPercent(1.0)
+	case components.MsgRevealExpired:
+		if model.detailsPanel.ExpireReveal() {
+			model.refreshInspectionContent()
 		}
-		model.CloseOverlay()
+
+	case base.MsgJobsUpdated:
+		cmds = append(cmds, handleFinishedJobs(model.OwnFinished(msg.Finished)))
+
+	case MsgPruneComplete:
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
 		}
@@ -379,6 +509,32 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			},
 		)
 
+	case msgExportGenerated:
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		model.exportPreview = msg.preview
+		model.refreshInspectionContent()
+		model.showExportDialog(msg.format, msg.content)
+
+	case msgDiffLoaded:
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		model.showDiff(msg.left, msg.right)
+
+	case msgExportWritten:
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		return model, notifications.ShowSuccess(fmt.Sprintf("Exported %s: %s", strings.ToLower(msg.format.title), msg.path))
+
+	case base.CloseDialogMessage:
+		if model.exportPreview != nil {
+			model.exportPreview = nil
+			model.refreshInspectionContent()
+		}
+
 	case MsgRenameComplete:
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
@@ -409,9 +565,8 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					model.CloseOverlay()
 					return model, notifications.ShowError(fmt.Errorf("invalid payload type for DeleteContainer"))
 				}
-				spinnerCmd := model.setWorkingState(containerIDs, true)
 				model.CloseOverlay()
-				return model, tea.Batch(spinnerCmd, PerformContainerOperations(Remove, containerIDs, false))
+				return model, model.startOperation(Remove, containerIDs, false)
 			}
 			if confirmMsg.Action.Type == "ForceDeleteContainer" {
 				containerIDs, ok := confirmMsg.Action.Payload.([]string)
@@ -419,12 +574,39 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					model.CloseOverlay()
 					return model, notifications.ShowError(fmt.Errorf("invalid payload type for ForceDeleteContainer"))
 				}
-				spinnerCmd := model.setWorkingState(containerIDs, true)
 				model.CloseOverlay()
-				return model, tea.Batch(
-					spinnerCmd,
-					PerformContainerOperations(Remove, containerIDs, true),
-				)
+				return model, model.startOperation(Remove, containerIDs, true)
+			}
+			if confirmMsg.Action.Type == "Export" {
+				model.CloseOverlay()
+				model.exportPreview = nil
+				model.refreshInspectionContent()
+
+				payload, ok := confirmMsg.Action.Payload.(map[string]any)
+				if !ok {
+					return model, notifications.ShowError(fmt.Errorf("invalid payload type"))
+				}
+				formValues, ok := payload["values"].(map[string]string)
+				if !ok {
+					return model, notifications.ShowError(fmt.Errorf("invalid form values"))
+				}
+				format, _ := payload["format"].(exportFormat)
+				content, _ := payload["content"].([]byte)
+				return model, writeExportFile(format, formValues["Path"], content)
+			}
+			if confirmMsg.Action.Type == "Compose" {
+				model.CloseOverlay()
+				payload, ok := confirmMsg.Action.Payload.(map[string]any)
+				if !ok {
+					return model, notifications.ShowError(fmt.Errorf("invalid payload type"))
+				}
+				formValues, ok := payload["values"].(map[string]string)
+				if !ok {
+					return model, notifications.ShowError(fmt.Errorf("invalid form values"))
+				}
+				path, action := formValues["Compose File"], formValues["Action"]
+				job := composeJob(path, action, splitProfiles(formValues["Profiles"]), strings.TrimSpace(formValues["Project Name"]))
+				return model, model.SubmitJob(fmt.Sprintf("Compose %s %s", action, path), jobKindCompose, job)
 			}
 			if confirmMsg.Action.Type == "RenameContainer" {
 				// Extract form values and container ID
@@ -497,6 +679,8 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				model.showPruneContainersConfirmation()
 			case key.Matches(msg, model.keybindings.renameContainer):
 				model.handleRenameContainer()
+			case key.Matches(msg, model.keybindings.cancelOperation):
+				model.handleCancelOperation()
 			case key.Matches(msg, model.keybindings.showLogs):
 				if cmd := model.handleShowLogs(); cmd != nil {
 					cmds = append(cmds, cmd)
@@ -505,6 +689,26 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				if cmd := model.handleExecShell(); cmd != nil {
 					cmds = append(cmds, cmd)
 				}
+			case key.Matches(msg, model.keybindings.copyDockerRun):
+				if cmd := model.handleCopyRunCommand("docker"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			case key.Matches(msg, model.keybindings.copyPodmanRun):
+				if cmd := model.handleCopyRunCommand("podman"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			case key.Matches(msg, model.keybindings.exportCompose):
+				if cmd := model.handleExport(composeExport); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			case key.Matches(msg, model.keybindings.exportKube):
+				if cmd := model.handleExport(kubeExport); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			case key.Matches(msg, model.keybindings.diff):
+				cmds = append(cmds, model.handleDiff())
+			case key.Matches(msg, model.keybindings.compose):
+				model.showComposeDialog()
 			case key.Matches(msg, model.keybindings.toggleSelection):
 				model.handleToggleSelection()
 			case key.Matches(msg, model.keybindings.toggleSelectionOfAll):
@@ -528,6 +732,11 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					cmd := model.detailsPanel.HandleCopyToClipboard(model.inspection)
 					cmds = append(cmds, cmd)
 				}
+				if key.Matches(msg, model.detailsKeybindings.RevealSecrets) {
+					cmd := model.detailsPanel.HandleRevealSecrets()
+					model.refreshInspectionContent()
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 	}
@@ -542,7 +751,9 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			// Capture ID for closure
 			id := selectedItem.ID
 			cmds = append(cmds, func() tea.Msg {
-				containerInfo, err := state.GetBackend().InspectContainer(stdcontext.Background(), id)
+				ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+				defer cancel()
+				containerInfo, err := state.GetBackend().InspectContainer(ctx, id)
 				return MsgContainerInspection{ID: id, Container: containerInfo, Err: err}
 			})
 		}
@@ -562,13 +773,14 @@ func (model Model) IsFiltering() bool {
 func (model Model) ShortHelp() []key.Binding {
 	// If detail pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return []key.Binding{
+		return append([]key.Binding{
 			model.detailsKeybindings.Up,
 			model.detailsKeybindings.Down,
 			model.detailsKeybindings.ToggleJSON,
 			model.detailsKeybindings.CopyOutput,
+			model.detailsKeybindings.RevealSecrets,
 			model.detailsKeybindings.Switch,
-		}
+		}, model.InspectTreeHelp()...)
 	}
 
 	return model.ResourceView.ShortHelp()
@@ -584,21 +796,30 @@ func (model *Model) getViewport() *viewport.Model {
 
 // refreshInspectionContent regenerates and sets the inspection content
 func (model *Model) refreshInspectionContent() {
+	if model.exportPreview != nil {
+		model.HideInspectTree()
+		model.SetContent(builders.BuildExportPanel(model.exportPreview, model.GetContentWidth()))
+		return
+	}
 	if model.inspection.ID == "" {
 		return
 	}
 
-	// Use DetailsPanel to get the current format
-	format := model.detailsPanel.GetFormatForDisplay()
-
-	content := builders.BuildContainerPanel(model.inspection, model.GetContentWidth(), false, format)
-	model.SetContent(content)
+	// The tree draws itself from the data; other formats are built as text
+	if model.detailsPanel.IsTreeFormat() {
+		model.ShowInspectTree(model.inspection, model.detailsPanel.SecretsRevealed())
+	} else {
+		model.HideInspectTree()
+		format := model.detailsPanel.GetFormatForDisplay()
+		content := builders.BuildContainerPanel(model.inspection, model.GetContentWidth(), model.detailsPanel.SecretsRevealed(), format)
+		model.SetContent(content)
+	}
 }
 
 func (model Model) FullHelp() [][]key.Binding {
 	// If detail pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return [][]key.Binding{
+		help := [][]key.Binding{
 			{
 				model.detailsKeybindings.Up,
 				model.detailsKeybindings.Down,
@@ -607,8 +828,13 @@ func (model Model) FullHelp() [][]key.Binding {
 			{
 				model.detailsKeybindings.ToggleJSON,
 				model.detailsKeybindings.CopyOutput,
+				model.detailsKeybindings.RevealSecrets,
 			},
 		}
+		if tree := model.InspectTreeHelp(); len(tree) > 0 {
+			help = append(help, tree)
+		}
+		return help
 	}
 
 	return model.ResourceView.FullHelp()
@@ -656,17 +882,54 @@ func (model *Model) handleContainerLifecycleOp(op Operation) tea.Cmd {
 		if model.anySelectedWorking() {
 			return nil
 		}
-		spinnerCmd := model.setWorkingState(selectedIDs, true)
-		return tea.Batch(spinnerCmd, PerformContainerOperations(op, selectedIDs, false))
+		return model.startOperation(op, selectedIDs, false)
 	}
 	selectedItem := model.GetSelectedItem()
 	if selectedItem != nil && !selectedItem.isWorking {
-		spinnerCmd := model.setWorkingState([]string{selectedItem.ID}, true)
-		return tea.Batch(spinnerCmd, PerformContainerOperation(op, selectedItem.ID, false))
+		return model.startOperation(op, []string{selectedItem.ID}, false)
 	}
 	return nil
 }
 
+// startOperation marks containerIDs as working and runs operation on them.
+// Each container gets its own cancellable context so it can be cancelled
+// individually while it is working.
+func (model *Model) startOperation(operation Operation, containerIDs []string, force bool) tea.Cmd {
+	if model.operations == nil {
+		model.operations = make(map[string]stdcontext.CancelFunc)
+	}
+
+	contexts := make(map[string]stdcontext.Context, len(containerIDs))
+	for _, id := range containerIDs {
+		ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+		model.operations[id] = cancel
+		contexts[id] = ctx
+	}
+
+	spinnerCmd := model.setWorkingState(containerIDs, true)
+	return tea.Batch(spinnerCmd, model.performContainerOperations(contexts, operation, containerIDs, force))
+}
+
+// handleCancelOperation cancels the in-flight operation on the selected container.
+func (model *Model) handleCancelOperation() {
+	selectedItem := model.GetSelectedItem()
+	if selectedItem == nil || !selectedItem.isWorking {
+		return
+	}
+
+	if cancel, ok := model.operations[selectedItem.ID]; ok {
+		cancel()
+	}
+}
+
+// finishOperation releases the context of the operation on containerID.
+func (model *Model) finishOperation(containerID string) {
+	if cancel, ok := model.operations[containerID]; ok {
+		cancel()
+		delete(model.operations, containerID)
+	}
+}
+
 func (model *Model) handleRemoveContainers(force bool) tea.Cmd {
 	selectedIDs := model.GetSelectedIDs()
 	if len(selectedIDs) > 0 {
@@ -747,11 +1010,7 @@ func (model *Model) handleShowLogs() tea.Cmd {
 		return notifications.ShowInfo(item.Name + " is not running")
 	}
 
-	tmpFile := fmt.Sprintf("/tmp/containertui-logs-%s.txt", item.ID)
-	command := exec.Command("sh", "-c",
-		fmt.Sprintf("docker logs --tail 500 --timestamps %s > %s 2>&1; less -R %s; rm -f %s",
-			item.ID, tmpFile, tmpFile, tmpFile))
-	return tea.ExecProcess(command, func(err error) tea.Msg {
+	return tea.ExecProcess(LogsCommand(item.ID), func(err error) tea.Msg {
 		if err != nil {
 			return notifications.AddNotificationMsg{
 				Message:  fmt.Sprintf("logs exited with error: %v", err),
@@ -781,7 +1040,9 @@ func (model *Model) handleExecShell() tea.Cmd {
 	// exec-ing. The cached item.State can be up to 3 seconds stale, which is enough time
 	// for a short-lived container to have exited — causing an opaque "exit status 1".
 	return func() tea.Msg {
-		detail, err := state.GetBackend().InspectContainer(stdcontext.Background(), containerID)
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+		defer cancel()
+		detail, err := state.GetBackend().InspectContainer(ctx, containerID)
 		if err != nil {
 			return msgExecShellNotRunning{name: containerName, err: err}
 		}
@@ -792,6 +1053,117 @@ func (model *Model) handleExecShell() tea.Cmd {
 	}
 }
 
+// handleCopyRunCommand copies the engine's run command that recreates the
+// selected container, with secrets masked unless the details panel reveals
+// them.
+func (model *Model) handleCopyRunCommand(engine string) tea.Cmd {
+	item := model.GetSelectedItem()
+	if item == nil {
+		return nil
+	}
+	containerID := item.ID
+	var rules *secrets.Detector
+	if !model.detailsPanel.SecretsRevealed() {
+		rules = secrets.Current()
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+		defer cancel()
+		detail, err := state.GetBackend().InspectContainer(ctx, containerID)
+		if err != nil {
+			return notifications.ShowError(fmt.Errorf("failed to inspect container: %w", err))()
+		}
+		// Without the image nothing can be left out as its default
+		var image *backend.ImageDetail
+		imageID := detail.Image
+		if detail.Host != "" {
+			imageID = multi.QualifyID(detail.Host, imageID)
+		}
+		if imageDetail, err := state.GetBackend().InspectImage(ctx, imageID); err == nil {
+			image = &imageDetail
+		}
+
+		command := export.RunCommand(engine, detail, image, rules)
+		if err := clipboard.WriteAll(command); err != nil {
+			return notifications.ShowError(err)()
+		}
+		if strings.Contains(command, secrets.Mask) {
+			return notifications.ShowSuccess("Copied " + engine + " run command with secrets masked")()
+		}
+		return notifications.ShowSuccess("Copied " + engine + " run command")()
+	}
+}
+
+// handleExport generates a file in format from the selected containers,
+// or the current one when none are selected, to preview it before asking
+// where to write it.
+func (model *Model) handleExport(format exportFormat) tea.Cmd {
+	ids := model.GetSelectedIDs()
+	if len(ids) == 0 {
+		item := model.GetSelectedItem()
+		if item == nil {
+			return nil
+		}
+		ids = []string{item.ID}
+	}
+	var rules *secrets.Detector
+	if !model.detailsPanel.SecretsRevealed() {
+		rules = secrets.Current()
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+		defer cancel()
+		resources, err := export.GatherCompose(ctx, state.GetBackend(), ids)
+		if err != nil {
+			return msgExportGenerated{err: err}
+		}
+		preview, err := format.generate(resources, rules)
+		if err != nil {
+			return msgExportGenerated{err: err}
+		}
+		content, err := format.generate(resources, nil)
+		return msgExportGenerated{format: format, preview: preview, content: content, err: err}
+	}
+}
+
+// showExportDialog asks where to write a generated file.
+func (model *Model) showExportDialog(format exportFormat, content []byte) {
+	fields := []components.FormField{
+		{
+			Label:       "Path",
+			Placeholder: format.path,
+			Value:       format.path,
+			Required:    true,
+		},
+	}
+
+	metadata := map[string]any{
+		"format":  format,
+		"content": content,
+	}
+
+	dialog := components.NewFormDialog(
+		"Export "+format.title,
+		fields,
+		base.SmartDialogAction{Type: "Export"},
+		metadata,
+	)
+
+	model.SetOverlay(dialog)
+}
+
+// writeExportFile writes an exported file, secrets included, to path.
+func writeExportFile(format exportFormat, path string, content []byte) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return msgExportWritten{err: fmt.Errorf("failed to write %s: %w", strings.ToLower(format.title), err)}
+		}
+		return msgExportWritten{format: format, path: path}
+	}
+}
+
 func (model *Model) handleToggleSelection() {
 	selectedItem := model.GetSelectedItem()
 	if selectedItem != nil && !selectedItem.isWorking {
@@ -845,7 +1217,14 @@ func (model *Model) handleToggleSelectionOfAll() {
 func (model *Model) handleContainerOperationResult(msg MsgContainerOperationResult) tea.Cmd {
 	// Stop spinner for this container
 	model.setWorkingState([]string{msg.ID}, false)
+	model.finishOperation(msg.ID)
 
+	if errors.Is(msg.Error, stdcontext.Canceled) {
+		return notifications.ShowInfo(fmt.Sprintf("%s cancelled: %s", msg.Operation, shortID(msg.ID)))
+	}
+	if errors.Is(msg.Error, stdcontext.DeadlineExceeded) {
+		return notifications.ShowError(fmt.Errorf("%s timed out: %s", msg.Operation, shortID(msg.ID)))
+	}
 	if msg.Error != nil {
 		return notifications.ShowError(msg.Error)
 	}
@@ -904,18 +1283,9 @@ func (model *Model) handleContainerOperationResult(msg MsgContainerOperationResu
 	return notifications.ShowSuccess(successMsg)
 }
 
-func isPruneEligibleContainerState(state string) bool {
-	switch state {
-	case "exited", "created", "dead":
-		return true
-	default:
-		return false
-	}
-}
-
 func (model Model) hasPrunableContainers() bool {
-	for _, item := range model.GetItems() {
-		if isPruneEligibleContainerState(item.State) {
+	for _, item := range model.AllItems() {
+		if safety.PruneEligibleContainerState(item.State) {
 			return true
 		}
 	}
@@ -923,22 +1293,60 @@ func (model Model) hasPrunableContainers() bool {
 	return false
 }
 
-// handlePruneContainers prunes all stopped containers
+// handlePruneContainers prunes all stopped containers in a background job
 func (model *Model) handlePruneContainers() tea.Cmd {
-	progressDialog := components.NewProgressDialogWithBar("Pruning stopped containers")
-	progressDialog.EnableAutoAdvance(0.95, 0.04)
-	progressDialog.SetStatus("Discovering stopped containers to prune...")
-	model.SetOverlay(progressDialog)
-
-	// Start async prune operation
-	return func() tea.Msg {
-		ctx := stdcontext.Background()
+	return model.SubmitJob("Prune stopped containers", jobKindPrune, func(ctx stdcontext.Context, progress *jobs.Progress) error {
+		progress.SetMessage("Discovering stopped containers to prune...")
+		ctx, cancel := state.OperationContext(ctx, config.OperationPrune)
+		defer cancel()
 		spaceReclaimed, err := state.GetBackend().PruneContainers(ctx)
-		return MsgPruneComplete{
-			SpaceReclaimed: spaceReclaimed,
-			Err:            err,
+		progress.SetResult(spaceReclaimed)
+		return err
+	})
+}
+
+// handleFinishedJobs translates finished jobs submitted by this tab into
+// their completion messages.
+func handleFinishedJobs(finished []jobs.Job) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range finished {
+		switch job.Kind {
+		case jobKindPrune:
+			if job.Status == jobs.Cancelled {
+				cmds = append(cmds, jobpanel.CancelledNotice(job))
+				continue
+			}
+			spaceReclaimed, _ := job.Result.(uint64)
+			cmds = append(cmds, func() tea.Msg {
+				return MsgPruneComplete{SpaceReclaimed: spaceReclaimed, Err: job.Err}
+			})
+
+		case jobKindCompose:
+			switch {
+			case job.Status == jobs.Cancelled:
+				cmds = append(cmds, jobpanel.CancelledNotice(job))
+			case job.Err != nil:
+				cmds = append(cmds, notifications.ShowError(job.Err))
+			default:
+				message, _ := job.Result.(string)
+				cmds = append(cmds, notifications.ShowSuccess(message))
+			}
+			// Whatever ran may have changed containers, networks and volumes
+			for _, resource := range []base.ResourceType{base.ResourceContainer, base.ResourceNetwork, base.ResourceVolume} {
+				cmds = append(cmds, func() tea.Msg {
+					return base.MsgResourceChanged{Resource: resource, Operation: base.OperationUpdated}
+				})
+			}
+
+		case jobKindBulkOperation:
+			// Cancelled containers are reported individually by their results.
+			results, _ := job.Result.([]MsgContainerOperationResult)
+			for _, result := range results {
+				cmds = append(cmds, func() tea.Msg { return result })
+			}
 		}
 	}
+	return tea.Batch(cmds...)
 }
 
 func (model *Model) showPruneContainersConfirmation() {
@@ -963,15 +1371,13 @@ func (model *Model) showPruneContainersConfirmation() {
 }
 
 func (model Model) pruneContainerCandidates() []string {
-	items := model.GetItems()
-	candidates := make([]string, 0, len(items))
+	items := model.AllItems()
+	containers := make([]backend.Container, 0, len(items))
 	for _, item := range items {
-		if isPruneEligibleContainerState(item.State) {
-			candidates = append(candidates, item.Name)
-		}
+		containers = append(containers, item.Container)
 	}
 
-	return candidates
+	return safety.PruneContainerCandidates(containers)
 }
 
 // handleRenameContainer shows a dialog to rename the selected container
@@ -1006,7 +1412,8 @@ func (model *Model) handleRenameContainer() {
 // performRenameContainer renames a container
 func (model *Model) performRenameContainer(containerID, newName string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := stdcontext.Background()
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationAction)
+		defer cancel()
 		err := state.GetBackend().RenameContainer(ctx, containerID, newName)
 		return MsgRenameComplete{ContainerID: containerID, NewName: newName, Err: err}
 	}
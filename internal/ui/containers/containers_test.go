@@ -1,15 +1,22 @@
 package containers
 
 import (
+	stdcontext "context"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"github.com/givensuman/containertui/internal/backend"
+	dockerbackend "github.com/givensuman/containertui/internal/backend/docker"
+	"github.com/givensuman/containertui/internal/backend/fake"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/notifications"
 )
 
 func newContainersTestModel() Model {
@@ -159,3 +166,165 @@ func TestPruneConfirmationUsesSafetyHelper(t *testing.T) {
 		t.Fatalf("expected destructive warning in dialog, got %q", text)
 	}
 }
+
+func TestHandleFinishedJobsDeliversBulkOperationResults(t *testing.T) {
+	results := []MsgContainerOperationResult{
+		{Operation: Stop, ID: "aaaaaaaaaaaaaaaa"},
+		{Operation: Stop, ID: "bbbbbbbbbbbbbbbb", Error: fmt.Errorf("boom")},
+	}
+
+	cmd := handleFinishedJobs([]jobs.Job{
+		{Kind: jobKindBulkOperation, Status: jobs.Failed, Result: results},
+	})
+	if cmd == nil {
+		t.Fatal("expected command for finished bulk job")
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != len(results) {
+		t.Fatalf("expected one command per container result, got %#v", cmd())
+	}
+	for i, resultCmd := range batch {
+		got, ok := resultCmd().(MsgContainerOperationResult)
+		if !ok || got.ID != results[i].ID {
+			t.Fatalf("expected result for %s, got %#v", results[i].ID, got)
+		}
+	}
+}
+
+func TestContainerOperationResultReportsCancellation(t *testing.T) {
+	model := newContainersTestModel()
+
+	cmd := model.handleContainerOperationResult(MsgContainerOperationResult{
+		Operation: Stop,
+		ID:        "aaaaaaaaaaaaaaaa",
+		Error:     stdcontext.Canceled,
+	})
+	if cmd == nil {
+		t.Fatal("expected a notification for a cancelled operation")
+	}
+
+	notification, ok := cmd().(notifications.AddNotificationMsg)
+	if !ok || notification.Level != notifications.Info {
+		t.Fatalf("expected info notification, got %#v", cmd())
+	}
+	if !strings.Contains(notification.Message, "cancelled") {
+		t.Fatalf("expected cancellation message, got %q", notification.Message)
+	}
+}
+
+func TestCancelOperationCancelsSelectedWorkingContainer(t *testing.T) {
+	model := newContainersTestModel()
+	model.SplitView.List.SetItems([]list.Item{
+		ContainerItem{Container: backend.Container{ID: "aaaaaaaaaaaaaaaa"}, isWorking: true},
+	})
+
+	ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+	defer cancel()
+	model.operations = map[string]stdcontext.CancelFunc{"aaaaaaaaaaaaaaaa": cancel}
+
+	model.handleCancelOperation()
+	if ctx.Err() == nil {
+		t.Fatal("expected esc to cancel the selected container's operation")
+	}
+
+	model.handleContainerOperationResult(MsgContainerOperationResult{
+		Operation: Stop,
+		ID:        "aaaaaaaaaaaaaaaa",
+		Error:     stdcontext.Canceled,
+	})
+	if _, ok := model.operations["aaaaaaaaaaaaaaaa"]; ok {
+		t.Fatal("expected finished operation to be released")
+	}
+}
+
+func TestAggregatedContainerShowsHostAndBareID(t *testing.T) {
+	item := ContainerItem{Container: backend.Container{ID: "staging-1/0123456789abcdef", Name: "api-1", Host: "staging-1"}}
+
+	if !strings.Contains(item.Title(), "staging-1") {
+		t.Fatalf("expected title to include the host, got %q", item.Title())
+	}
+	if got := strings.TrimSpace(item.Description()); got != "0123456789ab" {
+		t.Fatalf("expected the short ID without the host, got %q", got)
+	}
+}
+
+func TestStopContainerAgainstFakeBackend(t *testing.T) {
+	state.UseBackend(fake.NewDemo())
+	model := newContainersTestModel()
+
+	refreshed, ok := model.refreshWithState()().(MsgContainersRefreshed)
+	if !ok || refreshed.Err != nil {
+		t.Fatalf("expected refreshed containers, got %+v", refreshed)
+	}
+	var running ContainerItem
+	for _, item := range refreshed.Items {
+		if item.State == "running" {
+			running = item
+			break
+		}
+	}
+	if running.ID == "" {
+		t.Fatal("expected the demo to have a running container")
+	}
+
+	result, ok := PerformContainerOperation(stdcontext.Background(), Stop, running.ID, false)().(MsgContainerOperationResult)
+	if !ok || result.Error != nil {
+		t.Fatalf("expected stop to succeed, got %+v", result)
+	}
+	if containerState, _ := state.GetBackend().GetContainerState(stdcontext.Background(), running.ID); containerState != "exited" {
+		t.Fatalf("expected %s to be exited, got %q", running.Name, containerState)
+	}
+}
+
+func TestShellCommandTargetsTheOwningHost(t *testing.T) {
+	previous := state.GetEndpoints()
+	defer state.SetEndpoints(previous)
+	state.SetEndpoints([]dockerbackend.Endpoint{{Context: "staging-1"}, {Host: "tcp://10.0.0.2:2376"}})
+
+	command := ShellCommand("tcp://10.0.0.2:2376/0123456789ab")
+	if got := strings.Join(command.Args, " "); got != "docker exec -it 0123456789ab /bin/sh" {
+		t.Fatalf("expected the bare ID to be passed to docker, got %q", got)
+	}
+	if !slices.Contains(command.Env, "DOCKER_HOST=tcp://10.0.0.2:2376") {
+		t.Fatal("expected the docker CLI to be pointed at the owning host")
+	}
+
+	if command := LogsCommand("0123456789ab"); !slices.Contains(command.Env, "DOCKER_CONTEXT=staging-1") {
+		t.Fatal("expected unqualified IDs to use the first endpoint")
+	}
+}
+
+func TestTableColumnsReadTheDaemonStatus(t *testing.T) {
+	status := "Up 2 hours (healthy)"
+	if got := uptime(status); got != "2 hours" {
+		t.Fatalf("uptime = %q, want 2 hours", got)
+	}
+	if got := health(status); got != "healthy" {
+		t.Fatalf("health = %q, want healthy", got)
+	}
+	if uptime("Exited (0) 3 days ago") != "" || health("Up 5 seconds") != "" {
+		t.Fatal("expected no uptime for stopped containers and no health without a check")
+	}
+
+	ascending := []string{"Less than a second", "5 seconds", "About a minute", "2 hours", "3 days"}
+	for i := 1; i < len(ascending); i++ {
+		if approximateDuration(ascending[i-1]) >= approximateDuration(ascending[i]) {
+			t.Fatalf("expected %q to sort before %q", ascending[i-1], ascending[i])
+		}
+	}
+	if approximateDuration("") != -1 {
+		t.Fatal("expected an unknown duration to sort first")
+	}
+}
+
+func TestFormatPortsShowsPublishedPortsOnce(t *testing.T) {
+	item := ContainerItem{Container: backend.Container{Ports: []backend.Port{
+		{PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+		{PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+		{PrivatePort: 443, Type: "tcp"},
+	}}}
+	if got := formatPorts(item); got != "8080→80/tcp, 443/tcp" {
+		t.Fatalf("formatPorts = %q", got)
+	}
+}
@@ -0,0 +1,58 @@
+package containers
+
+import (
+	stdcontext "context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// msgDiffLoaded carries the two containers being compared.
+type msgDiffLoaded struct {
+	left  backend.ContainerDetail
+	right backend.ContainerDetail
+	err   error
+}
+
+// handleDiff inspects the two selected containers to compare them.
+func (model *Model) handleDiff() tea.Cmd {
+	ids := model.GetSelectedIDs()
+	if len(ids) != 2 {
+		return notifications.ShowInfo("Select two containers to compare")
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+		defer cancel()
+		var details [2]backend.ContainerDetail
+		for i, id := range ids {
+			detail, err := state.GetBackend().InspectContainer(ctx, id)
+			if err != nil {
+				return msgDiffLoaded{err: fmt.Errorf("failed to inspect container %s: %w", id, err)}
+			}
+			details[i] = detail
+		}
+		return msgDiffLoaded{left: details[0], right: details[1]}
+	}
+}
+
+// showDiff opens an overlay comparing two containers.
+func (model *Model) showDiff(left, right backend.ContainerDetail) {
+	leftName := strings.TrimPrefix(left.Name, "/")
+	rightName := strings.TrimPrefix(right.Name, "/")
+	reveal := model.detailsPanel.SecretsRevealed()
+
+	model.SetOverlay(components.NewDiffView(
+		fmt.Sprintf("Comparing %s and %s", leftName, rightName),
+		func(width int) string {
+			return builders.BuildDiffPanel(leftName, rightName, left, right, width, reveal)
+		},
+	))
+}
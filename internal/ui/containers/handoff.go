@@ -0,0 +1,54 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/givensuman/containertui/internal/backend/multi"
+	"github.com/givensuman/containertui/internal/state"
+)
+
+// dockerTarget returns the environment that points the docker CLI at the
+// daemon owning containerID, and the ID as that daemon knows it.
+func dockerTarget(containerID string) ([]string, string) {
+	endpoint := state.GetEndpoint()
+	if host, id, ok := multi.SplitID(containerID); ok {
+		for _, e := range state.GetEndpoints() {
+			if e.Name() == host {
+				endpoint, containerID = e, id
+				break
+			}
+		}
+	}
+
+	env := os.Environ()
+	switch {
+	case endpoint.Host != "":
+		env = append(env, "DOCKER_HOST="+endpoint.Host)
+	case endpoint.Context != "":
+		env = append(env, "DOCKER_CONTEXT="+endpoint.Context)
+	}
+	return env, containerID
+}
+
+// LogsCommand returns the command that shows the recent logs of a container
+// in a pager.
+func LogsCommand(containerID string) *exec.Cmd {
+	env, id := dockerTarget(containerID)
+	tmpFile := fmt.Sprintf("/tmp/containertui-logs-%s.txt", id)
+	command := exec.Command("sh", "-c",
+		fmt.Sprintf("docker logs --tail 500 --timestamps %s > %s 2>&1; less -R %s; rm -f %s",
+			id, tmpFile, tmpFile, tmpFile))
+	command.Env = env
+	return command
+}
+
+// ShellCommand returns the command that opens an interactive shell in a
+// running container.
+func ShellCommand(containerID string) *exec.Cmd {
+	env, id := dockerTarget(containerID)
+	command := exec.Command("docker", "exec", "-it", id, "/bin/sh")
+	command.Env = env
+	return command
+}
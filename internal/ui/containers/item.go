@@ -9,10 +9,15 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/ui/icons"
 )
 
+// hostColumnWidth is the width of the host column shown when containers from
+// several hosts are listed together.
+const hostColumnWidth = 12
+
 type ContainerItem struct {
 	backend.Container
 	isSelected bool
@@ -122,13 +127,22 @@ func (containerItem ContainerItem) Title() string {
 	nameStyle := lipgloss.NewStyle().Foreground(statusColor)
 	styledName := nameStyle.Render(containerItem.Name)
 
+	if containerItem.Host != "" {
+		host := lipgloss.NewStyle().Foreground(colors.Muted()).Width(hostColumnWidth).MaxWidth(hostColumnWidth).Render(containerItem.Host)
+		return fmt.Sprintf("%s %s %s %s", statusIcon, statusStateIcon, host, styledName)
+	}
+
 	return fmt.Sprintf("%s %s %s", statusIcon, statusStateIcon, styledName)
 }
 
 func (containerItem ContainerItem) Description() string {
 	shortID := containerItem.ID
-	if len(containerItem.ID) > 12 {
-		shortID = containerItem.ID[:12]
+	// Aggregated IDs carry the host, which Title already shows
+	if _, id, ok := multi.SplitID(shortID); ok && containerItem.Host != "" {
+		shortID = id
+	}
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
 	}
 
 	return "   " + shortID
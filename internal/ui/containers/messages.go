@@ -1,11 +1,24 @@
 package containers
 
 import (
-	tea "charm.land/bubbletea/v2"
 	stdcontext "context"
+	"errors"
+	"fmt"
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/state"
 )
 
+// Job kinds submitted by the containers tab.
+const (
+	jobKindBulkOperation = "containers/bulk"
+	jobKindPrune         = "containers/prune"
+	jobKindCompose       = "containers/compose"
+)
+
 // MsgContainerOperationResult indicates the result of a container operation.
 type MsgContainerOperationResult struct {
 	Operation Operation
@@ -14,9 +27,13 @@ type MsgContainerOperationResult struct {
 }
 
 // MsgContainersRefreshed carries a full refreshed container item list.
+// HostErr reports aggregated hosts that failed while the others answered.
 type MsgContainersRefreshed struct {
-	Items []ContainerItem
-	Err   error
+	Items   []ContainerItem
+	Err     error
+	HostErr error
+	// source is the LoadSource of the model that asked for the refresh
+	source *int
 }
 
 type Operation int
@@ -30,35 +47,132 @@ const (
 	Remove
 )
 
-// PerformContainerOperation performs the specified operation on a single container asynchronously.
-func PerformContainerOperation(operation Operation, containerID string, force bool) tea.Cmd {
+func (operation Operation) String() string {
+	return [...]string{
+		"Pause",
+		"Unpause",
+		"Start",
+		"Stop",
+		"Restart",
+		"Remove",
+	}[operation]
+}
+
+func performContainerOperation(ctx stdcontext.Context, operation Operation, containerID string, force bool) error {
+	client := state.GetBackend()
+	switch operation {
+	case Pause:
+		return client.PauseContainer(ctx, containerID)
+	case Unpause:
+		return client.UnpauseContainer(ctx, containerID)
+	case Start:
+		return client.StartContainer(ctx, containerID)
+	case Stop:
+		return client.StopContainer(ctx, containerID)
+	case Restart:
+		return client.RestartContainer(ctx, containerID)
+	case Remove:
+		return client.RemoveContainer(ctx, containerID, force)
+	}
+	return nil
+}
+
+// PerformContainerOperation performs the specified operation on a single container
+// asynchronously. The operation stops early when ctx is cancelled or the configured
+// action timeout elapses.
+func PerformContainerOperation(ctx stdcontext.Context, operation Operation, containerID string, force bool) tea.Cmd {
 	return func() tea.Msg {
-		var err error
-		client := state.GetBackend()
-		switch operation {
-		case Pause:
-			err = client.PauseContainer(stdcontext.Background(), containerID)
-		case Unpause:
-			err = client.UnpauseContainer(stdcontext.Background(), containerID)
-		case Start:
-			err = client.StartContainer(stdcontext.Background(), containerID)
-		case Stop:
-			err = client.StopContainer(stdcontext.Background(), containerID)
-		case Restart:
-			err = client.RestartContainer(stdcontext.Background(), containerID)
-		case Remove:
-			err = client.RemoveContainer(stdcontext.Background(), containerID, force)
-		}
+		ctx, cancel := state.OperationContext(ctx, config.OperationAction)
+		defer cancel()
+		err := performContainerOperation(ctx, operation, containerID, force)
 		return MsgContainerOperationResult{Operation: operation, ID: containerID, Error: err}
 	}
 }
 
-// PerformContainerOperations performs the specified operation on multiple containers asynchronously.
-// Returns a batch of commands, one for each container.
-func PerformContainerOperations(operation Operation, containerIDs []string, force bool) tea.Cmd {
-	var cmds []tea.Cmd
-	for _, id := range containerIDs {
-		cmds = append(cmds, PerformContainerOperation(operation, id, force))
+// performContainerOperations performs the specified operation on multiple containers
+// as a single background job with one progress item per container. Each container
+// runs under its context in contexts, so it can be cancelled on its own as well as
+// with the whole job. The per-container results are delivered as
+// MsgContainerOperationResult once the job finishes.
+func (model *Model) performContainerOperations(contexts map[string]stdcontext.Context, operation Operation, containerIDs []string, force bool) tea.Cmd {
+	if len(containerIDs) == 1 {
+		return PerformContainerOperation(itemContext(contexts, containerIDs[0]), operation, containerIDs[0], force)
+	}
+
+	title := fmt.Sprintf("%s %d containers", operation, len(containerIDs))
+	return model.SubmitJob(title, jobKindBulkOperation, bulkOperationJob(contexts, operation, containerIDs, force))
+}
+
+func bulkOperationJob(contexts map[string]stdcontext.Context, operation Operation, containerIDs []string, force bool) jobs.Func {
+	return func(ctx stdcontext.Context, progress *jobs.Progress) error {
+		results := make([]MsgContainerOperationResult, len(containerIDs))
+		for _, id := range containerIDs {
+			progress.SetItem(shortID(id), "pending", 0, 1)
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		completed := 0
+		for i, id := range containerIDs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// Cancel the container's operation when either it or the job is cancelled.
+				opCtx, cancel := state.OperationContext(itemContext(contexts, id), config.OperationAction)
+				defer cancel()
+				stop := stdcontext.AfterFunc(ctx, cancel)
+				defer stop()
+
+				err := performContainerOperation(opCtx, operation, id, force)
+				results[i] = MsgContainerOperationResult{Operation: operation, ID: id, Error: err}
+
+				status := "done"
+				switch {
+				case errors.Is(err, stdcontext.Canceled):
+					status = "cancelled"
+				case err != nil:
+					status = "failed"
+				}
+				progress.SetItem(shortID(id), status, 1, 1)
+
+				mu.Lock()
+				completed++
+				progress.SetPercent(float64(completed) / float64(len(containerIDs)))
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		progress.SetResult(results)
+
+		failed := 0
+		for _, result := range results {
+			if result.Error != nil && !errors.Is(result.Error, stdcontext.Canceled) {
+				failed++
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d containers failed", failed, len(containerIDs))
+		}
+		return nil
+	}
+}
+
+// itemContext returns the context for containerID, or a background context
+// when none was provided.
+func itemContext(contexts map[string]stdcontext.Context, containerID string) stdcontext.Context {
+	if ctx, ok := contexts[containerID]; ok {
+		return ctx
+	}
+	return stdcontext.Background()
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
 	}
-	return tea.Batch(cmds...)
+	return id
 }
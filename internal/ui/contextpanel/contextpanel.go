@@ -0,0 +1,229 @@
+// Package contextpanel implements the Docker context switcher overlay and the
+// glue that rebuilds the shared backend for the chosen context.
+package contextpanel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	dockerbackend "github.com/givensuman/containertui/internal/backend/docker"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/layout"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// msgContextsLoaded carries the contexts read from the Docker context store.
+type msgContextsLoaded struct {
+	contexts []dockerbackend.Context
+	err      error
+}
+
+// SwitchTo tears down the shared backend and rebuilds it for the named
+// context. Jobs running against the previous daemon are cancelled first.
+func SwitchTo(name string) tea.Cmd {
+	return func() tea.Msg {
+		state.GetJobs().CancelAll()
+		if err := state.SwitchContext(name); err != nil {
+			return base.MsgContextSwitched{Context: name, Err: err}
+		}
+
+		supervisor := state.GetConnection()
+		supervisor.Reset()
+		supervisor.Check(context.Background())
+		return base.MsgContextSwitched{Context: name}
+	}
+}
+
+func loadContexts() tea.Msg {
+	contexts, err := dockerbackend.ListContexts()
+	return msgContextsLoaded{contexts: contexts, err: err}
+}
+
+type keybindings struct {
+	Toggle key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Close  key.Binding
+}
+
+func newKeybindings() keybindings {
+	return keybindings{
+		Toggle: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "contexts"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "switch"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// Model is the context switcher listing every known Docker context.
+type Model struct {
+	base.WindowSize
+	Keybindings keybindings
+	contexts    []dockerbackend.Context
+	active      string
+	cursor      int
+	visible     bool
+	err         error
+}
+
+// New creates a hidden context switcher.
+func New() Model {
+	return Model{Keybindings: newKeybindings()}
+}
+
+// IsVisible reports whether the switcher is shown.
+func (model Model) IsVisible() bool {
+	return model.visible
+}
+
+// Open shows the switcher and reloads the context store.
+func (model *Model) Open() tea.Cmd {
+	model.visible = true
+	model.active = state.GetEndpoint().Name()
+	return loadContexts
+}
+
+// Update handles loaded contexts and switcher keys while visible.
+func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		model.WindowWidth = msg.Width
+		model.WindowHeight = msg.Height
+
+	case msgContextsLoaded:
+		model.contexts = msg.contexts
+		model.err = msg.err
+		model.cursor = 0
+		for i, dockerContext := range model.contexts {
+			if dockerContext.Name == model.active {
+				model.cursor = i
+			}
+		}
+
+	case tea.KeyPressMsg:
+		if !model.visible {
+			return model, nil
+		}
+
+		switch {
+		case key.Matches(msg, model.Keybindings.Close), key.Matches(msg, model.Keybindings.Toggle):
+			model.visible = false
+		case key.Matches(msg, model.Keybindings.Up):
+			model.cursor = max(0, model.cursor-1)
+		case key.Matches(msg, model.Keybindings.Down):
+			model.cursor = min(max(0, len(model.contexts)-1), model.cursor+1)
+		case key.Matches(msg, model.Keybindings.Select):
+			if model.cursor >= len(model.contexts) {
+				return model, nil
+			}
+			model.visible = false
+			name := model.contexts[model.cursor].Name
+			if name == model.active {
+				return model, nil
+			}
+			return model, tea.Batch(
+				notifications.ShowInfo(fmt.Sprintf("Switching to context %s...", name)),
+				SwitchTo(name),
+			)
+		}
+	}
+
+	return model, nil
+}
+
+// View renders the switcher box.
+func (model Model) View() string {
+	style := lipgloss.NewStyle().
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	layoutManager := layout.NewLayoutManager(model.WindowWidth, model.WindowHeight)
+	dimensions := layoutManager.CalculateLarge(style)
+	style = style.Width(dimensions.Width).Height(dimensions.Height)
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Docker Contexts"), "")
+
+	if model.err != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colors.Error()).Render(model.err.Error()))
+	}
+
+	for i, dockerContext := range model.contexts {
+		lines = append(lines, renderContext(dockerContext, i == model.cursor, dockerContext.Name == model.active, dimensions.ContentWidth)...)
+	}
+
+	lines = append(lines, "", mutedStyle.Render("↑/↓ select • enter switch • esc close"))
+
+	content := strings.Join(lines, "\n")
+	return style.Render(lipgloss.NewStyle().MaxHeight(dimensions.ContentHeight).Render(content))
+}
+
+func renderContext(dockerContext dockerbackend.Context, selected, active bool, width int) []string {
+	cursor := "  "
+	nameStyle := lipgloss.NewStyle().Foreground(colors.Text())
+	if selected {
+		cursor = "> "
+		nameStyle = nameStyle.Bold(true).Foreground(colors.Primary())
+	}
+
+	header := cursor + nameStyle.Render(dockerContext.Name)
+	if active {
+		header += "  " + lipgloss.NewStyle().Foreground(colors.Success()).Render("active")
+	}
+
+	detailStyle := lipgloss.NewStyle().Foreground(colors.Muted()).MaxWidth(width)
+	host := dockerContext.Host
+	if host == "" {
+		host = "default socket"
+	}
+	lines := []string{
+		lipgloss.NewStyle().MaxWidth(width).Render(header),
+		detailStyle.Render("    " + host),
+	}
+	if dockerContext.Description != "" {
+		lines = append(lines, detailStyle.Render("    "+dockerContext.Description))
+	}
+	return lines
+}
+
+// ShortHelp returns keybindings for the mini help view.
+func (model Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		model.Keybindings.Up,
+		model.Keybindings.Down,
+		model.Keybindings.Select,
+		model.Keybindings.Close,
+	}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (model Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{model.ShortHelp()}
+}
@@ -0,0 +1,62 @@
+package contextpanel
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	dockerbackend "github.com/givensuman/containertui/internal/backend/docker"
+)
+
+func loadedTestPanel() Model {
+	model := New()
+	model.visible = true
+	model.active = "remote"
+	model.WindowWidth = 120
+	model.WindowHeight = 40
+	model, _ = model.Update(msgContextsLoaded{contexts: []dockerbackend.Context{
+		{Name: dockerbackend.DefaultContext},
+		{Name: "build-vm", Host: "tcp://build:2375"},
+		{Name: "remote", Host: "tcp://10.0.0.5:2376", Description: "prod"},
+	}})
+	return model
+}
+
+func TestLoadedContextsSelectActiveContext(t *testing.T) {
+	model := loadedTestPanel()
+	if model.cursor != 2 {
+		t.Fatalf("expected cursor on active context, got %d", model.cursor)
+	}
+
+	view := model.View()
+	for _, want := range []string{"build-vm", "tcp://10.0.0.5:2376", "prod", "active", "default socket"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestSelectingActiveContextOnlyCloses(t *testing.T) {
+	model := loadedTestPanel()
+
+	model, cmd := model.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatal("expected no switch when selecting the active context")
+	}
+	if model.IsVisible() {
+		t.Fatal("expected switcher to close after selecting")
+	}
+}
+
+func TestSelectingOtherContextSwitches(t *testing.T) {
+	model := loadedTestPanel()
+
+	model, _ = model.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	model, cmd := model.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a switch command for another context")
+	}
+	if model.IsVisible() {
+		t.Fatal("expected switcher to close after selecting")
+	}
+}
@@ -0,0 +1,70 @@
+package images
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/layout"
+)
+
+// tableColumns are the columns the images table can show.
+func tableColumns() []components.Column[ImageItem] {
+	return []components.Column[ImageItem]{
+		{
+			Key: "repo", Title: "Repository", ColumnSpec: layout.ColumnSpec{MinWidth: 20, Flex: 3},
+			// The in-use marker stays in front of the repository
+			Value: func(item ImageItem) string {
+				repo, _ := repoAndTag(item.Image)
+				return item.getTitleOrnament() + repo
+			},
+			Compare: func(a, b ImageItem) int {
+				repoA, _ := repoAndTag(a.Image)
+				repoB, _ := repoAndTag(b.Image)
+				return cmp.Compare(repoA, repoB)
+			},
+		},
+		{
+			Key: "tag", Title: "Tag", ColumnSpec: layout.ColumnSpec{MinWidth: 10, Flex: 1},
+			Value: func(item ImageItem) string {
+				_, tag := repoAndTag(item.Image)
+				return tag
+			},
+		},
+		{
+			Key: "size", Title: "Size", ColumnSpec: layout.ColumnSpec{MinWidth: 10},
+			Value:   func(item ImageItem) string { return infopanel.FormatBytes(item.Image.Size) },
+			Compare: func(a, b ImageItem) int { return cmp.Compare(a.Image.Size, b.Image.Size) },
+		},
+		{
+			Key: "created", Title: "Created", ColumnSpec: layout.ColumnSpec{MinWidth: 14},
+			Value:   func(item ImageItem) string { return infopanel.FormatTimeAgo(item.Image.Created) },
+			Compare: func(a, b ImageItem) int { return a.Image.Created.Compare(b.Image.Created) },
+		},
+		{
+			Key: "containers", Title: "Containers", ColumnSpec: layout.ColumnSpec{MinWidth: 10},
+			Value:   func(item ImageItem) string { return strconv.Itoa(item.Containers) },
+			Compare: func(a, b ImageItem) int { return cmp.Compare(a.Containers, b.Containers) },
+		},
+		{
+			Key: "id", Title: "ID", ColumnSpec: layout.ColumnSpec{MinWidth: 12},
+			Value: func(item ImageItem) string { return infopanel.TruncateID(item.Image.ID) },
+		},
+	}
+}
+
+// repoAndTag splits the image's first tag into its repository and tag.
+func repoAndTag(image backend.Image) (string, string) {
+	if len(image.RepoTags) == 0 {
+		return "<none>", "<none>"
+	}
+	ref := image.RepoTags[0]
+	// A colon before the last slash belongs to a registry port
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
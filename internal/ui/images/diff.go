@@ -0,0 +1,65 @@
+package images
+
+import (
+	stdcontext "context"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// msgDiffLoaded carries the two images being compared.
+type msgDiffLoaded struct {
+	left  backend.ImageDetail
+	right backend.ImageDetail
+	err   error
+}
+
+// handleDiff inspects the two selected images to compare them.
+func (model *Model) handleDiff() tea.Cmd {
+	ids := model.GetSelectedIDs()
+	if len(ids) != 2 {
+		return notifications.ShowInfo("Select two images to compare")
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+		defer cancel()
+		var details [2]backend.ImageDetail
+		for i, id := range ids {
+			detail, err := state.GetBackend().InspectImage(ctx, id)
+			if err != nil {
+				return msgDiffLoaded{err: fmt.Errorf("failed to inspect image %s: %w", id, err)}
+			}
+			details[i] = detail
+		}
+		return msgDiffLoaded{left: details[0], right: details[1]}
+	}
+}
+
+// showDiff opens an overlay comparing two images.
+func (model *Model) showDiff(left, right backend.ImageDetail) {
+	leftName, rightName := imageName(left), imageName(right)
+	reveal := model.detailsPanel.SecretsRevealed()
+
+	model.SetOverlay(components.NewDiffView(
+		fmt.Sprintf("Comparing %s and %s", leftName, rightName),
+		func(width int) string {
+			return builders.BuildDiffPanel(leftName, rightName, left, right, width, reveal)
+		},
+	))
+}
+
+// imageName names an image by its first tag, or else its short ID.
+func imageName(image backend.ImageDetail) string {
+	if len(image.RepoTags) > 0 && image.RepoTags[0] != "<none>:<none>" {
+		return image.RepoTags[0]
+	}
+	return infopanel.TruncateID(image.ID)
+}
@@ -0,0 +1,140 @@
+package images
+
+import (
+	stdcontext "context"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/atotto/clipboard"
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/export"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/secrets"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// msgDockerfileGenerated carries a Dockerfile reconstructed from an image,
+// masked for the preview and as written. toClipboard asks for the preview
+// to be copied rather than exported.
+type msgDockerfileGenerated struct {
+	preview     []byte
+	content     []byte
+	toClipboard bool
+	err         error
+}
+
+// msgDockerfileWritten is sent when a Dockerfile has been exported.
+type msgDockerfileWritten struct {
+	path string
+	err  error
+}
+
+// dockerfileResult is the result of a Dockerfile job.
+type dockerfileResult struct {
+	preview     []byte
+	content     []byte
+	toClipboard bool
+}
+
+// secretRules masks secrets unless the details panel reveals them.
+func (model *Model) secretRules() *secrets.Detector {
+	if model.detailsPanel.SecretsRevealed() {
+		return nil
+	}
+	return secrets.Current()
+}
+
+// handleCopyDockerfile copies the Dockerfile reconstructed from the image
+// under the cursor, with secrets masked unless the details panel reveals
+// them.
+func (model *Model) handleCopyDockerfile() tea.Cmd {
+	return model.submitDockerfileJob(true)
+}
+
+// handleExportDockerfile reconstructs the Dockerfile of the image under the
+// cursor, to preview it before asking where to write it.
+func (model *Model) handleExportDockerfile() tea.Cmd {
+	return model.submitDockerfileJob(false)
+}
+
+// submitDockerfileJob reconstructs the Dockerfile of the image under the
+// cursor in the background, since guessing its base inspects every local
+// image.
+func (model *Model) submitDockerfileJob(toClipboard bool) tea.Cmd {
+	item := model.GetSelectedItem()
+	if item == nil {
+		return nil
+	}
+	name := imageName(backend.ImageDetail{Image: item.Image})
+	return model.SubmitJob(fmt.Sprintf("Reconstruct Dockerfile of %s", name), jobKindDockerfile, dockerfileJob(item.Image.ID, model.secretRules(), toClipboard))
+}
+
+// dockerfileJob reconstructs the Dockerfile of an image, masked by rules for
+// the preview.
+func dockerfileJob(id string, rules *secrets.Detector, toClipboard bool) jobs.Func {
+	return func(ctx stdcontext.Context, jobProgress *jobs.Progress) error {
+		jobProgress.SetMessage("Inspecting image and guessing its base...")
+
+		ctx, cancel := state.OperationContext(ctx, config.OperationInspect)
+		defer cancel()
+		source, err := export.GatherDockerfile(ctx, state.GetBackend(), id)
+		if err != nil {
+			return err
+		}
+
+		jobProgress.SetResult(dockerfileResult{
+			preview:     export.Dockerfile(source, rules),
+			content:     export.Dockerfile(source, nil),
+			toClipboard: toClipboard,
+		})
+		return nil
+	}
+}
+
+// copyDockerfile copies a reconstructed Dockerfile preview to the clipboard.
+func copyDockerfile(preview []byte) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(string(preview)); err != nil {
+			return notifications.ShowError(err)()
+		}
+		if strings.Contains(string(preview), secrets.Mask) {
+			return notifications.ShowSuccess("Copied Dockerfile with secrets masked")()
+		}
+		return notifications.ShowSuccess("Copied Dockerfile")()
+	}
+}
+
+// showDockerfileDialog asks where to write a reconstructed Dockerfile.
+func (model *Model) showDockerfileDialog(content []byte) {
+	dialog := components.NewFormDialog(
+		"Export Dockerfile",
+		[]components.FormField{
+			{
+				Label:       "Path",
+				Placeholder: "Dockerfile",
+				Value:       "Dockerfile",
+				Required:    true,
+			},
+		},
+		base.SmartDialogAction{Type: "ExportDockerfile"},
+		map[string]any{"content": content},
+	)
+
+	model.SetOverlay(dialog)
+}
+
+// writeDockerfile writes an exported Dockerfile, secrets included, to path.
+func writeDockerfile(path string, content []byte) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return msgDockerfileWritten{err: fmt.Errorf("failed to write Dockerfile: %w", err)}
+		}
+		return msgDockerfileWritten{path: path}
+	}
+}
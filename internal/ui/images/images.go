@@ -16,11 +16,16 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
 	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/progress"
 	"github.com/givensuman/containertui/internal/ui/safety"
@@ -67,15 +72,6 @@ type pullProgressDetail struct {
 	Total   int64 `json:"total"`
 }
 
-// MsgPullProgress contains progress information from image pull.
-type MsgPullProgress struct {
-	ImageName    string
-	Message      string
-	Raw          string
-	ProgressChan <-chan string
-	DoneChan     <-chan error
-}
-
 // MsgPullComplete indicates the image pull has finished.
 type MsgPullComplete struct {
 	ImageName string
@@ -91,6 +87,13 @@ type MsgCreateContainerComplete struct {
 	Err         error
 }
 
+// MsgRemoveImageComplete indicates an image removal finished.
+type MsgRemoveImageComplete struct {
+	ImageID string
+	Force   bool
+	Err     error
+}
+
 // MsgCreateContainerStart indicates create flow should begin execution.
 type MsgCreateContainerStart struct {
 	Config    backend.ContainerConfig
@@ -135,16 +138,6 @@ type MsgBuildImageComplete struct {
 	Err error
 }
 
-// MsgBuildImageProgress contains streamed build progress updates.
-type MsgBuildImageProgress struct {
-	Tag          string
-	Message      string
-	Percent      float64
-	HasPercent   bool
-	ProgressChan <-chan string
-	DoneChan     <-chan error
-}
-
 // MsgPruneComplete is sent when the prune operation completes
 type MsgPruneComplete struct {
 	SpaceReclaimed uint64
@@ -161,6 +154,11 @@ type keybindings struct {
 	buildImage           key.Binding
 	pullImage            key.Binding
 	createContainer      key.Binding
+	diff                 key.Binding
+	exploreLayers        key.Binding
+	copyDockerfile       key.Binding
+	exportDockerfile     key.Binding
+	cancelOperation      key.Binding
 	switchTab            key.Binding
 }
 
@@ -202,9 +200,29 @@ func newKeybindings() *keybindings {
 			key.WithKeys("c"),
 			key.WithHelp("c", "create container"),
 		),
+		diff: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "compare selected"),
+		),
+		exploreLayers: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "explore layers"),
+		),
+		copyDockerfile: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "copy dockerfile"),
+		),
+		exportDockerfile: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "export dockerfile"),
+		),
+		cancelOperation: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel operation"),
+		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "5"),
-			key.WithHelp("1-5", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "switch tab"),
 		),
 	}
 }
@@ -345,59 +363,48 @@ type Model struct {
 	detailsKeybindings components.DetailsKeybindings
 	inspection         backend.ImageDetail
 	detailsPanel       components.DetailsPanel
-	pullLayers         map[string]pullLayerProgress
-	pullPercent        float64
-	buildPercent       float64
-	activeOperation    string
-	operationCancel    stdcontext.CancelFunc
-}
-
-type pullLayerProgress struct {
-	current int64
-	total   int64
+	// operation is the removal or container creation in flight, which esc
+	// cancels.
+	operation components.Operation
+	// dockerfilePreview is the Dockerfile shown in the details panel while
+	// asking where to export it
+	dockerfilePreview []byte
 }
 
 func New() Model {
 	imageKeybindings := newKeybindings()
 
 	fetchImages := func() ([]ImageItem, error) {
-		imageList, err := state.GetBackend().ListImages(stdcontext.Background())
-		if err != nil {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+		defer cancel()
+		imageList, hostErr := state.GetBackend().ListImages(ctx)
+		if err := multi.IgnorePartial(hostErr); err != nil {
 			return nil, err
 		}
 
 		// Get all containers to determine which images are in use
-		containers, err := state.GetBackend().ListContainers(stdcontext.Background())
-		if err != nil {
+		containers, err := state.GetBackend().ListContainers(ctx)
+		if err := multi.IgnorePartial(err); err != nil {
 			return nil, err
 		}
-
-		// Build a map of image IDs that are in use
-		inUseImages := make(map[string]bool)
-		for _, container := range containers {
-			// Mark by image name (e.g., "nginx:latest") and ID
-			inUseImages[container.Image] = true
+		if hostErr == nil {
+			// Usage from the hosts that answered is still complete
+			hostErr = err
 		}
 
+		// Images are in use when a container runs them by ID or by any tag
+		users := safety.ImageContainerCounts(imageList, containers)
+
 		items := make([]ImageItem, 0, len(imageList))
 		for _, image := range imageList {
-			// Check if this image is in use by checking its ID or any of its tags
-			inUse := inUseImages[image.ID]
-			if !inUse {
-				// Also check if any repo tags match
-				for _, tag := range image.RepoTags {
-					if inUseImages[tag] {
-						inUse = true
-						break
-					}
-				}
-			}
 			items = append(items, ImageItem{
-				Image: image,
-				InUse: inUse,
+				Image:      image,
+				InUse:      users[image.ID] > 0,
+				Containers: users[image.ID],
 			})
 		}
-		return items, nil
+		// Hosts that failed are reported by the view, which keeps the rest
+		return items, hostErr
 	}
 
 	resourceView := components.NewResourceView[string, ImageItem](
@@ -410,6 +417,11 @@ func New() Model {
 		},
 	)
 
+	resourceView.EnableQuery(filter.ImageQueryKeys, func(item ImageItem) filter.Fields {
+		return filter.ImageFields(item.Image)
+	})
+	resourceView.EnableTable(tableColumns(), components.TableSettings("images"), components.SaveTableSettings("images"))
+
 	// Add extra pane below detail pane
 	extraPane := components.NewViewportPane()
 	extraPane.SetContent("")                            // Will be populated when an image is selected
@@ -429,7 +441,6 @@ func New() Model {
 		detailsKeybindings: components.NewDetailsKeybindings(),
 		inspection:         backend.ImageDetail{},
 		detailsPanel:       components.NewDetailsPanel(),
-		pullLayers:         make(map[string]pullLayerProgress),
 	}
 
 	// Add custom keybindings to help
@@ -442,25 +453,45 @@ func New() Model {
 		imageKeybindings.buildImage,
 		imageKeybindings.pullImage,
 		imageKeybindings.createContainer,
+		imageKeybindings.diff,
+		imageKeybindings.exploreLayers,
+		imageKeybindings.copyDockerfile,
+		imageKeybindings.exportDockerfile,
+		imageKeybindings.cancelOperation,
+	}
+
+	// Actions that change daemon state are disabled while it is unreachable
+	model.MutatingKeys = []key.Binding{
+		imageKeybindings.remove,
+		imageKeybindings.pruneImages,
+		imageKeybindings.tagImage,
+		imageKeybindings.runAndExec,
+		imageKeybindings.buildImage,
+		imageKeybindings.pullImage,
+		imageKeybindings.createContainer,
 	}
 
 	return model
 }
 
+// SetLaunchFilter limits the list to the images matching f and, once they
+// load, puts the cursor on the one called selectName.
+func (model *Model) SetLaunchFilter(f filter.Filter, selectName string) {
+	if !f.Empty() {
+		model.Filter = func(item ImageItem) bool { return f.Match(filter.ImageFields(item.Image)) }
+	}
+	model.SelectOnLoad(selectName)
+}
+
 func (model Model) Init() tea.Cmd {
 	return model.ResourceView.Init()
 }
 
 func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.String() == "esc" && model.activeOperation != "" {
-		if model.operationCancel != nil {
-			model.operationCancel()
-		}
-		op := model.activeOperation
-		model.activeOperation = ""
-		model.operationCancel = nil
-		model.CloseOverlay()
-		return model, notifications.ShowInfo(fmt.Sprintf("Cancelled %s operation", op))
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.String() == "esc" && model.canCancelOperation() {
+		// The outcome is reported once the cancelled operation returns
+		model.operation.Cancel()
+		return model, nil
 	}
 
 	// 1. Try standard ResourceView updates first (resizing, dialog closing, basic navigation)
@@ -471,6 +502,41 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	// 2. Handle Messages
 	switch msg := msg.(type) {
+	case msgDiffLoaded:
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		model.showDiff(msg.left, msg.right)
+
+	case msgLayersLoaded:
+		if msg.err != nil {
+			return model, notifications.ShowError(fmt.Errorf("failed to read image layers: %w", msg.err))
+		}
+		model.showLayers(msg.name, msg.image)
+
+	case msgDockerfileGenerated:
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		if msg.toClipboard {
+			return model, copyDockerfile(msg.preview)
+		}
+		model.dockerfilePreview = msg.preview
+		model.refreshInspectionContent()
+		model.showDockerfileDialog(msg.content)
+
+	case msgDockerfileWritten:
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		return model, notifications.ShowSuccess(fmt.Sprintf("Exported Dockerfile: %s", msg.path))
+
+	case base.CloseDialogMessage:
+		if model.dockerfilePreview != nil {
+			model.dockerfilePreview = nil
+			model.refreshInspectionContent()
+		}
+
 	case MsgImageInspection:
 		if msg.ID == model.detailsPanel.GetCurrentID() && msg.Err == nil {
 			model.inspection = msg.Image
@@ -482,67 +548,24 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case base.MsgRestoreScroll:
 		// Restore scroll position after viewport has processed content
 		model.detailsPanel.RestoreScrollPosition(model.getViewport())
-		return model, nil
 
-	case MsgPullComplete:
-		model.activeOperation = ""
-		model.operationCancel = nil
-		if msg.Err != nil {
-			// Show error dialog
-			errorDialog := components.NewDialog(
-				fmt.Sprintf("Failed to pull image:\n\n%v", msg.Err),
-				[]components.DialogButton{{Label: "OK"}},
-			)
-			model.SetOverlay(errorDialog)
-		} else {
-			if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-				_ = progressDialog.SetPercent(1.0)
-			}
-
-			// Success - close dialog and refresh list
-			model.CloseOverlay()
-			model.pullLayers = make(map[string]pullLayerProgress)
-			model.pullPercent = 0
-			// Trigger images refresh
-			return model, func() tea.Msg {
-				return MsgRefreshImages{}
-			}
+	case components.MsgRevealExpired:
+		if model.detailsPanel.ExpireReveal() {
+			model.refreshInspectionContent()
 		}
 		return model, nil
 
-	case MsgPullProgress:
-		var progressCmd tea.Cmd
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			progressDialog.SetStatus(msg.Message)
-			if percent, hasPercent := model.estimatePullProgress(msg.Raw); hasPercent {
-				progressCmd = progressDialog.SetPercent(percent)
-			}
-			model.Foreground = progressDialog
-		}
+	case base.MsgJobsUpdated:
+		cmds = append(cmds, handleFinishedJobs(model.OwnFinished(msg.Finished)))
 
-		if msg.ProgressChan != nil && msg.DoneChan != nil {
-			return model, tea.Batch(progressCmd, listenToPullProgress(msg.ImageName, msg.ProgressChan, msg.DoneChan))
-		}
-		return model, progressCmd
-
-	case MsgBuildImageProgress:
-		var progressCmd tea.Cmd
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			progressDialog.SetStatus(msg.Message)
-			if msg.HasPercent {
-				if msg.Percent < model.buildPercent {
-					msg.Percent = model.buildPercent
-				}
-				model.buildPercent = msg.Percent
-				progressCmd = progressDialog.SetPercent(msg.Percent)
-			}
-			model.Foreground = progressDialog
-		}
-
-		if msg.ProgressChan != nil && msg.DoneChan != nil {
-			return model, tea.Batch(progressCmd, listenToBuildProgress(msg.Tag, msg.Percent, msg.ProgressChan, msg.DoneChan))
+	case MsgPullComplete:
+		if msg.Err != nil {
+			return model, notifications.ShowError(fmt.Errorf("failed to pull image: %w", msg.Err))
 		}
-		return model, progressCmd
+		return model, tea.Batch(
+			notifications.ShowSuccess(fmt.Sprintf("Pulled image: %s", msg.ImageName)),
+			model.Refresh(),
+		)
 
 	case MsgCreateContainerStart:
 		var progressCmd tea.Cmd
@@ -554,8 +577,9 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			model.Foreground = progressDialog
 		}
 
+		ctx := model.operation.Context()
 		createCmd := func() tea.Msg {
-			containerID, err := state.GetBackend().CreateContainer(stdcontext.Background(), msg.Config)
+			containerID, err := state.GetBackend().CreateContainer(ctx, msg.Config)
 			return MsgCreateContainerCreated{ContainerID: containerID, AutoStart: msg.AutoStart, Err: err}
 		}
 
@@ -576,8 +600,9 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				model.Foreground = progressDialog
 			}
 
+			ctx := model.operation.Context()
 			startCmd := func() tea.Msg {
-				err := state.GetBackend().StartContainer(stdcontext.Background(), msg.ContainerID)
+				err := state.GetBackend().StartContainer(ctx, msg.ContainerID)
 				return MsgCreateContainerStarted{ContainerID: msg.ContainerID, Err: err}
 			}
 			return model, tea.Batch(progressCmd, startCmd)
@@ -605,15 +630,16 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 	case MsgCreateContainerComplete:
+		name := model.operation.Name()
+		model.operation.Finish()
 		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
 			_ = progressDialog.SetPercent(1.0)
+			// Close the progress dialog
+			model.CloseOverlay()
 		}
-		// Close the progress dialog
-		model.CloseOverlay()
 
 		if msg.Err != nil {
-			// Show error notification
-			return model, notifications.ShowError(msg.Err)
+			return model, components.OperationOutcome(name, msg.Err, "")
 		}
 
 		// Success - show success notification
@@ -650,7 +676,9 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case MsgRunAndExecReady:
 		command := exec.Command("docker", "exec", "-it", msg.ContainerID, "/bin/sh")
 		return model, tea.ExecProcess(command, func(err error) tea.Msg {
-			cleanupErr := state.GetBackend().RemoveContainer(stdcontext.Background(), msg.ContainerID, true)
+			ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationAction)
+			defer cancel()
+			cleanupErr := state.GetBackend().RemoveContainer(ctx, msg.ContainerID, true)
 			if cleanupErr != nil {
 				cleanupErr = fmt.Errorf("shell exited but failed to clean up container %s: %w", msg.ContainerID[:12], cleanupErr)
 			}
@@ -662,6 +690,21 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return MsgRunAndExecComplete{ContainerID: msg.ContainerID, Err: err, CleanupErr: cleanupErr}
 		})
 
+	case MsgRemoveImageComplete:
+		name := model.operation.Name()
+		model.operation.Finish()
+		if msg.Err != nil {
+			return model, components.OperationOutcome(name, msg.Err, "")
+		}
+		success := fmt.Sprintf("Image removed: %s", msg.ImageID[:12])
+		if msg.Force {
+			success = fmt.Sprintf("Force deleted image: %s", msg.ImageID[:12])
+		}
+		return model, tea.Batch(
+			components.OperationOutcome(name, nil, success),
+			model.Refresh(),
+		)
+
 	case MsgTagImageComplete:
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
@@ -680,16 +723,6 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		)
 
 	case MsgBuildImageComplete:
-		model.activeOperation = ""
-		model.operationCancel = nil
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			_ = progressDialog.SetPercent(1.0)
-		}
-		model.buildPercent = 0
-		if model.IsOverlayVisible() {
-			model.CloseOverlay()
-		}
-
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
 		}
@@ -707,10 +740,6 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		)
 
 	case MsgPruneComplete:
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			_ = progressDialog.SetPercent(1.0)
-		}
-		model.CloseOverlay()
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
 		}
@@ -738,6 +767,21 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if model.IsOverlayVisible() {
 		if confirmMsg, ok := msg.(base.SmartConfirmationMessage); ok {
 			switch confirmMsg.Action.Type {
+			case "ExportDockerfile":
+				model.CloseOverlay()
+				model.dockerfilePreview = nil
+				model.refreshInspectionContent()
+
+				payload, ok := confirmMsg.Action.Payload.(map[string]any)
+				if !ok {
+					return model, notifications.ShowError(fmt.Errorf("invalid payload type"))
+				}
+				formValues, ok := payload["values"].(map[string]string)
+				if !ok {
+					return model, notifications.ShowError(fmt.Errorf("invalid form values"))
+				}
+				content, _ := payload["content"].([]byte)
+				return model, writeDockerfile(formValues["Path"], content)
 			case "PruneImages":
 				model.CloseOverlay()
 				if cmd := model.handlePruneImages(); cmd != nil {
@@ -746,29 +790,12 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				return model, nil
 			case "DeleteImage":
 				imageID := confirmMsg.Action.Payload.(string)
-				err := state.GetBackend().RemoveImage(stdcontext.Background(), imageID)
-				if err == nil {
-					model.CloseOverlay()
-					return model, tea.Batch(
-						notifications.ShowSuccess(fmt.Sprintf("Image removed: %s", imageID[:12])),
-						model.Refresh(),
-					)
-				} else {
-					// Show error notification
-					model.CloseOverlay()
-					return model, notifications.ShowError(err)
-				}
+				model.CloseOverlay()
+				return model, model.startRemoveImage(imageID, false)
 			case "ForceDeleteImage":
 				imageID := confirmMsg.Action.Payload.(string)
-				err := state.GetBackend().RemoveImage(stdcontext.Background(), imageID)
 				model.CloseOverlay()
-				if err != nil {
-					return model, notifications.ShowError(fmt.Errorf("failed to force delete image: %w", err))
-				}
-				return model, tea.Batch(
-					notifications.ShowSuccess(fmt.Sprintf("Force deleted image: %s", imageID[:12])),
-					model.Refresh(),
-				)
+				return model, model.startRemoveImage(imageID, true)
 			case "PullImageAction":
 				// Extract image name from form values
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
@@ -787,26 +814,8 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					return model, notifications.ShowError(fmt.Errorf("image name is required"))
 				}
 
-				// Show progress dialog
-				progressDialog := components.NewProgressDialogWithBar(fmt.Sprintf("Pulling image: %s", imageName))
-				progressDialog.SetStatus("Preparing pull...")
-				model.SetOverlay(progressDialog)
-				model.pullLayers = make(map[string]pullLayerProgress)
-				model.pullPercent = 0
-
-				progressChan := make(chan string, 100)
-				doneChan := make(chan error, 1)
-				ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
-				model.activeOperation = "pull"
-				model.operationCancel = cancel
-
-				go func() {
-					err := state.GetBackend().PullImage(ctx, imageName, progressChan)
-					doneChan <- err
-					close(doneChan)
-				}()
-
-				return model, listenToPullProgress(imageName, progressChan, doneChan)
+				model.CloseOverlay()
+				return model, model.SubmitJob(fmt.Sprintf("Pull %s", imageName), jobKindPull, jobpanel.PullImage(imageName, parsePullStatusMessage))
 			case "CreateContainerAction":
 				// Extract form values and image ID
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
@@ -835,7 +844,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				// Create container config.
 				// Always set Tty and OpenStdin so shell-based images (alpine, ubuntu, etc.)
 				// stay alive when started detached, and can be exec'd into with 'x'.
-				config := backend.ContainerConfig{
+				containerConfig := backend.ContainerConfig{
 					Name:      formValues["Name"],
 					Image:     imageID,
 					Ports:     ports,
@@ -851,6 +860,10 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				// Close the form overlay and show progress dialog
 				model.CloseOverlay()
 
+				if _, notice := model.operation.Start("Create container", config.OperationAction); notice != nil {
+					return model, notice
+				}
+
 				progressDialog := components.NewProgressDialogWithBar("Creating container")
 				phases := newStagedOperationProgress(createContainerStages(autoStart))
 				progressDialog.SetStatus(phases.status())
@@ -859,7 +872,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				model.SetOverlay(progressDialog)
 
 				return model, func() tea.Msg {
-					return MsgCreateContainerStart{Config: config, AutoStart: autoStart}
+					return MsgCreateContainerStart{Config: containerConfig, AutoStart: autoStart}
 				}
 			case "TagImageAction":
 				// Extract form values and image ID from metadata
@@ -914,14 +927,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 
 				model.CloseOverlay()
-				progressDialog := components.NewProgressDialogWithBar(fmt.Sprintf("Building image: %s", tag))
-				progressDialog.SetStatus("Preparing build context...")
-				model.SetOverlay(progressDialog)
-				model.buildPercent = 0
-				model.activeOperation = "build"
-				ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
-				model.operationCancel = cancel
-				return model, model.performBuildImage(ctx, dockerfile, tag, contextPath, buildArgsMap)
+				return model, model.SubmitJob(fmt.Sprintf("Build %s", tag), jobKindBuild, buildImageJob(dockerfile, tag, contextPath, buildArgsMap))
 			}
 
 			model.CloseOverlay()
@@ -1012,6 +1018,18 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					model.SetOverlay(formDialog)
 				}
 
+			case key.Matches(msg, model.keybindings.diff):
+				cmds = append(cmds, model.handleDiff())
+
+			case key.Matches(msg, model.keybindings.exploreLayers):
+				cmds = append(cmds, model.handleExploreLayers())
+
+			case key.Matches(msg, model.keybindings.copyDockerfile):
+				cmds = append(cmds, model.handleCopyDockerfile())
+
+			case key.Matches(msg, model.keybindings.exportDockerfile):
+				cmds = append(cmds, model.handleExportDockerfile())
+
 			case key.Matches(msg, model.keybindings.remove):
 				model.handleRemove()
 				return model, nil
@@ -1054,6 +1072,11 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					cmd := model.detailsPanel.HandleCopyToClipboard(model.inspection)
 					cmds = append(cmds, cmd)
 				}
+				if key.Matches(msg, model.detailsKeybindings.RevealSecrets) {
+					cmd := model.detailsPanel.HandleRevealSecrets()
+					model.refreshInspectionContent()
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 	}
@@ -1127,7 +1150,9 @@ func (model *Model) handleRemove() {
 		return
 	}
 
-	containersUsingImage, err := state.GetBackend().GetContainersUsingImage(stdcontext.Background(), selectedItem.Image.ID)
+	ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+	defer cancel()
+	containersUsingImage, err := state.GetBackend().GetContainersUsingImage(ctx, selectedItem.Image.ID)
 	if err != nil {
 		// If we can't check usage, show error and don't proceed with deletion
 		errorDialog := components.NewDialog(
@@ -1178,7 +1203,9 @@ func (model *Model) updateDetailContent() tea.Cmd {
 
 		// Fetch inspection data asynchronously
 		return func() tea.Msg {
-			imageInfo, err := state.GetBackend().InspectImage(stdcontext.Background(), imageID)
+			ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+			defer cancel()
+			imageInfo, err := state.GetBackend().InspectImage(ctx, imageID)
 			return MsgImageInspection{ID: imageID, Image: imageInfo, Err: err}
 		}
 	}
@@ -1196,12 +1223,21 @@ func (model *Model) getViewport() *viewport.Model {
 
 // refreshInspectionContent refreshes the detail content with current inspection data
 func (model *Model) refreshInspectionContent() {
-	// Use DetailsPanel to get the current format
-	format := model.detailsPanel.GetFormatForDisplay()
+	if model.dockerfilePreview != nil {
+		model.HideInspectTree()
+		model.SetContent(builders.BuildDockerfilePanel(model.dockerfilePreview, model.GetContentWidth()))
+		return
+	}
 
-	// Build content with current format
-	content := builders.BuildImagePanel(model.inspection, model.GetContentWidth(), format)
-	model.SetContent(content)
+	// The tree draws itself from the data; other formats are built as text
+	if model.detailsPanel.IsTreeFormat() {
+		model.ShowInspectTree(model.inspection, model.detailsPanel.SecretsRevealed())
+	} else {
+		model.HideInspectTree()
+		format := model.detailsPanel.GetFormatForDisplay()
+		content := builders.BuildImagePanel(model.inspection, model.GetContentWidth(), model.detailsPanel.SecretsRevealed(), format)
+		model.SetContent(content)
+	}
 
 	// Update "Used By" panel
 	model.updateUsedByPanel()
@@ -1215,13 +1251,15 @@ func (model *Model) updateUsedByPanel() {
 	}
 
 	// Fetch containers using this image
-	usedBy, err := state.GetBackend().GetContainersUsingImage(stdcontext.Background(), model.inspection.ID)
+	ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+	defer cancel()
+	usedBy, err := state.GetBackend().GetContainersUsingImage(ctx, model.inspection.ID)
 	if err != nil {
 		model.SetExtraContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render(fmt.Sprintf("Error: %v", err)))
 		return
 	}
 
-	history, err := state.GetBackend().ImageHistory(stdcontext.Background(), model.inspection.ID)
+	history, err := state.GetBackend().ImageHistory(ctx, model.inspection.ID)
 	if err != nil {
 		history = nil
 	}
@@ -1283,13 +1321,14 @@ func buildImageUsageAndHistoryContent(inspection backend.ImageDetail, usedBy []s
 func (model Model) ShortHelp() []key.Binding {
 	// If detail or extra pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return []key.Binding{
+		return append([]key.Binding{
 			model.detailsKeybindings.Up,
 			model.detailsKeybindings.Down,
 			model.detailsKeybindings.Switch,
 			model.detailsKeybindings.ToggleJSON,
 			model.detailsKeybindings.CopyOutput,
-		}
+			model.detailsKeybindings.RevealSecrets,
+		}, model.InspectTreeHelp()...)
 	} else if model.IsExtraFocused() {
 		return []key.Binding{
 			model.detailsKeybindings.Up,
@@ -1303,7 +1342,7 @@ func (model Model) ShortHelp() []key.Binding {
 func (model Model) FullHelp() [][]key.Binding {
 	// If detail or extra pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return [][]key.Binding{
+		help := [][]key.Binding{
 			{
 				model.detailsKeybindings.Up,
 				model.detailsKeybindings.Down,
@@ -1312,8 +1351,13 @@ func (model Model) FullHelp() [][]key.Binding {
 			{
 				model.detailsKeybindings.ToggleJSON,
 				model.detailsKeybindings.CopyOutput,
+				model.detailsKeybindings.RevealSecrets,
 			},
 		}
+		if tree := model.InspectTreeHelp(); len(tree) > 0 {
+			help = append(help, tree)
+		}
+		return help
 	} else if model.IsExtraFocused() {
 		return [][]key.Binding{
 			{
@@ -1327,7 +1371,7 @@ func (model Model) FullHelp() [][]key.Binding {
 }
 
 func (model Model) hasPrunableImages() bool {
-	for _, item := range model.GetItems() {
+	for _, item := range model.AllItems() {
 		if !item.InUse {
 			return true
 		}
@@ -1336,22 +1380,16 @@ func (model Model) hasPrunableImages() bool {
 	return false
 }
 
-// handlePruneImages prunes unused images
+// handlePruneImages prunes unused images in a background job
 func (model *Model) handlePruneImages() tea.Cmd {
-	progressDialog := components.NewProgressDialogWithBar("Pruning unused images")
-	progressDialog.EnableAutoAdvance(0.95, 0.04)
-	progressDialog.SetStatus("Discovering unused images to prune...")
-	model.SetOverlay(progressDialog)
-
-	// Start async prune operation
-	return func() tea.Msg {
-		ctx := stdcontext.Background()
+	return model.SubmitJob("Prune unused images", jobKindPrune, func(ctx stdcontext.Context, progress *jobs.Progress) error {
+		progress.SetMessage("Discovering unused images to prune...")
+		ctx, cancel := state.OperationContext(ctx, config.OperationPrune)
+		defer cancel()
 		spaceReclaimed, err := state.GetBackend().PruneImages(ctx)
-		return MsgPruneComplete{
-			SpaceReclaimed: spaceReclaimed,
-			Err:            err,
-		}
-	}
+		progress.SetResult(spaceReclaimed)
+		return err
+	})
 }
 
 func (model *Model) showPruneImagesConfirmation() {
@@ -1376,26 +1414,15 @@ func (model *Model) showPruneImagesConfirmation() {
 }
 
 func (model Model) pruneImageCandidates() []string {
-	items := model.GetItems()
-	candidates := make([]string, 0, len(items))
+	items := model.AllItems()
+	images := make([]backend.Image, 0, len(items))
+	inUse := make(map[string]bool, len(items))
 	for _, item := range items {
-		if item.InUse {
-			continue
-		}
-
-		if len(item.Image.RepoTags) > 0 && strings.TrimSpace(item.Image.RepoTags[0]) != "" {
-			candidates = append(candidates, item.Image.RepoTags[0])
-			continue
-		}
-
-		trimmedID := strings.TrimPrefix(item.Image.ID, "sha256:")
-		if len(trimmedID) > 12 {
-			trimmedID = trimmedID[:12]
-		}
-		candidates = append(candidates, trimmedID)
+		images = append(images, item.Image)
+		inUse[item.Image.ID] = item.InUse
 	}
 
-	return candidates
+	return safety.PruneImageCandidates(images, inUse)
 }
 
 // handleTagImage shows dialog to tag an image
@@ -1435,11 +1462,12 @@ func (model *Model) handleRunAndExec() tea.Cmd {
 	}
 
 	return func() tea.Msg {
-		ctx := stdcontext.Background()
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationAction)
+		defer cancel()
 
-		config := buildTempShellContainerConfig(selectedItem.Image.ID, time.Now())
+		containerConfig := buildTempShellContainerConfig(selectedItem.Image.ID, time.Now())
 
-		containerID, err := state.GetBackend().CreateContainer(ctx, config)
+		containerID, err := state.GetBackend().CreateContainer(ctx, containerConfig)
 		if err != nil {
 			return MsgRunAndExecComplete{Err: fmt.Errorf("failed to create container: %w", err)}
 		}
@@ -1516,7 +1544,8 @@ func (model *Model) handleBuildImage() {
 // performTagImage tags an image with a new name
 func (model *Model) performTagImage(imageID, newTag string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := stdcontext.Background()
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationAction)
+		defer cancel()
 		err := state.GetBackend().TagImage(ctx, imageID, newTag)
 		if err != nil {
 			return MsgTagImageComplete{Err: fmt.Errorf("failed to tag image: %w", err)}
@@ -1525,140 +1554,39 @@ func (model *Model) performTagImage(imageID, newTag string) tea.Cmd {
 	}
 }
 
-// performBuildImage builds an image from a Dockerfile
-func (model *Model) performBuildImage(ctx stdcontext.Context, dockerfile, tag, contextPath string, buildArgs map[string]string) tea.Cmd {
-	return func() tea.Msg {
-		// Convert buildArgs to map[string]*string
-		buildArgsPtr := make(map[string]*string)
-		for k, v := range buildArgs {
-			val := v
-			buildArgsPtr[k] = &val
-		}
-
-		buildOutput, err := state.GetBackend().BuildImage(ctx, dockerfile, tag, contextPath, buildArgsPtr)
-		if err != nil {
-			return MsgBuildImageComplete{Err: fmt.Errorf("failed to build image: %w", err)}
-		}
-		progressChan, doneChan := progress.StreamLines(buildOutput)
-
-		return MsgBuildImageProgress{
-			Tag:          tag,
-			Message:      "Building image...",
-			ProgressChan: progressChan,
-			DoneChan:     doneChan,
-		}
-	}
-}
-
-func listenToBuildProgress(tag string, currentPercent float64, progressChan <-chan string, doneChan <-chan error) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case line, ok := <-progressChan:
-			if !ok {
-				err := <-doneChan
-				if err != nil {
-					return MsgBuildImageComplete{Err: err}
-				}
-				return MsgBuildImageComplete{Tag: tag}
-			}
-
-			percent, hasPercent := estimateBuildPercent(line, currentPercent)
-			return MsgBuildImageProgress{
-				Tag:          tag,
-				Message:      parseBuildStatusMessage(line),
-				Percent:      percent,
-				HasPercent:   hasPercent,
-				ProgressChan: progressChan,
-				DoneChan:     doneChan,
-			}
-
-		case err := <-doneChan:
-			if err != nil {
-				return MsgBuildImageComplete{Err: err}
-			}
-			return MsgBuildImageComplete{Tag: tag}
-		}
+// canCancelOperation reports whether esc should cancel the tab's operation
+// rather than reach the list or an open dialog: only while one is running
+// and nothing but its progress dialog is shown.
+func (model Model) canCancelOperation() bool {
+	if !model.operation.Active() || model.IsFiltering() {
+		return false
 	}
-}
-
-func listenToPullProgress(imageName string, progressChan <-chan string, doneChan <-chan error) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case status, ok := <-progressChan:
-			if !ok {
-				err := <-doneChan
-				return MsgPullComplete{ImageName: imageName, Err: err}
-			}
-
-			return MsgPullProgress{
-				ImageName:    imageName,
-				Message:      parsePullStatusMessage(status),
-				Raw:          status,
-				ProgressChan: progressChan,
-				DoneChan:     doneChan,
-			}
-
-		case err := <-doneChan:
-			return MsgPullComplete{ImageName: imageName, Err: err}
-		}
+	if !model.IsOverlayVisible() {
+		return true
 	}
+	_, ok := model.Foreground.(components.ProgressDialog)
+	return ok
 }
 
-func (model *Model) estimatePullProgress(raw string) (float64, bool) {
-	layerID, current, total, ok := parsePullLayerProgress(raw)
-	if !ok {
-		return 0, false
+// startRemoveImage removes the image imageID in the background, as the tab's
+// cancellable operation.
+func (model *Model) startRemoveImage(imageID string, force bool) tea.Cmd {
+	name := fmt.Sprintf("Remove image %s", imageID[:12])
+	if force {
+		name = fmt.Sprintf("Force delete image %s", imageID[:12])
 	}
-
-	model.pullLayers[layerID] = pullLayerProgress{current: current, total: total}
-
-	var sumCurrent int64
-	var sumTotal int64
-	for _, layer := range model.pullLayers {
-		sumCurrent += layer.current
-		sumTotal += layer.total
-	}
-
-	if sumTotal <= 0 {
-		return 0, false
-	}
-
-	percent := float64(sumCurrent) / float64(sumTotal)
-	if percent > 0.98 {
-		percent = 0.98
-	}
-	if percent < model.pullPercent {
-		percent = model.pullPercent
-	}
-
-	model.pullPercent = percent
-	return percent, true
-}
-
-func parsePullLayerProgress(raw string) (string, int64, int64, bool) {
-	type pullStatus struct {
-		ID             string             `json:"id"`
-		ProgressDetail pullProgressDetail `json:"progressDetail"`
-	}
-
-	var status pullStatus
-	if err := json.Unmarshal([]byte(raw), &status); err != nil {
-		return "", 0, 0, false
+	ctx, notice := model.operation.Start(name, config.OperationAction)
+	if notice != nil {
+		return notice
 	}
 
-	if status.ID == "" || status.ProgressDetail.Total <= 0 {
-		return "", 0, 0, false
-	}
-
-	current := status.ProgressDetail.Current
-	if current < 0 {
-		current = 0
-	}
-	if current > status.ProgressDetail.Total {
-		current = status.ProgressDetail.Total
+	return func() tea.Msg {
+		err := state.GetBackend().RemoveImage(ctx, imageID)
+		if err != nil && force {
+			err = fmt.Errorf("failed to force delete image: %w", err)
+		}
+		return MsgRemoveImageComplete{ImageID: imageID, Force: force, Err: err}
 	}
-
-	return status.ID, current, status.ProgressDetail.Total, true
 }
 
 func parsePullStatusMessage(raw string) string {
@@ -1,6 +1,8 @@
 package images
 
 import (
+	stdcontext "context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -9,8 +11,11 @@ import (
 
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/givensuman/containertui/internal/backend"
 	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/layers"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
@@ -27,59 +32,6 @@ func newPruneTestModel(items []ImageItem) Model {
 	return Model{ResourceView: components.ResourceView[string, ImageItem]{SplitView: splitView}}
 }
 
-func TestParsePullLayerProgressValid(t *testing.T) {
-	raw := `{"id":"layer-1","progressDetail":{"current":50,"total":100}}`
-
-	id, current, total, ok := parsePullLayerProgress(raw)
-	if !ok {
-		t.Fatal("expected valid progress payload")
-	}
-	if id != "layer-1" || current != 50 || total != 100 {
-		t.Fatalf("unexpected parsed values: id=%q current=%d total=%d", id, current, total)
-	}
-}
-
-func TestEstimatePullProgressAggregatesLayers(t *testing.T) {
-	model := Model{pullLayers: map[string]pullLayerProgress{}}
-
-	first, ok := model.estimatePullProgress(`{"id":"a","progressDetail":{"current":50,"total":100}}`)
-	if !ok {
-		t.Fatal("expected first progress estimate")
-	}
-	if first != 0.5 {
-		t.Fatalf("expected 0.5, got %f", first)
-	}
-
-	second, ok := model.estimatePullProgress(`{"id":"b","progressDetail":{"current":50,"total":100}}`)
-	if !ok {
-		t.Fatal("expected second progress estimate")
-	}
-	if second != 0.5 {
-		t.Fatalf("expected 0.5 aggregate, got %f", second)
-	}
-}
-
-func TestEstimatePullProgressIsMonotonicAndCapped(t *testing.T) {
-	model := Model{pullLayers: map[string]pullLayerProgress{}}
-
-	_, ok := model.estimatePullProgress(`{"id":"a","progressDetail":{"current":99,"total":100}}`)
-	if !ok {
-		t.Fatal("expected progress estimate")
-	}
-
-	if model.pullPercent != 0.98 {
-		t.Fatalf("expected cap at 0.98, got %f", model.pullPercent)
-	}
-
-	percent, ok := model.estimatePullProgress(`{"id":"a","progressDetail":{"current":10,"total":100}}`)
-	if !ok {
-		t.Fatal("expected progress estimate")
-	}
-	if percent != 0.98 {
-		t.Fatalf("expected monotonic percent 0.98, got %f", percent)
-	}
-}
-
 func TestCreateContainerStages(t *testing.T) {
 	withoutStart := createContainerStages(false)
 	if len(withoutStart) != 3 {
@@ -254,17 +206,20 @@ func TestPruneImageCandidatesFallsBackToImageID(t *testing.T) {
 
 func TestEscCancelsActiveImageOperation(t *testing.T) {
 	model := Model{}
-	cancelled := false
-	model.activeOperation = "pull"
-	model.operationCancel = func() { cancelled = true }
-	model.SetOverlay(components.NewProgressDialogWithBar("Pulling image"))
+	ctx, notice := model.operation.Start("Create container", config.OperationAction)
+	if notice != nil {
+		t.Fatal("expected the operation to start")
+	}
+	model.SetOverlay(components.NewProgressDialogWithBar("Creating container"))
 
-	updated, cmd := model.Update(tea.KeyPressMsg{Code: tea.KeyEsc})
-	if !cancelled {
+	updated, _ := model.Update(tea.KeyPressMsg{Code: tea.KeyEsc})
+	if !errors.Is(ctx.Err(), stdcontext.Canceled) {
 		t.Fatal("expected active image operation to be cancelled on esc")
 	}
-	if updated.activeOperation != "" {
-		t.Fatalf("expected active operation to be cleared, got %q", updated.activeOperation)
+
+	updated, cmd := updated.Update(MsgCreateContainerComplete{Err: ctx.Err()})
+	if updated.operation.Active() {
+		t.Fatalf("expected active operation to be cleared, got %q", updated.operation.Name())
 	}
 	if updated.IsOverlayVisible() {
 		t.Fatal("expected overlay to be closed after cancellation")
@@ -274,6 +229,20 @@ func TestEscCancelsActiveImageOperation(t *testing.T) {
 	}
 }
 
+func TestRemoveImageRefusesWhileAnotherOperationRuns(t *testing.T) {
+	model := Model{}
+	if _, notice := model.operation.Start("Create container", config.OperationAction); notice != nil {
+		t.Fatal("expected the operation to start")
+	}
+
+	if cmd := model.startRemoveImage("sha256:abcdef1234567890", false); cmd == nil {
+		t.Fatal("expected a notice that an operation is already running")
+	}
+	if got := model.operation.Name(); got != "Create container" {
+		t.Fatalf("operation = %q, want the running one kept", got)
+	}
+}
+
 func TestBuildImageUsageAndHistoryContentIncludesMetadataAndCleanupHint(t *testing.T) {
 	inspection := backend.ImageDetail{
 		Image: backend.Image{
@@ -409,3 +378,89 @@ func TestImageResourceChangedMessageRefreshesImagesList(t *testing.T) {
 		t.Fatal("expected model to keep resource view configuration")
 	}
 }
+
+func TestHandleFinishedJobsTranslatesImageJobs(t *testing.T) {
+	cmd := handleFinishedJobs([]jobs.Job{
+		{Kind: jobKindPull, Status: jobs.Succeeded, Result: "nginx:latest"},
+		{Kind: jobKindPrune, Status: jobs.Succeeded, Result: uint64(1024)},
+		{Kind: "containers/prune", Status: jobs.Succeeded},
+	})
+	if cmd == nil {
+		t.Fatal("expected command for finished image jobs")
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected batch of 2 commands, got %#v", cmd())
+	}
+
+	pull, ok := batch[0]().(MsgPullComplete)
+	if !ok || pull.ImageName != "nginx:latest" || pull.Err != nil {
+		t.Fatalf("expected successful pull completion for nginx:latest, got %#v", pull)
+	}
+
+	prune, ok := batch[1]().(MsgPruneComplete)
+	if !ok || prune.SpaceReclaimed != 1024 {
+		t.Fatalf("expected prune completion with 1024 bytes, got %#v", prune)
+	}
+}
+
+func TestFinishedDockerfileCopyJobDoesNotAskForAPath(t *testing.T) {
+	cmd := handleFinishedJobs([]jobs.Job{{
+		Kind:   jobKindDockerfile,
+		Status: jobs.Succeeded,
+		Result: dockerfileResult{preview: []byte("FROM alpine\n"), content: []byte("FROM alpine\n"), toClipboard: true},
+	}})
+	generated, ok := cmd().(msgDockerfileGenerated)
+	if !ok || !generated.toClipboard || string(generated.preview) != "FROM alpine\n" {
+		t.Fatalf("expected a Dockerfile to copy, got %#v", cmd())
+	}
+
+	updated, copyCmd := newPruneTestModel(nil).Update(generated)
+	if copyCmd == nil {
+		t.Fatal("expected the Dockerfile to be copied")
+	}
+	if updated.IsOverlayVisible() {
+		t.Fatal("expected no export dialog when copying")
+	}
+}
+
+func TestLayerExplorerStepsThroughLayers(t *testing.T) {
+	state.SetConfig(config.DefaultConfig())
+	image := layers.Image{
+		Layers: []layers.Layer{
+			{Command: "/bin/sh -c #(nop) ADD file:rootfs in / ", Size: 300, Files: []layers.File{
+				{Path: "etc/motd", Size: 100, Wasted: true},
+				{Path: "usr/bin/app", Size: 200},
+			}},
+			{Command: "RUN rm /etc/motd", Files: []layers.File{
+				{Path: "etc/motd", Size: 100, Change: layers.Removed},
+			}},
+		},
+		Size:       300,
+		WastedSize: 100,
+		Wasted:     []layers.WastedFile{{Path: "etc/motd", Size: 100, Count: 1}},
+	}
+	explorer := newLayerExplorer("app:1", image)
+	explorer.UpdateWindowDimensions(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	view := ansi.Strip(explorer.String())
+	if !strings.Contains(view, "efficiency 66.7%") || !strings.Contains(view, "ADD file:rootfs in /") {
+		t.Fatalf("expected the summary and the first layer, got:\n%s", view)
+	}
+	if !strings.Contains(view, "+ motd 100B wasted") || !strings.Contains(view, "└── + app") {
+		t.Errorf("expected the first layer's files as a tree, got:\n%s", view)
+	}
+
+	model, _ := explorer.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	explorer = model.(layerExplorer)
+	if view := ansi.Strip(explorer.String()); explorer.selected != 1 || !strings.Contains(view, "- motd") {
+		t.Errorf("expected down to show the removal in the second layer, got:\n%s", view)
+	}
+
+	model, _ = explorer.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	explorer = model.(layerExplorer)
+	if view := ansi.Strip(explorer.String()); !strings.Contains(view, "1 wasted file") {
+		t.Errorf("expected w to list the wasted files, got:\n%s", view)
+	}
+}
@@ -13,6 +13,7 @@ type ImageItem struct {
 	Image      backend.Image
 	isSelected bool
 	InUse      bool // Whether the image is being used by any containers
+	Containers int  // How many containers use the image
 }
 
 var (
@@ -0,0 +1,100 @@
+package images
+
+import (
+	stdcontext "context"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
+	"github.com/givensuman/containertui/internal/ui/progress"
+)
+
+// Job kinds submitted by the images tab.
+const (
+	jobKindPull       = "images/pull"
+	jobKindBuild      = "images/build"
+	jobKindPrune      = "images/prune"
+	jobKindLayers     = "images/layers"
+	jobKindDockerfile = "images/dockerfile"
+)
+
+// buildImageJob builds an image from a Dockerfile, reporting build steps.
+func buildImageJob(dockerfile, tag, contextPath string, buildArgs map[string]string) jobs.Func {
+	return func(ctx stdcontext.Context, jobProgress *jobs.Progress) error {
+		jobProgress.SetResult(tag)
+		jobProgress.SetMessage("Preparing build context...")
+
+		ctx, cancel := state.OperationContext(ctx, config.OperationBuild)
+		defer cancel()
+
+		// Convert buildArgs to map[string]*string
+		buildArgsPtr := make(map[string]*string)
+		for k, v := range buildArgs {
+			val := v
+			buildArgsPtr[k] = &val
+		}
+
+		buildOutput, err := state.GetBackend().BuildImage(ctx, dockerfile, tag, contextPath, buildArgsPtr)
+		if err != nil {
+			return fmt.Errorf("failed to build image: %w", err)
+		}
+		progressChan, doneChan := progress.StreamLines(buildOutput)
+
+		var percent float64
+		for line := range progressChan {
+			jobProgress.SetMessage(parseBuildStatusMessage(line))
+			if next, hasPercent := estimateBuildPercent(line, percent); hasPercent {
+				percent = next
+				jobProgress.SetPercent(percent)
+			}
+		}
+		return <-doneChan
+	}
+}
+
+// handleFinishedJobs translates finished jobs submitted by this tab into
+// their completion messages.
+func handleFinishedJobs(finished []jobs.Job) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range finished {
+		if job.Status == jobs.Cancelled {
+			switch job.Kind {
+			case jobKindPull, jobKindBuild, jobKindPrune, jobKindLayers, jobKindDockerfile:
+				cmds = append(cmds, jobpanel.CancelledNotice(job))
+			}
+			continue
+		}
+
+		switch job.Kind {
+		case jobKindPull:
+			imageName, _ := job.Result.(string)
+			cmds = append(cmds, func() tea.Msg {
+				return MsgPullComplete{ImageName: imageName, Err: job.Err}
+			})
+		case jobKindBuild:
+			tag, _ := job.Result.(string)
+			cmds = append(cmds, func() tea.Msg {
+				return MsgBuildImageComplete{Tag: tag, Err: job.Err}
+			})
+		case jobKindPrune:
+			spaceReclaimed, _ := job.Result.(uint64)
+			cmds = append(cmds, func() tea.Msg {
+				return MsgPruneComplete{SpaceReclaimed: spaceReclaimed, Err: job.Err}
+			})
+		case jobKindLayers:
+			result, _ := job.Result.(layersResult)
+			cmds = append(cmds, func() tea.Msg {
+				return msgLayersLoaded{name: result.name, image: result.image, err: job.Err}
+			})
+		case jobKindDockerfile:
+			result, _ := job.Result.(dockerfileResult)
+			cmds = append(cmds, func() tea.Msg {
+				return msgDockerfileGenerated{preview: result.preview, content: result.content, toClipboard: result.toClipboard, err: job.Err}
+			})
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,351 @@
+package images
+
+import (
+	stdcontext "context"
+	"fmt"
+	"io"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/layers"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/layout"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// layersResult is the result of a layers job.
+type layersResult struct {
+	name  string
+	image layers.Image
+}
+
+// msgLayersLoaded carries the layers of an image to explore.
+type msgLayersLoaded struct {
+	name  string
+	image layers.Image
+	err   error
+}
+
+// handleExploreLayers reads the layers of the image under the cursor in
+// the background, since it means saving the whole image.
+func (model *Model) handleExploreLayers() tea.Cmd {
+	selected := model.GetSelectedItem()
+	if selected == nil {
+		return notifications.ShowInfo("No image selected")
+	}
+	name := imageName(backend.ImageDetail{Image: selected.Image})
+	return model.SubmitJob(fmt.Sprintf("Read layers of %s", name), jobKindLayers, layersJob(selected.Image.ID, name, selected.Image.Size))
+}
+
+// countingReader reports how much of an archive has been read.
+type countingReader struct {
+	reader io.Reader
+	read   int64
+	report func(read int64)
+}
+
+func (counter *countingReader) Read(p []byte) (int, error) {
+	n, err := counter.reader.Read(p)
+	counter.read += int64(n)
+	counter.report(counter.read)
+	return n, err
+}
+
+// layersJob saves an image and reads what each of its layers changes. The
+// archive is about as large as the image, which gives the progress.
+func layersJob(id, name string, size int64) jobs.Func {
+	return func(ctx stdcontext.Context, jobProgress *jobs.Progress) error {
+		jobProgress.SetMessage("Saving image...")
+
+		// Saving streams the whole image, which can take as long as a pull
+		ctx, cancel := state.OperationContext(ctx, config.OperationPull)
+		defer cancel()
+
+		archive, err := state.GetBackend().SaveImage(ctx, id)
+		if err != nil {
+			return err
+		}
+		defer archive.Close()
+
+		image, err := layers.Read(&countingReader{reader: archive, report: func(read int64) {
+			jobProgress.SetMessage(fmt.Sprintf("Read %s", infopanel.FormatBytes(read)))
+			if size > 0 {
+				jobProgress.SetPercent(min(float64(read)/float64(size), 0.99))
+			}
+		}})
+		if err != nil {
+			return err
+		}
+		jobProgress.SetResult(layersResult{name: name, image: image})
+		return nil
+	}
+}
+
+// showLayers opens the layer explorer.
+func (model *Model) showLayers(name string, image layers.Image) {
+	model.SetOverlay(newLayerExplorer(name, image))
+}
+
+// layerExplorer steps through the layers of an image, showing the files
+// each one changes as a tree, or the files wasted across the image.
+type layerExplorer struct {
+	name       string
+	image      layers.Image
+	selected   int
+	showWasted bool
+	viewport   viewport.Model
+	style      lipgloss.Style
+	listWidth  int
+	listHeight int
+	width      int
+	height     int
+}
+
+var _ fmt.Stringer = (*layerExplorer)(nil)
+
+func newLayerExplorer(name string, image layers.Image) layerExplorer {
+	width, height := state.GetWindowSize()
+	explorer := layerExplorer{
+		name:     name,
+		image:    image,
+		viewport: viewport.New(),
+		width:    width,
+		height:   height,
+	}
+	explorer.updateStyle()
+	return explorer
+}
+
+// updateStyle sizes the overlay to the window, splitting its content
+// between the layer list and the selected layer's files.
+func (explorer *layerExplorer) updateStyle() {
+	explorer.style = lipgloss.NewStyle().
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	dimensions := layout.NewLayoutManager(explorer.width, explorer.height).CalculateLargeOverlay(explorer.style)
+	explorer.style = explorer.style.Width(dimensions.Width).Height(dimensions.Height)
+
+	// The title, summary and help lines take three rows of the content
+	explorer.listWidth = max(dimensions.ContentWidth*2/5, 20)
+	explorer.listHeight = max(dimensions.ContentHeight-3, 1)
+	explorer.viewport.SetWidth(max(dimensions.ContentWidth-explorer.listWidth-3, 10))
+	explorer.viewport.SetHeight(explorer.listHeight)
+	explorer.refresh()
+}
+
+// refresh renders the files of the selected layer, or the wasted files.
+func (explorer *layerExplorer) refresh() {
+	if explorer.showWasted {
+		explorer.viewport.SetContent(renderWasted(explorer.image, explorer.viewport.Width()))
+	} else if len(explorer.image.Layers) > 0 {
+		explorer.viewport.SetContent(renderLayer(explorer.image.Layers[explorer.selected], explorer.viewport.Width()))
+	}
+	explorer.viewport.GotoTop()
+}
+
+func (explorer *layerExplorer) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	explorer.width = msg.Width
+	explorer.height = msg.Height
+	explorer.updateStyle()
+}
+
+func (explorer layerExplorer) Init() tea.Cmd {
+	return nil
+}
+
+func (explorer layerExplorer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		explorer.UpdateWindowDimensions(msg)
+		return explorer, nil
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return explorer, func() tea.Msg { return base.CloseDialogMessage{} }
+		case "up", "k":
+			if explorer.selected > 0 {
+				explorer.selected--
+				explorer.showWasted = false
+				explorer.refresh()
+			}
+			return explorer, nil
+		case "down", "j":
+			if explorer.selected < len(explorer.image.Layers)-1 {
+				explorer.selected++
+				explorer.showWasted = false
+				explorer.refresh()
+			}
+			return explorer, nil
+		case "w":
+			explorer.showWasted = !explorer.showWasted
+			explorer.refresh()
+			return explorer, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	explorer.viewport, cmd = explorer.viewport.Update(msg)
+	return explorer, cmd
+}
+
+func (explorer layerExplorer) View() tea.View {
+	return tea.NewView(explorer.String())
+}
+
+func (explorer layerExplorer) String() string {
+	title := lipgloss.NewStyle().Foreground(colors.Primary()).Bold(true).Render("Layers of " + explorer.name)
+	muted := lipgloss.NewStyle().Foreground(colors.Muted())
+	summary := muted.Render(fmt.Sprintf("%d layers • %s • efficiency %.1f%% • %s wasted",
+		len(explorer.image.Layers),
+		infopanel.FormatBytes(explorer.image.Size),
+		explorer.image.Efficiency()*100,
+		infopanel.FormatBytes(explorer.image.WastedSize)))
+
+	filesHelp := "w wasted files"
+	if explorer.showWasted {
+		filesHelp = "w layer files"
+	}
+	help := muted.Render(fmt.Sprintf("↑/↓ layer • pgup/pgdn scroll • %s • esc close", filesHelp))
+
+	list := lipgloss.NewStyle().Width(explorer.listWidth).Height(explorer.listHeight).Render(explorer.renderList())
+	gap := lipgloss.NewStyle().Foreground(colors.Border()).Render(strings.Repeat(" │ \n", explorer.listHeight-1) + " │ ")
+
+	return explorer.style.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		summary,
+		lipgloss.JoinHorizontal(lipgloss.Top, list, gap, explorer.viewport.View()),
+		help,
+	))
+}
+
+// renderList lists the layers that fit, keeping the selected one in view.
+func (explorer layerExplorer) renderList() string {
+	layerCount := len(explorer.image.Layers)
+	if layerCount == 0 {
+		return lipgloss.NewStyle().Foreground(colors.Muted()).Render("No layers")
+	}
+	start := min(max(explorer.selected-explorer.listHeight/2, 0), max(layerCount-explorer.listHeight, 0))
+	end := min(start+explorer.listHeight, layerCount)
+
+	selected := lipgloss.NewStyle().Background(colors.Selected()).Foreground(colors.PrimaryText()).Width(explorer.listWidth)
+	rows := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		layer := explorer.image.Layers[i]
+		row := fmt.Sprintf("%2d %7s %s", i+1, infopanel.FormatBytesShort(layer.Size), shortCommand(layer.Command))
+		row = ansi.Truncate(row, explorer.listWidth, "…")
+		if i == explorer.selected {
+			row = selected.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// shortCommand drops the shell prefix the engine writes before a step.
+func shortCommand(command string) string {
+	command = strings.TrimPrefix(command, "/bin/sh -c ")
+	command = strings.TrimPrefix(command, "#(nop) ")
+	return strings.TrimSpace(command)
+}
+
+// renderLayer describes a layer and draws the files it changes as a tree.
+func renderLayer(layer layers.Layer, width int) string {
+	muted := lipgloss.NewStyle().Foreground(colors.Muted())
+	var counts [3]int
+	for _, file := range layer.Files {
+		counts[file.Change]++
+	}
+
+	var out strings.Builder
+	out.WriteString(lipgloss.NewStyle().Width(width).Render(shortCommand(layer.Command)))
+	out.WriteString("\n")
+	out.WriteString(muted.Render(fmt.Sprintf("%s • %s • %d added, %d modified, %d removed",
+		infopanel.TruncateID(layer.Digest), infopanel.FormatBytes(layer.Size),
+		counts[layers.Added], counts[layers.Modified], counts[layers.Removed])))
+	out.WriteString("\n\n")
+	if len(layer.Files) == 0 {
+		out.WriteString(muted.Render("This layer changes no files"))
+		return out.String()
+	}
+
+	var lines []string
+	drawTree(layers.Tree(layer.Files), "", &lines)
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	out.WriteString(strings.Join(lines, "\n"))
+	return out.String()
+}
+
+// drawTree draws the children of node below prefix, marking what the layer
+// did to each file and which of them are wasted.
+func drawTree(node *layers.Node, prefix string, lines *[]string) {
+	muted := lipgloss.NewStyle().Foreground(colors.Muted())
+	markers := map[layers.Change]string{
+		layers.Added:    lipgloss.NewStyle().Foreground(colors.Success()).Render("+"),
+		layers.Modified: lipgloss.NewStyle().Foreground(colors.Warning()).Render("~"),
+		layers.Removed:  lipgloss.NewStyle().Foreground(colors.Error()).Render("-"),
+	}
+
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		var line string
+		switch file := child.File; {
+		case file == nil:
+			line = muted.Render(child.Name + "/")
+		case file.Dir:
+			line = markers[file.Change] + " " + child.Name + "/"
+		default:
+			line = markers[file.Change] + " " + child.Name + " " + muted.Render(infopanel.FormatBytesShort(file.Size))
+		}
+		if child.File != nil && child.File.Wasted {
+			line += " " + lipgloss.NewStyle().Foreground(colors.Warning()).Render("wasted")
+		}
+		*lines = append(*lines, muted.Render(prefix+branch)+line)
+		drawTree(child, prefix+indent, lines)
+	}
+}
+
+// renderWasted lists the files whose bytes are wasted, largest first.
+func renderWasted(image layers.Image, width int) string {
+	muted := lipgloss.NewStyle().Foreground(colors.Muted())
+	if len(image.Wasted) == 0 {
+		return muted.Render("No space is wasted")
+	}
+
+	var out strings.Builder
+	heading := fmt.Sprintf("%d wasted files", len(image.Wasted))
+	if len(image.Wasted) == 1 {
+		heading = "1 wasted file"
+	}
+	out.WriteString(lipgloss.NewStyle().Bold(true).Render(heading))
+	out.WriteString("\n")
+	out.WriteString(muted.Render("Overwritten or removed by a later layer"))
+	out.WriteString("\n")
+	for _, file := range image.Wasted {
+		line := fmt.Sprintf("%7s %s", infopanel.FormatBytesShort(file.Size), file.Path)
+		if file.Count > 1 {
+			line += muted.Render(fmt.Sprintf(" (%d versions)", file.Count))
+		}
+		out.WriteString("\n")
+		out.WriteString(ansi.Truncate(line, width, "…"))
+	}
+	return out.String()
+}
@@ -0,0 +1,288 @@
+// Package jobpanel implements the background jobs overlay and the glue
+// between tabs and the shared job manager.
+package jobpanel
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/icons"
+	"github.com/givensuman/containertui/internal/ui/layout"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// maxVisibleItems limits how many per-item progress lines are shown for a running job.
+const maxVisibleItems = 4
+
+// Submit queues a job on the shared job manager and notifies the user. It
+// returns the job's ID, which the job keeps once it has finished.
+func Submit(title, kind string, fn jobs.Func) (int64, tea.Cmd) {
+	id := state.GetJobs().Submit(title, kind, fn)
+	return id, notifications.ShowInfo(fmt.Sprintf("Queued: %s (ctrl+t for jobs)", title))
+}
+
+// ListenForJobs waits for the next change in the shared job manager and
+// reports the current job list along with any jobs that finished since.
+func ListenForJobs() tea.Cmd {
+	manager := state.GetJobs()
+	return func() tea.Msg {
+		<-manager.Changed()
+		return base.MsgJobsUpdated{
+			Jobs:     manager.Jobs(),
+			Finished: manager.DrainFinished(),
+		}
+	}
+}
+
+// CancelledNotice returns the notification shown when a job was cancelled.
+func CancelledNotice(job jobs.Job) tea.Cmd {
+	return notifications.ShowInfo(fmt.Sprintf("Cancelled: %s", job.Title))
+}
+
+type keybindings struct {
+	Toggle key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Cancel key.Binding
+	Clear  key.Binding
+	Close  key.Binding
+}
+
+func newKeybindings() keybindings {
+	return keybindings{
+		Toggle: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "jobs"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "cancel job"),
+		),
+		Clear: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "clear finished"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// Model is the jobs panel listing running, queued and finished jobs.
+type Model struct {
+	base.WindowSize
+	Keybindings keybindings
+	jobs        []jobs.Job
+	cursor      int
+	visible     bool
+}
+
+// New creates a hidden jobs panel.
+func New() Model {
+	return Model{Keybindings: newKeybindings()}
+}
+
+// IsVisible reports whether the panel is shown.
+func (model Model) IsVisible() bool {
+	return model.visible
+}
+
+// Toggle shows or hides the panel.
+func (model *Model) Toggle() {
+	model.visible = !model.visible
+	model.cursor = 0
+}
+
+// Jobs returns the last known job snapshots.
+func (model Model) Jobs() []jobs.Job {
+	return model.jobs
+}
+
+// Update tracks job changes and handles panel keys while visible.
+func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		model.WindowWidth = msg.Width
+		model.WindowHeight = msg.Height
+
+	case base.MsgJobsUpdated:
+		model.jobs = msg.Jobs
+		model.cursor = min(model.cursor, max(0, len(model.jobs)-1))
+
+	case tea.KeyPressMsg:
+		if !model.visible {
+			return model, nil
+		}
+
+		switch {
+		case key.Matches(msg, model.Keybindings.Close), key.Matches(msg, model.Keybindings.Toggle):
+			model.visible = false
+		case key.Matches(msg, model.Keybindings.Up):
+			model.cursor = max(0, model.cursor-1)
+		case key.Matches(msg, model.Keybindings.Down):
+			model.cursor = min(max(0, len(model.jobs)-1), model.cursor+1)
+		case key.Matches(msg, model.Keybindings.Cancel):
+			if model.cursor < len(model.jobs) {
+				job := model.jobs[model.cursor]
+				if !job.Done() {
+					state.GetJobs().Cancel(job.ID)
+				}
+			}
+		case key.Matches(msg, model.Keybindings.Clear):
+			state.GetJobs().ClearFinished()
+		}
+	}
+
+	return model, nil
+}
+
+// Indicator renders a short summary of active jobs for the tab bar, or an
+// empty string when nothing is running or queued.
+func (model Model) Indicator() string {
+	running, queued := 0, 0
+	for _, job := range model.jobs {
+		switch job.Status {
+		case jobs.Running:
+			running++
+		case jobs.Queued:
+			queued++
+		}
+	}
+
+	if running == 0 && queued == 0 {
+		return ""
+	}
+
+	summary := fmt.Sprintf("%s %d running", icons.Get().Restarting, running)
+	if queued > 0 {
+		summary += fmt.Sprintf(", %d queued", queued)
+	}
+	return lipgloss.NewStyle().Foreground(colors.Primary()).Render(summary)
+}
+
+// View renders the panel box.
+func (model Model) View() string {
+	style := lipgloss.NewStyle().
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	layoutManager := layout.NewLayoutManager(model.WindowWidth, model.WindowHeight)
+	dimensions := layoutManager.CalculateLarge(style)
+	style = style.Width(dimensions.Width).Height(dimensions.Height)
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Jobs (max %d at once)", state.GetJobs().Limit())), "")
+
+	if len(model.jobs) == 0 {
+		lines = append(lines, mutedStyle.Render("No background jobs"))
+	}
+
+	for i, job := range model.jobs {
+		lines = append(lines, renderJob(job, i == model.cursor, dimensions.ContentWidth)...)
+	}
+
+	lines = append(lines, "", mutedStyle.Render("↑/↓ select • x cancel • c clear finished • esc close"))
+
+	content := strings.Join(lines, "\n")
+	return style.Render(lipgloss.NewStyle().MaxHeight(dimensions.ContentHeight).Render(content))
+}
+
+func renderJob(job jobs.Job, selected bool, width int) []string {
+	cursor := "  "
+	titleStyle := lipgloss.NewStyle().Foreground(colors.Text())
+	if selected {
+		cursor = "> "
+		titleStyle = titleStyle.Bold(true).Foreground(colors.Primary())
+	}
+
+	status := statusStyle(job.Status).Render(job.Status.String())
+	if job.Status == jobs.Running && job.Percent > 0 {
+		status += fmt.Sprintf(" %3d%%", int(job.Percent*100))
+	}
+
+	header := cursor + titleStyle.Render(job.Title) + "  " + status
+	lines := []string{lipgloss.NewStyle().MaxWidth(width).Render(header)}
+
+	detailStyle := lipgloss.NewStyle().Foreground(colors.Muted()).MaxWidth(width)
+	switch {
+	case job.Status == jobs.Failed && job.Err != nil:
+		lines = append(lines, detailStyle.Foreground(colors.Error()).Render("    "+job.Err.Error()))
+	case job.Status == jobs.Running && job.Message != "":
+		lines = append(lines, detailStyle.Render("    "+job.Message))
+	}
+
+	if job.Status != jobs.Running {
+		return lines
+	}
+
+	items := job.Items
+	hidden := 0
+	if len(items) > maxVisibleItems {
+		hidden = len(items) - maxVisibleItems
+		items = items[len(items)-maxVisibleItems:]
+	}
+	if hidden > 0 {
+		lines = append(lines, detailStyle.Render(fmt.Sprintf("    … %d more", hidden)))
+	}
+	for _, item := range items {
+		line := fmt.Sprintf("    %s  %s", item.Name, item.Status)
+		if percent := item.Percent(); percent >= 0 {
+			line += fmt.Sprintf(" %3d%%", int(percent*100))
+		}
+		lines = append(lines, detailStyle.Render(line))
+	}
+
+	return lines
+}
+
+func statusStyle(status jobs.Status) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	switch status {
+	case jobs.Running:
+		return style.Foreground(colors.Primary())
+	case jobs.Succeeded:
+		return style.Foreground(colors.Success())
+	case jobs.Failed:
+		return style.Foreground(colors.Error())
+	case jobs.Cancelled:
+		return style.Foreground(colors.Warning())
+	default:
+		return style.Foreground(colors.Muted())
+	}
+}
+
+// ShortHelp returns keybindings for the mini help view.
+func (model Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		model.Keybindings.Up,
+		model.Keybindings.Down,
+		model.Keybindings.Cancel,
+		model.Keybindings.Clear,
+		model.Keybindings.Close,
+	}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (model Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{model.ShortHelp()}
+}
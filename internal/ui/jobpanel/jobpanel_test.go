@@ -0,0 +1,153 @@
+package jobpanel
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/ui/base"
+)
+
+func TestIndicatorSummarisesActiveJobs(t *testing.T) {
+	model := New()
+	if got := model.Indicator(); got != "" {
+		t.Fatalf("expected empty indicator without jobs, got %q", got)
+	}
+
+	model, _ = model.Update(base.MsgJobsUpdated{Jobs: []jobs.Job{
+		{ID: 1, Title: "Pull nginx", Status: jobs.Running},
+		{ID: 2, Title: "Pull redis", Status: jobs.Queued},
+		{ID: 3, Title: "Pull alpine", Status: jobs.Queued},
+		{ID: 4, Title: "Prune images", Status: jobs.Succeeded},
+	}})
+
+	got := model.Indicator()
+	if !strings.Contains(got, "1 running") || !strings.Contains(got, "2 queued") {
+		t.Fatalf("expected running and queued counts in indicator, got %q", got)
+	}
+}
+
+func TestIndicatorHiddenWhenAllJobsFinished(t *testing.T) {
+	model := New()
+	model, _ = model.Update(base.MsgJobsUpdated{Jobs: []jobs.Job{
+		{ID: 1, Title: "Pull nginx", Status: jobs.Failed, Err: errors.New("boom")},
+	}})
+
+	if got := model.Indicator(); got != "" {
+		t.Fatalf("expected empty indicator when nothing is active, got %q", got)
+	}
+}
+
+func TestPanelIgnoresKeysWhileHidden(t *testing.T) {
+	model := New()
+	model, _ = model.Update(base.MsgJobsUpdated{Jobs: []jobs.Job{
+		{ID: 1, Status: jobs.Running},
+		{ID: 2, Status: jobs.Queued},
+	}})
+
+	model, _ = model.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if model.cursor != 0 {
+		t.Fatalf("expected cursor to stay at 0 while hidden, got %d", model.cursor)
+	}
+
+	model.Toggle()
+	model, _ = model.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if model.cursor != 1 {
+		t.Fatalf("expected cursor to move to 1 while visible, got %d", model.cursor)
+	}
+
+	model, _ = model.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if model.IsVisible() {
+		t.Fatal("expected esc to close the panel")
+	}
+}
+
+func TestViewListsJobItemsAndErrors(t *testing.T) {
+	model := New()
+	model.WindowWidth = 120
+	model.WindowHeight = 40
+	model, _ = model.Update(base.MsgJobsUpdated{Jobs: []jobs.Job{
+		{
+			ID:      1,
+			Title:   "Pull nginx",
+			Status:  jobs.Running,
+			Percent: 0.5,
+			Items:   []jobs.Item{{Name: "a1b2c3", Status: "Downloading", Current: 5, Total: 10}},
+		},
+		{ID: 2, Title: "Build app", Status: jobs.Failed, Err: errors.New("no such file")},
+	}})
+
+	view := model.View()
+	for _, want := range []string{"Pull nginx", "a1b2c3", "Downloading", "50%", "Build app", "no such file"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestParsePullLayerProgressValid(t *testing.T) {
+	raw := `{"id":"layer-1","progressDetail":{"current":50,"total":100}}`
+
+	id, current, total, ok := parsePullLayerProgress(raw)
+	if !ok {
+		t.Fatal("expected valid progress payload")
+	}
+	if id != "layer-1" || current != 50 || total != 100 {
+		t.Fatalf("unexpected parsed values: id=%q current=%d total=%d", id, current, total)
+	}
+}
+
+func TestPullTrackerAggregatesLayers(t *testing.T) {
+	tracker := newPullTracker()
+
+	first, ok := tracker.estimate(`{"id":"a","progressDetail":{"current":50,"total":100}}`)
+	if !ok {
+		t.Fatal("expected first progress estimate")
+	}
+	if first != 0.5 {
+		t.Fatalf("expected 0.5, got %f", first)
+	}
+
+	second, ok := tracker.estimate(`{"id":"b","progressDetail":{"current":50,"total":100}}`)
+	if !ok {
+		t.Fatal("expected second progress estimate")
+	}
+	if second != 0.5 {
+		t.Fatalf("expected 0.5 aggregate, got %f", second)
+	}
+}
+
+func TestPullTrackerIsMonotonicAndCapped(t *testing.T) {
+	tracker := newPullTracker()
+
+	_, ok := tracker.estimate(`{"id":"a","progressDetail":{"current":99,"total":100}}`)
+	if !ok {
+		t.Fatal("expected progress estimate")
+	}
+
+	if tracker.percent != 0.98 {
+		t.Fatalf("expected cap at 0.98, got %f", tracker.percent)
+	}
+
+	percent, ok := tracker.estimate(`{"id":"a","progressDetail":{"current":10,"total":100}}`)
+	if !ok {
+		t.Fatal("expected progress estimate")
+	}
+	if percent != 0.98 {
+		t.Fatalf("expected monotonic percent 0.98, got %f", percent)
+	}
+}
+
+func TestParsePullLayerStatus(t *testing.T) {
+	id, status := parsePullLayerStatus(`{"status":"Pull complete","id":"a1b2c3"}`)
+	if id != "a1b2c3" || status != "Pull complete" {
+		t.Fatalf("unexpected layer status: id=%q status=%q", id, status)
+	}
+
+	id, _ = parsePullLayerStatus("not json")
+	if id != "" {
+		t.Fatalf("expected empty id for invalid input, got %q", id)
+	}
+}
@@ -0,0 +1,140 @@
+package jobpanel
+
+import (
+	stdcontext "context"
+	"encoding/json"
+
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/jobs"
+	"github.com/givensuman/containertui/internal/state"
+)
+
+// PullImage returns a job pulling imageName, reporting one progress item
+// per layer. describe turns each raw pull event into the job's message, so
+// that tabs keep their own wording. The job's result is imageName.
+func PullImage(imageName string, describe func(raw string) string) jobs.Func {
+	return func(ctx stdcontext.Context, jobProgress *jobs.Progress) error {
+		jobProgress.SetResult(imageName)
+		jobProgress.SetMessage("Preparing pull...")
+
+		ctx, cancel := state.OperationContext(ctx, config.OperationPull)
+		defer cancel()
+
+		progressChan := make(chan string, 100)
+		doneChan := make(chan error, 1)
+		go func() {
+			doneChan <- state.GetBackend().PullImage(ctx, imageName, progressChan)
+		}()
+
+		tracker := newPullTracker()
+		for {
+			select {
+			case raw, ok := <-progressChan:
+				if !ok {
+					return <-doneChan
+				}
+
+				jobProgress.SetMessage(describe(raw))
+				if percent, hasPercent := tracker.estimate(raw); hasPercent {
+					jobProgress.SetPercent(percent)
+				}
+				if layerID, status := parsePullLayerStatus(raw); layerID != "" {
+					layer := tracker.layers[layerID]
+					jobProgress.SetItem(layerID, status, layer.current, layer.total)
+				}
+
+			case err := <-doneChan:
+				return err
+			}
+		}
+	}
+}
+
+type pullLayerProgress struct {
+	current int64
+	total   int64
+}
+
+// pullTracker aggregates per-layer pull progress into a single percentage.
+type pullTracker struct {
+	layers  map[string]pullLayerProgress
+	percent float64
+}
+
+func newPullTracker() *pullTracker {
+	return &pullTracker{layers: make(map[string]pullLayerProgress)}
+}
+
+// estimate records the layer progress in raw and returns the overall pull
+// percentage. The result never decreases and is capped below completion.
+func (tracker *pullTracker) estimate(raw string) (float64, bool) {
+	layerID, current, total, ok := parsePullLayerProgress(raw)
+	if !ok {
+		return 0, false
+	}
+
+	tracker.layers[layerID] = pullLayerProgress{current: current, total: total}
+
+	var sumCurrent int64
+	var sumTotal int64
+	for _, layer := range tracker.layers {
+		sumCurrent += layer.current
+		sumTotal += layer.total
+	}
+
+	if sumTotal <= 0 {
+		return 0, false
+	}
+
+	percent := float64(sumCurrent) / float64(sumTotal)
+	if percent > 0.98 {
+		percent = 0.98
+	}
+	if percent < tracker.percent {
+		percent = tracker.percent
+	}
+
+	tracker.percent = percent
+	return percent, true
+}
+
+// parsePullLayerProgress extracts a layer's downloaded and total bytes from
+// a pull event.
+func parsePullLayerProgress(raw string) (string, int64, int64, bool) {
+	var status struct {
+		ID             string `json:"id"`
+		ProgressDetail struct {
+			Current int64 `json:"current"`
+			Total   int64 `json:"total"`
+		} `json:"progressDetail"`
+	}
+	if err := json.Unmarshal([]byte(raw), &status); err != nil {
+		return "", 0, 0, false
+	}
+
+	if status.ID == "" || status.ProgressDetail.Total <= 0 {
+		return "", 0, 0, false
+	}
+
+	current := status.ProgressDetail.Current
+	if current < 0 {
+		current = 0
+	}
+	if current > status.ProgressDetail.Total {
+		current = status.ProgressDetail.Total
+	}
+
+	return status.ID, current, status.ProgressDetail.Total, true
+}
+
+// parsePullLayerStatus extracts the layer ID and status text from a pull event.
+func parsePullLayerStatus(raw string) (string, string) {
+	var status struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(raw), &status); err != nil {
+		return "", ""
+	}
+	return status.ID, status.Status
+}
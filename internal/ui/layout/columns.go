@@ -0,0 +1,55 @@
+package layout
+
+// ColumnSpec describes how a table column takes up width.
+type ColumnSpec struct {
+	// MinWidth is the narrowest the column is shown at
+	MinWidth int
+	// Flex is the column's share of the width left over once every shown
+	// column has its minimum. Columns with no flex stay at their minimum.
+	Flex int
+}
+
+// ColumnWidths lays out columns across width with gap cells between them.
+// Columns that do not fit at their minimum width are dropped from the right
+// and get a width of 0.
+func ColumnWidths(width, gap int, columns []ColumnSpec) []int {
+	widths := make([]int, len(columns))
+
+	used, shown := 0, 0
+	for i, column := range columns {
+		needed := column.MinWidth
+		if shown > 0 {
+			needed += gap
+		}
+		if used+needed > width {
+			break
+		}
+		used += needed
+		widths[i] = column.MinWidth
+		shown = i + 1
+	}
+
+	totalFlex := 0
+	for _, column := range columns[:shown] {
+		totalFlex += column.Flex
+	}
+	if totalFlex == 0 {
+		return widths
+	}
+
+	spare := width - used
+	remaining := spare
+	lastFlexible := -1
+	for i, column := range columns[:shown] {
+		if column.Flex == 0 {
+			continue
+		}
+		extra := spare * column.Flex / totalFlex
+		widths[i] += extra
+		remaining -= extra
+		lastFlexible = i
+	}
+	// Rounding leftovers go to the last flexible column
+	widths[lastFlexible] += remaining
+	return widths
+}
@@ -174,3 +174,29 @@ func TestRatioConstants(t *testing.T) {
 		t.Errorf("RatioLargeOverlay = (%f, %f); want (0.8, 0.8)", RatioLargeOverlay.width, RatioLargeOverlay.height)
 	}
 }
+
+func TestColumnWidths(t *testing.T) {
+	columns := []ColumnSpec{{MinWidth: 10, Flex: 2}, {MinWidth: 8}, {MinWidth: 6, Flex: 1}}
+
+	tests := []struct {
+		name     string
+		width    int
+		expected []int
+	}{
+		{"exact fit", 26, []int{10, 8, 6}},
+		{"spare width shared by flex", 35, []int{16, 8, 9}},
+		{"last column dropped", 20, []int{11, 8, 0}},
+		{"too narrow for any", 5, []int{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ColumnWidths(tt.width, 1, columns)
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Fatalf("ColumnWidths(%d) = %v; want %v", tt.width, result, tt.expected)
+				}
+			}
+		})
+	}
+}
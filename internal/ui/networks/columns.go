@@ -0,0 +1,36 @@
+package networks
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/layout"
+)
+
+// tableColumns are the columns the networks table can show.
+func tableColumns() []components.Column[NetworkItem] {
+	return []components.Column[NetworkItem]{
+		{
+			Key: "name", Title: "Name", ColumnSpec: layout.ColumnSpec{MinWidth: 18, Flex: 3},
+			// The activity marker stays in front of the name
+			Value: func(item NetworkItem) string { return item.Title() },
+			Compare: func(a, b NetworkItem) int {
+				return cmp.Compare(strings.ToLower(a.Network.Name), strings.ToLower(b.Network.Name))
+			},
+		},
+		{
+			Key: "driver", Title: "Driver", ColumnSpec: layout.ColumnSpec{MinWidth: 8},
+			Value: func(item NetworkItem) string { return item.Network.Driver },
+		},
+		{
+			Key: "scope", Title: "Scope", ColumnSpec: layout.ColumnSpec{MinWidth: 7},
+			Value: func(item NetworkItem) string { return item.Network.Scope },
+		},
+		{
+			Key: "id", Title: "ID", ColumnSpec: layout.ColumnSpec{MinWidth: 12},
+			Value: func(item NetworkItem) string { return infopanel.TruncateID(item.Network.ID) },
+		},
+	}
+}
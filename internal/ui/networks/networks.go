@@ -11,11 +11,16 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
 	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/safety"
 )
@@ -27,6 +32,9 @@ type MsgNetworkInspection struct {
 	Err     error
 }
 
+// jobKindPrune identifies prune jobs submitted by this tab.
+const jobKindPrune = "networks/prune"
+
 // MsgPruneComplete is sent when the prune operation completes
 type MsgPruneComplete struct {
 	NetworksDeleted int
@@ -39,6 +47,13 @@ type MsgCreateNetworkComplete struct {
 	Err       error
 }
 
+// MsgRemoveNetworkComplete indicates a network removal finished.
+type MsgRemoveNetworkComplete struct {
+	NetworkID string
+	Force     bool
+	Err       error
+}
+
 type MsgAttachContainerComplete struct {
 	NetworkID   string
 	ContainerID string
@@ -51,16 +66,6 @@ type MsgDetachContainerComplete struct {
 	Err         error
 }
 
-// isSystemNetwork returns true if the network is a predefined system network.
-func isSystemNetwork(name string) bool {
-	switch name {
-	case "bridge", "host", "none", "podman":
-		return true
-	default:
-		return false
-	}
-}
-
 type keybindings struct {
 	toggleSelection      key.Binding
 	toggleSelectionOfAll key.Binding
@@ -69,6 +74,7 @@ type keybindings struct {
 	createNetwork        key.Binding
 	attachContainer      key.Binding
 	detachContainer      key.Binding
+	cancelOperation      key.Binding
 	switchTab            key.Binding
 }
 
@@ -102,9 +108,13 @@ func newKeybindings() *keybindings {
 			key.WithKeys("d"),
 			key.WithHelp("d", "detach container"),
 		),
+		cancelOperation: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel operation"),
+		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "5"),
-			key.WithHelp("1-5", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "switch tab"),
 		),
 	}
 }
@@ -116,22 +126,31 @@ type Model struct {
 	detailsKeybindings components.DetailsKeybindings
 	inspection         backend.NetworkDetail
 	detailsPanel       components.DetailsPanel
+	// operation is the removal, creation or container change in flight,
+	// which esc cancels.
+	operation components.Operation
 }
 
 func New() Model {
 	networkKeybindings := newKeybindings()
 
 	fetchNetworks := func() ([]NetworkItem, error) {
-		networkList, err := state.GetBackend().ListNetworks(stdcontext.Background())
-		if err != nil {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+		defer cancel()
+		networkList, hostErr := state.GetBackend().ListNetworks(ctx)
+		if err := multi.IgnorePartial(hostErr); err != nil {
 			return nil, err
 		}
 
 		// Get network usage map (single API call for all networks)
-		activeNetworks, err := state.GetBackend().GetAllNetworkUsage(stdcontext.Background())
-		if err != nil {
+		activeNetworks, err := state.GetBackend().GetAllNetworkUsage(ctx)
+		if err := multi.IgnorePartial(err); err != nil {
 			return nil, err
 		}
+		if hostErr == nil {
+			// Usage from the hosts that answered is still complete
+			hostErr = err
+		}
 
 		items := make([]NetworkItem, 0, len(networkList))
 		for _, network := range networkList {
@@ -143,7 +162,8 @@ func New() Model {
 				IsActive: isActive,
 			})
 		}
-		return items, nil
+		// Hosts that failed are reported by the view, which keeps the rest
+		return items, hostErr
 	}
 
 	resourceView := components.NewResourceView[string, NetworkItem](
@@ -156,6 +176,11 @@ func New() Model {
 		},
 	)
 
+	resourceView.EnableQuery(filter.NetworkQueryKeys, func(item NetworkItem) filter.Fields {
+		return filter.NetworkFields(item.Network)
+	})
+	resourceView.EnableTable(tableColumns(), components.TableSettings("networks"), components.SaveTableSettings("networks"))
+
 	// Add extra pane below detail pane
 	extraPane := components.NewViewportPane()
 	extraPane.SetContent("")                            // Will be populated when a network is selected
@@ -185,11 +210,30 @@ func New() Model {
 		networkKeybindings.createNetwork,
 		networkKeybindings.attachContainer,
 		networkKeybindings.detachContainer,
+		networkKeybindings.cancelOperation,
+	}
+
+	// Actions that change daemon state are disabled while it is unreachable
+	model.MutatingKeys = []key.Binding{
+		networkKeybindings.remove,
+		networkKeybindings.pruneNetworks,
+		networkKeybindings.createNetwork,
+		networkKeybindings.attachContainer,
+		networkKeybindings.detachContainer,
 	}
 
 	return model
 }
 
+// SetLaunchFilter limits the list to the networks matching f and, once they
+// load, puts the cursor on the one called selectName.
+func (model *Model) SetLaunchFilter(f filter.Filter, selectName string) {
+	if !f.Empty() {
+		model.Filter = func(item NetworkItem) bool { return f.Match(filter.NetworkFields(item.Network)) }
+	}
+	model.SelectOnLoad(selectName)
+}
+
 func (model Model) Init() tea.Cmd {
 	return model.ResourceView.Init()
 }
@@ -215,11 +259,15 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// Restore scroll position after viewport has processed content
 		model.detailsPanel.RestoreScrollPosition(model.getViewport())
 
-	case MsgPruneComplete:
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			_ = progressDialog.SetPercent(1.0)
+	case components.MsgRevealExpired:
+		if model.detailsPanel.ExpireReveal() {
+			model.refreshInspectionContent()
 		}
-		model.CloseOverlay()
+
+	case base.MsgJobsUpdated:
+		cmds = append(cmds, handleFinishedJobs(model.OwnFinished(msg.Finished)))
+
+	case MsgPruneComplete:
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
 		}
@@ -246,23 +294,14 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case MsgCreateNetworkComplete:
 		return model.handleCreateNetworkComplete(msg)
 
+	case MsgRemoveNetworkComplete:
+		return model.handleRemoveNetworkComplete(msg)
+
 	case MsgAttachContainerComplete:
-		if msg.Err != nil {
-			return model, notifications.ShowError(msg.Err)
-		}
-		return model, tea.Batch(
-			notifications.ShowSuccess(fmt.Sprintf("Attached container %s to network", shortID(msg.ContainerID))),
-			model.Refresh(),
-		)
+		return model.finishOperation(msg.Err, fmt.Sprintf("Attached container %s to network", shortID(msg.ContainerID)))
 
 	case MsgDetachContainerComplete:
-		if msg.Err != nil {
-			return model, notifications.ShowError(msg.Err)
-		}
-		return model, tea.Batch(
-			notifications.ShowSuccess(fmt.Sprintf("Detached container %s from network", shortID(msg.ContainerID))),
-			model.Refresh(),
-		)
+		return model.finishOperation(msg.Err, fmt.Sprintf("Detached container %s from network", shortID(msg.ContainerID)))
 	}
 
 	// 3. Handle Overlay/Dialog logic specifically for ConfirmationMessage
@@ -281,34 +320,16 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					model.CloseOverlay()
 					return model, notifications.ShowError(fmt.Errorf("invalid payload type for DeleteNetwork"))
 				}
-				err := state.GetBackend().RemoveNetwork(stdcontext.Background(), networkID)
-				if err == nil {
-					// Close the overlay and refresh list
-					model.CloseOverlay()
-					return model, tea.Batch(
-						notifications.ShowSuccess(fmt.Sprintf("Network removed: %s", networkID[:12])),
-						model.Refresh(),
-					)
-				} else {
-					// Show error notification
-					model.CloseOverlay()
-					return model, notifications.ShowError(err)
-				}
+				model.CloseOverlay()
+				return model, model.startRemoveNetwork(networkID, false)
 			} else if confirmMsg.Action.Type == "ForceDeleteNetwork" {
 				networkID, ok := confirmMsg.Action.Payload.(string)
 				if !ok {
 					model.CloseOverlay()
 					return model, notifications.ShowError(fmt.Errorf("invalid payload type for ForceDeleteNetwork"))
 				}
-				err := state.GetBackend().RemoveNetwork(stdcontext.Background(), networkID)
 				model.CloseOverlay()
-				if err != nil {
-					return model, notifications.ShowError(fmt.Errorf("failed to force delete network: %w", err))
-				}
-				return model, tea.Batch(
-					notifications.ShowSuccess(fmt.Sprintf("Force deleted network: %s", networkID[:12])),
-					model.Refresh(),
-				)
+				return model, model.startRemoveNetwork(networkID, true)
 			} else if confirmMsg.Action.Type == "CreateNetworkAction" {
 				// Extract form values
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
@@ -351,7 +372,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 
 				model.CloseOverlay()
-				return model, model.performCreateNetwork(name, driver, subnet, gateway, enableIPv6, labelsMap)
+				return model, model.startCreateNetwork(name, driver, subnet, gateway, enableIPv6, labelsMap)
 			} else if confirmMsg.Action.Type == "AttachContainerAction" {
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
 				if !ok {
@@ -370,7 +391,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					return model, notifications.ShowError(fmt.Errorf("container ID and network ID are required"))
 				}
 				model.CloseOverlay()
-				return model, model.performAttachContainer(networkID, containerID)
+				return model, model.startAttachContainer(networkID, containerID)
 			} else if confirmMsg.Action.Type == "DetachContainerAction" {
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
 				if !ok {
@@ -389,7 +410,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					return model, notifications.ShowError(fmt.Errorf("container ID and network ID are required"))
 				}
 				model.CloseOverlay()
-				return model, model.performDetachContainer(networkID, containerID)
+				return model, model.startDetachContainer(networkID, containerID)
 			}
 			model.CloseOverlay()
 			return model, nil
@@ -414,6 +435,10 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			case key.Matches(msg, model.keybindings.remove):
 				return model, model.handleRemove()
 
+			case key.Matches(msg, model.keybindings.cancelOperation):
+				model.operation.Cancel()
+				return model, tea.Batch(cmds...)
+
 			case key.Matches(msg, model.keybindings.pruneNetworks):
 				if !model.hasPrunableNetworks() {
 					return model, notifications.ShowSuccess("No unused networks to prune")
@@ -448,6 +473,11 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					cmd := model.detailsPanel.HandleCopyToClipboard(model.inspection)
 					cmds = append(cmds, cmd)
 				}
+				if key.Matches(msg, model.detailsKeybindings.RevealSecrets) {
+					cmd := model.detailsPanel.HandleRevealSecrets()
+					model.refreshInspectionContent()
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 	}
@@ -462,12 +492,14 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (model Model) handleCreateNetworkComplete(msg MsgCreateNetworkComplete) (Model, tea.Cmd) {
+	name := model.operation.Name()
+	model.operation.Finish()
 	if msg.Err != nil {
-		return model, notifications.ShowError(msg.Err)
+		return model, components.OperationOutcome(name, msg.Err, "")
 	}
 
 	return model, tea.Batch(
-		notifications.ShowSuccess(fmt.Sprintf("Created network: %s", msg.NetworkID[:12])),
+		components.OperationOutcome(name, nil, fmt.Sprintf("Created network: %s", msg.NetworkID[:12])),
 		model.Refresh(),
 		func() tea.Msg {
 			return base.MsgResourceChanged{
@@ -479,6 +511,28 @@ func (model Model) handleCreateNetworkComplete(msg MsgCreateNetworkComplete) (Mo
 	)
 }
 
+func (model Model) handleRemoveNetworkComplete(msg MsgRemoveNetworkComplete) (Model, tea.Cmd) {
+	success := fmt.Sprintf("Network removed: %s", shortID(msg.NetworkID))
+	if msg.Force {
+		success = fmt.Sprintf("Force deleted network: %s", shortID(msg.NetworkID))
+	}
+	return model.finishOperation(msg.Err, success)
+}
+
+// finishOperation releases the tab's operation once its result has arrived
+// and reports the outcome, refreshing the list when it succeeded.
+func (model Model) finishOperation(err error, success string) (Model, tea.Cmd) {
+	name := model.operation.Name()
+	model.operation.Finish()
+	if err != nil {
+		return model, components.OperationOutcome(name, err, "")
+	}
+	return model, tea.Batch(
+		components.OperationOutcome(name, nil, success),
+		model.Refresh(),
+	)
+}
+
 func (model Model) View() string {
 	return model.ResourceView.View()
 }
@@ -514,13 +568,15 @@ func (model *Model) handleRemove() tea.Cmd {
 	}
 
 	// Check if this is a system network
-	if isSystemNetwork(selectedItem.Network.Name) {
+	if safety.IsSystemNetwork(selectedItem.Network.Name) {
 		return notifications.ShowInfo(
 			fmt.Sprintf("Cannot delete system network: %s", selectedItem.Network.Name),
 		)
 	}
 
-	containersUsingNetwork, err := state.GetBackend().GetContainersUsingNetwork(stdcontext.Background(), selectedItem.Network.ID)
+	ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+	defer cancel()
+	containersUsingNetwork, err := state.GetBackend().GetContainersUsingNetwork(ctx, selectedItem.Network.ID)
 	if err != nil {
 		// If we can't check usage, show error and don't proceed with deletion
 		errorDialog := components.NewDialog(
@@ -572,7 +628,9 @@ func (model *Model) updateDetailContent() tea.Cmd {
 
 		// Fetch inspection data asynchronously
 		return func() tea.Msg {
-			networkInfo, err := state.GetBackend().InspectNetwork(stdcontext.Background(), networkID)
+			ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+			defer cancel()
+			networkInfo, err := state.GetBackend().InspectNetwork(ctx, networkID)
 			return MsgNetworkInspection{ID: networkID, Network: networkInfo, Err: err}
 		}
 	}
@@ -590,12 +648,15 @@ func (model Model) getViewport() *viewport.Model {
 
 // refreshInspectionContent refreshes the detail content with current inspection data
 func (model *Model) refreshInspectionContent() {
-	// Use DetailsPanel to get the current format
-	format := model.detailsPanel.GetFormatForDisplay()
-
-	// Build content with current format
-	content := builders.BuildNetworkPanel(model.inspection, model.GetContentWidth(), format)
-	model.SetContent(content)
+	// The tree draws itself from the data; other formats are built as text
+	if model.detailsPanel.IsTreeFormat() {
+		model.ShowInspectTree(model.inspection, model.detailsPanel.SecretsRevealed())
+	} else {
+		model.HideInspectTree()
+		format := model.detailsPanel.GetFormatForDisplay()
+		content := builders.BuildNetworkPanel(model.inspection, model.GetContentWidth(), model.detailsPanel.SecretsRevealed(), format)
+		model.SetContent(content)
+	}
 
 	// Update "Used By" panel
 	model.updateUsedByPanel()
@@ -609,7 +670,9 @@ func (model *Model) updateUsedByPanel() {
 	}
 
 	// Fetch containers using this network
-	usedBy, err := state.GetBackend().GetContainersUsingNetwork(stdcontext.Background(), model.inspection.ID)
+	ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+	defer cancel()
+	usedBy, err := state.GetBackend().GetContainersUsingNetwork(ctx, model.inspection.ID)
 	if err != nil {
 		model.SetExtraContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render(fmt.Sprintf("Error: %v", err)))
 		return
@@ -671,13 +734,14 @@ func shortID(id string) string {
 func (model Model) ShortHelp() []key.Binding {
 	// If detail or extra pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return []key.Binding{
+		return append([]key.Binding{
 			model.detailsKeybindings.Up,
 			model.detailsKeybindings.Down,
 			model.detailsKeybindings.Switch,
 			model.detailsKeybindings.ToggleJSON,
 			model.detailsKeybindings.CopyOutput,
-		}
+			model.detailsKeybindings.RevealSecrets,
+		}, model.InspectTreeHelp()...)
 	} else if model.IsExtraFocused() {
 		return []key.Binding{
 			model.detailsKeybindings.Up,
@@ -691,7 +755,7 @@ func (model Model) ShortHelp() []key.Binding {
 func (model Model) FullHelp() [][]key.Binding {
 	// If detail or extra pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return [][]key.Binding{
+		help := [][]key.Binding{
 			{
 				model.detailsKeybindings.Up,
 				model.detailsKeybindings.Down,
@@ -700,8 +764,13 @@ func (model Model) FullHelp() [][]key.Binding {
 			{
 				model.detailsKeybindings.ToggleJSON,
 				model.detailsKeybindings.CopyOutput,
+				model.detailsKeybindings.RevealSecrets,
 			},
 		}
+		if tree := model.InspectTreeHelp(); len(tree) > 0 {
+			help = append(help, tree)
+		}
+		return help
 	} else if model.IsExtraFocused() {
 		return [][]key.Binding{
 			{
@@ -715,11 +784,11 @@ func (model Model) FullHelp() [][]key.Binding {
 }
 
 func (model Model) hasPrunableNetworks() bool {
-	for _, item := range model.GetItems() {
+	for _, item := range model.AllItems() {
 		if item.IsActive {
 			continue
 		}
-		if isSystemNetwork(item.Network.Name) {
+		if safety.IsSystemNetwork(item.Network.Name) {
 			continue
 		}
 
@@ -729,22 +798,36 @@ func (model Model) hasPrunableNetworks() bool {
 	return false
 }
 
-// handlePruneNetworks prunes unused networks
+// handlePruneNetworks prunes unused networks in a background job
 func (model *Model) handlePruneNetworks() tea.Cmd {
-	progressDialog := components.NewProgressDialogWithBar("Pruning unused networks")
-	progressDialog.EnableAutoAdvance(0.95, 0.04)
-	progressDialog.SetStatus("Discovering unused networks to prune...")
-	model.SetOverlay(progressDialog)
-
-	// Start async prune operation
-	return func() tea.Msg {
-		ctx := stdcontext.Background()
+	return model.SubmitJob("Prune unused networks", jobKindPrune, func(ctx stdcontext.Context, progress *jobs.Progress) error {
+		progress.SetMessage("Discovering unused networks to prune...")
+		ctx, cancel := state.OperationContext(ctx, config.OperationPrune)
+		defer cancel()
 		networksDeleted, err := state.GetBackend().PruneNetworks(ctx)
-		return MsgPruneComplete{
-			NetworksDeleted: networksDeleted,
-			Err:             err,
+		progress.SetResult(networksDeleted)
+		return err
+	})
+}
+
+// handleFinishedJobs translates finished jobs submitted by this tab into
+// their completion messages.
+func handleFinishedJobs(finished []jobs.Job) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range finished {
+		if job.Kind != jobKindPrune {
+			continue
 		}
+		if job.Status == jobs.Cancelled {
+			cmds = append(cmds, jobpanel.CancelledNotice(job))
+			continue
+		}
+		networksDeleted, _ := job.Result.(int)
+		cmds = append(cmds, func() tea.Msg {
+			return MsgPruneComplete{NetworksDeleted: networksDeleted, Err: job.Err}
+		})
 	}
+	return tea.Batch(cmds...)
 }
 
 func (model *Model) showPruneNetworksConfirmation() {
@@ -769,16 +852,15 @@ func (model *Model) showPruneNetworksConfirmation() {
 }
 
 func (model Model) pruneNetworkCandidates() []string {
-	items := model.GetItems()
-	candidates := make([]string, 0, len(items))
+	items := model.AllItems()
+	networks := make([]backend.Network, 0, len(items))
+	usage := make(map[string]bool, len(items))
 	for _, item := range items {
-		if item.IsActive || isSystemNetwork(item.Network.Name) {
-			continue
-		}
-		candidates = append(candidates, item.Network.Name)
+		networks = append(networks, item.Network)
+		usage[item.Network.ID] = item.IsActive
 	}
 
-	return candidates
+	return safety.PruneNetworkCandidates(networks, usage)
 }
 
 // withCreateNetworkDialog returns model with create-network dialog shown.
@@ -827,11 +909,14 @@ func (model Model) withCreateNetworkDialog() Model {
 	return model
 }
 
-// performCreateNetwork creates a network
-func (model Model) performCreateNetwork(name, driver, subnet, gateway string, enableIPv6 bool, labels map[string]string) tea.Cmd {
-	return func() tea.Msg {
-		ctx := stdcontext.Background()
+// startCreateNetwork creates a network as the tab's cancellable operation.
+func (model *Model) startCreateNetwork(name, driver, subnet, gateway string, enableIPv6 bool, labels map[string]string) tea.Cmd {
+	ctx, notice := model.operation.Start(fmt.Sprintf("Create network %s", name), config.OperationAction)
+	if notice != nil {
+		return notice
+	}
 
+	return func() tea.Msg {
 		// Use "bridge" as default driver if not specified
 		if driver == "" {
 			driver = "bridge"
@@ -890,16 +975,47 @@ func (model *Model) handleDetachContainer() {
 	model.SetOverlay(dialog)
 }
 
-func (model Model) performAttachContainer(networkID, containerID string) tea.Cmd {
+// startRemoveNetwork removes the network networkID in the background, as the
+// tab's cancellable operation.
+func (model *Model) startRemoveNetwork(networkID string, force bool) tea.Cmd {
+	name := fmt.Sprintf("Remove network %s", shortID(networkID))
+	if force {
+		name = fmt.Sprintf("Force delete network %s", shortID(networkID))
+	}
+	ctx, notice := model.operation.Start(name, config.OperationAction)
+	if notice != nil {
+		return notice
+	}
+
 	return func() tea.Msg {
-		err := state.GetBackend().ConnectContainerToNetwork(stdcontext.Background(), containerID, networkID)
+		err := state.GetBackend().RemoveNetwork(ctx, networkID)
+		if err != nil && force {
+			err = fmt.Errorf("failed to force delete network: %w", err)
+		}
+		return MsgRemoveNetworkComplete{NetworkID: networkID, Force: force, Err: err}
+	}
+}
+
+func (model *Model) startAttachContainer(networkID, containerID string) tea.Cmd {
+	ctx, notice := model.operation.Start(fmt.Sprintf("Attach container %s", shortID(containerID)), config.OperationAction)
+	if notice != nil {
+		return notice
+	}
+
+	return func() tea.Msg {
+		err := state.GetBackend().ConnectContainerToNetwork(ctx, containerID, networkID)
 		return MsgAttachContainerComplete{NetworkID: networkID, ContainerID: containerID, Err: err}
 	}
 }
 
-func (model Model) performDetachContainer(networkID, containerID string) tea.Cmd {
+func (model *Model) startDetachContainer(networkID, containerID string) tea.Cmd {
+	ctx, notice := model.operation.Start(fmt.Sprintf("Detach container %s", shortID(containerID)), config.OperationAction)
+	if notice != nil {
+		return notice
+	}
+
 	return func() tea.Msg {
-		err := state.GetBackend().DisconnectContainerFromNetwork(stdcontext.Background(), containerID, networkID, false)
+		err := state.GetBackend().DisconnectContainerFromNetwork(ctx, containerID, networkID, false)
 		return MsgDetachContainerComplete{NetworkID: networkID, ContainerID: containerID, Err: err}
 	}
 }
@@ -0,0 +1,121 @@
+package safety
+
+import (
+	"strings"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+// PruneEligibleContainerState reports whether a container in state is removed
+// by a container prune.
+func PruneEligibleContainerState(state string) bool {
+	switch state {
+	case "exited", "created", "dead":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSystemNetwork reports whether name is a network the daemon creates itself
+// and never prunes.
+func IsSystemNetwork(name string) bool {
+	switch name {
+	case "bridge", "host", "none", "podman":
+		return true
+	default:
+		return false
+	}
+}
+
+// ImagesInUse returns the IDs of the images that containers run, matching
+// containers by image ID or by any of the image's tags.
+func ImagesInUse(images []backend.Image, containers []backend.Container) map[string]bool {
+	inUse := make(map[string]bool)
+	for id, count := range ImageContainerCounts(images, containers) {
+		inUse[id] = count > 0
+	}
+	return inUse
+}
+
+// ImageContainerCounts returns how many containers run each image, keyed by
+// image ID, matching containers as ImagesInUse does. Unused images are left
+// out.
+func ImageContainerCounts(images []backend.Image, containers []backend.Container) map[string]int {
+	refCounts := make(map[string]int, len(containers))
+	for _, container := range containers {
+		refCounts[container.Image]++
+	}
+
+	counts := make(map[string]int)
+	for _, image := range images {
+		count := refCounts[image.ID]
+		for _, tag := range image.RepoTags {
+			count += refCounts[tag]
+		}
+		if count > 0 {
+			counts[image.ID] = count
+		}
+	}
+	return counts
+}
+
+// PruneContainerCandidates returns the names of the containers a prune
+// would remove.
+func PruneContainerCandidates(containers []backend.Container) []string {
+	candidates := make([]string, 0, len(containers))
+	for _, container := range containers {
+		if PruneEligibleContainerState(container.State) {
+			candidates = append(candidates, container.Name)
+		}
+	}
+	return candidates
+}
+
+// PruneImageCandidates returns the images a prune would remove, by first tag
+// or short ID. inUse is keyed by image ID, as returned by ImagesInUse.
+func PruneImageCandidates(images []backend.Image, inUse map[string]bool) []string {
+	candidates := make([]string, 0, len(images))
+	for _, image := range images {
+		if inUse[image.ID] {
+			continue
+		}
+
+		if len(image.RepoTags) > 0 && strings.TrimSpace(image.RepoTags[0]) != "" {
+			candidates = append(candidates, image.RepoTags[0])
+			continue
+		}
+
+		trimmedID := strings.TrimPrefix(image.ID, "sha256:")
+		if len(trimmedID) > 12 {
+			trimmedID = trimmedID[:12]
+		}
+		candidates = append(candidates, trimmedID)
+	}
+	return candidates
+}
+
+// PruneNetworkCandidates returns the names of the networks a prune would
+// remove. usage marks active networks by name or ID.
+func PruneNetworkCandidates(networks []backend.Network, usage map[string]bool) []string {
+	candidates := make([]string, 0, len(networks))
+	for _, network := range networks {
+		if usage[network.Name] || usage[network.ID] || IsSystemNetwork(network.Name) {
+			continue
+		}
+		candidates = append(candidates, network.Name)
+	}
+	return candidates
+}
+
+// PruneVolumeCandidates returns the names of the volumes a prune would
+// remove. usage marks mounted volumes by name.
+func PruneVolumeCandidates(volumes []backend.Volume, usage map[string]bool) []string {
+	candidates := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		if !usage[volume.Name] {
+			candidates = append(candidates, volume.Name)
+		}
+	}
+	return candidates
+}
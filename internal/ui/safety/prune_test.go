@@ -0,0 +1,71 @@
+package safety
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/backend"
+)
+
+func TestPruneContainerCandidatesSkipRunningContainers(t *testing.T) {
+	got := PruneContainerCandidates([]backend.Container{
+		{Name: "web", State: "running"},
+		{Name: "job", State: "exited"},
+		{Name: "fresh", State: "created"},
+		{Name: "paused", State: "paused"},
+	})
+	if strings.Join(got, ",") != "job,fresh" {
+		t.Fatalf("expected job and fresh, got %v", got)
+	}
+}
+
+func TestPruneImageCandidatesSkipImagesInUse(t *testing.T) {
+	images := []backend.Image{
+		{ID: "sha256:aaaaaaaaaaaaaaaa", RepoTags: []string{"nginx:latest"}},
+		{ID: "sha256:bbbbbbbbbbbbbbbb", RepoTags: []string{"redis:7"}},
+		{ID: "sha256:cccccccccccccccc"},
+		{ID: "sha256:dddddddddddddddd"},
+	}
+	containers := []backend.Container{
+		{Image: "nginx:latest"},
+		{Image: "sha256:dddddddddddddddd"},
+	}
+
+	got := PruneImageCandidates(images, ImagesInUse(images, containers))
+	if strings.Join(got, ",") != "redis:7,cccccccccccc" {
+		t.Fatalf("expected redis:7 and a short untagged ID, got %v", got)
+	}
+}
+
+func TestPruneNetworkAndVolumeCandidates(t *testing.T) {
+	networks := PruneNetworkCandidates([]backend.Network{
+		{ID: "n1", Name: "bridge"},
+		{ID: "n2", Name: "app"},
+		{ID: "n3", Name: "idle"},
+	}, map[string]bool{"n2": true})
+	if strings.Join(networks, ",") != "idle" {
+		t.Fatalf("expected only idle, got %v", networks)
+	}
+
+	volumes := PruneVolumeCandidates([]backend.Volume{{Name: "data"}, {Name: "cache"}}, map[string]bool{"data": true})
+	if strings.Join(volumes, ",") != "cache" {
+		t.Fatalf("expected only cache, got %v", volumes)
+	}
+}
+
+func TestImageContainerCountsMatchesTagsAndIDs(t *testing.T) {
+	images := []backend.Image{
+		{ID: "sha256:aaaaaaaaaaaaaaaa", RepoTags: []string{"nginx:latest", "nginx:1"}},
+		{ID: "sha256:bbbbbbbbbbbbbbbb", RepoTags: []string{"redis:7"}},
+	}
+	containers := []backend.Container{
+		{Image: "nginx:latest"},
+		{Image: "nginx:1"},
+		{Image: "sha256:aaaaaaaaaaaaaaaa"},
+	}
+
+	got := ImageContainerCounts(images, containers)
+	if got["sha256:aaaaaaaaaaaaaaaa"] != 3 || len(got) != 1 {
+		t.Fatalf("expected three containers on nginx only, got %v", got)
+	}
+}
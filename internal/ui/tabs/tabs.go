@@ -2,6 +2,9 @@
 package tabs
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -21,7 +24,28 @@ const (
 	Browse
 )
 
+// FirstView is the first saved view's tab. Views registered with SetViews
+// follow the built-in tabs in order.
+const FirstView = Browse + 1
+
+// viewNames are the names of the saved views, in tab order.
+var viewNames []string
+
+// SetViews registers the saved views shown after the built-in tabs.
+func SetViews(names []string) {
+	viewNames = slices.Clone(names)
+}
+
+// ViewIndex returns the index of the saved view shown on tab t.
+func ViewIndex(t Tab) (int, bool) {
+	index := int(t - FirstView)
+	return index, index >= 0 && index < len(viewNames)
+}
+
 func (t Tab) String() string {
+	if index, ok := ViewIndex(t); ok {
+		return viewNames[index]
+	}
 	return [...]string{
 		"Containers",
 		"Images",
@@ -33,7 +57,7 @@ func (t Tab) String() string {
 
 // TabFromString converts a string to a Tab, returns -1 if invalid
 func TabFromString(s string) Tab {
-	switch strings.ToLower(strings.TrimSpace(s)) {
+	switch name := strings.ToLower(strings.TrimSpace(s)); name {
 	case "containers":
 		return Containers
 	case "images":
@@ -45,6 +69,11 @@ func TabFromString(s string) Tab {
 	case "browse":
 		return Browse
 	default:
+		for i, view := range viewNames {
+			if strings.ToLower(view) == name {
+				return FirstView + Tab(i)
+			}
+		}
 		return -1
 	}
 }
@@ -56,38 +85,64 @@ func IsValidTab(s string) bool {
 
 // AllTabNames returns all valid tab names
 func AllTabNames() []string {
-	return []string{"containers", "images", "volumes", "networks", "browse"}
+	names := []string{"containers", "images", "volumes", "networks", "browse"}
+	for _, view := range viewNames {
+		names = append(names, strings.ToLower(view))
+	}
+	return names
+}
+
+// allTabs returns the built-in tabs followed by the saved views.
+func allTabs() []Tab {
+	all := []Tab{Containers, Images, Volumes, Networks, Browse}
+	for i := range viewNames {
+		all = append(all, FirstView+Tab(i))
+	}
+	return all
+}
+
+// Arrange returns the tabs to show: those named in order first, then the
+// rest in their default order, leaving out the hidden ones.
+func Arrange(order, hidden []string) ([]Tab, error) {
+	var arranged []Tab
+	for _, name := range order {
+		t := TabFromString(name)
+		if t == -1 {
+			return nil, fmt.Errorf("unknown tab %q in tab order", name)
+		}
+		if !slices.Contains(arranged, t) {
+			arranged = append(arranged, t)
+		}
+	}
+	for _, t := range allTabs() {
+		if !slices.Contains(arranged, t) {
+			arranged = append(arranged, t)
+		}
+	}
+
+	for _, name := range hidden {
+		t := TabFromString(name)
+		if t == -1 {
+			return nil, fmt.Errorf("unknown hidden tab %q", name)
+		}
+		arranged = slices.DeleteFunc(arranged, func(shown Tab) bool { return shown == t })
+	}
+	if len(arranged) == 0 {
+		return nil, fmt.Errorf("every tab is hidden")
+	}
+	return arranged, nil
 }
 
 type KeyMap struct {
-	SwitchToContainers key.Binding
-	SwitchToImages     key.Binding
-	SwitchToVolumes    key.Binding
-	SwitchToNetworks   key.Binding
-	SwitchToBrowse     key.Binding
+	// SwitchTab jumps to the tab at the position of the digit pressed
+	SwitchTab key.Binding
 }
 
 func NewKeyMap() KeyMap {
 	return KeyMap{
-		SwitchToContainers: key.NewBinding(
-			key.WithKeys("1"),
-			key.WithHelp("1", "containers"),
-		),
-		SwitchToImages: key.NewBinding(
-			key.WithKeys("2"),
-			key.WithHelp("2", "images"),
-		),
-		SwitchToVolumes: key.NewBinding(
-			key.WithKeys("3"),
-			key.WithHelp("3", "volumes"),
-		),
-		SwitchToNetworks: key.NewBinding(
-			key.WithKeys("4"),
-			key.WithHelp("4", "networks"),
-		),
-		SwitchToBrowse: key.NewBinding(
-			key.WithKeys("5"),
-			key.WithHelp("5", "browse"),
+		SwitchTab: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "switch tab"),
 		),
 	}
 }
@@ -97,12 +152,14 @@ type Model struct {
 	ActiveTab Tab
 	Tabs      []Tab
 	KeyMap    KeyMap
+	// Status is rendered right-aligned on the tab row, e.g. the active jobs summary.
+	Status string
 }
 
 func New(startupTab Tab) Model {
 	return Model{
 		ActiveTab: startupTab,
-		Tabs:      []Tab{Containers, Images, Volumes, Networks, Browse},
+		Tabs:      allTabs(),
 		KeyMap:    NewKeyMap(),
 	}
 }
@@ -114,17 +171,11 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
-		switch {
-		case key.Matches(msg, m.KeyMap.SwitchToContainers):
-			m.ActiveTab = Containers
-		case key.Matches(msg, m.KeyMap.SwitchToImages):
-			m.ActiveTab = Images
-		case key.Matches(msg, m.KeyMap.SwitchToVolumes):
-			m.ActiveTab = Volumes
-		case key.Matches(msg, m.KeyMap.SwitchToNetworks):
-			m.ActiveTab = Networks
-		case key.Matches(msg, m.KeyMap.SwitchToBrowse):
-			m.ActiveTab = Browse
+		if key.Matches(msg, m.KeyMap.SwitchTab) {
+			position, _ := strconv.Atoi(msg.String())
+			if position <= len(m.Tabs) {
+				m.ActiveTab = m.Tabs[position-1]
+			}
 		}
 	case tea.WindowSizeMsg:
 		m.WindowWidth = msg.Width
@@ -147,10 +198,10 @@ func (m Model) View() string {
 
 	// Fill the rest of the line with the gap style
 	// We need to account for borders in width calculation
-	gapWidth := maxInt(0, m.WindowWidth-lipgloss.Width(row)-2) // -2 for safety margin
+	gapWidth := maxInt(0, m.WindowWidth-lipgloss.Width(row)-lipgloss.Width(m.Status)-2) // -2 for safety margin
 	gap := strings.Repeat(" ", gapWidth)
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, row, gap)
+	return lipgloss.JoinHorizontal(lipgloss.Top, row, gap, m.Status)
 }
 
 func maxInt(a, b int) int {
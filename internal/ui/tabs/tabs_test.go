@@ -1,8 +1,11 @@
 package tabs
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	tea "charm.land/bubbletea/v2"
 )
 
 func TestView_DoesNotRenderBoxBorders(t *testing.T) {
@@ -35,3 +38,52 @@ func TestServicesIsNotAValidTab(t *testing.T) {
 		t.Fatal("expected services to be an invalid tab name")
 	}
 }
+
+func TestViewRendersStatusRightAligned(t *testing.T) {
+	m := New(Containers)
+	m.WindowWidth = 120
+	m.Status = "2 running"
+
+	view := m.View()
+	if !strings.HasSuffix(strings.TrimRight(view, " "), "2 running") {
+		t.Fatalf("expected status at the end of the tab row, got %q", view)
+	}
+}
+
+func TestArrangeOrdersAndHidesTabs(t *testing.T) {
+	SetViews([]string{"Payments"})
+	t.Cleanup(func() { SetViews(nil) })
+
+	arranged, err := Arrange([]string{"payments", "volumes"}, []string{"browse", "networks"})
+	if err != nil {
+		t.Fatalf("Arrange failed: %v", err)
+	}
+	want := []Tab{FirstView, Volumes, Containers, Images}
+	if !slices.Equal(arranged, want) {
+		t.Fatalf("expected %v, got %v", want, arranged)
+	}
+	if index, ok := ViewIndex(arranged[0]); !ok || index != 0 {
+		t.Fatalf("expected the first tab to be view 0, got %d, %t", index, ok)
+	}
+	if arranged[0].String() != "Payments" {
+		t.Fatalf("expected the view's name on its tab, got %q", arranged[0].String())
+	}
+
+	if _, err := Arrange([]string{"nope"}, nil); err == nil {
+		t.Fatal("expected an unknown tab to be rejected")
+	}
+}
+
+func TestSwitchTabFollowsArrangedOrder(t *testing.T) {
+	m := New(Containers)
+	m.Tabs = []Tab{Volumes, Containers}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: '1', Text: "1"})
+	if m.ActiveTab != Volumes {
+		t.Fatalf("expected 1 to open the first shown tab, got %v", m.ActiveTab)
+	}
+	m, _ = m.Update(tea.KeyPressMsg{Code: '3', Text: "3"})
+	if m.ActiveTab != Volumes {
+		t.Fatalf("expected a digit past the last tab to be ignored, got %v", m.ActiveTab)
+	}
+}
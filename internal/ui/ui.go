@@ -2,8 +2,10 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/help"
@@ -12,14 +14,22 @@ import (
 	"charm.land/lipgloss/v2"
 
 	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/connection"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/secrets"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/browse"
+	"github.com/givensuman/containertui/internal/ui/components"
 	"github.com/givensuman/containertui/internal/ui/containers"
+	"github.com/givensuman/containertui/internal/ui/contextpanel"
 	"github.com/givensuman/containertui/internal/ui/images"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
 	"github.com/givensuman/containertui/internal/ui/networks"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/tabs"
+	"github.com/givensuman/containertui/internal/ui/views"
 	"github.com/givensuman/containertui/internal/ui/volumes"
 )
 
@@ -34,7 +44,11 @@ type Model struct {
 	volumesModel       volumes.Model
 	networksModel      networks.Model
 	browseModel        browse.Model
+	views              []views.Model
 	notificationsModel notifications.Model
+	jobPanel           jobpanel.Model
+	contextPanel       contextpanel.Model
+	connection         connection.State
 	help               help.Model
 }
 
@@ -62,18 +76,41 @@ func NewModel(startupTab tabs.Tab) Model {
 		networksModel:      networksModel,
 		browseModel:        browseModel,
 		notificationsModel: notificationsModel,
+		jobPanel:           jobpanel.New(),
+		contextPanel:       contextpanel.New(),
 		help:               helpModel,
 	}
 }
 
 func (model Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		model.containersModel.Init(),
 		model.imagesModel.Init(),
 		model.volumesModel.Init(),
 		model.networksModel.Init(),
 		model.browseModel.Init(),
-	)
+		jobpanel.ListenForJobs(),
+		listenForConnection(),
+	}
+	for _, view := range model.views {
+		cmds = append(cmds, view.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+// activeView returns the index of the saved view on the active tab.
+func (model Model) activeView() (int, bool) {
+	index, ok := tabs.ViewIndex(model.tabsModel.ActiveTab)
+	return index, ok && index < len(model.views)
+}
+
+// refreshViews reloads every saved view.
+func (model Model) refreshViews() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(model.views))
+	for _, view := range model.views {
+		cmds = append(cmds, view.Refresh())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -107,7 +144,43 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, browseCmd)
 	}
 
+	jobPanelVisible := model.jobPanel.IsVisible()
+	var jobPanelCmd tea.Cmd
+	model.jobPanel, jobPanelCmd = model.jobPanel.Update(msg)
+	cmds = append(cmds, jobPanelCmd)
+
+	contextPanelVisible := model.contextPanel.IsVisible()
+	var contextPanelCmd tea.Cmd
+	model.contextPanel, contextPanelCmd = model.contextPanel.Update(msg)
+	cmds = append(cmds, contextPanelCmd)
+
 	switch msg := msg.(type) {
+	case base.MsgJobsUpdated:
+		model.tabsModel.Status = model.statusLine()
+		// Keep listening for the next job change
+		cmds = append(cmds, jobpanel.ListenForJobs())
+
+	case base.MsgConnectionChanged:
+		previous := model.connection
+		model.connection = msg.State
+		model.tabsModel.Status = model.statusLine()
+		cmds = append(cmds, model.handleConnectionChange(previous, msg.State), listenForConnection())
+
+	case base.MsgContextSwitched:
+		model.tabsModel.Status = model.statusLine()
+		if msg.Err != nil {
+			cmds = append(cmds, notifications.ShowError(fmt.Errorf("failed to switch to context %s: %w", msg.Context, msg.Err)))
+			break
+		}
+		cmds = append(cmds,
+			notifications.ShowSuccess(fmt.Sprintf("Switched to context %s", msg.Context)),
+			model.containersModel.Refresh(),
+			model.imagesModel.Refresh(),
+			model.volumesModel.Refresh(),
+			model.networksModel.Refresh(),
+			model.refreshViews(),
+		)
+
 	case tea.WindowSizeMsg:
 		model.width = msg.Width
 		model.height = msg.Height
@@ -140,31 +213,61 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		model.networksModel, networksCmd = model.networksModel.Update(contentMsg)
 		cmds = append(cmds, networksCmd)
 
+		for i := range model.views {
+			var viewCmd tea.Cmd
+			model.views[i], viewCmd = model.views[i].Update(contentMsg)
+			cmds = append(cmds, viewCmd)
+		}
+
 	case tea.KeyPressMsg:
 		switch msg.String() {
 		case "ctrl+c", "ctrl+d":
 			return model, tea.Quit
 		}
 
+		// The jobs panel and context switcher sit above every tab and take all keys while open
+		if jobPanelVisible || contextPanelVisible {
+			model.help.ShowAll = false
+			return model, tea.Batch(cmds...)
+		}
+
 		// Check if the current view is filtering or has an overlay before processing quit and tab switches
 		isFiltering := false
 		hasOverlay := false
+		isMutating := false
 		switch model.tabsModel.ActiveTab {
 		case tabs.Containers:
 			isFiltering = model.containersModel.IsFiltering()
 			hasOverlay = model.containersModel.IsOverlayVisible()
+			isMutating = model.containersModel.IsMutatingKey(msg)
 		case tabs.Images:
 			isFiltering = model.imagesModel.IsFiltering()
 			hasOverlay = model.imagesModel.IsOverlayVisible()
+			isMutating = model.imagesModel.IsMutatingKey(msg)
 		case tabs.Volumes:
 			isFiltering = model.volumesModel.IsFiltering()
 			hasOverlay = model.volumesModel.IsOverlayVisible()
+			isMutating = model.volumesModel.IsMutatingKey(msg)
 		case tabs.Networks:
 			isFiltering = model.networksModel.IsFiltering()
 			hasOverlay = model.networksModel.IsOverlayVisible()
+			isMutating = model.networksModel.IsMutatingKey(msg)
 		case tabs.Browse:
 			isFiltering = model.browseModel.IsFiltering()
 			hasOverlay = model.browseModel.IsOverlayVisible()
+			isMutating = model.browseModel.IsMutatingKey(msg)
+		default:
+			if i, ok := model.activeView(); ok {
+				isFiltering = model.views[i].IsFiltering()
+				hasOverlay = model.views[i].IsOverlayVisible()
+				isMutating = model.views[i].IsMutatingKey(msg)
+			}
+		}
+
+		// Keep the last-known lists browsable but read-only while the daemon is unreachable
+		if isMutating && !isFiltering && !hasOverlay && model.connection.Status != connection.Connected {
+			cmds = append(cmds, notifications.ShowInfo("Daemon unreachable: actions are disabled until it reconnects"))
+			return model, tea.Batch(cmds...)
 		}
 
 		// Allow "q" to quit only when not filtering and no overlay is visible
@@ -172,6 +275,18 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return model, tea.Quit
 		}
 
+		if key.Matches(msg, model.jobPanel.Keybindings.Toggle) && !isFiltering && !hasOverlay {
+			model.jobPanel.Toggle()
+			model.help.ShowAll = false
+			return model, tea.Batch(cmds...)
+		}
+
+		if key.Matches(msg, model.contextPanel.Keybindings.Toggle) && !isFiltering && !hasOverlay {
+			cmds = append(cmds, model.contextPanel.Open())
+			model.help.ShowAll = false
+			return model, tea.Batch(cmds...)
+		}
+
 		// Only process tab switching keypresses if not filtering and no overlay is visible
 		if !isFiltering && !hasOverlay {
 			var tabsCmd tea.Cmd
@@ -228,6 +343,13 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			model.browseModel, browseCmd = model.browseModel.Update(dummyMsg)
 			cmds = append(cmds, browseCmd)
 			cmds = append(cmds, model.browseModel.Refresh())
+		default:
+			if i, ok := model.activeView(); ok {
+				var viewCmd tea.Cmd
+				model.views[i], viewCmd = model.views[i].Update(dummyMsg)
+				cmds = append(cmds, viewCmd)
+				cmds = append(cmds, model.views[i].Refresh())
+			}
 		}
 	}
 
@@ -268,6 +390,12 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				model.browseModel, browseCmd = model.browseModel.Update(msg)
 				cmds = append(cmds, browseCmd)
 			}
+		default:
+			if i, ok := model.activeView(); ok {
+				var viewCmd tea.Cmd
+				model.views[i], viewCmd = model.views[i].Update(msg)
+				cmds = append(cmds, viewCmd)
+			}
 		}
 	} else if _, isWindowSize := msg.(tea.WindowSizeMsg); !isWindowSize {
 		// Non-keyboard, non-window-size messages go to all tabs so async results are delivered.
@@ -296,9 +424,21 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			model.browseModel, browseCmd = model.browseModel.Update(msg)
 			cmds = append(cmds, browseCmd)
 		}
+		// Views pass on only the results of their own sections, so the
+		// containers tab's tick leaves them to their own timer
+		for i := range model.views {
+			var viewCmd tea.Cmd
+			model.views[i], viewCmd = model.views[i].Update(msg)
+			cmds = append(cmds, viewCmd)
+		}
 	}
 
-	if model.containersModel.IsOverlayVisible() ||
+	hasViewOverlay := false
+	if i, ok := model.activeView(); ok {
+		hasViewOverlay = model.views[i].IsOverlayVisible()
+	}
+	if hasViewOverlay ||
+		model.containersModel.IsOverlayVisible() ||
 		model.imagesModel.IsOverlayVisible() ||
 		model.volumesModel.IsOverlayVisible() ||
 		model.networksModel.IsOverlayVisible() ||
@@ -393,9 +533,19 @@ func (model Model) View() tea.View {
 		contentViewContent = model.networksModel.View()
 	case tabs.Browse:
 		contentViewContent = model.browseModel.View()
+	default:
+		if i, ok := model.activeView(); ok {
+			contentViewContent = model.views[i].View()
+		}
 	}
 
 	contentViewStr := contentViewContent
+	if model.jobPanel.IsVisible() {
+		contentViewStr = components.RenderOverlayString(contentViewStr, model.jobPanel.View(), model.width, max(0, model.height-4))
+	}
+	if model.contextPanel.IsVisible() {
+		contentViewStr = components.RenderOverlayString(contentViewStr, model.contextPanel.View(), model.width, max(0, model.height-4))
+	}
 
 	var helpView string
 	var currentHelp helpProvider
@@ -411,6 +561,16 @@ func (model Model) View() tea.View {
 		currentHelp = model.networksModel
 	case tabs.Browse:
 		currentHelp = model.browseModel
+	default:
+		if i, ok := model.activeView(); ok {
+			currentHelp = model.views[i]
+		}
+	}
+	if model.jobPanel.IsVisible() {
+		currentHelp = model.jobPanel
+	}
+	if model.contextPanel.IsVisible() {
+		currentHelp = model.contextPanel
 	}
 
 	if currentHelp != nil {
@@ -468,9 +628,107 @@ func (model Model) View() tea.View {
 	return view
 }
 
-func Start() error {
+// statusLine combines the job, context and connection indicators shown in the tab bar.
+func (model Model) statusLine() string {
+	var parts []string
+	if indicator := model.jobPanel.Indicator(); indicator != "" {
+		parts = append(parts, indicator)
+	}
+	endpoints := state.GetEndpoints()
+	names := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		names[i] = endpoint.Name()
+	}
+	parts = append(parts, contextIndicator(names))
+	if indicator := connectionIndicator(model.connection); indicator != "" {
+		parts = append(parts, indicator)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// handleConnectionChange notifies about lost and restored daemon connections,
+// refreshing every tab once the daemon is reachable again.
+func (model *Model) handleConnectionChange(previous, current connection.State) tea.Cmd {
+	switch {
+	case previous.Status == connection.Connected && current.Status != connection.Connected:
+		return notifications.ShowError(fmt.Errorf("daemon unreachable: %w", current.Err))
+
+	case previous.Status != connection.Connected && current.Status == connection.Connected:
+		return tea.Batch(
+			notifications.ShowSuccess(fmt.Sprintf("Reconnected to %s %s", current.Backend, current.Version)),
+			model.containersModel.Refresh(),
+			model.imagesModel.Refresh(),
+			model.volumesModel.Refresh(),
+			model.networksModel.Refresh(),
+			model.refreshViews(),
+		)
+	}
+	return nil
+}
+
+// Options are the command line settings that shape the initial view.
+type Options struct {
+	// Filter limits the startup tab to the resources it matches.
+	Filter filter.Filter
+	// Select names the resource to put the cursor on once it loads.
+	Select string
+}
+
+// applyLaunchOptions passes options to the startup tab.
+func (model *Model) applyLaunchOptions(startupTab tabs.Tab, options Options) {
+	switch startupTab {
+	case tabs.Containers:
+		model.containersModel.SetLaunchFilter(options.Filter, options.Select)
+	case tabs.Images:
+		model.imagesModel.SetLaunchFilter(options.Filter, options.Select)
+	case tabs.Volumes:
+		model.volumesModel.SetLaunchFilter(options.Filter, options.Select)
+	case tabs.Networks:
+		model.networksModel.SetLaunchFilter(options.Filter, options.Select)
+	default:
+		if !options.Filter.Empty() || options.Select != "" {
+			fmt.Fprintf(os.Stderr, "warning: --filter and --select are not supported on the %s tab\n", startupTab)
+		}
+	}
+}
+
+// loadViews builds the saved views in cfg and registers them as tabs,
+// returning them with the tabs to show in order.
+func loadViews(cfg *config.Config) ([]views.Model, []tabs.Tab, error) {
+	if err := config.ValidateViews(cfg.Views, tabs.AllTabNames()); err != nil {
+		return nil, nil, err
+	}
+
+	savedViews := make([]views.Model, 0, len(cfg.Views))
+	names := make([]string, 0, len(cfg.Views))
+	for _, viewConfig := range cfg.Views {
+		view, err := views.New(viewConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		savedViews = append(savedViews, view)
+		names = append(names, view.Name())
+	}
+	tabs.SetViews(names)
+
+	shown, err := tabs.Arrange(cfg.Tabs.Order, cfg.Tabs.Hidden)
+	if err != nil {
+		return nil, nil, err
+	}
+	return savedViews, shown, nil
+}
+
+func Start(options Options) error {
 	cfg := state.GetConfig()
 
+	savedViews, shownTabs, err := loadViews(cfg)
+	if err != nil {
+		return err
+	}
+	if err := secrets.Configure(cfg.Secrets); err != nil {
+		return err
+	}
+
 	// Determine startup tab
 	startupTab := tabs.Containers // default
 	if cfg.StartupTab != "" {
@@ -480,9 +738,20 @@ func Start() error {
 			startupTab = tabs.TabFromString(cfg.StartupTab)
 		}
 	}
+	if !slices.Contains(shownTabs, startupTab) {
+		startupTab = shownTabs[0]
+	}
+
+	// Watch the daemon connection for as long as the UI runs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go state.GetConnection().Run(ctx)
 
 	model := NewModel(startupTab)
+	model.views = savedViews
+	model.tabsModel.Tabs = shownTabs
+	model.applyLaunchOptions(startupTab, options)
 	p := tea.NewProgram(model)
-	_, err := p.Run()
+	_, err = p.Run()
 	return err
 }
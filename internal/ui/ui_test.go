@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/connection"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/containers"
 	"github.com/givensuman/containertui/internal/ui/notifications"
@@ -112,3 +114,31 @@ func TestCrossTabRefreshTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectionIndicatorReflectsStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		state connection.State
+		want  string
+	}{
+		{name: "before first check", state: connection.State{}, want: ""},
+		{name: "connected", state: connection.State{Backend: "docker", Version: "27.1.0"}, want: "docker 27.1.0"},
+		{name: "reconnecting", state: connection.State{Status: connection.Reconnecting, Attempts: 2}, want: "reconnecting (attempt 2)"},
+		{name: "offline", state: connection.State{Status: connection.Offline, Attempts: 5}, want: "offline"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := connectionIndicator(tt.state)
+			if tt.want == "" {
+				if got != "" {
+					t.Fatalf("expected empty indicator, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Fatalf("expected indicator to contain %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
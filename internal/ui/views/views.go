@@ -0,0 +1,330 @@
+// Package views implements saved views: tabs defined in the config that list
+// one or more resource types narrowed down by a filter query.
+package views
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/ui/base"
+	"github.com/givensuman/containertui/internal/ui/containers"
+	"github.com/givensuman/containertui/internal/ui/images"
+	"github.com/givensuman/containertui/internal/ui/networks"
+	"github.com/givensuman/containertui/internal/ui/volumes"
+)
+
+// pane is one resource list shown by a view.
+type pane interface {
+	update(msg tea.Msg) (pane, tea.Cmd)
+	View() string
+	Refresh() tea.Cmd
+	IsFiltering() bool
+	IsOverlayVisible() bool
+	IsMutatingKey(msg tea.KeyPressMsg) bool
+	ShortHelp() []key.Binding
+	FullHelp() [][]key.Binding
+}
+
+// listModel is what a view needs from a tab model, implemented by pointers to
+// the containers, images, volumes and networks models.
+type listModel[M any] interface {
+	*M
+	Update(msg tea.Msg) (M, tea.Cmd)
+	View() string
+	Refresh() tea.Cmd
+	IsFiltering() bool
+	IsOverlayVisible() bool
+	IsMutatingKey(msg tea.KeyPressMsg) bool
+	ShortHelp() []key.Binding
+	FullHelp() [][]key.Binding
+	RestrictToQuery(text string) error
+	SortBy(key string, descending bool)
+	ShowTable(columnKeys []string)
+}
+
+// section adapts a tab model to a pane.
+type section[M any, PM listModel[M]] struct {
+	model M
+	// id addresses the results of the section's own operations to it
+	id *int
+}
+
+// msgForSection carries a message of a section's tab package, such as the
+// result of an operation it started, to that section alone. Messages are
+// broadcast to every tab and view, where other models of the same type
+// would otherwise handle them too.
+type msgForSection struct {
+	section *int
+	msg     tea.Msg
+}
+
+// ownMessage reports whether msg is declared by the package of model, as
+// the results a tab model sends itself are.
+func ownMessage[M any](msg tea.Msg) bool {
+	msgType := reflect.TypeOf(msg)
+	if msgType == nil {
+		return false
+	}
+	if msgType.Kind() == reflect.Pointer {
+		msgType = msgType.Elem()
+	}
+	return msgType.PkgPath() == reflect.TypeFor[M]().PkgPath()
+}
+
+// address tags the messages of the section's tab package that cmd produces
+// as the section's own, including those of a batch.
+func (s section[M, PM]) address(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			addressed := make(tea.BatchMsg, len(batch))
+			for i, batched := range batch {
+				addressed[i] = s.address(batched)
+			}
+			return addressed
+		}
+		if ownMessage[M](msg) {
+			return msgForSection{section: s.id, msg: msg}
+		}
+		return msg
+	}
+}
+
+func newSection[M any, PM listModel[M]](model M, view config.ViewConfig) (pane, error) {
+	if err := PM(&model).RestrictToQuery(view.Query); err != nil {
+		return nil, err
+	}
+	if sortKey, descending := view.SortKey(); sortKey != "" {
+		PM(&model).SortBy(sortKey, descending)
+	}
+	if len(view.Columns) > 0 {
+		PM(&model).ShowTable(view.Columns)
+	}
+	return section[M, PM]{model: model, id: new(int)}, nil
+}
+
+func (s section[M, PM]) update(msg tea.Msg) (pane, tea.Cmd) {
+	if addressed, ok := msg.(msgForSection); ok {
+		if addressed.section != s.id {
+			return s, nil
+		}
+		msg = addressed.msg
+	} else if ownMessage[M](msg) {
+		// Results of the tab's own operations belong to the tab
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.model, cmd = PM(&s.model).Update(msg)
+	return s, s.address(cmd)
+}
+
+func (s section[M, PM]) View() string           { return PM(&s.model).View() }
+func (s section[M, PM]) Refresh() tea.Cmd       { return s.address(PM(&s.model).Refresh()) }
+func (s section[M, PM]) IsFiltering() bool      { return PM(&s.model).IsFiltering() }
+func (s section[M, PM]) IsOverlayVisible() bool { return PM(&s.model).IsOverlayVisible() }
+func (s section[M, PM]) IsMutatingKey(msg tea.KeyPressMsg) bool {
+	return PM(&s.model).IsMutatingKey(msg)
+}
+func (s section[M, PM]) ShortHelp() []key.Binding  { return PM(&s.model).ShortHelp() }
+func (s section[M, PM]) FullHelp() [][]key.Binding { return PM(&s.model).FullHelp() }
+
+// msgRefreshView is sent every refresh interval to the view it names.
+type msgRefreshView struct {
+	view *int
+}
+
+type keybindings struct {
+	nextSection     key.Binding
+	previousSection key.Binding
+}
+
+func newKeybindings() keybindings {
+	return keybindings{
+		nextSection: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next section"),
+		),
+		previousSection: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "previous section"),
+		),
+	}
+}
+
+// Model is a saved view: a list for each of its resources, one shown at a
+// time, each keeping its own cursor, selection and filter bar.
+type Model struct {
+	base.WindowSize
+	name        string
+	titles      []string
+	panes       []pane
+	active      int
+	refresh     time.Duration
+	id          *int
+	keybindings keybindings
+}
+
+// New builds the view described by view, which must have been validated with
+// config.ValidateViews.
+func New(view config.ViewConfig) (Model, error) {
+	model := Model{
+		name:        view.Name,
+		refresh:     view.RefreshInterval(),
+		id:          new(int),
+		keybindings: newKeybindings(),
+	}
+
+	for _, resource := range view.Resources {
+		var p pane
+		var err error
+		switch resource {
+		case "containers":
+			p, err = newSection[containers.Model](containers.New(), view)
+		case "images":
+			p, err = newSection[images.Model](images.New(), view)
+		case "volumes":
+			p, err = newSection[volumes.Model](volumes.New(), view)
+		case "networks":
+			p, err = newSection[networks.Model](networks.New(), view)
+		default:
+			err = fmt.Errorf("unknown resource")
+		}
+		if err != nil {
+			return Model{}, fmt.Errorf("view %q: %s: %w", view.Name, resource, err)
+		}
+		model.titles = append(model.titles, strings.ToUpper(resource[:1])+resource[1:])
+		model.panes = append(model.panes, p)
+	}
+	return model, nil
+}
+
+// Name returns the view's name, as shown on its tab.
+func (model Model) Name() string {
+	return model.name
+}
+
+func (model Model) tick() tea.Cmd {
+	id := model.id
+	return tea.Tick(model.refresh, func(time.Time) tea.Msg {
+		return msgRefreshView{view: id}
+	})
+}
+
+// Init loads every section and starts the view's refresh timer.
+func (model Model) Init() tea.Cmd {
+	return tea.Batch(model.Refresh(), model.tick())
+}
+
+// Refresh reloads every section.
+func (model Model) Refresh() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(model.panes))
+	for _, p := range model.panes {
+		cmds = append(cmds, p.Refresh())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case msgRefreshView:
+		if msg.view != model.id {
+			return model, nil
+		}
+		return model, tea.Batch(model.Refresh(), model.tick())
+
+	case tea.WindowSizeMsg:
+		model.WindowWidth = msg.Width
+		model.WindowHeight = msg.Height
+		// The section header takes a line when there is more than one section
+		if len(model.panes) > 1 {
+			msg.Height = max(0, msg.Height-1)
+		}
+		cmds := make([]tea.Cmd, len(model.panes))
+		for i := range model.panes {
+			model.panes[i], cmds[i] = model.panes[i].update(msg)
+		}
+		return model, tea.Batch(cmds...)
+
+	case tea.KeyPressMsg, tea.KeyReleaseMsg:
+		// Keys go to the shown section only
+		active := model.panes[model.active]
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok && len(model.panes) > 1 && !active.IsFiltering() && !active.IsOverlayVisible() {
+			switch {
+			case key.Matches(keyMsg, model.keybindings.nextSection):
+				model.active = (model.active + 1) % len(model.panes)
+				return model, model.panes[model.active].Refresh()
+			case key.Matches(keyMsg, model.keybindings.previousSection):
+				model.active = (model.active + len(model.panes) - 1) % len(model.panes)
+				return model, model.panes[model.active].Refresh()
+			}
+		}
+		var cmd tea.Cmd
+		model.panes[model.active], cmd = active.update(msg)
+		return model, cmd
+	}
+
+	cmds := make([]tea.Cmd, len(model.panes))
+	for i := range model.panes {
+		model.panes[i], cmds[i] = model.panes[i].update(msg)
+	}
+	return model, tea.Batch(cmds...)
+}
+
+func (model Model) View() string {
+	content := model.panes[model.active].View()
+	if len(model.panes) == 1 {
+		return content
+	}
+
+	activeStyle := lipgloss.NewStyle().Foreground(colors.Primary()).Bold(true)
+	inactiveStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	titles := make([]string, len(model.titles))
+	for i, title := range model.titles {
+		if i == model.active {
+			titles[i] = activeStyle.Render(title)
+		} else {
+			titles[i] = inactiveStyle.Render(title)
+		}
+	}
+	header := " " + strings.Join(titles, inactiveStyle.Render(" · "))
+	return lipgloss.JoinVertical(lipgloss.Left, header, content)
+}
+
+func (model Model) IsFiltering() bool {
+	return model.panes[model.active].IsFiltering()
+}
+
+func (model Model) IsOverlayVisible() bool {
+	return model.panes[model.active].IsOverlayVisible()
+}
+
+func (model Model) IsMutatingKey(msg tea.KeyPressMsg) bool {
+	return model.panes[model.active].IsMutatingKey(msg)
+}
+
+func (model Model) ShortHelp() []key.Binding {
+	help := model.panes[model.active].ShortHelp()
+	if len(model.panes) > 1 {
+		help = append(help, model.keybindings.nextSection)
+	}
+	return help
+}
+
+func (model Model) FullHelp() [][]key.Binding {
+	help := model.panes[model.active].FullHelp()
+	if len(model.panes) > 1 {
+		help = append(help, []key.Binding{model.keybindings.nextSection, model.keybindings.previousSection})
+	}
+	return help
+}
@@ -0,0 +1,136 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/givensuman/containertui/internal/backend/fake"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/state"
+	"github.com/givensuman/containertui/internal/ui/containers"
+	"github.com/givensuman/containertui/internal/ui/images"
+)
+
+// run delivers the messages cmd produces to model, leaving out timers.
+func run(model Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return model
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			model = run(model, cmd)
+		}
+	case msgRefreshView:
+	default:
+		model, _ = model.Update(msg)
+	}
+	return model
+}
+
+func TestViewListsOnlyMatchingResources(t *testing.T) {
+	state.UseBackend(fake.NewDemo())
+	view, err := New(config.ViewConfig{
+		Name:      "demo",
+		Resources: []string{"containers", "volumes"},
+		Query:     "label:com.docker.compose.project=containertui-demo",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	view, _ = view.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	view = run(view, view.Refresh())
+
+	rendered := view.View()
+	if !strings.Contains(rendered, "Containers") || !strings.Contains(rendered, "Volumes") {
+		t.Fatalf("expected a header naming both sections, got %q", rendered)
+	}
+	containersSection := view.panes[0].(section[containers.Model, *containers.Model])
+	items := containersSection.model.GetItems()
+	if len(items) == 0 {
+		t.Fatal("expected the demo project's containers to be listed")
+	}
+	for _, item := range items {
+		if item.Labels["com.docker.compose.project"] != "containertui-demo" {
+			t.Fatalf("expected only the demo project's containers, got %s", item.Name)
+		}
+	}
+
+	view, _ = view.Update(tea.KeyPressMsg{Code: ']', Text: "]"})
+	if view.active != 1 {
+		t.Fatalf("expected ] to show the volumes section, got section %d", view.active)
+	}
+	view, _ = view.Update(tea.KeyPressMsg{Code: '[', Text: "["})
+	if view.active != 0 {
+		t.Fatalf("expected [ to go back to the containers section, got section %d", view.active)
+	}
+}
+
+func TestViewRejectsQueriesItsResourcesCannotUse(t *testing.T) {
+	_, err := New(config.ViewConfig{Name: "running", Resources: []string{"containers", "volumes"}, Query: "state:running"})
+	if err == nil || !strings.Contains(err.Error(), "volumes") {
+		t.Fatalf("expected the volumes section to reject state:, got %v", err)
+	}
+}
+
+func TestViewOnlyReschedulesItsOwnTimer(t *testing.T) {
+	first, err := New(config.ViewConfig{Name: "first", Resources: []string{"images"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	second, err := New(config.ViewConfig{Name: "second", Resources: []string{"images"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if _, cmd := first.Update(msgRefreshView{view: second.id}); cmd != nil {
+		t.Fatal("expected another view's timer to be ignored")
+	}
+	if _, cmd := first.Update(msgRefreshView{view: first.id}); cmd == nil {
+		t.Fatal("expected the view's own timer to refresh it")
+	}
+}
+
+func TestViewColumnsShowItsListsAsTables(t *testing.T) {
+	state.UseBackend(fake.NewDemo())
+	view, err := New(config.ViewConfig{Name: "ports", Resources: []string{"containers"}, Columns: []string{"name", "ports"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	view, _ = view.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	view = run(view, view.Refresh())
+
+	containersSection := view.panes[0].(section[containers.Model, *containers.Model])
+	if !containersSection.model.IsTableMode() {
+		t.Fatal("expected the view's columns to turn on table mode")
+	}
+	if rendered := view.View(); !strings.Contains(rendered, "Ports") || strings.Contains(rendered, "Uptime") {
+		t.Fatalf("expected only the view's columns in the header, got %q", rendered)
+	}
+}
+
+func TestViewResultsReachOnlyTheSectionThatStartedThem(t *testing.T) {
+	first, err := New(config.ViewConfig{Name: "first", Resources: []string{"images"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	second, err := New(config.ViewConfig{Name: "second", Resources: []string{"images"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	result := images.MsgRemoveImageComplete{ImageID: "sha256:abcdef1234567890", Err: errors.New("image is in use")}
+
+	firstSection := first.panes[0].(section[images.Model, *images.Model])
+	addressed := firstSection.address(func() tea.Msg { return result })()
+	if _, cmd := first.Update(addressed); cmd == nil {
+		t.Fatal("expected the section that removed the image to report the result")
+	}
+	if _, cmd := second.Update(addressed); cmd != nil {
+		t.Fatal("expected another view's section to ignore the result")
+	}
+	if _, cmd := first.Update(result); cmd != nil {
+		t.Fatal("expected the images tab's own result to be left to the tab")
+	}
+}
@@ -0,0 +1,37 @@
+package volumes
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/components/infopanel"
+	"github.com/givensuman/containertui/internal/ui/layout"
+)
+
+// tableColumns are the columns the volumes table can show.
+func tableColumns() []components.Column[VolumeItem] {
+	return []components.Column[VolumeItem]{
+		{
+			Key: "name", Title: "Name", ColumnSpec: layout.ColumnSpec{MinWidth: 20, Flex: 3},
+			// The mount marker stays in front of the name
+			Value: func(item VolumeItem) string { return item.getStatusIcon() + " " + item.Volume.Name },
+			Compare: func(a, b VolumeItem) int {
+				return cmp.Compare(strings.ToLower(a.Volume.Name), strings.ToLower(b.Volume.Name))
+			},
+		},
+		{
+			Key: "driver", Title: "Driver", ColumnSpec: layout.ColumnSpec{MinWidth: 8},
+			Value: func(item VolumeItem) string { return item.Volume.Driver },
+		},
+		{
+			Key: "mountpoint", Title: "Mountpoint", ColumnSpec: layout.ColumnSpec{MinWidth: 20, Flex: 2},
+			Value: func(item VolumeItem) string { return item.Volume.Mountpoint },
+		},
+		{
+			Key: "created", Title: "Created", ColumnSpec: layout.ColumnSpec{MinWidth: 14},
+			Value:   func(item VolumeItem) string { return infopanel.FormatTimeAgo(item.Volume.CreatedAt) },
+			Compare: func(a, b VolumeItem) int { return a.Volume.CreatedAt.Compare(b.Volume.CreatedAt) },
+		},
+	}
+}
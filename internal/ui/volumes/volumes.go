@@ -12,11 +12,16 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/backend/multi"
 	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/filter"
+	"github.com/givensuman/containertui/internal/jobs"
 	"github.com/givensuman/containertui/internal/state"
 	"github.com/givensuman/containertui/internal/ui/base"
 	"github.com/givensuman/containertui/internal/ui/components"
 	"github.com/givensuman/containertui/internal/ui/components/infopanel/builders"
+	"github.com/givensuman/containertui/internal/ui/jobpanel"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/safety"
 	"github.com/givensuman/containertui/internal/ui/utils"
@@ -29,6 +34,9 @@ type MsgVolumeInspection struct {
 	Err    error
 }
 
+// jobKindPrune identifies prune jobs submitted by this tab.
+const jobKindPrune = "volumes/prune"
+
 // MsgPruneComplete is sent when the prune operation completes
 type MsgPruneComplete struct {
 	SpaceReclaimed uint64
@@ -41,6 +49,13 @@ type MsgCreateVolumeComplete struct {
 	Err        error
 }
 
+// MsgRemoveVolumeComplete indicates a volume removal finished.
+type MsgRemoveVolumeComplete struct {
+	VolumeName string
+	Force      bool
+	Err        error
+}
+
 type MsgAttachVolumeComplete struct {
 	VolumeName  string
 	ContainerID string
@@ -61,6 +76,7 @@ type keybindings struct {
 	createVolume         key.Binding
 	attachVolume         key.Binding
 	detachVolume         key.Binding
+	cancelOperation      key.Binding
 	switchTab            key.Binding
 }
 
@@ -94,9 +110,13 @@ func newKeybindings() *keybindings {
 			key.WithKeys("d"),
 			key.WithHelp("d", "detach volume"),
 		),
+		cancelOperation: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel operation"),
+		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "5"),
-			key.WithHelp("1-5", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "switch tab"),
 		),
 	}
 }
@@ -108,22 +128,30 @@ type Model struct {
 	detailsKeybindings components.DetailsKeybindings
 	inspection         backend.VolumeDetail
 	detailsPanel       components.DetailsPanel
+	// operation is the removal or creation in flight, which esc cancels.
+	operation components.Operation
 }
 
 func New() Model {
 	volumeKeybindings := newKeybindings()
 
 	fetchVolumes := func() ([]VolumeItem, error) {
-		volumeList, err := state.GetBackend().ListVolumes(stdcontext.Background())
-		if err != nil {
+		ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+		defer cancel()
+		volumeList, hostErr := state.GetBackend().ListVolumes(ctx)
+		if err := multi.IgnorePartial(hostErr); err != nil {
 			return nil, err
 		}
 
 		// Get volume usage map (single API call for all volumes)
-		mountedVolumes, err := state.GetBackend().GetAllVolumeUsage(stdcontext.Background())
-		if err != nil {
+		mountedVolumes, err := state.GetBackend().GetAllVolumeUsage(ctx)
+		if err := multi.IgnorePartial(err); err != nil {
 			return nil, err
 		}
+		if hostErr == nil {
+			// Usage from the hosts that answered is still complete
+			hostErr = err
+		}
 
 		items := make([]VolumeItem, 0, len(volumeList))
 		for _, volume := range volumeList {
@@ -135,7 +163,8 @@ func New() Model {
 				IsMounted: isMounted,
 			})
 		}
-		return items, nil
+		// Hosts that failed are reported by the view, which keeps the rest
+		return items, hostErr
 	}
 
 	resourceView := components.NewResourceView[string, VolumeItem](
@@ -148,6 +177,11 @@ func New() Model {
 		},
 	)
 
+	resourceView.EnableQuery(filter.VolumeQueryKeys, func(item VolumeItem) filter.Fields {
+		return filter.VolumeFields(item.Volume)
+	})
+	resourceView.EnableTable(tableColumns(), components.TableSettings("volumes"), components.SaveTableSettings("volumes"))
+
 	// Add extra pane below detail pane
 	extraPane := components.NewViewportPane()
 	extraPane.SetContent("")                            // Will be populated when a volume is selected
@@ -177,11 +211,30 @@ func New() Model {
 		volumeKeybindings.createVolume,
 		volumeKeybindings.attachVolume,
 		volumeKeybindings.detachVolume,
+		volumeKeybindings.cancelOperation,
+	}
+
+	// Actions that change daemon state are disabled while it is unreachable
+	model.MutatingKeys = []key.Binding{
+		volumeKeybindings.remove,
+		volumeKeybindings.pruneVolumes,
+		volumeKeybindings.createVolume,
+		volumeKeybindings.attachVolume,
+		volumeKeybindings.detachVolume,
 	}
 
 	return model
 }
 
+// SetLaunchFilter limits the list to the volumes matching f and, once they
+// load, puts the cursor on the one called selectName.
+func (model *Model) SetLaunchFilter(f filter.Filter, selectName string) {
+	if !f.Empty() {
+		model.Filter = func(item VolumeItem) bool { return f.Match(filter.VolumeFields(item.Volume)) }
+	}
+	model.SelectOnLoad(selectName)
+}
+
 func (model Model) Init() tea.Cmd {
 	return model.ResourceView.Init()
 }
@@ -207,11 +260,15 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// Restore scroll position after viewport has processed content
 		model.detailsPanel.RestoreScrollPosition(model.getViewport())
 
-	case MsgPruneComplete:
-		if progressDialog, ok := model.Foreground.(components.ProgressDialog); ok {
-			_ = progressDialog.SetPercent(1.0)
+	case components.MsgRevealExpired:
+		if model.detailsPanel.ExpireReveal() {
+			model.refreshInspectionContent()
 		}
-		model.CloseOverlay()
+
+	case base.MsgJobsUpdated:
+		cmds = append(cmds, handleFinishedJobs(model.OwnFinished(msg.Finished)))
+
+	case MsgPruneComplete:
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
 		}
@@ -237,6 +294,9 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case MsgCreateVolumeComplete:
 		return model.handleCreateVolumeComplete(msg)
 
+	case MsgRemoveVolumeComplete:
+		return model.handleRemoveVolumeComplete(msg)
+
 	case MsgAttachVolumeComplete:
 		if msg.Err != nil {
 			return model, notifications.ShowError(msg.Err)
@@ -272,34 +332,16 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					model.CloseOverlay()
 					return model, notifications.ShowError(fmt.Errorf("invalid payload type for DeleteVolume"))
 				}
-				err := state.GetBackend().RemoveVolume(stdcontext.Background(), volumeName)
-				if err == nil {
-					// Close the overlay and refresh list
-					model.CloseOverlay()
-					return model, tea.Batch(
-						notifications.ShowSuccess(fmt.Sprintf("Volume removed: %s", volumeName)),
-						model.Refresh(),
-					)
-				} else {
-					// Show error notification
-					model.CloseOverlay()
-					return model, notifications.ShowError(err)
-				}
+				model.CloseOverlay()
+				return model, model.startRemoveVolume(volumeName, false)
 			} else if confirmMsg.Action.Type == "ForceDeleteVolume" {
 				volumeName, ok := confirmMsg.Action.Payload.(string)
 				if !ok {
 					model.CloseOverlay()
 					return model, notifications.ShowError(fmt.Errorf("invalid payload type for ForceDeleteVolume"))
 				}
-				err := state.GetBackend().RemoveVolume(stdcontext.Background(), volumeName)
 				model.CloseOverlay()
-				if err != nil {
-					return model, notifications.ShowError(fmt.Errorf("failed to force delete volume: %w", err))
-				}
-				return model, tea.Batch(
-					notifications.ShowSuccess(fmt.Sprintf("Force deleted volume: %s", volumeName)),
-					model.Refresh(),
-				)
+				return model, model.startRemoveVolume(volumeName, true)
 			} else if confirmMsg.Action.Type == "CreateVolumeAction" {
 				// Extract form values
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
@@ -336,7 +378,7 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 
 				model.CloseOverlay()
-				return model, model.performCreateVolume(name, driver, labelsMap)
+				return model, model.startCreateVolume(name, driver, labelsMap)
 			} else if confirmMsg.Action.Type == "AttachVolumeAction" {
 				payload, ok := confirmMsg.Action.Payload.(map[string]any)
 				if !ok {
@@ -400,6 +442,10 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				model.handleRemove()
 				return model, nil
 
+			case key.Matches(msg, model.keybindings.cancelOperation):
+				model.operation.Cancel()
+				return model, tea.Batch(cmds...)
+
 			case key.Matches(msg, model.keybindings.pruneVolumes):
 				if !model.hasPrunableVolumes() {
 					return model, notifications.ShowSuccess("No unused volumes to prune")
@@ -434,6 +480,11 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					cmd := model.detailsPanel.HandleCopyToClipboard(model.inspection)
 					cmds = append(cmds, cmd)
 				}
+				if key.Matches(msg, model.detailsKeybindings.RevealSecrets) {
+					cmd := model.detailsPanel.HandleRevealSecrets()
+					model.refreshInspectionContent()
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 	}
@@ -448,12 +499,14 @@ func (model Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (model Model) handleCreateVolumeComplete(msg MsgCreateVolumeComplete) (Model, tea.Cmd) {
+	name := model.operation.Name()
+	model.operation.Finish()
 	if msg.Err != nil {
-		return model, notifications.ShowError(msg.Err)
+		return model, components.OperationOutcome(name, msg.Err, "")
 	}
 
 	return model, tea.Batch(
-		notifications.ShowSuccess(fmt.Sprintf("Created volume: %s", msg.VolumeName)),
+		components.OperationOutcome(name, nil, fmt.Sprintf("Created volume: %s", msg.VolumeName)),
 		model.Refresh(),
 		func() tea.Msg {
 			return base.MsgResourceChanged{
@@ -465,6 +518,44 @@ func (model Model) handleCreateVolumeComplete(msg MsgCreateVolumeComplete) (Mode
 	)
 }
 
+// startRemoveVolume removes the volume called volumeName in the background,
+// as the tab's cancellable operation.
+func (model *Model) startRemoveVolume(volumeName string, force bool) tea.Cmd {
+	name := fmt.Sprintf("Remove volume %s", volumeName)
+	if force {
+		name = fmt.Sprintf("Force delete volume %s", volumeName)
+	}
+	ctx, notice := model.operation.Start(name, config.OperationAction)
+	if notice != nil {
+		return notice
+	}
+
+	return func() tea.Msg {
+		err := state.GetBackend().RemoveVolume(ctx, volumeName)
+		if err != nil && force {
+			err = fmt.Errorf("failed to force delete volume: %w", err)
+		}
+		return MsgRemoveVolumeComplete{VolumeName: volumeName, Force: force, Err: err}
+	}
+}
+
+func (model Model) handleRemoveVolumeComplete(msg MsgRemoveVolumeComplete) (Model, tea.Cmd) {
+	name := model.operation.Name()
+	model.operation.Finish()
+
+	success := fmt.Sprintf("Volume removed: %s", msg.VolumeName)
+	if msg.Force {
+		success = fmt.Sprintf("Force deleted volume: %s", msg.VolumeName)
+	}
+	if msg.Err != nil {
+		return model, components.OperationOutcome(name, msg.Err, "")
+	}
+	return model, tea.Batch(
+		components.OperationOutcome(name, nil, success),
+		model.Refresh(),
+	)
+}
+
 func (model Model) handleToggleSelection() {
 	selectedItem := model.GetSelectedItem()
 	if selectedItem != nil {
@@ -517,7 +608,9 @@ func (model Model) handleRemove() {
 		return
 	}
 
-	containersUsingVolume, err := state.GetBackend().GetContainersUsingVolume(stdcontext.Background(), selectedItem.Volume.Name)
+	ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+	defer cancel()
+	containersUsingVolume, err := state.GetBackend().GetContainersUsingVolume(ctx, selectedItem.Volume.Name)
 	if err != nil {
 		// If we can't check usage, show error and don't proceed with deletion
 		errorDialog := components.NewDialog(
@@ -571,7 +664,9 @@ func (model *Model) updateDetailContent() tea.Cmd {
 
 		// Fetch inspection data asynchronously
 		return func() tea.Msg {
-			volumeInfo, err := state.GetBackend().InspectVolume(stdcontext.Background(), volumeName)
+			ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationInspect)
+			defer cancel()
+			volumeInfo, err := state.GetBackend().InspectVolume(ctx, volumeName)
 			return MsgVolumeInspection{Name: volumeName, Volume: volumeInfo, Err: err}
 		}
 	}
@@ -589,12 +684,15 @@ func (model Model) getViewport() *viewport.Model {
 
 // refreshInspectionContent refreshes the detail content with current inspection data
 func (model *Model) refreshInspectionContent() {
-	// Use DetailsPanel to get the current format
-	format := model.detailsPanel.GetFormatForDisplay()
-
-	// Build content with current format
-	content := builders.BuildVolumePanel(model.inspection, model.GetContentWidth(), format)
-	model.SetContent(content)
+	// The tree draws itself from the data; other formats are built as text
+	if model.detailsPanel.IsTreeFormat() {
+		model.ShowInspectTree(model.inspection, model.detailsPanel.SecretsRevealed())
+	} else {
+		model.HideInspectTree()
+		format := model.detailsPanel.GetFormatForDisplay()
+		content := builders.BuildVolumePanel(model.inspection, model.GetContentWidth(), model.detailsPanel.SecretsRevealed(), format)
+		model.SetContent(content)
+	}
 
 	// Update "Used By" panel
 	model.updateUsedByPanel()
@@ -608,7 +706,9 @@ func (model *Model) updateUsedByPanel() {
 	}
 
 	// Fetch containers using this volume
-	usedBy, err := state.GetBackend().GetContainersUsingVolume(stdcontext.Background(), model.inspection.Name)
+	ctx, cancel := state.OperationContext(stdcontext.Background(), config.OperationList)
+	defer cancel()
+	usedBy, err := state.GetBackend().GetContainersUsingVolume(ctx, model.inspection.Name)
 	if err != nil {
 		model.SetExtraContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render(fmt.Sprintf("Error: %v", err)))
 		return
@@ -649,13 +749,14 @@ func (model Model) IsFiltering() bool {
 func (model Model) ShortHelp() []key.Binding {
 	// If detail or extra pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return []key.Binding{
+		return append([]key.Binding{
 			model.detailsKeybindings.Up,
 			model.detailsKeybindings.Down,
 			model.detailsKeybindings.Switch,
 			model.detailsKeybindings.ToggleJSON,
 			model.detailsKeybindings.CopyOutput,
-		}
+			model.detailsKeybindings.RevealSecrets,
+		}, model.InspectTreeHelp()...)
 	} else if model.IsExtraFocused() {
 		return []key.Binding{
 			model.detailsKeybindings.Up,
@@ -669,7 +770,7 @@ func (model Model) ShortHelp() []key.Binding {
 func (model Model) FullHelp() [][]key.Binding {
 	// If detail or extra pane is focused, show detail keybindings
 	if model.IsDetailFocused() {
-		return [][]key.Binding{
+		help := [][]key.Binding{
 			{
 				model.detailsKeybindings.Up,
 				model.detailsKeybindings.Down,
@@ -678,8 +779,13 @@ func (model Model) FullHelp() [][]key.Binding {
 			{
 				model.detailsKeybindings.ToggleJSON,
 				model.detailsKeybindings.CopyOutput,
+				model.detailsKeybindings.RevealSecrets,
 			},
 		}
+		if tree := model.InspectTreeHelp(); len(tree) > 0 {
+			help = append(help, tree)
+		}
+		return help
 	} else if model.IsExtraFocused() {
 		return [][]key.Binding{
 			{
@@ -693,7 +799,7 @@ func (model Model) FullHelp() [][]key.Binding {
 }
 
 func (model Model) hasPrunableVolumes() bool {
-	for _, item := range model.GetItems() {
+	for _, item := range model.AllItems() {
 		if !item.IsMounted {
 			return true
 		}
@@ -702,22 +808,36 @@ func (model Model) hasPrunableVolumes() bool {
 	return false
 }
 
-// handlePruneVolumes prunes unused volumes
+// handlePruneVolumes prunes unused volumes in a background job
 func (model *Model) handlePruneVolumes() tea.Cmd {
-	progressDialog := components.NewProgressDialogWithBar("Pruning unused volumes")
-	progressDialog.EnableAutoAdvance(0.95, 0.04)
-	progressDialog.SetStatus("Discovering unused volumes to prune...")
-	model.SetOverlay(progressDialog)
-
-	// Start async prune operation
-	return func() tea.Msg {
-		ctx := stdcontext.Background()
+	return model.SubmitJob("Prune unused volumes", jobKindPrune, func(ctx stdcontext.Context, progress *jobs.Progress) error {
+		progress.SetMessage("Discovering unused volumes to prune...")
+		ctx, cancel := state.OperationContext(ctx, config.OperationPrune)
+		defer cancel()
 		spaceReclaimed, err := state.GetBackend().PruneVolumes(ctx)
-		return MsgPruneComplete{
-			SpaceReclaimed: spaceReclaimed,
-			Err:            err,
+		progress.SetResult(spaceReclaimed)
+		return err
+	})
+}
+
+// handleFinishedJobs translates finished jobs submitted by this tab into
+// their completion messages.
+func handleFinishedJobs(finished []jobs.Job) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range finished {
+		if job.Kind != jobKindPrune {
+			continue
+		}
+		if job.Status == jobs.Cancelled {
+			cmds = append(cmds, jobpanel.CancelledNotice(job))
+			continue
 		}
+		spaceReclaimed, _ := job.Result.(uint64)
+		cmds = append(cmds, func() tea.Msg {
+			return MsgPruneComplete{SpaceReclaimed: spaceReclaimed, Err: job.Err}
+		})
 	}
+	return tea.Batch(cmds...)
 }
 
 func (model *Model) showPruneVolumesConfirmation() {
@@ -742,15 +862,15 @@ func (model *Model) showPruneVolumesConfirmation() {
 }
 
 func (model Model) pruneVolumeCandidates() []string {
-	items := model.GetItems()
-	candidates := make([]string, 0, len(items))
+	items := model.AllItems()
+	volumes := make([]backend.Volume, 0, len(items))
+	usage := make(map[string]bool, len(items))
 	for _, item := range items {
-		if !item.IsMounted {
-			candidates = append(candidates, item.Volume.Name)
-		}
+		volumes = append(volumes, item.Volume)
+		usage[item.Volume.Name] = item.IsMounted
 	}
 
-	return candidates
+	return safety.PruneVolumeCandidates(volumes, usage)
 }
 
 // withCreateVolumeDialog returns model with create-volume dialog shown.
@@ -784,11 +904,14 @@ func (model Model) withCreateVolumeDialog() Model {
 	return model
 }
 
-// performCreateVolume creates a volume
-func (model Model) performCreateVolume(name, driver string, labels map[string]string) tea.Cmd {
-	return func() tea.Msg {
-		ctx := stdcontext.Background()
+// startCreateVolume creates a volume as the tab's cancellable operation.
+func (model *Model) startCreateVolume(name, driver string, labels map[string]string) tea.Cmd {
+	ctx, notice := model.operation.Start(fmt.Sprintf("Create volume %s", name), config.OperationAction)
+	if notice != nil {
+		return notice
+	}
 
+	return func() tea.Msg {
 		// Use "local" as default driver if not specified
 		if driver == "" {
 			driver = "local"
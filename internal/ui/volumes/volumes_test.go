@@ -7,7 +7,9 @@ import (
 
 	"charm.land/bubbles/v2/list"
 	"github.com/givensuman/containertui/internal/backend"
+	"github.com/givensuman/containertui/internal/config"
 	"github.com/givensuman/containertui/internal/ui/components"
+	"github.com/givensuman/containertui/internal/ui/notifications"
 )
 
 func newPruneTestModel(items []VolumeItem) Model {
@@ -144,3 +146,21 @@ type testError string
 func (e testError) Error() string {
 	return string(e)
 }
+
+func TestHandleRemoveVolumeCompleteReportsCancellation(t *testing.T) {
+	model := Model{}
+	ctx, notice := model.operation.Start("Remove volume data", config.OperationAction)
+	if notice != nil {
+		t.Fatal("expected the operation to start")
+	}
+	model.operation.Cancel()
+
+	updated, cmd := model.handleRemoveVolumeComplete(MsgRemoveVolumeComplete{VolumeName: "data", Err: ctx.Err()})
+	if updated.operation.Active() {
+		t.Fatal("expected the removal to be released")
+	}
+	msg, ok := cmd().(notifications.AddNotificationMsg)
+	if !ok || msg.Level != notifications.Info || msg.Message != "Remove volume data cancelled" {
+		t.Fatalf("outcome = %#v, want a cancelled notice", cmd())
+	}
+}